@@ -26,6 +26,7 @@ import (
 	"github.com/cheggaaa/pb"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
 )
 
 // accounter keeps tabs of ongoing data transfer information.
@@ -153,3 +154,69 @@ func (a *accounter) Read(p []byte) (n int, err error) {
 	a.Add(int64(n))
 	return
 }
+
+// plainProgressInterval is the interval between two plain progress lines.
+const plainProgressInterval = 5 * time.Second
+
+// plainProgress keeps tabs of the transfer like accounter and prints
+// it as plain text lines at a fixed interval, to not pollute logs and
+// non-interactive outputs with progress bar control characters.
+type plainProgress struct {
+	*accounter
+	total    int64
+	doneCh   chan struct{}
+	doneOnce sync.Once
+}
+
+// newPlainProgress - instantiate a plain progress printer.
+func newPlainProgress(total int64) *plainProgress {
+	p := &plainProgress{
+		accounter: newAccounter(total),
+		total:     total,
+		doneCh:    make(chan struct{}),
+	}
+	go func() {
+		ticker := time.NewTicker(plainProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.doneCh:
+				return
+			case <-ticker.C:
+				p.printLine()
+			}
+		}
+	}()
+	return p
+}
+
+// SetTotal sets the total size of the transfer.
+func (p *plainProgress) SetTotal(total int64) {
+	atomic.StoreInt64(&p.total, total)
+}
+
+// printLine prints the current progress.
+func (p *plainProgress) printLine() {
+	console.Println(plainProgressLine(p.Get(), atomic.LoadInt64(&p.total), time.Since(p.startTime)))
+}
+
+// Finish stops the periodic printing and prints the final progress.
+func (p *plainProgress) Finish() {
+	p.doneOnce.Do(func() {
+		close(p.doneCh)
+		p.printLine()
+	})
+}
+
+// plainProgressLine formats one progress line.
+func plainProgressLine(current, total int64, elapsed time.Duration) string {
+	line := fmt.Sprintf("Transferred: %s", pb.Format(current).To(pb.U_BYTES))
+	if total > 0 {
+		line += fmt.Sprintf(" / %s (%d%%)", pb.Format(total).To(pb.U_BYTES), current*100/total)
+	}
+	if elapsed > 0 {
+		speed := int64(float64(current) / elapsed.Seconds())
+		line += fmt.Sprintf(", Speed: %s/s", pb.Format(speed).To(pb.U_BYTES))
+	}
+	return line
+}
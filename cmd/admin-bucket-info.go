@@ -18,7 +18,19 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/console"
 )
 
@@ -26,17 +38,253 @@ var adminBucketInfoFlags = []cli.Flag{}
 
 var adminBucketInfoCmd = cli.Command{
 	Name:            "info",
-	Usage:           "display bucket information",
+	Usage:           "display the usage, configuration and replication status of a bucket",
 	Action:          mainAdminBucketInfo,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
 	Flags:           append(adminBucketInfoFlags, globalFlags...),
 	HideHelpCommand: true,
-	Hidden:          true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Gathers from the server the usage of a bucket computed by the scanner, its versioning,
+  object lock, quota and lifecycle configuration, and the backlog of each replication
+  target. A feature the server does not support is reported as unavailable.
+
+EXAMPLES:
+  1. Display the information of 'mybucket' on 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  2. Display the replication backlog of 'mybucket' on 'myminio' in JSON.
+     {{.Prompt}} {{.HelpName}} --json myminio/mybucket | jq .replication
+`,
+}
+
+// adminBucketUsage is the usage of a bucket computed by the scanner.
+type adminBucketUsage struct {
+	Size       uint64    `json:"size"`
+	Objects    uint64    `json:"objects"`
+	Versions   uint64    `json:"versions"`
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+// adminBucketReplicationTarget is the backlog of a replication target.
+type adminBucketReplicationTarget struct {
+	Arn          string `json:"arn"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Bucket       string `json:"bucket,omitempty"`
+	PendingSize  uint64 `json:"pendingSize"`
+	PendingCount uint64 `json:"pendingCount"`
+	FailedSize   uint64 `json:"failedSize"`
+	FailedCount  uint64 `json:"failedCount"`
+}
+
+// adminBucketInfoMessage container for the information of a bucket.
+type adminBucketInfoMessage struct {
+	Status         string                         `json:"status"`
+	Bucket         string                         `json:"bucket"`
+	Usage          *adminBucketUsage              `json:"usage,omitempty"`
+	Versioning     string                         `json:"versioning"`
+	ObjectLock     string                         `json:"objectLock"`
+	Quota          *madmin.BucketQuota            `json:"quota,omitempty"`
+	LifecycleRules int                            `json:"lifecycleRules"`
+	Replication    []adminBucketReplicationTarget `json:"replication,omitempty"`
+	// Parts of the information the server could not provide, with the reason.
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// String colorized bucket information message.
+func (a adminBucketInfoMessage) String() string {
+	var b strings.Builder
+	field := func(name, value string) {
+		fmt.Fprintf(&b, "%-12s: %s\n", name, value)
+	}
+	unavailable := func(name string) string {
+		return console.Colorize("Unavailable", "unavailable ("+a.Unavailable[name]+")")
+	}
+
+	fmt.Fprintln(&b, console.Colorize("Title", fmt.Sprintf("%-12s: %s", "Bucket", a.Bucket)))
+	switch {
+	case a.Usage != nil:
+		usage := fmt.Sprintf("%s, %s objects, %s versions", humanize.IBytes(a.Usage.Size),
+			humanize.Comma(int64(a.Usage.Objects)), humanize.Comma(int64(a.Usage.Versions)))
+		if !a.Usage.LastUpdate.IsZero() {
+			usage += ", updated " + humanize.Time(a.Usage.LastUpdate)
+		}
+		field("Usage", usage)
+	case a.Unavailable["usage"] != "":
+		field("Usage", unavailable("usage"))
+	}
+	field("Versioning", a.Versioning)
+	field("Object lock", a.ObjectLock)
+	switch {
+	case a.Quota != nil && a.Quota.Quota > 0:
+		field("Quota", fmt.Sprintf("%s (%s)", humanize.IBytes(a.Quota.Quota), a.Quota.Type))
+	case a.Unavailable["quota"] != "":
+		field("Quota", unavailable("quota"))
+	default:
+		field("Quota", "none")
+	}
+	field("Lifecycle", fmt.Sprintf("%d rule(s)", a.LifecycleRules))
+
+	switch {
+	case a.Unavailable["replication"] != "":
+		field("Replication", unavailable("replication"))
+	case len(a.Replication) == 0:
+		field("Replication", "disabled")
+	default:
+		var pendingSize, pendingCount, failedCount uint64
+		for _, t := range a.Replication {
+			pendingSize += t.PendingSize
+			pendingCount += t.PendingCount
+			failedCount += t.FailedCount
+		}
+		field("Replication", fmt.Sprintf("%d target(s), %s pending in %s operation(s), %s failed operation(s)",
+			len(a.Replication), humanize.IBytes(pendingSize), humanize.Comma(int64(pendingCount)), humanize.Comma(int64(failedCount))))
+		for _, t := range a.Replication {
+			target := t.Arn
+			if t.Endpoint != "" {
+				target = t.Endpoint + "/" + t.Bucket
+			}
+			failed := fmt.Sprintf("%s failed (%d)", humanize.IBytes(t.FailedSize), t.FailedCount)
+			if t.FailedCount > 0 {
+				failed = console.Colorize("Unavailable", failed)
+			}
+			fmt.Fprintf(&b, "  %s: %s pending (%d), %s\n", target, humanize.IBytes(t.PendingSize), t.PendingCount, failed)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified bucket information message.
+func (a adminBucketInfoMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminBucketInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// getAdminBucketInfo gathers the information of a bucket, the parts the
+// server cannot provide are recorded as unavailable instead of failing.
+func getAdminBucketInfo(ctx context.Context, clnt Client, adminClnt *madmin.AdminClient, bucket string) (adminBucketInfoMessage, *probe.Error) {
+	info, err := clnt.GetBucketInfo(ctx)
+	if err != nil {
+		return adminBucketInfoMessage{}, err
+	}
+	msg := adminBucketInfoMessage{
+		Bucket:      bucket,
+		Versioning:  "unversioned",
+		ObjectLock:  "disabled",
+		Unavailable: make(map[string]string),
+	}
+	if info.Versioning.Status != "" {
+		msg.Versioning = strings.ToLower(info.Versioning.Status)
+	}
+	if info.Locking.Enabled == "Enabled" {
+		msg.ObjectLock = "enabled"
+		if info.Locking.Mode != "" {
+			msg.ObjectLock += fmt.Sprintf(", %s for %s", info.Locking.Mode, info.Locking.Validity)
+		}
+	}
+	if info.ILM.Config != nil {
+		msg.LifecycleRules = len(info.ILM.Config.Rules)
+	}
+
+	if usage, e := adminClnt.DataUsageInfo(ctx); e != nil {
+		msg.Unavailable["usage"] = e.Error()
+	} else if bu, ok := usage.BucketsUsage[bucket]; ok {
+		msg.Usage = &adminBucketUsage{
+			Size:       bu.Size,
+			Objects:    bu.ObjectsCount,
+			Versions:   bu.VersionsCount,
+			LastUpdate: usage.LastUpdate,
+		}
+	} else {
+		msg.Unavailable["usage"] = "not scanned yet"
+	}
+
+	if quota, e := adminClnt.GetBucketQuota(ctx, bucket); e != nil {
+		msg.Unavailable["quota"] = e.Error()
+	} else {
+		msg.Quota = &quota
+	}
+
+	if info.Replication.Enabled {
+		if metrics, err := clnt.GetReplicationMetrics(ctx); err != nil {
+			msg.Unavailable["replication"] = err.ToGoError().Error()
+		} else {
+			// Without the targets, only their ARN is shown.
+			targets, _ := adminClnt.ListRemoteTargets(ctx, bucket, string(madmin.ReplicationService))
+			msg.Replication = newAdminBucketReplicationTargets(targets, metrics.Stats)
+		}
+	}
+
+	if len(msg.Unavailable) == 0 {
+		msg.Unavailable = nil
+	}
+	return msg, nil
+}
+
+// newAdminBucketReplicationTargets returns the backlog of each replication
+// target, ordered by ARN.
+func newAdminBucketReplicationTargets(targets []madmin.BucketTarget, stats map[string]replication.TargetMetrics) []adminBucketReplicationTarget {
+	byArn := make(map[string]madmin.BucketTarget, len(targets))
+	for _, t := range targets {
+		byArn[t.Arn] = t
+	}
+	replTargets := make([]adminBucketReplicationTarget, 0, len(stats))
+	for arn, st := range stats {
+		replTargets = append(replTargets, adminBucketReplicationTarget{
+			Arn:          arn,
+			Endpoint:     byArn[arn].Endpoint,
+			Bucket:       byArn[arn].TargetBucket,
+			PendingSize:  st.PendingSize,
+			PendingCount: st.PendingCount,
+			FailedSize:   st.FailedSize,
+			FailedCount:  st.FailedCount,
+		})
+	}
+	sort.Slice(replTargets, func(i, j int) bool {
+		return replTargets[i].Arn < replTargets[j].Arn
+	})
+	return replTargets
 }
 
 // mainAdminBucketInfo is the handler for "mc admin bucket info" command.
 func mainAdminBucketInfo(ctx *cli.Context) error {
-	console.Infoln("Please use 'mc stat'")
+	checkAdminBucketInfoSyntax(ctx)
+
+	console.SetColor("Title", color.New(color.Bold, color.FgBlue))
+	console.SetColor("Unavailable", color.New(color.FgYellow))
+
+	aliasedURL := ctx.Args().Get(0)
+	_, bucket := url2Alias(aliasedURL)
+	bucket = strings.SplitN(bucket, "/", 2)[0]
+	if bucket == "" {
+		fatalIf(errInvalidArgument().Trace(aliasedURL), "Please provide a bucket, e.g. myminio/mybucket.")
+	}
+
+	adminClnt, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin client.")
+	clnt, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
+
+	msg, err := getAdminBucketInfo(globalContext, clnt, adminClnt, bucket)
+	fatalIf(err.Trace(aliasedURL), "Unable to get the information of bucket `"+bucket+"`.")
+	printMsg(msg)
 	return nil
 }
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestNewAdminBucketReplicationTargets(t *testing.T) {
+	targets := []madmin.BucketTarget{
+		{Arn: "arn:b", Endpoint: "site-b:9000", TargetBucket: "backup"},
+	}
+	stats := map[string]replication.TargetMetrics{
+		"arn:b": {PendingSize: 10, PendingCount: 2, FailedCount: 1},
+		"arn:a": {PendingSize: 5, PendingCount: 1},
+	}
+	replTargets := newAdminBucketReplicationTargets(targets, stats)
+	if len(replTargets) != 2 || replTargets[0].Arn != "arn:a" || replTargets[1].Arn != "arn:b" {
+		t.Fatalf("Expected the targets ordered by ARN, got %+v", replTargets)
+	}
+	if replTargets[0].Endpoint != "" || replTargets[1].Endpoint != "site-b:9000" || replTargets[1].Bucket != "backup" {
+		t.Errorf("Unexpected target endpoints %+v", replTargets)
+	}
+	if replTargets[1].PendingSize != 10 || replTargets[1].PendingCount != 2 || replTargets[1].FailedCount != 1 {
+		t.Errorf("Unexpected target metrics %+v", replTargets[1])
+	}
+}
+
+func TestAdminBucketInfoMessageUnavailable(t *testing.T) {
+	msg := adminBucketInfoMessage{
+		Bucket:      "mybucket",
+		Versioning:  "enabled",
+		ObjectLock:  "disabled",
+		Unavailable: map[string]string{"quota": "not implemented"},
+	}
+	out := msg.String()
+	for _, expected := range []string{"mybucket", "Versioning  : enabled", "Quota       : unavailable (not implemented)", "Replication : disabled"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Expected %q in %q", expected, out)
+		}
+	}
+	if strings.Contains(out, "Usage") {
+		t.Errorf("Expected no usage without scanner data, got %q", out)
+	}
+}
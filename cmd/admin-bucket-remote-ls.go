@@ -0,0 +1,201 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminBucketRemoteListFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "service",
+		Usage: "list only the remote targets of this type of service",
+	},
+}
+
+var adminBucketRemoteListCmd = cli.Command{
+	Name:         "ls",
+	Usage:        "list configured remote targets",
+	Action:       mainAdminBucketRemoteList,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(globalFlags, adminBucketRemoteListFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the remote targets of the bucket "srcbucket" of "myminio", secret keys are not displayed.
+     {{.Prompt}} {{.HelpName}} myminio/srcbucket
+
+  2. List the replication targets of all buckets of "myminio".
+     {{.Prompt}} {{.HelpName}} myminio --service replication
+`,
+}
+
+// checkAdminBucketRemoteListSyntax - validate all the passed arguments
+func checkAdminBucketRemoteListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+type remoteLSRowHdr int
+
+const (
+	remoteLSArnHdr remoteLSRowHdr = iota
+	remoteLSSourceHdr
+	remoteLSEndpointHdr
+	remoteLSBucketHdr
+	remoteLSCredentialsHdr
+	remoteLSTypeHdr
+)
+
+var remoteLSRowNames = []string{
+	"ARN",
+	"Source",
+	"Endpoint",
+	"Bucket",
+	"Credentials",
+	"Type",
+}
+
+var remoteLSColorScheme = []*color.Color{
+	color.New(color.FgHiBlue),
+	color.New(color.FgYellow),
+	color.New(color.FgGreen),
+	color.New(color.FgHiWhite),
+	color.New(color.FgYellow, color.Italic),
+	color.New(color.FgCyan),
+}
+
+// redactedSecret replaces a secret key which must not be displayed.
+const redactedSecret = "REDACTED"
+
+// maskRemoteCredentials returns the access key of the target with its secret key masked.
+func maskRemoteCredentials(target madmin.BucketTarget) string {
+	if target.Credentials == nil {
+		return ""
+	}
+	return target.Credentials.AccessKey + ":" + redactedSecret
+}
+
+type remoteLS []madmin.BucketTarget
+
+func (t remoteLS) NumRows() int {
+	return len(t)
+}
+
+func (t remoteLS) NumCols() int {
+	return len(remoteLSRowNames)
+}
+
+func (t remoteLS) EmptyMessage() string {
+	return "No remote target has been configured"
+}
+
+func (t remoteLS) ToRow(i int, ls []int) []string {
+	row := make([]string, len(remoteLSRowNames))
+	if i == -1 {
+		copy(row, remoteLSRowNames)
+	} else {
+		target := t[i]
+		scheme := "http://"
+		if target.Secure {
+			scheme = "https://"
+		}
+		row[remoteLSArnHdr] = target.Arn
+		row[remoteLSSourceHdr] = target.SourceBucket
+		row[remoteLSEndpointHdr] = scheme + target.Endpoint
+		row[remoteLSBucketHdr] = target.TargetBucket
+		row[remoteLSCredentialsHdr] = maskRemoteCredentials(target)
+		row[remoteLSTypeHdr] = string(target.Type)
+	}
+
+	// update ls to accommodate this row's values
+	for i := range remoteLSRowNames {
+		if ls[i] < len(row[i]) {
+			ls[i] = len(row[i])
+		}
+	}
+	return row
+}
+
+var _ tabulator = (remoteLS)(nil)
+
+type remoteListMessage struct {
+	Status  string                `json:"status"`
+	Targets []madmin.BucketTarget `json:"targets"`
+}
+
+// String method returns a tabular listing of remote targets.
+func (msg remoteListMessage) String() string {
+	return toTable(remoteLS(msg.Targets))
+}
+
+// JSON method returns JSON encoding of msg.
+func (msg remoteListMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(msg, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// mainAdminBucketRemoteList is the handle for "mc admin bucket remote ls" command.
+func mainAdminBucketRemoteList(cliCtx *cli.Context) error {
+	ctx, cancelRemoteList := context.WithCancel(globalContext)
+	defer cancelRemoteList()
+
+	checkAdminBucketRemoteListSyntax(cliCtx)
+
+	for i, color := range remoteLSColorScheme {
+		console.SetColor(remoteLSRowNames[i], color)
+	}
+
+	args := cliCtx.Args()
+	aliasedURL := args.Get(0)
+	_, sourceBucket := url2Alias(aliasedURL)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	targets, e := client.ListRemoteTargets(ctx, sourceBucket, cliCtx.String("service"))
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to list remote targets.")
+
+	// Never display the secret keys.
+	for i := range targets {
+		if targets[i].Credentials != nil {
+			targets[i].Credentials = &madmin.Credentials{AccessKey: targets[i].Credentials.AccessKey}
+		}
+	}
+
+	printMsg(remoteListMessage{Status: "success", Targets: targets})
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestRemoteListMasksSecret(t *testing.T) {
+	targets := remoteLS{{
+		Arn:          "arn:minio:replication::id:targetbucket",
+		SourceBucket: "srcbucket",
+		Endpoint:     "minio.siteb.example.com",
+		Secure:       true,
+		TargetBucket: "targetbucket",
+		Credentials:  &madmin.Credentials{AccessKey: "foobar", SecretKey: "foo12345"},
+		Type:         madmin.ReplicationService,
+	}}
+	lengths := make([]int, targets.NumCols())
+	row := targets.ToRow(0, lengths)
+	expected := []string{
+		"arn:minio:replication::id:targetbucket",
+		"srcbucket",
+		"https://minio.siteb.example.com",
+		"targetbucket",
+		"foobar:" + redactedSecret,
+		"replication",
+	}
+	if strings.Join(row, "|") != strings.Join(expected, "|") {
+		t.Fatalf("Expected %v, got %v", expected, row)
+	}
+	if table := toTable(targets); strings.Contains(table, "foo12345") {
+		t.Fatalf("Secret key displayed in %s", table)
+	}
+}
@@ -18,24 +18,78 @@
 package cmd
 
 import (
+	"context"
+
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
 
+var adminBucketRemoteRmFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "arn",
+		Usage: "ARN of the remote target to remove",
+	},
+}
+
 var adminBucketRemoteRmCmd = cli.Command{
 	Name:         "rm",
 	Usage:        "remove configured remote target",
 	Action:       mainAdminBucketRemoteRemove,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
-	HideHelp:     true,
-	Hidden:       true,
+	Flags:        append(globalFlags, adminBucketRemoteRmFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET --arn ARN
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove a replication target of the bucket "srcbucket" of "myminio".
+     {{.Prompt}} {{.HelpName}} myminio/srcbucket --arn "arn:minio:replication::c5be6b16-769d-432a-9ef1-4567081f3566:targetbucket"
+`,
+}
+
+// checkAdminBucketRemoteRmSyntax - validate all the passed arguments
+func checkAdminBucketRemoteRmSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if ctx.String("arn") == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--arn flag needs to be specified.")
+	}
 }
 
 // mainAdminBucketRemoteRemove is the handle for "mc admin bucket remote rm" command.
-func mainAdminBucketRemoteRemove(ctx *cli.Context) error {
-	console.Infoln("Please use 'mc replicate rm'")
+func mainAdminBucketRemoteRemove(cliCtx *cli.Context) error {
+	ctx, cancelRemoteRm := context.WithCancel(globalContext)
+	defer cancelRemoteRm()
+
+	checkAdminBucketRemoteRmSyntax(cliCtx)
+
+	console.SetColor("RemoteMessage", color.New(color.FgGreen))
+
+	args := cliCtx.Args()
+	aliasedURL := args.Get(0)
+	_, sourceBucket := url2Alias(aliasedURL)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	arn := cliCtx.String("arn")
+	e := client.RemoveRemoteTarget(ctx, sourceBucket, arn)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to remove remote target `"+arn+"`.")
 
+	printMsg(remoteTargetMessage{
+		Op:           cliCtx.Command.Name,
+		SourceBucket: sourceBucket,
+		Arn:          arn,
+	})
 	return nil
 }
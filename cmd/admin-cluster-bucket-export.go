@@ -44,7 +44,7 @@ var adminClusterBucketExportCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] TARGET/[BUCKET]
+  {{.HelpName}} [FLAGS] TARGET/[BUCKET] [FILE]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -52,11 +52,15 @@ FLAGS:
 EXAMPLES:
   1. Save metadata of all buckets to a zip file.
      {{.Prompt}} {{.HelpName}} myminio
+
+  2. Save policy, lifecycle, replication, encryption, tags, notification, quota, versioning and
+     object lock configuration of 'mybucket' to 'mybucket.zip', to import it on another cluster.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket mybucket.zip
 `,
 }
 
 func checkBucketExportSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 }
@@ -105,6 +109,9 @@ func mainClusterBucketExport(ctx *cli.Context) error {
 
 	// We use 4 bytes of the 32 bytes to identify the file.
 	downloadPath := fmt.Sprintf("%s-metadata.%s", bucket, ext)
+	if args.Get(1) != "" {
+		downloadPath = args.Get(1)
+	}
 	fi, e := os.Stat(downloadPath)
 	if e == nil && !fi.IsDir() {
 		e = moveFile(downloadPath, downloadPath+"."+time.Now().Format(dateTimeFormatFilename))
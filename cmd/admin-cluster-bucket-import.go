@@ -55,6 +55,10 @@ FLAGS:
 EXAMPLES:
   1. Recover bucket metadata for all buckets from previously saved bucket metadata backup.
      {{.Prompt}} {{.HelpName}} myminio /backups/cluster-metadata.zip
+
+  2. Apply the configuration exported from 'mybucket' on another cluster, configuration which
+     cannot be applied (e.g. object lock on an existing bucket) is reported and skipped.
+     {{.Prompt}} {{.HelpName}} otherminio/mybucket mybucket.zip
 `,
 }
 
@@ -140,6 +144,15 @@ func statusTick(s madmin.MetaStatus) string {
 	}
 }
 
+// statusDetail returns the status tick followed by the
+// reason of the failure, if any.
+func statusDetail(s madmin.MetaStatus) string {
+	if s.Err != "" {
+		return statusTick(s) + " " + s.Err
+	}
+	return statusTick(s)
+}
+
 func (i importMetaMsg) String() string {
 	m := i.BucketMetaImportErrs.Buckets
 	totBuckets := len(m)
@@ -195,36 +208,36 @@ func printImportErrs(bucket string, r madmin.BucketStatus) string {
 	fmt.Fprintln(&b, console.Colorize("Name", key))
 
 	if r.ObjectLock.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Object lock: ", statusTick(r.ObjectLock))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Object lock: ", statusDetail(r.ObjectLock))
 		fmt.Fprintln(&b)
 	}
 	if r.Versioning.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Versioning: ", statusTick(r.Versioning))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Versioning: ", statusDetail(r.Versioning))
 		fmt.Fprintln(&b)
 	}
 
 	if r.SSEConfig.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Encryption: ", statusTick(r.SSEConfig))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Encryption: ", statusDetail(r.SSEConfig))
 		fmt.Fprintln(&b)
 	}
 	if r.Lifecycle.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Lifecycle: ", statusTick(r.Lifecycle))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Lifecycle: ", statusDetail(r.Lifecycle))
 		fmt.Fprintln(&b)
 	}
 	if r.Notification.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Notification: ", statusTick(r.Notification))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Notification: ", statusDetail(r.Notification))
 		fmt.Fprintln(&b)
 	}
 	if r.Quota.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Quota: ", statusTick(r.Quota))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Quota: ", statusDetail(r.Quota))
 		fmt.Fprintln(&b)
 	}
 	if r.Policy.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Policy: ", statusTick(r.Policy))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Policy: ", statusDetail(r.Policy))
 		fmt.Fprintln(&b)
 	}
 	if r.Tagging.IsSet {
-		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Tagging: ", statusTick(r.Tagging))
+		fmt.Fprintf(&b, "%2s%s %s", placeHolder, "Tagging: ", statusDetail(r.Tagging))
 		fmt.Fprintln(&b)
 	}
 	return b.String()
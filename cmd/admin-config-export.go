@@ -22,36 +22,56 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
 
+var adminConfigExportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "template",
+		Usage: "replace secret values with ${ENV_VAR} placeholders, to be substituted by 'mc admin config import'",
+	},
+}
+
 var adminConfigExportCmd = cli.Command{
 	Name:         "export",
 	Usage:        "export all config keys to STDOUT",
 	Before:       setGlobalsFromContext,
 	Action:       mainAdminConfigExport,
 	OnUsageError: onUsageError,
-	Flags:        globalFlags,
+	Flags:        append(adminConfigExportFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  With --template, the config is exported as one 'SUB-SYSTEM[:TARGET] KEY=VALUE ...'
+  line per sub-system target, without the environment variables set on the server,
+  and every secret value is replaced by a placeholder such as
+  ${MINIO_IDENTITY_OPENID_CLIENT_SECRET}. The output contains no plaintext secrets
+  and can be kept under version control, 'mc admin config import --expand-env'
+  substitutes the placeholders from its environment.
+
 EXAMPLES:
   The output includes environment variables set on the server. These cannot be overridden from the client.
 
   1. Export the current config from MinIO server
      {{.Prompt}} {{.HelpName}} play/ > config.txt
+
+  2. Export the current config from MinIO server as a template without secrets
+     {{.Prompt}} {{.HelpName}} --template play/ > config.kv
 `,
 }
 
@@ -116,6 +136,12 @@ func mainAdminConfigExport(ctx *cli.Context) error {
 	buf, e := client.GetConfig(globalContext)
 	fatalIf(probe.NewError(e), "Unable to get server config")
 
+	if ctx.Bool("template") {
+		tmpl, e := configTemplate(string(buf))
+		fatalIf(probe.NewError(e), "Unable to parse server config")
+		buf = []byte(tmpl)
+	}
+
 	// Print
 	printMsg(configExportMessage{
 		Value: buf,
@@ -123,3 +149,52 @@ func mainAdminConfigExport(ctx *cli.Context) error {
 
 	return nil
 }
+
+// configTemplateVarRegex matches the characters not allowed in the
+// placeholder variable names.
+var configTemplateVarRegex = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// configTemplateVar returns the name of the environment variable a
+// secret config key is templated with, named after the server
+// environment variable for the same key.
+func configTemplateVar(subSys, target, key string) string {
+	name := madmin.EnvPrefix + subSys + madmin.EnvWordDelimiter + key
+	if target != "" {
+		name += madmin.EnvWordDelimiter + target
+	}
+	return configTemplateVarRegex.ReplaceAllString(strings.ToUpper(name), madmin.EnvWordDelimiter)
+}
+
+// configTemplate converts the server config output to one line per
+// sub-system target, with every secret value replaced by a ${VAR}
+// placeholder. Environment variables set on the server are left out.
+func configTemplate(output string) (string, error) {
+	configs, e := madmin.ParseServerConfigOutput(output)
+	if e != nil {
+		return "", e
+	}
+
+	var sb strings.Builder
+	for _, cfg := range configs {
+		sb.WriteString(cfg.SubSystem)
+		if cfg.Target != "" {
+			sb.WriteString(madmin.SubSystemSeparator + cfg.Target)
+		}
+		for _, kv := range mergeTargetEnvOverrides(cfg) {
+			if kv.EnvOverride != nil && kv.Value == "" {
+				// Only known from the server environment.
+				continue
+			}
+			value := kv.Value
+			switch {
+			case isConfigSecret(cfg.SubSystem, kv.Key, value):
+				value = "${" + configTemplateVar(cfg.SubSystem, cfg.Target, kv.Key) + "}"
+			case madmin.HasSpace(value):
+				value = madmin.KvDoubleQuote + value + madmin.KvDoubleQuote
+			}
+			sb.WriteString(madmin.KvSpaceSeparator + kv.Key + madmin.KvSeparator + value)
+		}
+		sb.WriteString(madmin.KvNewline)
+	}
+	return sb.String(), nil
+}
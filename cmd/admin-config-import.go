@@ -18,46 +18,89 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
 
+var adminConfigImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "expand-env",
+		Usage: "replace the ${VAR} placeholders of a config template with the environment variables",
+	},
+}
+
 var adminConfigImportCmd = cli.Command{
 	Name:         "import",
-	Usage:        "import multiple config keys from STDIN",
+	Usage:        "import multiple config keys from a file or STDIN",
 	Before:       setGlobalsFromContext,
 	Action:       mainAdminConfigImport,
 	OnUsageError: onUsageError,
-	Flags:        globalFlags,
+	Flags:        append(adminConfigImportFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET [FILE]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  The config is read from FILE, or from STDIN when no FILE is given. With
+  --expand-env, every ${VAR} placeholder, as written by 'mc admin config export
+  --template', is replaced by the value of the environment variable VAR, the
+  import fails if any of them is not set. Without it the config is imported
+  as is. The config keys changing from the live config are reported, with
+  secret values masked.
+
 EXAMPLES:
   1. Import the new local config and apply to the MinIO server
      {{.Prompt}} {{.HelpName}} play/ < config.txt
+
+  2. Import a config template, substituting its secrets from the environment
+     {{.Prompt}} export MINIO_IDENTITY_OPENID_CLIENT_SECRET=mysecret
+     {{.Prompt}} {{.HelpName}} --expand-env play/ config.kv
 `,
 }
 
-// configImportMessage container to hold locks information.
+// configImportChange is a config key changed by an import.
+type configImportChange struct {
+	SubSystem string `json:"subSystem"`
+	Target    string `json:"target,omitempty"`
+	Key       string `json:"key"`
+	OldValue  string `json:"oldValue"`
+	NewValue  string `json:"newValue"`
+}
+
+func (c configImportChange) name() string {
+	return configEnvKey{SubSystem: c.SubSystem, Target: c.Target, Key: c.Key}.name()
+}
+
 type configImportMessage struct {
-	Status      string `json:"status"`
+	Status      string               `json:"status"`
+	Changes     []configImportChange `json:"changes"`
 	targetAlias string
 }
 
-// String colorized service status message.
 func (u configImportMessage) String() (msg string) {
+	if len(u.Changes) == 0 {
+		return console.Colorize("SetConfigSuccess", "No config keys changed.")
+	}
+	for _, c := range u.Changes {
+		msg += fmt.Sprintf("%s: %s -> %s\n", c.name(), c.OldValue, c.NewValue)
+	}
 	msg += console.Colorize("SetConfigSuccess",
 		"Setting new key has been successful.\n")
 	suggestion := fmt.Sprintf("mc admin service restart %s", u.targetAlias)
@@ -66,7 +109,6 @@ func (u configImportMessage) String() (msg string) {
 	return msg
 }
 
-// JSON jsonified service status Message message.
 func (u configImportMessage) JSON() string {
 	u.Status = "success"
 	statusJSONBytes, e := json.MarshalIndent(u, "", " ")
@@ -75,9 +117,92 @@ func (u configImportMessage) JSON() string {
 	return string(statusJSONBytes)
 }
 
-// checkAdminConfigImportSyntax - validate all the passed arguments
+// configPlaceholderRegex matches the ${VAR} placeholders of a config template.
+var configPlaceholderRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// substituteConfigPlaceholders replaces the ${VAR} placeholders of the
+// config with the values returned by lookup, values with spaces are
+// quoted. The names of the variables lookup has no value for are
+// returned sorted, when there are any the config is not usable.
+func substituteConfigPlaceholders(config string, lookup func(string) (string, bool)) (string, []string) {
+	missing := make(map[string]struct{})
+	config = configPlaceholderRegex.ReplaceAllStringFunc(config, func(placeholder string) string {
+		name := configPlaceholderRegex.FindStringSubmatch(placeholder)[1]
+		value, ok := lookup(name)
+		if !ok {
+			missing[name] = struct{}{}
+			return placeholder
+		}
+		if madmin.HasSpace(value) {
+			value = madmin.KvDoubleQuote + value + madmin.KvDoubleQuote
+		}
+		return value
+	})
+
+	var names []string
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return config, names
+}
+
+// configImportChanges returns the keys of the imported config whose
+// value differs from the live config, sorted by sub-system, target and
+// key. Secret values are masked.
+func configImportChanges(live, imported string) ([]configImportChange, error) {
+	liveConfigs, e := madmin.ParseServerConfigOutput(live)
+	if e != nil {
+		return nil, e
+	}
+	// Comments, including the environment variables of an export, are
+	// not applied by the server.
+	var lines []string
+	for _, line := range strings.Split(imported, madmin.KvNewline) {
+		if !strings.HasPrefix(strings.TrimSpace(line), madmin.KvComment) {
+			lines = append(lines, line)
+		}
+	}
+	importedConfigs, e := madmin.ParseServerConfigOutput(strings.Join(lines, madmin.KvNewline))
+	if e != nil {
+		return nil, e
+	}
+
+	liveValues := make(map[string]string)
+	for _, cfg := range liveConfigs {
+		for _, kv := range mergeTargetEnvOverrides(cfg) {
+			k := configEnvKey{SubSystem: cfg.SubSystem, Target: cfg.Target, Key: kv.Key}
+			liveValues[k.name()] = kv.Value
+		}
+	}
+
+	var changes []configImportChange
+	for _, cfg := range importedConfigs {
+		for _, kv := range cfg.KV {
+			c := configImportChange{SubSystem: cfg.SubSystem, Target: cfg.Target, Key: kv.Key}
+			old := liveValues[c.name()]
+			if old == kv.Value {
+				continue
+			}
+			c.OldValue = maskConfigValue(cfg.SubSystem, kv.Key, old)
+			c.NewValue = maskConfigValue(cfg.SubSystem, kv.Key, kv.Value)
+			changes = append(changes, c)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].SubSystem != changes[j].SubSystem {
+			return changes[i].SubSystem < changes[j].SubSystem
+		}
+		if changes[i].Target != changes[j].Target {
+			return changes[i].Target < changes[j].Target
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
 func checkAdminConfigImportSyntax(ctx *cli.Context) {
-	if !ctx.Args().Present() || len(ctx.Args()) > 1 {
+	if !ctx.Args().Present() || len(ctx.Args()) > 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
 }
@@ -92,15 +217,44 @@ func mainAdminConfigImport(ctx *cli.Context) error {
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
 
+	var r io.Reader = os.Stdin
+	if file := args.Get(1); file != "" {
+		f, e := os.Open(file)
+		fatalIf(probe.NewError(e).Trace(file), "Unable to open the config file.")
+		defer f.Close()
+		r = f
+	}
+	buf, e := io.ReadAll(r)
+	fatalIf(probe.NewError(e), "Unable to read the config.")
+
+	config := string(buf)
+	if ctx.Bool("expand-env") {
+		var missing []string
+		config, missing = substituteConfigPlaceholders(config, os.LookupEnv)
+		if len(missing) > 0 {
+			fatalIf(errInvalidArgument().Trace(missing...),
+				"The config placeholders require the environment variables %s to be set.", strings.Join(missing, ", "))
+		}
+	}
+
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	// Call set config API
-	fatalIf(probe.NewError(client.SetConfig(globalContext, os.Stdin)), "Unable to set server config")
+	live, e := client.GetConfig(globalContext)
+	fatalIf(probe.NewError(e), "Unable to get server config")
+
+	changes, e := configImportChanges(string(live), config)
+	fatalIf(probe.NewError(e), "Unable to parse the config.")
+
+	if len(changes) > 0 {
+		// Call set config API
+		fatalIf(probe.NewError(client.SetConfig(globalContext, bytes.NewReader([]byte(config)))), "Unable to set server config")
+	}
 
 	// Print
 	printMsg(configImportMessage{
+		Changes:     changes,
 		targetAlias: aliasedURL,
 	})
 
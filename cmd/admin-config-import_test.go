@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubstituteConfigPlaceholders(t *testing.T) {
+	env := map[string]string{
+		"MINIO_IDENTITY_OPENID_CLIENT_SECRET_OKTA": "s3cr3t",
+		"MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN":          "Bearer abc",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	config, missing := substituteConfigPlaceholders(`identity_openid:okta client_id=minio client_secret=${MINIO_IDENTITY_OPENID_CLIENT_SECRET_OKTA}
+notify_webhook enable=on auth_token=${MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN}
+`, lookup)
+	if len(missing) != 0 {
+		t.Fatalf("unexpected missing variables %v", missing)
+	}
+	want := `identity_openid:okta client_id=minio client_secret=s3cr3t
+notify_webhook enable=on auth_token="Bearer abc"
+`
+	if config != want {
+		t.Errorf("expected %q, got %q", want, config)
+	}
+
+	_, missing = substituteConfigPlaceholders("a k=${B} l=${A} m=${B} n=${MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN}", lookup)
+	if !reflect.DeepEqual(missing, []string{"A", "B"}) {
+		t.Errorf("expected missing [A B], got %v", missing)
+	}
+}
+
+func TestConfigImportChanges(t *testing.T) {
+	live := `# MINIO_API_REQUESTS_DEADLINE=20s
+api requests_max=0 requests_deadline=10s
+identity_openid:okta client_id=minio client_secret=old scopes=
+`
+	imported := `# MINIO_API_REQUESTS_DEADLINE=20s
+api requests_max=0 requests_deadline=30s
+identity_openid:okta client_id=minio client_secret=new scopes="openid email"
+`
+	changes, e := configImportChanges(live, imported)
+	if e != nil {
+		t.Fatal(e)
+	}
+	want := []configImportChange{
+		{SubSystem: "api", Key: "requests_deadline", OldValue: "10s", NewValue: "30s"},
+		{SubSystem: "identity_openid", Target: "okta", Key: "client_secret", OldValue: "*redacted*", NewValue: "*redacted*"},
+		{SubSystem: "identity_openid", Target: "okta", Key: "scopes", OldValue: "", NewValue: "openid email"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("expected %+v, got %+v", want, changes)
+	}
+
+	changes, e = configImportChanges(live, live)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
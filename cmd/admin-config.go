@@ -27,6 +27,7 @@ var adminConfigSubcommands = []cli.Command{
 	adminConfigRestoreCmd,
 	adminConfigExportCmd,
 	adminConfigImportCmd,
+	adminConfigEnvCmd,
 }
 
 var adminConfigCmd = cli.Command{
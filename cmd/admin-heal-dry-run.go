@@ -0,0 +1,121 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// healDamage counts the items found damaged by a heal dry run, by kind
+// of damage. An item with several kinds of damage is counted in each.
+type healDamage struct {
+	// Items with missing or outdated shards.
+	Missing int64 `json:"missing"`
+	// Items with corrupted shards, deep scans detect bitrot.
+	Corrupted int64 `json:"corrupted"`
+	// Items with shards on offline drives, which cannot be inspected.
+	Offline int64 `json:"offline"`
+	// Items with shards on faulty, unformatted or inaccessible drives.
+	Faulty int64 `json:"faulty"`
+
+	// Items and objects needing heal, and the size of these objects.
+	Items   int64 `json:"items"`
+	Objects int64 `json:"objects"`
+	Size    int64 `json:"size"`
+}
+
+// add records the damage of a heal result item, the drives before heal
+// show the damage as nothing is healed during a dry run.
+func (d *healDamage) add(item madmin.HealResultItem) {
+	var missing, corrupted, offline, faulty bool
+	for _, drive := range item.Before.Drives {
+		switch drive.State {
+		case madmin.DriveStateOk:
+		case madmin.DriveStateMissing:
+			missing = true
+		case madmin.DriveStateCorrupt:
+			corrupted = true
+		case madmin.DriveStateOffline:
+			offline = true
+		default:
+			faulty = true
+		}
+	}
+	if missing {
+		d.Missing++
+	}
+	if corrupted {
+		d.Corrupted++
+	}
+	if offline {
+		d.Offline++
+	}
+	if faulty {
+		d.Faulty++
+	}
+	if !missing && !corrupted && !offline && !faulty {
+		return
+	}
+	d.Items++
+	if item.Type == madmin.HealItemObject {
+		d.Objects++
+		if item.ObjectSize > 0 {
+			d.Size += item.ObjectSize
+		}
+	}
+}
+
+// healDryRunMessage container for the damage found by a heal dry run.
+type healDryRunMessage struct {
+	Status       string     `json:"status"`
+	Type         string     `json:"type"`
+	Modified     bool       `json:"modified"`
+	ScanMode     string     `json:"scanMode"`
+	ItemsScanned int64      `json:"itemsScanned"`
+	Damage       healDamage `json:"damage"`
+}
+
+func (h healDryRunMessage) String() string {
+	var b strings.Builder
+	b.WriteString(console.Colorize("HealDryRun", "Dry run, no data was modified.") + "\n")
+	fmt.Fprintf(&b, "Scanned %s items (%s scan), %s need heal, including %s objects of %s.\n",
+		humanize.Comma(h.ItemsScanned), h.ScanMode, humanize.Comma(h.Damage.Items),
+		humanize.Comma(h.Damage.Objects), humanize.IBytes(uint64(h.Damage.Size)))
+	fmt.Fprintf(&b, "  Missing or outdated shards: %s\n", humanize.Comma(h.Damage.Missing))
+	fmt.Fprintf(&b, "  Corrupted shards (bitrot):  %s\n", humanize.Comma(h.Damage.Corrupted))
+	fmt.Fprintf(&b, "  Offline drives:             %s\n", humanize.Comma(h.Damage.Offline))
+	fmt.Fprintf(&b, "  Faulty drives:              %s", humanize.Comma(h.Damage.Faulty))
+	if h.ScanMode != scanDeepMode {
+		b.WriteString("\nBitrot is only detected with `--scan deep`.")
+	}
+	return b.String()
+}
+
+func (h healDryRunMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
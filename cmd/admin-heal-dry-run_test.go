@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestHealDamage(t *testing.T) {
+	newItem := func(itemType madmin.HealItemType, size int64, states ...string) madmin.HealResultItem {
+		item := madmin.HealResultItem{Type: itemType, ObjectSize: size}
+		for _, state := range states {
+			item.Before.Drives = append(item.Before.Drives, madmin.HealDriveInfo{State: state})
+		}
+		return item
+	}
+
+	var d healDamage
+	for _, item := range []madmin.HealResultItem{
+		newItem(madmin.HealItemObject, 10, madmin.DriveStateOk, madmin.DriveStateOk),
+		newItem(madmin.HealItemObject, 20, madmin.DriveStateOk, madmin.DriveStateMissing),
+		newItem(madmin.HealItemObject, 30, madmin.DriveStateCorrupt, madmin.DriveStateMissing),
+		newItem(madmin.HealItemObject, -1, madmin.DriveStateOffline, madmin.DriveStateOk),
+		newItem(madmin.HealItemBucket, 0, madmin.DriveStateMissing, madmin.DriveStateFaulty),
+	} {
+		d.add(item)
+	}
+
+	expected := healDamage{Missing: 3, Corrupted: 1, Offline: 1, Faulty: 1, Items: 4, Objects: 3, Size: 50}
+	if d != expected {
+		t.Errorf("Expected %+v, got %+v", expected, d)
+	}
+}
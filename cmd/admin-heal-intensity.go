@@ -0,0 +1,169 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	healIntensityBackground = "background"
+	healIntensityForeground = "foreground"
+)
+
+// healIntensityKVs are the settings of the heal sub-system for each intensity.
+// In the background, the heal pauses up to max_sleep between objects while more
+// than max_io requests are in flight; a max_io of zero never pauses.
+var healIntensityKVs = map[string]string{
+	healIntensityBackground: "max_sleep=1s max_io=10",
+	healIntensityForeground: "max_io=0",
+}
+
+// healIntensityMessage container for the heal intensity of a server.
+type healIntensityMessage struct {
+	Status    string `json:"status"`
+	Intensity string `json:"intensity"`
+	MaxSleep  string `json:"maxSleep"`
+	MaxIO     string `json:"maxIO"`
+	Bitrot    string `json:"bitrotScan"`
+	Updated   bool   `json:"updated"`
+	// Restored is set when the settings in place before the heal are
+	// applied again.
+	Restored bool `json:"restored,omitempty"`
+	// Persistent is set when the settings stay in place until changed
+	// again, rather than only for the heal started along.
+	Persistent bool `json:"persistent,omitempty"`
+}
+
+// String colorized heal intensity message.
+func (h healIntensityMessage) String() string {
+	settings := fmt.Sprintf("(max_io=%s, max_sleep=%s, bitrotscan=%s)", h.MaxIO, h.MaxSleep, h.Bitrot)
+	switch {
+	case h.Restored:
+		return console.Colorize("HealIntensity", "Heal intensity restored to `"+h.Intensity+"` "+settings+".")
+	case h.Updated && h.Persistent:
+		return console.Colorize("HealIntensity", "Heal intensity set to `"+h.Intensity+"` "+settings+" on the server until it is changed again.")
+	case h.Updated:
+		return console.Colorize("HealIntensity", "Heal intensity set to `"+h.Intensity+"` "+settings+" until the heal ends.")
+	}
+	return console.Colorize("HealIntensity", "Heal intensity is `"+h.Intensity+"` "+settings+".")
+}
+
+// JSON jsonified heal intensity message.
+func (h healIntensityMessage) JSON() string {
+	h.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkHealIntensitySyntax(ctx *cli.Context) {
+	if intensity := ctx.String("intensity"); intensity != "" {
+		if _, ok := healIntensityKVs[strings.ToLower(intensity)]; !ok {
+			fatalIf(errInvalidArgument().Trace(intensity), "Invalid heal intensity, expected `"+healIntensityBackground+"` or `"+healIntensityForeground+"`.")
+		}
+	}
+	if bitrot := ctx.String("bitrot"); bitrot != "" {
+		if bitrot = strings.ToLower(bitrot); bitrot != "on" && bitrot != "off" {
+			fatalIf(errInvalidArgument().Trace(bitrot), "Invalid --bitrot value, expected `on` or `off`.")
+		}
+	}
+}
+
+// getHealIntensity returns the heal settings of a server.
+func getHealIntensity(ctx context.Context, client *madmin.AdminClient) (healIntensityMessage, error) {
+	buf, e := client.GetConfigKV(ctx, madmin.HealSubSys)
+	if e != nil {
+		return healIntensityMessage{}, e
+	}
+	return parseHealIntensity(string(buf))
+}
+
+// parseHealIntensity parses the heal settings from the heal sub-system config.
+func parseHealIntensity(config string) (healIntensityMessage, error) {
+	subSysConfigs, e := madmin.ParseServerConfigOutput(config)
+	if e != nil {
+		return healIntensityMessage{}, e
+	}
+	for _, subSysConfig := range subSysConfigs {
+		maxIO, ok := subSysConfig.Lookup("max_io")
+		if !ok {
+			continue
+		}
+		msg := healIntensityMessage{MaxIO: maxIO, Intensity: healIntensityBackground}
+		if n, e := strconv.Atoi(maxIO); e == nil && n <= 0 {
+			msg.Intensity = healIntensityForeground
+		}
+		msg.MaxSleep, _ = subSysConfig.Lookup("max_sleep")
+		msg.Bitrot, _ = subSysConfig.Lookup("bitrotscan")
+		return msg, nil
+	}
+	return healIntensityMessage{}, fmt.Errorf("heal throttling is not supported by the server")
+}
+
+// setHealIntensity applies --intensity and --bitrot to the heal sub-system,
+// the change applies immediately to the running heals and is saved in the
+// server config. The settings in place before are returned to be restored.
+func setHealIntensity(ctx context.Context, client *madmin.AdminClient, intensity, bitrot string) (msg, previous healIntensityMessage, e error) {
+	// Fail on servers without heal throttling instead of setting unknown keys.
+	if previous, e = getHealIntensity(ctx, client); e != nil {
+		return msg, previous, e
+	}
+	var kvs []string
+	if intensity != "" {
+		kvs = append(kvs, healIntensityKVs[strings.ToLower(intensity)])
+	}
+	if bitrot != "" {
+		kvs = append(kvs, "bitrotscan="+strings.ToLower(bitrot))
+	}
+	if _, e = client.SetConfigKV(ctx, madmin.HealSubSys+" "+strings.Join(kvs, " ")); e != nil {
+		return msg, previous, e
+	}
+	msg, e = getHealIntensity(ctx, client)
+	msg.Updated = true
+	return msg, previous, e
+}
+
+// restoreHealIntensity applies again the heal settings returned by
+// setHealIntensity.
+func restoreHealIntensity(ctx context.Context, client *madmin.AdminClient, previous healIntensityMessage) (healIntensityMessage, error) {
+	kvs := []string{"max_io=" + previous.MaxIO}
+	if previous.MaxSleep != "" {
+		kvs = append(kvs, "max_sleep="+previous.MaxSleep)
+	}
+	if previous.Bitrot != "" {
+		kvs = append(kvs, "bitrotscan="+previous.Bitrot)
+	}
+	if _, e := client.SetConfigKV(ctx, madmin.HealSubSys+" "+strings.Join(kvs, " ")); e != nil {
+		return healIntensityMessage{}, e
+	}
+	msg, e := getHealIntensity(ctx, client)
+	msg.Updated = true
+	msg.Restored = true
+	return msg, e
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestParseHealIntensity(t *testing.T) {
+	testCases := []struct {
+		config    string
+		intensity string
+		bitrot    string
+		success   bool
+	}{
+		{"heal bitrotscan=off max_sleep=1s max_io=10", healIntensityBackground, "off", true},
+		{"heal bitrotscan=on max_sleep=1s max_io=0", healIntensityForeground, "on", true},
+		{"heal bitrotscan=off", "", "", false},
+	}
+	for i, testCase := range testCases {
+		msg, e := parseHealIntensity(testCase.config)
+		if testCase.success != (e == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, e)
+		}
+		if msg.Intensity != testCase.intensity || msg.Bitrot != testCase.bitrot {
+			t.Errorf("Test %d: expected %s with bitrot %s, got %+v", i+1, testCase.intensity, testCase.bitrot, msg)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	gojson "encoding/json"
+
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// healStateVersion is the version of the heal state format.
+const healStateVersion = 1
+
+// healState is a heal sequence started by mc, kept until it finishes so
+// that `admin heal --resume` can follow it again after an interruption.
+type healState struct {
+	Version       int             `json:"version"`
+	Target        string          `json:"target"`
+	ClientToken   string          `json:"clientToken"`
+	ClientAddress string          `json:"clientAddress,omitempty"`
+	StartTime     time.Time       `json:"startTime"`
+	Opts          madmin.HealOpts `json:"opts"`
+
+	path string
+}
+
+// healTarget returns the target of a heal, independently of the way it was
+// written on the command line.
+func healTarget(alias, bucket, prefix string) string {
+	return strings.TrimSuffix(strings.Join([]string{alias, bucket, prefix}, "/"), "/")
+}
+
+// healStatePath returns the state file of the heal of a target in the mc
+// config folder.
+func healStatePath(target string) string {
+	sum := sha256.Sum256([]byte(target))
+	return filepath.Join(mustGetMcConfigDir(), "heal", hex.EncodeToString(sum[:])+".json")
+}
+
+// loadHealState reads the state of the heal of a target, returns nil if
+// there is none.
+func loadHealState(path, target string) (*healState, *probe.Error) {
+	data, e := os.ReadFile(path)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil, nil
+		}
+		return nil, probe.NewError(e).Trace(path)
+	}
+	state := &healState{path: path}
+	if e = gojson.Unmarshal(data, state); e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	if state.Version != healStateVersion {
+		return nil, probe.NewError(fmt.Errorf("unsupported heal state version %d", state.Version)).Trace(path)
+	}
+	if state.Target != target {
+		return nil, probe.NewError(fmt.Errorf("heal state was recorded for `%s`", state.Target)).Trace(path)
+	}
+	return state, nil
+}
+
+// save persists the state.
+func (s *healState) save() *probe.Error {
+	if e := os.MkdirAll(filepath.Dir(s.path), 0o700); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	s.Version = healStateVersion
+	data, e := gojson.Marshal(s)
+	if e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	if e = os.WriteFile(s.path+".tmp", data, 0o600); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	if e = os.Rename(s.path+".tmp", s.path); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	return nil
+}
+
+// remove deletes the state once its heal sequence is over.
+func (s *healState) remove() {
+	os.Remove(s.path)
+}
+
+// healRunningTokenRe extracts the client token of the heal sequence
+// running on a path, from the error returned when starting another one.
+var healRunningTokenRe = regexp.MustCompile(`token is (\S+)`)
+
+// runningHealToken returns the client token of the heal sequence already
+// running, when starting a heal failed because of it.
+func runningHealToken(e error) (string, bool) {
+	if e == nil || madmin.ToErrorResponse(e).Code != "XMinioHealAlreadyRunning" {
+		return "", false
+	}
+	m := healRunningTokenRe.FindStringSubmatch(madmin.ToErrorResponse(e).Message)
+	if m == nil {
+		return "", false
+	}
+	return strings.TrimSuffix(m[1], "."), true
+}
+
+// isHealSequenceGone returns true when the heal sequence of a client token
+// is not known by the server anymore.
+func isHealSequenceGone(e error) bool {
+	switch madmin.ToErrorResponse(e).Code {
+	case "XMinioHealNoSuchProcess", "XMinioHealInvalidClientToken":
+		return true
+	}
+	return false
+}
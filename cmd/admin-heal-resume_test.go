@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestHealState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heal", "state.json")
+	target := healTarget("myminio", "mybucket", "")
+	if target != "myminio/mybucket" {
+		t.Fatalf("Unexpected heal target %s", target)
+	}
+
+	if state, err := loadHealState(path, target); err != nil || state != nil {
+		t.Fatalf("Expected no heal state, got %v, %v", state, err)
+	}
+
+	saved := &healState{
+		Target:      target,
+		ClientToken: "token@1",
+		Opts:        madmin.HealOpts{Recursive: true, ScanMode: madmin.HealDeepScan},
+		path:        path,
+	}
+	if err := saved.save(); err != nil {
+		t.Fatal(err)
+	}
+	state, err := loadHealState(path, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ClientToken != saved.ClientToken || state.Opts != saved.Opts {
+		t.Errorf("Expected %+v, got %+v", saved, state)
+	}
+
+	if _, err = loadHealState(path, healTarget("myminio", "otherbucket", "")); err == nil {
+		t.Error("Expected an error loading the heal state of another target")
+	}
+
+	state.remove()
+	if state, err = loadHealState(path, target); err != nil || state != nil {
+		t.Fatalf("Expected the heal state removed, got %v, %v", state, err)
+	}
+}
+
+func TestRunningHealToken(t *testing.T) {
+	testCases := []struct {
+		err   error
+		token string
+		ok    bool
+	}{
+		{nil, "", false},
+		{errors.New("token is abc"), "", false},
+		{madmin.ErrorResponse{
+			Code:    "XMinioHealAlreadyRunning",
+			Message: "Heal is already running on the given path (use force-start option to stop and start afresh). The heal was started by IP 10.0.0.1 at Mon, 02 Jan 2006 15:04:05 GMT, token is 8d0d5f42-5b6e-4b6e-a2e1-1d52c1e7b5a2@2",
+		}, "8d0d5f42-5b6e-4b6e-a2e1-1d52c1e7b5a2@2", true},
+		{madmin.ErrorResponse{Code: "XMinioHealAlreadyRunning", Message: "Heal is already running"}, "", false},
+		{madmin.ErrorResponse{Code: "XMinioHealNoSuchProcess", Message: "token is abc"}, "", false},
+	}
+	for i, testCase := range testCases {
+		token, ok := runningHealToken(testCase.err)
+		if token != testCase.token || ok != testCase.ok {
+			t.Errorf("Test %d: expected %q, %v, got %q, %v", i+1, testCase.token, testCase.ok, token, ok)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -84,9 +85,17 @@ type uiData struct {
 	// items
 	ObjectsScanned, ItemsScanned int64
 
+	// Number of objects within the heal scope, zero when unknown.
+	// It is counted in the background, access it atomically.
+	ObjectsTotal int64
+
 	// Counters for healed objects and all kinds of healed items
 	ObjectsHealed, ItemsHealed int64
 
+	// Objects removed because they could not be recovered, with
+	// --remove, and objects left unrecoverable.
+	ObjectsRemoved, ObjectsUnrecoverable int64
+
 	// Map from online drives to number of objects with that many
 	// online drives.
 	ObjectsByOnlineDrives map[int]int64
@@ -94,6 +103,9 @@ type uiData struct {
 	// health color code.
 	HealthCols map[col]int64
 
+	// Damage found by a dry run.
+	Damage healDamage
+
 	// channel to receive a prompt string to indicate activity on
 	// the terminal
 	CurChan (<-chan string)
@@ -110,6 +122,9 @@ func (ui *uiData) updateStats(i madmin.HealResultItem) error {
 		ui.ObjectsScanned++
 	}
 	ui.ItemsScanned++
+	if ui.HealOpts.DryRun {
+		ui.Damage.add(i)
+	}
 
 	beforeUp, afterUp := i.GetOnlineCounts()
 	if afterUp > beforeUp {
@@ -118,6 +133,15 @@ func (ui *uiData) updateStats(i madmin.HealResultItem) error {
 		}
 		ui.ItemsHealed++
 	}
+	if i.Type == madmin.HealItemObject && !ui.HealOpts.DryRun {
+		switch {
+		case ui.HealOpts.Remove && beforeUp > 0 && afterUp == 0:
+			// The server deleted the object from all drives.
+			ui.ObjectsRemoved++
+		case i.DataBlocks > 0 && afterUp < i.DataBlocks:
+			ui.ObjectsUnrecoverable++
+		}
+	}
 	ui.ObjectsByOnlineDrives[afterUp]++
 
 	// Update health color stats:
@@ -165,6 +189,18 @@ func (ui *uiData) getProgress() (oCount, objSize, duration string) {
 	return
 }
 
+// getScopeProgress returns how far the scan went through the objects
+// of the heal scope, or an empty string when their number is unknown.
+func (ui *uiData) getScopeProgress() string {
+	total := atomic.LoadInt64(&ui.ObjectsTotal)
+	if total <= 0 {
+		return ""
+	}
+	percent := math.Min(100, float64(ui.ObjectsScanned)*100/float64(total))
+	return fmt.Sprintf("; scanned %s/%s (%.1f%%)",
+		humanize.Comma(ui.ObjectsScanned), humanize.Comma(total), percent)
+}
+
 func (ui *uiData) getPercentsNBars() (p map[col]float64, b map[col]string) {
 	// barChar, emptyBarChar := "█", "░"
 	barChar, emptyBarChar := "█", " "
@@ -229,9 +265,9 @@ func (ui *uiData) printItemsQuietly(s *madmin.HealTaskStatus) (err error) {
 func (ui *uiData) printStatsQuietly(s *madmin.HealTaskStatus) {
 	totalObjects, totalSize, totalTime := ui.getProgress()
 
-	healedStr := fmt.Sprintf("Healed:\t%s/%s objects; %s in %s\n",
+	healedStr := fmt.Sprintf("Healed:\t%s/%s objects; %s in %s (%s scan)%s\n",
 		humanize.Comma(ui.ObjectsHealed), totalObjects,
-		totalSize, totalTime)
+		totalSize, totalTime, ui.scanModeName(), ui.getScopeProgress())
 
 	console.PrintC(healedStr)
 }
@@ -300,26 +336,34 @@ func (ui *uiData) printItemsJSON(s *madmin.HealTaskStatus) (err error) {
 
 func (ui *uiData) printStatsJSON(s *madmin.HealTaskStatus) {
 	var summary struct {
-		Status         string `json:"status"`
-		Error          string `json:"error,omitempty"`
-		Type           string `json:"type"`
-		ObjectsScanned int64  `json:"objects_scanned"`
-		ObjectsHealed  int64  `json:"objects_healed"`
-		ItemsScanned   int64  `json:"items_scanned"`
-		ItemsHealed    int64  `json:"items_healed"`
-		Size           int64  `json:"size"`
-		ElapsedTime    int64  `json:"duration"`
+		Status               string `json:"status"`
+		Error                string `json:"error,omitempty"`
+		Type                 string `json:"type"`
+		ObjectsScanned       int64  `json:"objects_scanned"`
+		ObjectsTotal         int64  `json:"objects_total,omitempty"`
+		ObjectsHealed        int64  `json:"objects_healed"`
+		ObjectsRemoved       int64  `json:"objects_removed"`
+		ObjectsUnrecoverable int64  `json:"objects_unrecoverable"`
+		ItemsScanned         int64  `json:"items_scanned"`
+		ItemsHealed          int64  `json:"items_healed"`
+		Size                 int64  `json:"size"`
+		ElapsedTime          int64  `json:"duration"`
+		ScanMode             string `json:"scan_mode"`
 	}
 
 	summary.Status = "success"
 	summary.Type = "summary"
 
 	summary.ObjectsScanned = ui.ObjectsScanned
+	summary.ObjectsTotal = atomic.LoadInt64(&ui.ObjectsTotal)
 	summary.ObjectsHealed = ui.ObjectsHealed
+	summary.ObjectsRemoved = ui.ObjectsRemoved
+	summary.ObjectsUnrecoverable = ui.ObjectsUnrecoverable
 	summary.ItemsScanned = ui.ItemsScanned
 	summary.ItemsHealed = ui.ItemsHealed
 	summary.Size = ui.BytesScanned
 	summary.ElapsedTime = int64(ui.HealDuration.Round(time.Second).Seconds())
+	summary.ScanMode = ui.scanModeName()
 
 	jBytes, e := json.MarshalIndent(summary, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal to JSON.")
@@ -340,9 +384,9 @@ func (ui *uiData) updateUI(s *madmin.HealTaskStatus) (err error) {
 	}
 
 	totalObjects, totalSize, totalTime := ui.getProgress()
-	healedStr := fmt.Sprintf("%s/%s objects; %s in %s",
+	healedStr := fmt.Sprintf("%s/%s objects; %s in %s (%s scan)%s",
 		humanize.Comma(ui.ObjectsHealed), totalObjects,
-		totalSize, totalTime)
+		totalSize, totalTime, ui.scanModeName(), ui.getScopeProgress())
 
 	console.Print(console.Colorize("HealUpdateUI", fmt.Sprintf(" %s", <-ui.CurChan)))
 	console.PrintC(fmt.Sprintf("  %s\n", scannedStr))
@@ -388,15 +432,35 @@ func (ui *uiData) UpdateDisplay(s *madmin.HealTaskStatus) (err error) {
 	return
 }
 
-func (ui *uiData) healResumeMsg(aliasedURL string) string {
-	var flags string
-	if ui.HealOpts.Recursive {
-		flags += "--recursive "
+// outcomeMessage summarizes how many objects were repaired versus removed,
+// and the objects left unrecoverable, empty when there is nothing to tell.
+func (ui *uiData) outcomeMessage() string {
+	if ui.HealOpts.DryRun || (!ui.HealOpts.Remove && ui.ObjectsUnrecoverable == 0) {
+		return ""
 	}
-	if ui.HealOpts.DryRun {
-		flags += "--dry-run "
+	msg := fmt.Sprintf("Repaired %s objects, removed %s objects beyond repair.",
+		humanize.Comma(ui.ObjectsHealed), humanize.Comma(ui.ObjectsRemoved))
+	if ui.ObjectsUnrecoverable > 0 {
+		msg += fmt.Sprintf(" %s objects could not be recovered and were left untouched",
+			humanize.Comma(ui.ObjectsUnrecoverable))
+		if !ui.HealOpts.Remove {
+			msg += ", use --remove to delete them"
+		}
+		msg += "."
+	}
+	return console.Colorize("HealOutcome", msg)
+}
+
+// scanModeName returns the name of the running heal scan mode.
+func (ui *uiData) scanModeName() string {
+	if ui.HealOpts.ScanMode == madmin.HealDeepScan {
+		return scanDeepMode
 	}
-	return fmt.Sprintf("Healing is backgrounded, to resume watching use `mc admin heal %s %s`", flags, aliasedURL)
+	return scanNormalMode
+}
+
+func (ui *uiData) healResumeMsg(aliasedURL string) string {
+	return fmt.Sprintf("Healing is backgrounded, to resume watching use `mc admin heal --resume %s`", aliasedURL)
 }
 
 func (ui *uiData) DisplayAndFollowHealStatus(aliasedURL string) (res madmin.HealTaskStatus, err error) {
@@ -428,8 +492,22 @@ func (ui *uiData) DisplayAndFollowHealStatus(aliasedURL string) (res madmin.Heal
 			if res.Summary == "finished" {
 				if globalJSON {
 					ui.printStatsJSON(&res)
-				} else if globalQuiet {
-					ui.printStatsQuietly(&res)
+				} else {
+					if globalQuiet {
+						ui.printStatsQuietly(&res)
+					}
+					if msg := ui.outcomeMessage(); msg != "" {
+						console.Println(msg)
+					}
+				}
+				if ui.HealOpts.DryRun {
+					printMsg(healDryRunMessage{
+						Status:       "success",
+						Type:         "dry-run",
+						ScanMode:     ui.scanModeName(),
+						ItemsScanned: ui.ItemsScanned,
+						Damage:       ui.Damage,
+					})
 				}
 				return res, nil
 			}
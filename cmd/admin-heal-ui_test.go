@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestHealScopeProgress(t *testing.T) {
+	testCases := []struct {
+		scanned, total int64
+		want           string
+	}{
+		{10, 0, ""},
+		{250, 1000, "; scanned 250/1,000 (25.0%)"},
+		// Versions are scanned as objects, never go beyond 100%.
+		{1200, 1000, "; scanned 1,200/1,000 (100.0%)"},
+	}
+	for i, testCase := range testCases {
+		ui := uiData{ObjectsScanned: testCase.scanned, ObjectsTotal: testCase.total}
+		if got := ui.getScopeProgress(); got != testCase.want {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.want, got)
+		}
+	}
+}
+
+func TestHealRemoveStats(t *testing.T) {
+	drives := func(before, after []string) (b, a []madmin.HealDriveInfo) {
+		for _, state := range before {
+			b = append(b, madmin.HealDriveInfo{State: state})
+		}
+		for _, state := range after {
+			a = append(a, madmin.HealDriveInfo{State: state})
+		}
+		return b, a
+	}
+	ok, missing := madmin.DriveStateOk, madmin.DriveStateMissing
+	testCases := []struct {
+		remove, dryRun       bool
+		before, after        []string
+		healed, removed, bad int64
+	}{
+		// Repaired object.
+		{true, false, []string{ok, ok, ok, missing}, []string{ok, ok, ok, ok}, 1, 0, 0},
+		// Dangling object removed with --remove.
+		{true, false, []string{ok, missing, missing, missing}, []string{missing, missing, missing, missing}, 0, 1, 0},
+		// Dangling object only reported without --remove.
+		{false, false, []string{ok, missing, missing, missing}, []string{ok, missing, missing, missing}, 0, 0, 1},
+		// Nothing is removed in dry run mode.
+		{true, true, []string{ok, missing, missing, missing}, []string{ok, missing, missing, missing}, 0, 0, 0},
+	}
+	for i, testCase := range testCases {
+		ui := uiData{
+			HealOpts:              &madmin.HealOpts{Remove: testCase.remove, DryRun: testCase.dryRun},
+			ObjectsByOnlineDrives: make(map[int]int64),
+			HealthCols:            make(map[col]int64),
+		}
+		item := madmin.HealResultItem{
+			Type:         madmin.HealItemObject,
+			DiskCount:    4,
+			DataBlocks:   2,
+			ParityBlocks: 2,
+		}
+		item.Before.Drives, item.After.Drives = drives(testCase.before, testCase.after)
+		if err := ui.updateStats(item); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if ui.ObjectsHealed != testCase.healed || ui.ObjectsRemoved != testCase.removed ||
+			ui.ObjectsUnrecoverable != testCase.bad {
+			t.Errorf("Test %d: expected healed=%d removed=%d unrecoverable=%d, got %d/%d/%d", i+1,
+				testCase.healed, testCase.removed, testCase.bad,
+				ui.ObjectsHealed, ui.ObjectsRemoved, ui.ObjectsUnrecoverable)
+		}
+	}
+}
@@ -18,12 +18,16 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
 	"math"
 	"net/url"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -43,7 +47,7 @@ const (
 var adminHealFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "scan",
-		Usage: "select the healing scan mode (normal/deep)",
+		Usage: "select the healing scan mode (normal/deep), deep also verifies object data for bitrot and is much slower",
 		Value: scanNormalMode,
 	},
 	cli.BoolFlag{
@@ -52,7 +56,7 @@ var adminHealFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:  "dry-run, n",
-		Usage: "only inspect data, but do not mutate",
+		Usage: "only inspect data and report the damage found, but do not mutate",
 	},
 	cli.BoolFlag{
 		Name:  "force-start, f",
@@ -62,9 +66,17 @@ var adminHealFlags = []cli.Flag{
 		Name:  "force-stop, s",
 		Usage: "force stop a running heal sequence",
 	},
+	cli.BoolFlag{
+		Name:  "resume",
+		Usage: "follow the heal sequence already running on the target instead of starting a new one",
+	},
 	cli.BoolFlag{
 		Name:  "remove",
-		Usage: "remove dangling objects in heal sequence",
+		Usage: "DESTRUCTIVE: remove objects which cannot be recovered and dangling data, instead of only reporting them",
+	},
+	cli.BoolFlag{
+		Name:  "yes",
+		Usage: "confirm the removal of objects with --remove without prompting",
 	},
 	cli.StringFlag{
 		Name:  "storage-class",
@@ -78,6 +90,14 @@ var adminHealFlags = []cli.Flag{
 		Name:  "verbose, v",
 		Usage: "show verbose information",
 	},
+	cli.StringFlag{
+		Name:  "intensity",
+		Usage: "set the heal intensity of the server (background/foreground), background throttles heal to favor client traffic; restored when the heal ends",
+	},
+	cli.StringFlag{
+		Name:  "bitrot",
+		Usage: "turn on or off (on/off) the bitrot verification of the background heal of the server; restored when the heal ends",
+	},
 }
 
 var adminHealCmd = cli.Command{
@@ -100,6 +120,35 @@ FLAGS:
 EXAMPLES:
   1. Monitor healing status on a running server at alias 'myminio':
      {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Heal all objects under 'mybucket' verifying their data for bitrot. Deep scans read every
+     object's data from all drives, expect them to take much longer and to load the drives.
+     {{.Prompt}} {{.HelpName}} --recursive --scan deep myminio/mybucket
+
+  3. Report the objects under 'mybucket' which need heal, by kind of damage, without healing them.
+     {{.Prompt}} {{.HelpName}} --recursive --dry-run myminio/mybucket
+
+  4. Heal only the objects under the prefix 'photos/2023/' of 'mybucket', progress is relative to that prefix.
+     {{.Prompt}} {{.HelpName}} --recursive myminio/mybucket/photos/2023/
+
+  5. Heal a single object.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket/photos/2023/beach.jpg
+
+  6. Heal all objects under 'mybucket' and remove the objects which cannot be recovered. Without --remove,
+     such objects are only reported. The removal is IRREVERSIBLE, --yes skips the confirmation prompt.
+     {{.Prompt}} {{.HelpName}} --recursive --remove myminio/mybucket
+
+  7. Throttle healing on 'myminio' during business hours, then show the background heal status. Without a
+     heal to follow, the setting stays in the server config until it is changed again.
+     {{.Prompt}} {{.HelpName}} --intensity background myminio/
+
+  8. Heal 'mybucket' at full speed, verifying bitrot in the background heal as well. The previous settings
+     are restored when the heal ends.
+     {{.Prompt}} {{.HelpName}} --intensity foreground --bitrot on --recursive myminio/mybucket
+
+  9. Follow again the heal of 'mybucket' after an interruption, without starting a new heal sequence. The
+     heal continues with the options it was started with, the progress counts start from zero.
+     {{.Prompt}} {{.HelpName}} --resume myminio/mybucket
 `,
 }
 
@@ -114,6 +163,32 @@ func checkAdminHealSyntax(ctx *cli.Context) {
 	if scanArg != scanNormalMode && scanArg != scanDeepMode {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+
+	if ctx.Bool("resume") && (ctx.Bool("force-start") || ctx.Bool("force-stop")) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "`--resume` cannot be used with `--force-start` or `--force-stop`.")
+	}
+
+	checkHealIntensitySyntax(ctx)
+}
+
+// confirmHealRemove asks to confirm the removal of the objects which cannot
+// be recovered, unless --yes is passed. Without a terminal to prompt on,
+// --yes is required.
+func confirmHealRemove(ctx *cli.Context, aliasedURL string) bool {
+	if ctx.Bool("yes") {
+		return true
+	}
+	if !isTerminal() {
+		fatalIf(errDummy().Trace(aliasedURL),
+			"--remove deletes the objects which cannot be recovered. This operation is *IRREVERSIBLE*, please pass --yes to confirm it.")
+	}
+	console.Print(console.Colorize("HealRemove", fmt.Sprintf(
+		"Healing `%s` with --remove will DELETE the objects which cannot be recovered. This operation is *IRREVERSIBLE*.\n", aliasedURL)))
+	console.Print("Please confirm [y/N]: ")
+	answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	fatalIf(probe.NewError(e), "Unable to parse user input.")
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
 }
 
 // stopHealMessage is container for stop heal success and failure messages.
@@ -617,13 +692,42 @@ func (s shortBackgroundHealStatusMessage) JSON() string {
 }
 
 func transformScanArg(scanArg string) madmin.HealScanMode {
-	switch scanArg {
-	case "deep":
+	switch strings.ToLower(scanArg) {
+	case scanDeepMode:
 		return madmin.HealDeepScan
 	}
 	return madmin.HealNormalScan
 }
 
+// healScopeObjects returns the number of objects within the heal scope,
+// so that progress is relative to the scope and not to the whole cluster.
+// Zero is returned when the number is unknown.
+func healScopeObjects(ctx context.Context, adminClnt *madmin.AdminClient, clnt Client, bucket, prefix string, recursive bool) int64 {
+	switch {
+	case prefix == "":
+		usage, e := adminClnt.DataUsageInfo(ctx)
+		if e != nil {
+			return 0
+		}
+		if bucket == "" {
+			return int64(usage.ObjectsTotalCount)
+		}
+		return int64(usage.BucketsUsage[bucket].ObjectsCount)
+	case !recursive:
+		return 0
+	}
+
+	// Data usage is only tracked per bucket, count the objects of the prefix.
+	var count int64
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return 0
+		}
+		count++
+	}
+	return count
+}
+
 // mainAdminHeal - the entry function of heal command
 func mainAdminHeal(ctx *cli.Context) error {
 	// Check for command syntax
@@ -639,6 +743,10 @@ func mainAdminHeal(ctx *cli.Context) error {
 	console.SetColor("HealBackground", color.New(color.Bold))
 	console.SetColor("HealUpdateUI", color.New(color.FgYellow, color.Bold))
 	console.SetColor("HealStopped", color.New(color.FgGreen, color.Bold))
+	console.SetColor("HealDryRun", color.New(color.FgYellow, color.Bold))
+	console.SetColor("HealRemove", color.New(color.FgRed, color.Bold))
+	console.SetColor("HealOutcome", color.New(color.FgYellow, color.Bold))
+	console.SetColor("HealIntensity", color.New(color.FgGreen, color.Bold))
 
 	console.SetColor("DiskHealing", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiskOK", color.New(color.FgGreen, color.Bold))
@@ -656,6 +764,22 @@ func mainAdminHeal(ctx *cli.Context) error {
 	aliasedURL = filepath.ToSlash(aliasedURL)
 	splits := splitStr(aliasedURL, "/", 3)
 	bucket, prefix := splits[1], splits[2]
+	target := healTarget(splits[0], bucket, prefix)
+
+	// The heal settings are saved in the server config. Along a heal they
+	// are restored once it is followed to its end, without a heal to follow
+	// they are set on purpose and stay in place.
+	var previousIntensity *healIntensityMessage
+	if intensity, bitrot := ctx.String("intensity"), ctx.String("bitrot"); intensity != "" || bitrot != "" {
+		msg, previous, e := setHealIntensity(globalContext, adminClnt, intensity, bitrot)
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to set the heal intensity.")
+		if bucket == "" && !ctx.Bool("recursive") {
+			msg.Persistent = true
+		} else {
+			previousIntensity = &previous
+		}
+		printMsg(msg)
+	}
 
 	clnt, err := newClient(aliasedURL)
 	if err != nil {
@@ -669,6 +793,10 @@ func mainAdminHeal(ctx *cli.Context) error {
 		bgHealStatus, e := adminClnt.BackgroundHealStatus(globalContext)
 		fatalIf(probe.NewError(e), "Unable to get background heal status.")
 		if ctx.Bool("verbose") {
+			// Old servers do not support heal throttling.
+			if msg, e := getHealIntensity(globalContext, adminClnt); e == nil && ctx.String("intensity") == "" && ctx.String("bitrot") == "" {
+				printMsg(msg)
+			}
 			printMsg(verboseBackgroundHealStatusMessage{
 				Status:         "success",
 				HealInfo:       bgHealStatus,
@@ -683,11 +811,13 @@ func mainAdminHeal(ctx *cli.Context) error {
 		return nil
 	}
 
+	// Only check that the scope is reachable, the first entry is enough.
 	for content := range clnt.List(globalContext, ListOptions{Recursive: false, ShowDir: DirNone}) {
 		if content.Err != nil {
 			fatalIf(content.Err.Trace(clnt.GetURL().String()), "Unable to heal bucket `"+bucket+"`.")
 			return nil
 		}
+		break
 	}
 
 	opts := madmin.HealOpts{
@@ -703,18 +833,54 @@ func mainAdminHeal(ctx *cli.Context) error {
 	if forceStop {
 		_, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, forceStop)
 		fatalIf(probe.NewError(e), "Unable to stop healing.")
+		(&healState{path: healStatePath(target)}).remove()
 		printMsg(stopHealMessage{Status: "success", Alias: aliasedURL})
 		return nil
 	}
 
-	healStart, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
-	fatalIf(probe.NewError(e), "Unable to start healing.")
+	resume := ctx.Bool("resume")
+	var state *healState
+	if resume {
+		state, err = loadHealState(healStatePath(target), target)
+		fatalIf(err.Trace(aliasedURL), "Unable to load the heal state.")
+	}
+	if state != nil {
+		// Follow the heal sequence with the options it was started with.
+		opts = state.Opts
+	} else {
+		if opts.Remove && !opts.DryRun && !confirmHealRemove(ctx, aliasedURL) {
+			console.Println("Heal aborted!")
+			return nil
+		}
+
+		healStart, _, e := adminClnt.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
+		if token, ok := runningHealToken(e); ok {
+			if !resume {
+				fatalIf(probe.NewError(e), "Unable to start healing, use --resume to follow the heal already running.")
+			}
+			// Follow the heal sequence started elsewhere, its options are unknown.
+			healStart = madmin.HealStartSuccess{ClientToken: token}
+		} else {
+			fatalIf(probe.NewError(e), "Unable to start healing.")
+		}
+		state = &healState{
+			Target:        target,
+			ClientToken:   healStart.ClientToken,
+			ClientAddress: healStart.ClientAddress,
+			StartTime:     healStart.StartTime,
+			Opts:          opts,
+			path:          healStatePath(target),
+		}
+		if err = state.save(); err != nil {
+			errorIf(err.Trace(aliasedURL), "Unable to save the heal state, --resume will not be able to follow this heal.")
+		}
+	}
 
 	ui := uiData{
 		Bucket:                bucket,
 		Prefix:                prefix,
 		Client:                adminClnt,
-		ClientToken:           healStart.ClientToken,
+		ClientToken:           state.ClientToken,
 		ForceStart:            forceStart,
 		HealOpts:              &opts,
 		ObjectsByOnlineDrives: make(map[int]int64),
@@ -722,7 +888,31 @@ func mainAdminHeal(ctx *cli.Context) error {
 		CurChan:               cursorAnimate(),
 	}
 
+	// Count the objects of the scope while the heal is followed, the
+	// progress is shown relative to the scope once they are counted.
+	scopeCtx, cancelScope := context.WithCancel(globalContext)
+	go func() {
+		atomic.StoreInt64(&ui.ObjectsTotal, healScopeObjects(scopeCtx, adminClnt, clnt, bucket, prefix, opts.Recursive))
+	}()
+
 	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
+	cancelScope()
+	if previousIntensity != nil {
+		// The heal may have been interrupted, restore with a fresh context.
+		restoreCtx, cancelRestore := context.WithTimeout(context.Background(), 10*time.Second)
+		msg, re := restoreHealIntensity(restoreCtx, adminClnt, *previousIntensity)
+		cancelRestore()
+		errorIf(probe.NewError(re).Trace(aliasedURL), "Unable to restore the heal intensity.")
+		if re == nil {
+			printMsg(msg)
+		}
+	}
+	if res.Summary == "finished" || res.Summary == "stopped" || isHealSequenceGone(e) {
+		state.remove()
+	}
+	if resume && isHealSequenceGone(e) {
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "The heal sequence is not running anymore, it may have finished. Run the heal without --resume to start a new one.")
+	}
 	if e != nil {
 		if res.FailureDetail != "" {
 			data, _ := json.MarshalIndent(res, "", " ")
@@ -18,23 +18,318 @@
 package cmd
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	"github.com/secure-io/sio-go"
+	"github.com/tinylib/msgp/msgp"
 )
 
+var adminInspectFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "dir",
+		Usage: "local directory to save the shard files and metadata into",
+	},
+	cli.BoolFlag{
+		Name:  "confirm",
+		Usage: "confirm downloading raw object data, which may be sensitive",
+	},
+}
+
 var adminInspectCmd = cli.Command{
-	Name:               "inspect",
-	Usage:              "inspect files on MinIO server",
-	Action:             mainAdminInspect,
-	OnUsageError:       onUsageError,
-	Before:             setGlobalsFromContext,
-	HideHelpCommand:    true,
-	Hidden:             true,
-	CustomHelpTemplate: "Please use 'mc support inspect'",
+	Name:            "inspect",
+	Usage:           "download raw object shards and metadata for forensics",
+	Action:          mainAdminInspect,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(adminInspectFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET --confirm
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Download the raw shard files and 'xl.meta' of an object from all drives into a
+  local directory. Every 'xl.meta' is also decoded into a readable 'xl.meta.json'
+  showing the versions, part layout and checksums.
+
+  The downloaded data is NOT encrypted and may contain sensitive object content,
+  handle it accordingly. To share it with MinIO support, use 'mc support inspect'.
+
+EXAMPLES:
+  1. Download 'xl.meta' of a specific object from all the drives
+     {{.Prompt}} {{.HelpName}} myminio/bucket/test/xl.meta --confirm
+
+  2. Download all the shard files of an object into the directory 'forensics'
+     {{.Prompt}} {{.HelpName}} myminio/bucket/test/* --dir forensics --confirm
+`,
+}
+
+type adminInspectMessage struct {
+	Status string   `json:"status"`
+	Dir    string   `json:"dir"`
+	Files  []string `json:"files"`
+	Parsed []string `json:"parsed,omitempty"`
 }
 
-// mainAdminHeal - the entry function of heal command
+// Colorized message for console printing.
+func (t adminInspectMessage) String() string {
+	msg := fmt.Sprintf("Downloaded %d file(s) into %s\n", len(t.Files), console.Colorize("File", t.Dir))
+	for _, p := range t.Parsed {
+		msg += fmt.Sprintf("  decoded %s\n", p)
+	}
+	msg += console.Colorize("InspectWarn", "The downloaded data is not encrypted and may contain sensitive information.")
+	return msg
+}
+
+func (t adminInspectMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func checkAdminInspectSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if !ctx.Bool("confirm") {
+		fatalIf(errDummy().Trace(ctx.Args()...), "Raw object data may contain sensitive information, please pass --confirm to download it.")
+	}
+}
+
+// mainAdminInspect - the entry function of inspect command
 func mainAdminInspect(ctx *cli.Context) error {
-	console.Infoln("Please use 'mc support inspect'")
+	checkAdminInspectSyntax(ctx)
+
+	console.SetColor("File", color.New(color.FgWhite, color.Bold))
+	console.SetColor("InspectWarn", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := filepath.ToSlash(ctx.Args().Get(0))
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin client.")
+
+	splits := splitStr(aliasedURL, "/", 3)
+	bucket, prefix := splits[1], splits[2]
+	if bucket == "" || prefix == "" {
+		fatalIf(errInvalidArgument().Trace(aliasedURL), "Please provide a path to an object.")
+	}
+
+	dir := ctx.String("dir")
+	if dir == "" {
+		alias, _ := url2Alias(aliasedURL)
+		dir = fmt.Sprintf("%s-inspect_%s", filepath.Clean(alias), UTCNow().Format("20060102150405"))
+	}
+
+	// No public key is sent, so the server encrypts the data with a
+	// one time key that is handed back to us and used to decrypt locally.
+	key, r, e := client.Inspect(context.Background(), madmin.InspectOptions{
+		Volume: bucket,
+		File:   prefix,
+	})
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to inspect file.")
+	defer r.Close()
+	if key == nil {
+		fatalIf(errDummy().Trace(aliasedURL), "Server returned data that can only be decrypted by MinIO support, please use 'mc support inspect'.")
+	}
+
+	tmpFile, e := os.CreateTemp("", "mc-inspect-")
+	fatalIf(probe.NewError(e), "Unable to download file data.")
+	defer os.Remove(tmpFile.Name())
+
+	e = decryptInspectData(key, r, tmpFile)
+	tmpFile.Close()
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to decrypt file data.")
+
+	files, parsed, e := extractInspectData(tmpFile.Name(), dir)
+	fatalIf(probe.NewError(e).Trace(dir), "Unable to extract file data.")
+
+	printMsg(adminInspectMessage{
+		Dir:    dir,
+		Files:  files,
+		Parsed: parsed,
+	})
 	return nil
 }
+
+// decryptInspectData decrypts inspect data encrypted with a one time key.
+func decryptInspectData(key []byte, r io.Reader, w io.Writer) error {
+	stream, e := sio.AES_256_GCM.Stream(key)
+	if e != nil {
+		return e
+	}
+	// A zero nonce is safe since every key is only used once.
+	nonce := make([]byte, stream.NonceSize())
+	_, e = io.Copy(w, stream.DecryptReader(r, nonce, nil))
+	return e
+}
+
+// extractInspectData extracts the inspect zip archive into dir and decodes
+// every xl.meta found into an xl.meta.json next to it.
+func extractInspectData(zipFile, dir string) (files, parsed []string, e error) {
+	zr, e := zip.OpenReader(zipFile)
+	if e != nil {
+		return nil, nil, e
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := filepath.Clean(filepath.FromSlash(f.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return files, parsed, fmt.Errorf("invalid file name %q in inspect data", f.Name)
+		}
+		target := filepath.Join(dir, name)
+		if e = os.MkdirAll(filepath.Dir(target), 0o700); e != nil {
+			return files, parsed, e
+		}
+
+		rc, e := f.Open()
+		if e != nil {
+			return files, parsed, e
+		}
+		data, e := io.ReadAll(rc)
+		rc.Close()
+		if e != nil {
+			return files, parsed, e
+		}
+		if e = os.WriteFile(target, data, 0o600); e != nil {
+			return files, parsed, e
+		}
+		files = append(files, target)
+
+		if filepath.Base(name) != "xl.meta" {
+			continue
+		}
+		js, e := xlMetaToJSON(data)
+		if e != nil {
+			errorIf(probe.NewError(e).Trace(target), "Unable to decode xl.meta.")
+			continue
+		}
+		if e = os.WriteFile(target+".json", js, 0o600); e != nil {
+			return files, parsed, e
+		}
+		parsed = append(parsed, target+".json")
+	}
+	return files, parsed, nil
+}
+
+// xlMetaToJSON decodes the msgpack encoded xl.meta format into indented JSON.
+func xlMetaToJSON(b []byte) ([]byte, error) {
+	if len(b) < 8 || !bytes.Equal(b[:4], []byte("XL2 ")) {
+		return nil, errors.New("not an xl.meta file")
+	}
+	major, minor := binary.LittleEndian.Uint16(b[4:6]), binary.LittleEndian.Uint16(b[6:8])
+	if major != 1 {
+		return nil, fmt.Errorf("unknown xl.meta major version %d", major)
+	}
+	b = b[8:]
+
+	var buf bytes.Buffer
+	switch minor {
+	case 0:
+		if _, e := msgp.CopyToJSON(&buf, bytes.NewReader(b)); e != nil {
+			return nil, e
+		}
+	case 1, 2:
+		v, _, e := msgp.ReadBytesZC(b)
+		if e != nil {
+			return nil, e
+		}
+		if _, e = msgp.UnmarshalAsJSON(&buf, v); e != nil {
+			return nil, e
+		}
+	case 3:
+		v, _, e := msgp.ReadBytesZC(b)
+		if e != nil {
+			return nil, e
+		}
+		versions, e := xlMetaVersionsToJSON(v)
+		if e != nil {
+			return nil, e
+		}
+		js, e := gojson.Marshal(struct {
+			Versions []gojson.RawMessage `json:"Versions"`
+		}{Versions: versions})
+		if e != nil {
+			return nil, e
+		}
+		buf.Write(js)
+	default:
+		return nil, fmt.Errorf("unknown xl.meta minor version %d", minor)
+	}
+
+	var out bytes.Buffer
+	if e := gojson.Indent(&out, buf.Bytes(), "", "  "); e != nil {
+		return nil, e
+	}
+	return out.Bytes(), nil
+}
+
+// xlMetaVersionsToJSON decodes the header and metadata of every version
+// stored in a v1.3 xl.meta.
+func xlMetaVersionsToJSON(v []byte) ([]gojson.RawMessage, error) {
+	// header and metadata versions, followed by the number of versions.
+	_, v, e := msgp.ReadUintBytes(v)
+	if e != nil {
+		return nil, e
+	}
+	_, v, e = msgp.ReadUintBytes(v)
+	if e != nil {
+		return nil, e
+	}
+	n, v, e := msgp.ReadIntBytes(v)
+	if e != nil {
+		return nil, e
+	}
+
+	versions := make([]gojson.RawMessage, 0, n)
+	for i := 0; i < n; i++ {
+		var hdr, meta []byte
+		if hdr, v, e = msgp.ReadBytesZC(v); e != nil {
+			return nil, e
+		}
+		if meta, v, e = msgp.ReadBytesZC(v); e != nil {
+			return nil, e
+		}
+		var hdrJSON, metaJSON bytes.Buffer
+		if _, e = msgp.UnmarshalAsJSON(&hdrJSON, hdr); e != nil {
+			return nil, e
+		}
+		if _, e = msgp.UnmarshalAsJSON(&metaJSON, meta); e != nil {
+			return nil, e
+		}
+		js, e := gojson.Marshal(struct {
+			Idx      int               `json:"Idx"`
+			Header   gojson.RawMessage `json:"Header"`
+			Metadata gojson.RawMessage `json:"Metadata"`
+		}{Idx: i, Header: hdrJSON.Bytes(), Metadata: metaJSON.Bytes()})
+		if e != nil {
+			return nil, e
+		}
+		versions = append(versions, js)
+	}
+	return versions, nil
+}
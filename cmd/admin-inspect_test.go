@@ -0,0 +1,120 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/secure-io/sio-go"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// testXLMetaV13 returns a minimal v1.3 xl.meta holding a single version.
+func testXLMetaV13() []byte {
+	hdr := msgp.AppendArrayHeader(nil, 2)
+	hdr = msgp.AppendString(hdr, "version-id")
+	hdr = msgp.AppendInt64(hdr, 1)
+
+	meta := msgp.AppendMapHeader(nil, 1)
+	meta = msgp.AppendString(meta, "PartSizes")
+	meta = msgp.AppendArrayHeader(meta, 1)
+	meta = msgp.AppendInt64(meta, 5242880)
+
+	v := msgp.AppendUint(nil, 2)
+	v = msgp.AppendUint(v, 2)
+	v = msgp.AppendInt(v, 1)
+	v = msgp.AppendBytes(v, hdr)
+	v = msgp.AppendBytes(v, meta)
+
+	b := []byte("XL2 ")
+	b = append(b, 1, 0, 3, 0)
+	return msgp.AppendBytes(b, v)
+}
+
+func TestXLMetaToJSON(t *testing.T) {
+	js, e := xlMetaToJSON(testXLMetaV13())
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, want := range []string{`"Versions"`, `"version-id"`, `"PartSizes"`, "5242880"} {
+		if !strings.Contains(string(js), want) {
+			t.Errorf("expected %s in %s", want, js)
+		}
+	}
+	if _, e = xlMetaToJSON([]byte("not xl.meta")); e == nil {
+		t.Error("expected an error for an invalid xl.meta")
+	}
+}
+
+func TestExtractInspectData(t *testing.T) {
+	var zipData bytes.Buffer
+	zw := zip.NewWriter(&zipData)
+	for name, data := range map[string][]byte{
+		"server1:9000/data1/bucket/object/xl.meta":     testXLMetaV13(),
+		"server1:9000/data1/bucket/object/uuid/part.1": []byte("shard"),
+	} {
+		w, e := zw.Create(name)
+		if e != nil {
+			t.Fatal(e)
+		}
+		w.Write(data)
+	}
+	if e := zw.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	key := bytes.Repeat([]byte{1}, 32)
+	stream, e := sio.AES_256_GCM.Stream(key)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var encrypted bytes.Buffer
+	ew := stream.EncryptWriter(&encrypted, make([]byte, stream.NonceSize()), nil)
+	ew.Write(zipData.Bytes())
+	if e = ew.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	tmp := t.TempDir()
+	zipFile := filepath.Join(tmp, "inspect.zip")
+	f, e := os.Create(zipFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if e = decryptInspectData(key, &encrypted, f); e != nil {
+		t.Fatal(e)
+	}
+	f.Close()
+
+	dir := filepath.Join(tmp, "out")
+	files, parsed, e := extractInspectData(zipFile, dir)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(files) != 2 || len(parsed) != 1 {
+		t.Fatalf("unexpected files %v, parsed %v", files, parsed)
+	}
+	if _, e = os.Stat(filepath.Join(dir, "server1:9000", "data1", "bucket", "object", "xl.meta.json")); e != nil {
+		t.Error(e)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	// Number of stack frames, from the top, part of a log signature.
+	logSignatureFrames = 3
+
+	// Interval to print the updated counts of the repeated logs.
+	logDedupInterval = 10 * time.Second
+)
+
+// Variable fields of a log message ignored in its signature: uuids,
+// request ids and numbers.
+var (
+	logUUIDRegex   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	logHexIDRegex  = regexp.MustCompile(`\b[0-9a-fA-F]{16,}\b`)
+	logNumberRegex = regexp.MustCompile(`\d+`)
+)
+
+// normalizeLogMessage replaces the variable fields of a log message.
+func normalizeLogMessage(msg string) string {
+	msg = logUUIDRegex.ReplaceAllString(msg, "<uuid>")
+	msg = logHexIDRegex.ReplaceAllString(msg, "<id>")
+	return logNumberRegex.ReplaceAllString(strings.TrimSpace(msg), "<n>")
+}
+
+// logSignature returns the signature of a log, based on its error
+// message and the top frames of its stack trace.
+func logSignature(l madmin.LogInfo) string {
+	h := sha256.New()
+	if l.Trace == nil {
+		h.Write([]byte(normalizeLogMessage(l.ConsoleMsg + l.Message)))
+	} else {
+		h.Write([]byte(normalizeLogMessage(l.Trace.Message)))
+		for i, frame := range l.Trace.Source {
+			if i == logSignatureFrames {
+				break
+			}
+			h.Write([]byte{0})
+			h.Write([]byte(frame))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// logDedupMessage container for a deduplicated log entry.
+type logDedupMessage struct {
+	Status    string         `json:"status"`
+	Signature string         `json:"signature"`
+	Count     int            `json:"count"`
+	FirstSeen time.Time      `json:"firstSeen"`
+	LastSeen  time.Time      `json:"lastSeen"`
+	Nodes     []string       `json:"nodes,omitempty"`
+	Log       madmin.LogInfo `json:"log"`
+}
+
+// JSON jsonified deduplicated log entry.
+func (m logDedupMessage) JSON() string {
+	m.Status = "success"
+	logJSON, e := json.MarshalIndent(&m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(logJSON)
+}
+
+// String colorized deduplicated log entry, the first occurrence of
+// the log follows its counts.
+func (m logDedupMessage) String() string {
+	header := fmt.Sprintf("Signature: %s, Occurrences: %d, First seen: %s, Last seen: %s",
+		m.Signature, m.Count, m.FirstSeen.Format(logTimeFormat), m.LastSeen.Format(logTimeFormat))
+	if len(m.Nodes) > 0 {
+		header += ", Nodes: " + strings.Join(m.Nodes, ",")
+	}
+	return console.Colorize("LogDedup", header) + "\n" + logMessage{LogInfo: m.Log}.String()
+}
+
+// logDedupEntry holds the occurrences of a log signature.
+type logDedupEntry struct {
+	logDedupMessage
+	nodes   map[string]struct{}
+	printed int
+}
+
+// logDeduper collapses the logs with the same signature.
+type logDeduper struct {
+	entries map[string]*logDedupEntry
+}
+
+func newLogDeduper() *logDeduper {
+	return &logDeduper{entries: make(map[string]*logDedupEntry)}
+}
+
+// add records a log, the entry of its signature is returned when it
+// is seen for the first time.
+func (d *logDeduper) add(l madmin.LogInfo) (msg logDedupMessage, first bool) {
+	seen, e := time.Parse(time.RFC3339Nano, l.Time)
+	if e != nil {
+		seen = time.Now().UTC()
+	}
+	signature := logSignature(l)
+	entry, ok := d.entries[signature]
+	if !ok {
+		entry = &logDedupEntry{
+			logDedupMessage: logDedupMessage{
+				Signature: signature,
+				FirstSeen: seen,
+				LastSeen:  seen,
+				Log:       l,
+			},
+			nodes: make(map[string]struct{}),
+		}
+		d.entries[signature] = entry
+	}
+	entry.Count++
+	if seen.Before(entry.FirstSeen) {
+		entry.FirstSeen = seen
+	}
+	if seen.After(entry.LastSeen) {
+		entry.LastSeen = seen
+	}
+	if l.NodeName != "" {
+		entry.nodes[l.NodeName] = struct{}{}
+	}
+	if ok {
+		return logDedupMessage{}, false
+	}
+	entry.printed = entry.Count
+	return entry.message(), true
+}
+
+// flush returns the entries repeated since they were last returned,
+// ordered by their first occurrence.
+func (d *logDeduper) flush() (msgs []logDedupMessage) {
+	for _, entry := range d.entries {
+		if entry.Count == entry.printed {
+			continue
+		}
+		entry.printed = entry.Count
+		msgs = append(msgs, entry.message())
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].FirstSeen.Before(msgs[j].FirstSeen)
+	})
+	return msgs
+}
+
+func (e *logDedupEntry) message() logDedupMessage {
+	msg := e.logDedupMessage
+	msg.Nodes = make([]string, 0, len(e.nodes))
+	for node := range e.nodes {
+		msg.Nodes = append(msg.Nodes, node)
+	}
+	sort.Strings(msg.Nodes)
+	return msg
+}
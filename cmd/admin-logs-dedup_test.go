@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	gojson "encoding/json"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func testLogInfo(t *testing.T, data string) madmin.LogInfo {
+	var l madmin.LogInfo
+	if e := gojson.Unmarshal([]byte(data), &l); e != nil {
+		t.Fatal(e)
+	}
+	return l
+}
+
+func TestLogSignature(t *testing.T) {
+	diskFull := testLogInfo(t, `{"time":"2023-01-02T10:00:00Z","requestID":"173A2B1C9D8E7F60","node":"node1",
+"error":{"message":"disk /data1 is full, 1024 bytes left","source":["cmd/xl-storage.go:10:cmd.a()","cmd/erasure.go:20:cmd.b()","cmd/api.go:30:cmd.c()","cmd/router.go:40:cmd.d()"]}}`)
+	sameError := testLogInfo(t, `{"time":"2023-01-02T10:05:00Z","requestID":"173A2B1C9D8E7F61","node":"node2",
+"error":{"message":"disk /data2 is full, 2048 bytes left","source":["cmd/xl-storage.go:10:cmd.a()","cmd/erasure.go:20:cmd.b()","cmd/api.go:30:cmd.c()","cmd/other.go:50:cmd.e()"]}}`)
+	otherStack := testLogInfo(t, `{"time":"2023-01-02T10:06:00Z",
+"error":{"message":"disk /data1 is full, 1024 bytes left","source":["cmd/xl-storage.go:11:cmd.f()"]}}`)
+
+	if logSignature(diskFull) != logSignature(sameError) {
+		t.Fatalf("expected the same signature for logs differing in variable fields and lower frames")
+	}
+	if logSignature(diskFull) == logSignature(otherStack) {
+		t.Fatalf("expected a different signature for a different stack trace")
+	}
+
+	deduper := newLogDeduper()
+	if _, first := deduper.add(diskFull); !first {
+		t.Fatalf("expected the first occurrence to be returned")
+	}
+	if _, first := deduper.add(sameError); first {
+		t.Fatalf("expected a repeated log to be collapsed")
+	}
+	if _, first := deduper.add(otherStack); !first {
+		t.Fatalf("expected the first occurrence to be returned")
+	}
+
+	msgs := deduper.flush()
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 repeated log, got %d", len(msgs))
+	}
+	msg := msgs[0]
+	if msg.Count != 2 || msg.Log.RequestID != "173A2B1C9D8E7F60" {
+		t.Fatalf("unexpected entry %+v", msg)
+	}
+	if !msg.LastSeen.Equal(msg.FirstSeen.Add(5*time.Minute)) || len(msg.Nodes) != 2 {
+		t.Fatalf("unexpected first/last seen or nodes %+v", msg)
+	}
+	if msgs = deduper.flush(); len(msgs) != 0 {
+		t.Fatalf("expected nothing to flush, got %d", len(msgs))
+	}
+}
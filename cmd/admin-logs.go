@@ -44,6 +44,10 @@ var logsShowFlags = []cli.Flag{
 		Usage: "list error logs by type. Valid options are '[minio, application, all]'",
 		Value: "all",
 	},
+	cli.BoolFlag{
+		Name:  "dedup",
+		Usage: "collapse repeated logs with the same error and stack trace, with their count and first/last seen time",
+	},
 }
 
 var adminLogsCmd = cli.Command{
@@ -68,6 +72,9 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --last 5 myminio node1
   3. Show application errors in logs for a MinIO server with alias 'myminio'
      {{.Prompt}} {{.HelpName}} --type application myminio
+  4. Show the distinct errors of a MinIO server with alias 'myminio', repeated errors are printed again with
+     their updated count every 10 seconds
+     {{.Prompt}} {{.HelpName}} --dedup --json myminio
 `,
 }
 
@@ -170,6 +177,7 @@ func mainAdminLogs(ctx *cli.Context) error {
 	checkLogsShowSyntax(ctx)
 	console.SetColor("LogMessage", color.New(color.Bold, color.FgRed))
 	console.SetColor("Api", color.New(color.Bold, color.FgWhite))
+	console.SetColor("LogDedup", color.New(color.Bold, color.FgYellow))
 	for _, c := range colors {
 		console.SetColor(fmt.Sprintf("Node%d", c), color.New(c))
 	}
@@ -201,6 +209,10 @@ func mainAdminLogs(ctx *cli.Context) error {
 
 	// Start listening on all console log activity.
 	logCh := client.GetLogs(ctxt, node, last, logType)
+	if ctx.Bool("dedup") {
+		dedupLogs(logCh, node)
+		return nil
+	}
 	for logInfo := range logCh {
 		if logInfo.Err != nil {
 			fatalIf(probe.NewError(logInfo.Err), "Unable to listen to console logs")
@@ -213,3 +225,38 @@ func mainAdminLogs(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+// dedupLogs prints the first occurrence of each log signature, the
+// repeated logs are printed again with their counts periodically and
+// at the end of the stream.
+func dedupLogs(logCh <-chan madmin.LogInfo, node string) {
+	deduper := newLogDeduper()
+	ticker := time.NewTicker(logDedupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case logInfo, ok := <-logCh:
+			if !ok {
+				for _, msg := range deduper.flush() {
+					printMsg(msg)
+				}
+				return
+			}
+			if logInfo.Err != nil {
+				fatalIf(probe.NewError(logInfo.Err), "Unable to listen to console logs")
+			}
+			// drop nodeName from output if specified as cli arg
+			if node != "" {
+				logInfo.NodeName = ""
+			}
+			if msg, first := deduper.add(logInfo); first {
+				printMsg(msg)
+			}
+		case <-ticker.C:
+			for _, msg := range deduper.flush() {
+				printMsg(msg)
+			}
+		}
+	}
+}
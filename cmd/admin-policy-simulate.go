@@ -0,0 +1,321 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/bucket/policy"
+	"github.com/minio/pkg/bucket/policy/condition"
+	"github.com/minio/pkg/console"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+var adminPolicySimulateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "user",
+		Usage: "user or service account whose policies are evaluated",
+	},
+	cli.StringSliceFlag{
+		Name:  "action",
+		Usage: "action to evaluate, e.g. s3:GetObject, may be repeated",
+	},
+	cli.StringFlag{
+		Name:  "resource",
+		Usage: "resource the actions apply to, e.g. arn:aws:s3:::mybucket/myobject",
+	},
+	cli.StringSliceFlag{
+		Name:  "condition",
+		Usage: "condition KEY=VALUE of the request, e.g. aws:SourceIp=10.0.0.1, may be repeated",
+	},
+}
+
+var adminPolicySimulateCmd = cli.Command{
+	Name:         "simulate",
+	Usage:        "evaluate whether a user is allowed to perform actions on a resource",
+	Action:       mainAdminPolicySimulate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminPolicySimulateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET --user USER --action ACTION [--action ACTION...] [--resource RESOURCE]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The policies attached to the user and to the enabled groups it is a member of
+  are evaluated the way the server does: an explicit 'Deny' statement wins over
+  any 'Allow' statement, and an action no statement allows is denied. The
+  inline policy of a service account further restricts the policies of its
+  parent user. The statement deciding each action is reported.
+
+  Policies attached through an identity provider, LDAP or OpenID, and the
+  bucket policies are not evaluated.
+
+EXAMPLES:
+  1. Check whether user 'bob' may download an object.
+     {{.Prompt}} {{.HelpName}} myminio --user bob --action s3:GetObject --resource arn:aws:s3:::mybucket/report.pdf
+
+  2. Check several actions at once on a bucket, as JSON.
+     {{.Prompt}} {{.HelpName}} --json myminio --user bob --action s3:ListBucket --action s3:DeleteBucket --resource arn:aws:s3:::mybucket
+
+  3. Check an action guarded by a condition on the client address.
+     {{.Prompt}} {{.HelpName}} myminio --user bob --action s3:PutObject --resource mybucket/uploads/a.txt --condition aws:SourceIp=10.0.0.1
+`,
+}
+
+const (
+	policyDecisionAllow = "ALLOW"
+	policyDecisionDeny  = "DENY"
+)
+
+// namedPolicy is a policy along with where it comes from.
+type namedPolicy struct {
+	Name   string
+	Source string
+	Policy *iampolicy.Policy
+}
+
+// policySimulateResult is the decision for one action.
+type policySimulateResult struct {
+	Action    string `json:"action"`
+	Resource  string `json:"resource,omitempty"`
+	Decision  string `json:"decision"`
+	Policy    string `json:"policy,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Statement int    `json:"statement,omitempty"`
+	Sid       string `json:"sid,omitempty"`
+}
+
+func (r policySimulateResult) reason() string {
+	if r.Policy == "" {
+		return "no statement allows the action"
+	}
+	reason := fmt.Sprintf("policy %s from %s, statement %d", r.Policy, r.Source, r.Statement)
+	if r.Sid != "" {
+		reason += fmt.Sprintf(" (%s)", r.Sid)
+	}
+	if r.Decision == policyDecisionDeny {
+		return "denied by " + reason
+	}
+	return "allowed by " + reason
+}
+
+type policySimulateMessage struct {
+	Status  string                 `json:"status"`
+	User    string                 `json:"user"`
+	Results []policySimulateResult `json:"results"`
+}
+
+func (m policySimulateMessage) String() string {
+	var lines []string
+	for _, r := range m.Results {
+		decision := console.Colorize("PolicyAllow", fmt.Sprintf("%-5s", r.Decision))
+		if r.Decision == policyDecisionDeny {
+			decision = console.Colorize("PolicyDeny", fmt.Sprintf("%-5s", r.Decision))
+		}
+		line := fmt.Sprintf("%s  %s", decision, r.Action)
+		if r.Resource != "" {
+			line += " " + r.Resource
+		}
+		lines = append(lines, line+"  "+r.reason())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m policySimulateMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// parsePolicyResource returns the bucket and the object of a resource,
+// given as an ARN or as BUCKET[/OBJECT].
+func parsePolicyResource(resource string) (bucket, object string) {
+	resource = strings.TrimPrefix(resource, iampolicy.ResourceARNPrefix)
+	bucket, object, _ = strings.Cut(resource, "/")
+	return bucket, object
+}
+
+// simulatePolicies evaluates an action against a set of policies, any
+// denying statement wins over the allowing ones.
+func simulatePolicies(policies []namedPolicy, args iampolicy.Args) policySimulateResult {
+	result := policySimulateResult{Action: string(args.Action), Decision: policyDecisionDeny}
+	for _, effect := range []policy.Effect{policy.Deny, policy.Allow} {
+		for _, p := range policies {
+			for i, st := range p.Policy.Statements {
+				if st.Effect != effect || st.IsAllowed(args) != (effect == policy.Allow) {
+					continue
+				}
+				result.Policy, result.Source = p.Name, p.Source
+				result.Statement, result.Sid = i+1, string(st.SID)
+				if effect == policy.Allow {
+					result.Decision = policyDecisionAllow
+				}
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// simulateServiceAccount evaluates an action for a service account, its
+// inline policy can only restrict what the parent user is allowed.
+func simulateServiceAccount(parent, inline []namedPolicy, args iampolicy.Args) policySimulateResult {
+	result := simulatePolicies(parent, args)
+	if result.Decision == policyDecisionDeny || len(inline) == 0 {
+		return result
+	}
+	if inlineResult := simulatePolicies(inline, args); inlineResult.Decision == policyDecisionDeny {
+		return inlineResult
+	}
+	return result
+}
+
+// fetchPolicies returns the named canned policies of a comma separated list.
+func fetchPolicies(client *madmin.AdminClient, names, source string) ([]namedPolicy, *probe.Error) {
+	var policies []namedPolicy
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		pinfo, e := getPolicyInfo(client, name)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(name)
+		}
+		p, e := iampolicy.ParseConfig(strings.NewReader(string(pinfo.Policy)))
+		if e != nil {
+			return nil, probe.NewError(e).Trace(name)
+		}
+		policies = append(policies, namedPolicy{Name: name, Source: source, Policy: p})
+	}
+	return policies, nil
+}
+
+// fetchUserPolicies returns the policies of a user and of its enabled groups.
+func fetchUserPolicies(client *madmin.AdminClient, user string) ([]namedPolicy, []string, *probe.Error) {
+	info, e := client.GetUserInfo(globalContext, user)
+	if e != nil {
+		return nil, nil, probe.NewError(e).Trace(user)
+	}
+	policies, err := fetchPolicies(client, info.PolicyName, "user "+user)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, group := range info.MemberOf {
+		desc, e := client.GetGroupDescription(globalContext, group)
+		if e != nil {
+			return nil, nil, probe.NewError(e).Trace(group)
+		}
+		if desc.Status == string(madmin.GroupDisabled) {
+			continue
+		}
+		groupPolicies, err := fetchPolicies(client, desc.Policy, "group "+group)
+		if err != nil {
+			return nil, nil, err
+		}
+		policies = append(policies, groupPolicies...)
+	}
+	return policies, info.MemberOf, nil
+}
+
+func checkAdminPolicySimulateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("user") == "" || len(ctx.StringSlice("action")) == 0 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	for _, action := range ctx.StringSlice("action") {
+		if !iampolicy.Action(action).IsValid() {
+			fatalIf(errInvalidArgument().Trace(action), "Unknown action `"+action+"`.")
+		}
+	}
+	for _, c := range ctx.StringSlice("condition") {
+		if k, _, ok := strings.Cut(c, "="); !ok || k == "" {
+			fatalIf(errInvalidArgument().Trace(c), "Invalid `--condition`, expected KEY=VALUE.")
+		}
+	}
+}
+
+func mainAdminPolicySimulate(ctx *cli.Context) error {
+	checkAdminPolicySimulateSyntax(ctx)
+
+	console.SetColor("PolicyAllow", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyDeny", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	user := ctx.String("user")
+	resource := ctx.String("resource")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var inline []namedPolicy
+	policies, groups, err := fetchUserPolicies(client, user)
+	if err != nil && madmin.ToErrorResponse(err.ToGoError()).Code == "XMinioAdminNoSuchUser" {
+		// Not a user, look for a service account.
+		svcInfo, e := client.InfoServiceAccount(globalContext, user)
+		fatalIf(probe.NewError(e).Trace(user), "Unable to find the user or service account `"+user+"`.")
+		policies, groups, err = fetchUserPolicies(client, svcInfo.ParentUser)
+		if err == nil && !svcInfo.ImpliedPolicy && svcInfo.Policy != "" {
+			p, e := iampolicy.ParseConfig(strings.NewReader(svcInfo.Policy))
+			fatalIf(probe.NewError(e).Trace(user), "Unable to parse the policy of service account `"+user+"`.")
+			inline = []namedPolicy{{Name: "inline", Source: "service account " + user, Policy: p}}
+		}
+	}
+	fatalIf(err, "Unable to get the policies of `"+user+"`.")
+
+	conditions := map[string][]string{"username": {user}}
+	for _, c := range ctx.StringSlice("condition") {
+		k, v, _ := strings.Cut(c, "=")
+		// Condition keys are matched by their name, e.g. 'aws:SourceIp' by 'SourceIp'.
+		k = condition.KeyName(k).Name()
+		conditions[k] = append(conditions[k], v)
+	}
+
+	bucket, object := parsePolicyResource(resource)
+	msg := policySimulateMessage{User: user}
+	for _, action := range ctx.StringSlice("action") {
+		args := iampolicy.Args{
+			AccountName:     user,
+			Groups:          groups,
+			Action:          iampolicy.Action(action),
+			BucketName:      bucket,
+			ObjectName:      object,
+			ConditionValues: conditions,
+		}
+		result := simulateServiceAccount(policies, inline, args)
+		result.Resource = resource
+		msg.Results = append(msg.Results, result)
+	}
+
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func mustParsePolicy(t *testing.T, name, source, text string) namedPolicy {
+	t.Helper()
+	p, e := iampolicy.ParseConfig(strings.NewReader(text))
+	if e != nil {
+		t.Fatal(e)
+	}
+	return namedPolicy{Name: name, Source: source, Policy: p}
+}
+
+func TestSimulatePolicies(t *testing.T) {
+	readwrite := mustParsePolicy(t, "readwrite", "user bob", `{
+ "Version": "2012-10-17",
+ "Statement": [{"Effect": "Allow", "Action": ["s3:*"], "Resource": ["arn:aws:s3:::*"]}]
+}`)
+	noDelete := mustParsePolicy(t, "nodelete", "group devs", `{
+ "Version": "2012-10-17",
+ "Statement": [
+  {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::public/*"]},
+  {"Sid": "NoDelete", "Effect": "Deny", "Action": ["s3:DeleteObject"], "Resource": ["arn:aws:s3:::data/*"]}
+ ]
+}`)
+	home := mustParsePolicy(t, "home", "user bob", `{
+ "Version": "2012-10-17",
+ "Statement": [{"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::home/${aws:username}/*"],
+  "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}]
+}`)
+
+	bucket, object := parsePolicyResource("arn:aws:s3:::data/report.pdf")
+	if bucket != "data" || object != "report.pdf" {
+		t.Fatalf("unexpected bucket %q and object %q", bucket, object)
+	}
+
+	testCases := []struct {
+		policies   []namedPolicy
+		action     string
+		resource   string
+		conditions map[string][]string
+		decision   string
+		policy     string
+		statement  int
+	}{
+		{[]namedPolicy{readwrite, noDelete}, "s3:GetObject", "data/report.pdf", nil, policyDecisionAllow, "readwrite", 1},
+		{[]namedPolicy{readwrite, noDelete}, "s3:DeleteObject", "data/report.pdf", nil, policyDecisionDeny, "nodelete", 2},
+		{[]namedPolicy{noDelete}, "s3:GetObject", "public/a.txt", nil, policyDecisionAllow, "nodelete", 1},
+		{[]namedPolicy{noDelete}, "s3:PutObject", "public/a.txt", nil, policyDecisionDeny, "", 0},
+		{[]namedPolicy{home}, "s3:PutObject", "home/bob/a.txt", map[string][]string{"username": {"bob"}, "SourceIp": {"10.1.2.3"}}, policyDecisionAllow, "home", 1},
+		{[]namedPolicy{home}, "s3:PutObject", "home/alice/a.txt", map[string][]string{"username": {"bob"}, "SourceIp": {"10.1.2.3"}}, policyDecisionDeny, "", 0},
+		{[]namedPolicy{home}, "s3:PutObject", "home/bob/a.txt", map[string][]string{"username": {"bob"}, "SourceIp": {"192.168.1.1"}}, policyDecisionDeny, "", 0},
+	}
+	for i, testCase := range testCases {
+		bucket, object := parsePolicyResource(testCase.resource)
+		result := simulatePolicies(testCase.policies, iampolicy.Args{
+			AccountName:     "bob",
+			Action:          iampolicy.Action(testCase.action),
+			BucketName:      bucket,
+			ObjectName:      object,
+			ConditionValues: testCase.conditions,
+		})
+		if result.Decision != testCase.decision || result.Policy != testCase.policy || result.Statement != testCase.statement {
+			t.Errorf("Test %d: expected %s by %q statement %d, got %+v", i+1, testCase.decision, testCase.policy, testCase.statement, result)
+		}
+	}
+
+	// The inline policy of a service account restricts its parent.
+	args := iampolicy.Args{Action: "s3:PutObject", BucketName: "data", ObjectName: "a.txt"}
+	inline := []namedPolicy{noDelete}
+	if result := simulateServiceAccount([]namedPolicy{readwrite}, inline, args); result.Decision != policyDecisionDeny || result.Policy != "" {
+		t.Errorf("expected the inline policy to deny, got %+v", result)
+	}
+	args = iampolicy.Args{Action: "s3:GetObject", BucketName: "public", ObjectName: "a.txt"}
+	if result := simulateServiceAccount([]namedPolicy{readwrite}, inline, args); result.Decision != policyDecisionAllow || result.Policy != "readwrite" {
+		t.Errorf("expected the parent policy to allow, got %+v", result)
+	}
+	if result := simulateServiceAccount([]namedPolicy{noDelete}, []namedPolicy{readwrite}, iampolicy.Args{Action: "s3:PutObject", BucketName: "data"}); result.Decision != policyDecisionDeny {
+		t.Errorf("expected the parent policies to deny, got %+v", result)
+	}
+}
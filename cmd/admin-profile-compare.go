@@ -0,0 +1,259 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var adminProfileCompareFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "per-node",
+		Usage: "compare the profiles of every node, instead of aggregating the nodes",
+	},
+	cli.IntFlag{
+		Name:  "top",
+		Usage: "number of functions to show per profile, 0 shows all",
+		Value: 10,
+	},
+}
+
+var adminProfileCompareCmd = cli.Command{
+	Name:         "compare",
+	Usage:        "compare the profiles of two profile bundles",
+	Action:       mainAdminProfileCompare,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminProfileCompareFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] BASE-ZIP ZIP
+
+  Profiles of the same type found in both bundles are compared and the functions
+  whose values changed the most from BASE-ZIP to ZIP are listed, as 'go tool pprof
+  -top -diff_base' would.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Compare the profiles captured before and after a change, aggregating all nodes.
+     {{.Prompt}} {{.HelpName}} profile-before.zip profile-after.zip
+
+  2. Compare the profiles of every node and show the 20 functions which changed the most.
+     {{.Prompt}} {{.HelpName}} --per-node --top 20 profile-before.zip profile-after.zip
+`,
+}
+
+// profileBundleKey identifies a profile in a profile bundle, node is
+// empty when the profiles of all nodes are aggregated.
+type profileBundleKey struct {
+	node     string
+	profType string
+}
+
+// parseProfileEntryName parses the name of a pprof profile in a profile
+// bundle, which is 'profile-<node>-<type>.pprof'.
+func parseProfileEntryName(name string) (node, profType string, ok bool) {
+	name = path.Base(name)
+	switch {
+	case strings.HasSuffix(name, ".pprof"):
+		name = strings.TrimSuffix(name, ".pprof")
+	case strings.HasSuffix(name, ".pb.gz"):
+		name = strings.TrimSuffix(name, ".pb.gz")
+	default:
+		return "", "", false
+	}
+	if !strings.HasPrefix(name, "profile-") {
+		return "", "", false
+	}
+	name = strings.TrimPrefix(name, "profile-")
+	i := strings.LastIndex(name, "-")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// loadProfileBundle loads the pprof profiles of a profile bundle.
+func loadProfileBundle(bundle string, perNode bool) (map[profileBundleKey]*pprofProfile, *probe.Error) {
+	zr, e := zip.OpenReader(bundle)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(bundle)
+	}
+	defer zr.Close()
+
+	profiles := make(map[profileBundleKey]*pprofProfile)
+	for _, f := range zr.File {
+		node, profType, ok := parseProfileEntryName(f.Name)
+		if !ok {
+			continue
+		}
+		rc, e := f.Open()
+		if e != nil {
+			return nil, probe.NewError(e).Trace(bundle, f.Name)
+		}
+		data, e := io.ReadAll(rc)
+		rc.Close()
+		if e != nil {
+			return nil, probe.NewError(e).Trace(bundle, f.Name)
+		}
+		p, e := parsePprofProfile(data)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(bundle, f.Name)
+		}
+
+		if !perNode {
+			node = ""
+		}
+		key := profileBundleKey{node: node, profType: profType}
+		if prev, ok := profiles[key]; ok {
+			prev.merge(p)
+		} else {
+			profiles[key] = p
+		}
+	}
+	return profiles, nil
+}
+
+// profileCompareMessage container for the comparison of two profiles.
+type profileCompareMessage struct {
+	Status     string               `json:"status"`
+	Node       string               `json:"node,omitempty"`
+	Type       string               `json:"type"`
+	SampleType string               `json:"sampleType"`
+	Unit       string               `json:"unit"`
+	BaseTotal  int64                `json:"baseTotal"`
+	Total      int64                `json:"total"`
+	Functions  []pprofFunctionDelta `json:"functions"`
+}
+
+// formatProfileValue formats a signed profile value according to its unit.
+func formatProfileValue(v int64, unit string, signed bool) string {
+	sign := ""
+	switch {
+	case v < 0:
+		sign = "-"
+		v = -v
+	case signed:
+		sign = "+"
+	}
+	switch unit {
+	case "nanoseconds":
+		return sign + time.Duration(v).String()
+	case "bytes":
+		return sign + humanize.IBytes(uint64(v))
+	}
+	return sign + strconv.FormatInt(v, 10)
+}
+
+func (p profileCompareMessage) String() string {
+	var sb strings.Builder
+	title := p.Type
+	if p.Node != "" {
+		title += " (" + p.Node + ")"
+	}
+	fmt.Fprintf(&sb, "%s %s: %s -> %s (%s)\n", console.Colorize("ProfileType", title), p.SampleType,
+		formatProfileValue(p.BaseTotal, p.Unit, false), formatProfileValue(p.Total, p.Unit, false),
+		formatProfileValue(p.Total-p.BaseTotal, p.Unit, true))
+	if len(p.Functions) == 0 {
+		sb.WriteString("  No changes found.\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "  %14s %14s  %s\n", "FLAT", "CUM", "FUNCTION")
+	for _, f := range p.Functions {
+		fmt.Fprintf(&sb, "  %14s %14s  %s\n", formatProfileValue(f.Flat, p.Unit, true),
+			formatProfileValue(f.Cum, p.Unit, true), f.Function)
+	}
+	return sb.String()
+}
+
+func (p profileCompareMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainAdminProfileCompare is the handle for "mc admin profile compare" command.
+func mainAdminProfileCompare(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+
+	console.SetColor("ProfileType", color.New(color.FgCyan, color.Bold))
+
+	perNode := ctx.Bool("per-node")
+	baseBundle, bundle := ctx.Args().Get(0), ctx.Args().Get(1)
+
+	baseProfiles, err := loadProfileBundle(baseBundle, perNode)
+	fatalIf(err, "Unable to load the profile bundle.")
+	profiles, err := loadProfileBundle(bundle, perNode)
+	fatalIf(err, "Unable to load the profile bundle.")
+
+	var keys []profileBundleKey
+	for key := range profiles {
+		if _, ok := baseProfiles[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		fatalIf(errDummy().Trace(baseBundle, bundle), "No profiles of the same type were found in both bundles.")
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].node != keys[j].node {
+			return keys[i].node < keys[j].node
+		}
+		return keys[i].profType < keys[j].profType
+	})
+
+	for _, key := range keys {
+		base, cur := baseProfiles[key], profiles[key]
+		if base.sampleType != cur.sampleType || base.unit != cur.unit {
+			errorIf(errDummy().Trace(key.profType), "Skipping `%s` profiles which have different sample types.", key.profType)
+			continue
+		}
+		printMsg(profileCompareMessage{
+			Node:       key.node,
+			Type:       key.profType,
+			SampleType: cur.sampleType,
+			Unit:       cur.unit,
+			BaseTotal:  base.total,
+			Total:      cur.total,
+			Functions:  diffPprofProfiles(base, cur, ctx.Int("top")),
+		})
+	}
+	return nil
+}
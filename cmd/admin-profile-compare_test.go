@@ -0,0 +1,148 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseProfileEntryName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		node     string
+		profType string
+		ok       bool
+	}{
+		{"profile-127.0.0.1:9000-cpu.pprof", "127.0.0.1:9000", "cpu", true},
+		{"dir/profile-minio-1.example.com:9000-mem.pprof", "minio-1.example.com:9000", "mem", true},
+		{"profile-node1-block.pb.gz", "node1", "block", true},
+		{"profile-127.0.0.1:9000-goroutines.txt", "", "", false},
+		{"profile-cpu.pprof", "", "", false},
+		{"cpu.pprof", "", "", false},
+	}
+	for i, testCase := range testCases {
+		node, profType, ok := parseProfileEntryName(testCase.name)
+		if node != testCase.node || profType != testCase.profType || ok != testCase.ok {
+			t.Errorf("Test %d: expected (%q, %q, %v), got (%q, %q, %v)", i+1,
+				testCase.node, testCase.profType, testCase.ok, node, profType, ok)
+		}
+	}
+}
+
+// encodeTestPprofProfile encodes a profile with the sample type 'samples/count'
+// and the given stacks, the leaf function being the first of every stack.
+func encodeTestPprofProfile(stacks [][]string, values []uint64) []byte {
+	strs := []string{"", "samples", "count"}
+	funcIDs := make(map[string]uint64)
+
+	var b []byte
+	appendMsg := func(num protowire.Number, msg []byte) {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	}
+
+	var vt []byte
+	vt = protowire.AppendTag(vt, pprofValueTypeType, protowire.VarintType)
+	vt = protowire.AppendVarint(vt, 1)
+	vt = protowire.AppendTag(vt, pprofValueTypeUnit, protowire.VarintType)
+	vt = protowire.AppendVarint(vt, 2)
+	appendMsg(pprofProfileSampleType, vt)
+
+	for i, stack := range stacks {
+		var locs []byte
+		for _, name := range stack {
+			id, ok := funcIDs[name]
+			if !ok {
+				id = uint64(len(funcIDs) + 1)
+				funcIDs[name] = id
+				strs = append(strs, name)
+
+				var fn []byte
+				fn = protowire.AppendTag(fn, pprofFunctionID, protowire.VarintType)
+				fn = protowire.AppendVarint(fn, id)
+				fn = protowire.AppendTag(fn, pprofFunctionName, protowire.VarintType)
+				fn = protowire.AppendVarint(fn, uint64(len(strs)-1))
+				appendMsg(pprofProfileFunction, fn)
+
+				// Use the same id for the location of the function.
+				var line []byte
+				line = protowire.AppendTag(line, pprofLineFunctionID, protowire.VarintType)
+				line = protowire.AppendVarint(line, id)
+				var loc []byte
+				loc = protowire.AppendTag(loc, pprofLocationID, protowire.VarintType)
+				loc = protowire.AppendVarint(loc, id)
+				loc = protowire.AppendTag(loc, pprofLocationLine, protowire.BytesType)
+				loc = protowire.AppendBytes(loc, line)
+				appendMsg(pprofProfileLocation, loc)
+			}
+			locs = protowire.AppendVarint(locs, id)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, pprofSampleLocationID, protowire.BytesType)
+		sample = protowire.AppendBytes(sample, locs)
+		sample = protowire.AppendTag(sample, pprofSampleValue, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, values[i])
+		appendMsg(pprofProfileSample, sample)
+	}
+
+	for _, s := range strs {
+		appendMsg(pprofProfileStringTable, []byte(s))
+	}
+	return b
+}
+
+func TestDiffPprofProfiles(t *testing.T) {
+	base, e := parsePprofProfile(encodeTestPprofProfile(
+		[][]string{{"read", "handler", "main"}, {"write", "handler", "main"}},
+		[]uint64{10, 5},
+	))
+	if e != nil {
+		t.Fatal(e)
+	}
+	cur, e := parsePprofProfile(encodeTestPprofProfile(
+		[][]string{{"read", "handler", "main"}, {"write", "handler", "main"}, {"gc", "main"}},
+		[]uint64{4, 5, 20},
+	))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if base.sampleType != "samples" || base.unit != "count" || base.total != 15 || cur.total != 29 {
+		t.Fatalf("Unexpected profile %s/%s with totals %d and %d", base.sampleType, base.unit, base.total, cur.total)
+	}
+
+	expected := []pprofFunctionDelta{
+		{Function: "gc", Flat: 20, Cum: 20},
+		{Function: "read", Flat: -6, Cum: -6},
+		{Function: "main", Flat: 0, Cum: 14},
+	}
+	if deltas := diffPprofProfiles(base, cur, 3); !reflect.DeepEqual(deltas, expected) {
+		t.Errorf("Expected %v, got %v", expected, deltas)
+	}
+	if deltas := diffPprofProfiles(base, cur, 0); len(deltas) != 4 {
+		t.Errorf("Expected 4 functions, got %v", deltas)
+	}
+
+	base.merge(cur)
+	if base.total != 44 || base.flat["read"] != 14 || base.cum["main"] != 44 {
+		t.Errorf("Unexpected merged profile %+v", base)
+	}
+}
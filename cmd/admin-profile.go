@@ -25,6 +25,7 @@ import (
 var adminProfileSubcommands = []cli.Command{
 	adminProfileStartCmd,
 	adminProfileStopCmd,
+	adminProfileCompareCmd,
 }
 
 var adminProfileCmd = cli.Command{
@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -33,13 +35,35 @@ import (
 const (
 	defaultJobName     = "minio-job"
 	defaultMetricsPath = "/minio/v2/metrics/cluster"
+	defaultMetricsType = "cluster"
 )
 
+// prometheusMetricsPaths maps the supported metric types to their endpoints.
+var prometheusMetricsPaths = map[string]string{
+	"cluster":  defaultMetricsPath,
+	"node":     "/minio/v2/metrics/node",
+	"bucket":   "/minio/v2/metrics/bucket",
+	"resource": "/minio/v2/metrics/resource",
+}
+
 var prometheusFlags = []cli.Flag{
 	cli.BoolFlag{
 		Name:  "public",
 		Usage: "disable bearer token generation for scrape_configs",
 	},
+	cli.StringFlag{
+		Name:  "job-name",
+		Usage: "name of the scrape job, suffixed with the alias and metric type when generating several jobs",
+		Value: defaultJobName,
+	},
+	cli.StringFlag{
+		Name:  "scrape-interval",
+		Usage: "how frequently to scrape the targets, e.g. 30s, 1m",
+	},
+	cli.StringSliceFlag{
+		Name:  "metrics",
+		Usage: "metric endpoints to scrape as separate jobs, one of: cluster, node, bucket, resource (default: cluster)",
+	},
 }
 
 var adminPrometheusGenerateCmd = cli.Command{
@@ -54,15 +78,31 @@ var adminPrometheusGenerateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [TARGET...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+TOKEN EXPIRY:
+  Unless --public is set, each job carries a bearer token signed with the alias
+  credentials. The expiry of the token is noted in the generated config, regenerate
+  the config before it lapses or when the credentials of the alias change.
+
 EXAMPLES:
   1. Generate a default prometheus config.
      {{.Prompt}} {{.HelpName}} myminio
 
+  2. Generate a prometheus config with a custom job name scraping every 30 seconds.
+     {{.Prompt}} {{.HelpName}} myminio --job-name minio-prod --scrape-interval 30s
+
+  3. Generate a single prometheus config scraping the clusters "site1" and "site2",
+     with one job per cluster.
+     {{.Prompt}} {{.HelpName}} site1 site2
+
+  4. Generate a prometheus config scraping the cluster, bucket and resource metrics
+     of "myminio" as separate jobs.
+     {{.Prompt}} {{.HelpName}} myminio --metrics cluster --metrics bucket --metrics resource
+
 `,
 }
 
@@ -76,14 +116,25 @@ func (c PrometheusConfig) String() string {
 	b, e := yaml.Marshal(c)
 	fatalIf(probe.NewError(e), "Unable to generate Prometheus config")
 
-	return console.Colorize("yaml", string(b))
+	var notes strings.Builder
+	for _, sc := range c.ScrapeConfigs {
+		if sc.BearerTokenExpiry != nil {
+			fmt.Fprintf(&notes, "# Bearer token of job %s expires on %s, regenerate this config before then.\n",
+				sc.JobName, sc.BearerTokenExpiry.Format(time.RFC3339))
+		}
+	}
+	return console.Colorize("yaml", notes.String()+string(b))
 }
 
-// JSON jsonified prometheus config.
+// JSON jsonified prometheus config, one scrape config per line.
 func (c PrometheusConfig) JSON() string {
-	jsonMessageBytes, e := json.MarshalIndent(c.ScrapeConfigs[0], "", " ")
-	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
-	return string(jsonMessageBytes)
+	configs := make([]string, 0, len(c.ScrapeConfigs))
+	for _, sc := range c.ScrapeConfigs {
+		jsonMessageBytes, e := json.MarshalIndent(sc, "", " ")
+		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+		configs = append(configs, string(jsonMessageBytes))
+	}
+	return strings.Join(configs, "\n")
 }
 
 // StatConfig - container to hold the targets config.
@@ -108,72 +159,121 @@ func (t StatConfig) JSON() string {
 
 // ScrapeConfig configures a scraping unit for Prometheus.
 type ScrapeConfig struct {
-	JobName       string       `yaml:"job_name" json:"jobName"`
-	BearerToken   string       `yaml:"bearer_token,omitempty" json:"bearerToken,omitempty"`
-	MetricsPath   string       `yaml:"metrics_path,omitempty" json:"metricsPath"`
-	Scheme        string       `yaml:"scheme,omitempty" json:"scheme"`
-	StaticConfigs []StatConfig `yaml:"static_configs,omitempty" json:"staticConfigs"`
+	JobName           string       `yaml:"job_name" json:"jobName"`
+	ScrapeInterval    string       `yaml:"scrape_interval,omitempty" json:"scrapeInterval,omitempty"`
+	BearerToken       string       `yaml:"bearer_token,omitempty" json:"bearerToken,omitempty"`
+	BearerTokenExpiry *time.Time   `yaml:"-" json:"bearerTokenExpiry,omitempty"`
+	MetricsPath       string       `yaml:"metrics_path,omitempty" json:"metricsPath"`
+	Scheme            string       `yaml:"scheme,omitempty" json:"scheme"`
+	StaticConfigs     []StatConfig `yaml:"static_configs,omitempty" json:"staticConfigs"`
 }
 
 const (
 	defaultPrometheusJWTExpiry = 100 * 365 * 24 * time.Hour
 )
 
-var defaultConfig = PrometheusConfig{
-	ScrapeConfigs: []ScrapeConfig{
-		{
-			JobName:     defaultJobName,
-			MetricsPath: defaultMetricsPath,
-			StaticConfigs: []StatConfig{
-				{
-					Targets: []string{""},
-				},
-			},
-		},
-	},
-}
-
 // checkAdminPrometheusSyntax - validate all the passed arguments
 func checkAdminPrometheusSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) < 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if strings.TrimSpace(ctx.String("job-name")) == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.String("job-name")), "--job-name cannot be empty.")
+	}
+	if interval := ctx.String("scrape-interval"); interval != "" {
+		d, e := time.ParseDuration(interval)
+		if e != nil || d <= 0 {
+			fatalIf(errInvalidArgument().Trace(interval), "Invalid --scrape-interval, expected a positive duration such as 30s or 1m.")
+		}
+	}
+	for _, metric := range ctx.StringSlice("metrics") {
+		if _, ok := prometheusMetricsPaths[metric]; !ok {
+			fatalIf(errInvalidArgument().Trace(metric), "Unknown metrics type `"+metric+"`, expected one of cluster, node, bucket, resource.")
+		}
+	}
+	seen := make(map[string]bool)
+	for _, arg := range ctx.Args() {
+		alias := cleanAlias(arg)
+		if seen[alias] {
+			fatalIf(errInvalidArgument().Trace(arg), "Alias `"+alias+"` specified more than once.")
+		}
+		seen[alias] = true
+	}
 }
 
-func generatePrometheusConfig(ctx *cli.Context) error {
-	// Get the alias parameter from cli
-	args := ctx.Args()
-	alias := cleanAlias(args.Get(0))
-
-	if !isValidAlias(alias) {
-		fatalIf(errInvalidAlias(alias), "Invalid alias.")
+// prometheusJobName returns the name of the scrape job for an alias and metric type,
+// the alias and the metric type are only appended when needed to keep jobs distinct.
+func prometheusJobName(jobName, alias, metric string, multiAlias, multiMetrics bool) string {
+	if multiAlias {
+		jobName += "-" + alias
+	}
+	if multiMetrics && metric != defaultMetricsType {
+		jobName += "-" + metric
 	}
+	return jobName
+}
 
-	hostConfig := mustGetHostConfig(alias)
-	if hostConfig == nil {
-		fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
-		return nil
+// newScrapeConfigs returns one scrape config per metric type for the given alias.
+func newScrapeConfigs(jobName, alias string, u *url.URL, metrics []string, multiAlias bool) []ScrapeConfig {
+	scrapeConfigs := make([]ScrapeConfig, 0, len(metrics))
+	for _, metric := range metrics {
+		scrapeConfigs = append(scrapeConfigs, ScrapeConfig{
+			JobName:     prometheusJobName(jobName, alias, metric, multiAlias, len(metrics) > 1),
+			MetricsPath: prometheusMetricsPaths[metric],
+			Scheme:      u.Scheme,
+			StaticConfigs: []StatConfig{
+				{
+					Targets: []string{u.Host},
+				},
+			},
+		})
 	}
+	return scrapeConfigs
+}
 
-	u, e := url.Parse(hostConfig.URL)
-	if e != nil {
-		return e
+func generatePrometheusConfig(ctx *cli.Context) (PrometheusConfig, error) {
+	metrics := ctx.StringSlice("metrics")
+	if len(metrics) == 0 {
+		metrics = []string{defaultMetricsType}
 	}
+	multiAlias := len(ctx.Args()) > 1
 
-	if !ctx.Bool("public") {
-		token, e := getPrometheusToken(hostConfig)
-		if e != nil {
-			return e
+	var config PrometheusConfig
+	for _, arg := range ctx.Args() {
+		alias := cleanAlias(arg)
+
+		if !isValidAlias(alias) {
+			fatalIf(errInvalidAlias(alias), "Invalid alias.")
 		}
-		// Setting the values
-		defaultConfig.ScrapeConfigs[0].BearerToken = token
-	}
-	defaultConfig.ScrapeConfigs[0].Scheme = u.Scheme
-	defaultConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
 
-	printMsg(defaultConfig)
+		hostConfig := mustGetHostConfig(alias)
+		if hostConfig == nil {
+			fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+			return config, nil
+		}
 
-	return nil
+		u, e := url.Parse(hostConfig.URL)
+		if e != nil {
+			return config, e
+		}
+
+		scrapeConfigs := newScrapeConfigs(ctx.String("job-name"), alias, u, metrics, multiAlias)
+		if !ctx.Bool("public") {
+			token, expiry, e := newPrometheusToken(hostConfig)
+			if e != nil {
+				return config, e
+			}
+			for i := range scrapeConfigs {
+				scrapeConfigs[i].BearerToken = token
+				scrapeConfigs[i].BearerTokenExpiry = &expiry
+			}
+		}
+		for i := range scrapeConfigs {
+			scrapeConfigs[i].ScrapeInterval = ctx.String("scrape-interval")
+		}
+		config.ScrapeConfigs = append(config.ScrapeConfigs, scrapeConfigs...)
+	}
+	return config, nil
 }
 
 // mainAdminPrometheus is the handle for "mc admin prometheus generate" sub-command.
@@ -182,9 +282,10 @@ func mainAdminPrometheusGenerate(ctx *cli.Context) error {
 
 	checkAdminPrometheusSyntax(ctx)
 
-	if err := generatePrometheusConfig(ctx); err != nil {
-		return nil
-	}
+	config, e := generatePrometheusConfig(ctx)
+	fatalIf(probe.NewError(e), "Unable to generate Prometheus config.")
+
+	printMsg(config)
 
 	return nil
 }
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewScrapeConfigs(t *testing.T) {
+	u, e := url.Parse("https://minio.example.com:9000")
+	if e != nil {
+		t.Fatal(e)
+	}
+	testCases := []struct {
+		metrics    []string
+		multiAlias bool
+		jobNames   []string
+		paths      []string
+	}{
+		{[]string{"cluster"}, false, []string{"minio-job"}, []string{"/minio/v2/metrics/cluster"}},
+		{[]string{"cluster"}, true, []string{"minio-job-site1"}, []string{"/minio/v2/metrics/cluster"}},
+		{
+			[]string{"cluster", "bucket", "resource"}, false,
+			[]string{"minio-job", "minio-job-bucket", "minio-job-resource"},
+			[]string{"/minio/v2/metrics/cluster", "/minio/v2/metrics/bucket", "/minio/v2/metrics/resource"},
+		},
+		{
+			[]string{"node", "bucket"}, true,
+			[]string{"minio-job-site1-node", "minio-job-site1-bucket"},
+			[]string{"/minio/v2/metrics/node", "/minio/v2/metrics/bucket"},
+		},
+	}
+	for i, tc := range testCases {
+		scrapeConfigs := newScrapeConfigs("minio-job", "site1", u, tc.metrics, tc.multiAlias)
+		if len(scrapeConfigs) != len(tc.jobNames) {
+			t.Fatalf("Test %d: expected %d scrape configs, got %d", i+1, len(tc.jobNames), len(scrapeConfigs))
+		}
+		for j, sc := range scrapeConfigs {
+			if sc.JobName != tc.jobNames[j] || sc.MetricsPath != tc.paths[j] {
+				t.Errorf("Test %d: expected job %s scraping %s, got %s scraping %s", i+1, tc.jobNames[j], tc.paths[j], sc.JobName, sc.MetricsPath)
+			}
+			if sc.Scheme != "https" || sc.StaticConfigs[0].Targets[0] != "minio.example.com:9000" {
+				t.Errorf("Test %d: unexpected target %s://%v", i+1, sc.Scheme, sc.StaticConfigs[0].Targets)
+			}
+		}
+	}
+}
+
+func TestPrometheusConfigTokenExpiry(t *testing.T) {
+	expiry := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{
+		{JobName: "minio-job", ScrapeInterval: "30s", BearerToken: "token", BearerTokenExpiry: &expiry},
+		{JobName: "minio-public"},
+	}}
+	out := config.String()
+	if !strings.Contains(out, "# Bearer token of job minio-job expires on 2030-01-01T00:00:00Z") {
+		t.Errorf("Expected token expiry note, got %s", out)
+	}
+	if strings.Contains(out, "minio-public expires") {
+		t.Errorf("Expected no expiry note for public job, got %s", out)
+	}
+	if !strings.Contains(out, "scrape_interval: 30s") {
+		t.Errorf("Expected scrape interval, got %s", out)
+	}
+	if jsonLines := strings.Count(config.JSON(), `"jobName"`); jsonLines != 2 {
+		t.Errorf("Expected 2 scrape configs in JSON, got %d", jsonLines)
+	}
+}
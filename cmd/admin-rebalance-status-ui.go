@@ -0,0 +1,204 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go/v2"
+)
+
+const (
+	// Interval to refresh the status of a rebalance with --watch.
+	rebalanceWatchInterval = 2 * time.Second
+
+	// Width of the usage bar of a pool, and distance from the target
+	// usage under which a pool is considered balanced, in percent.
+	rebalanceBarWidth  = 50
+	rebalanceTolerance = 1.0
+)
+
+var (
+	rebalanceBalancedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00"))
+	rebalanceUnbalancedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffff00"))
+)
+
+// rebalanceTarget returns the usage every pool converges to, the usage
+// of the whole cluster. Pools are weighted by their capacity when it is
+// known.
+func rebalanceTarget(pools []madmin.RebalancePoolStatus, capacity map[int]uint64) float64 {
+	var used, total float64
+	for _, pool := range pools {
+		weight := float64(capacity[pool.ID])
+		if len(capacity) == 0 {
+			weight = 1
+		}
+		used += pool.Used * weight
+		total += weight
+	}
+	if total == 0 {
+		return 0
+	}
+	return used / total
+}
+
+// rebalanceProgress returns the progress of a rebalance, estimated from
+// the elapsed time and the time left of its slowest pool.
+func rebalanceProgress(pools []madmin.RebalancePoolStatus) (progress float64, elapsed, eta time.Duration) {
+	for _, pool := range pools {
+		if pool.Progress.Elapsed > elapsed {
+			elapsed = pool.Progress.Elapsed
+		}
+		if pool.Progress.ETA > eta {
+			eta = pool.Progress.ETA
+		}
+	}
+	if elapsed+eta == 0 {
+		return 0, elapsed, eta
+	}
+	return 100 * float64(elapsed) / float64(elapsed+eta), elapsed, eta
+}
+
+// rebalanceBar renders the usage of a pool as a bar, with a marker at
+// the target usage.
+func rebalanceBar(used, target float64, width int) string {
+	scale := func(pct float64) int {
+		return int(math.Round(math.Max(0, math.Min(100, pct)) * float64(width) / 100))
+	}
+	filled, marker := scale(used), scale(target)
+	if marker >= width {
+		marker = width - 1
+	}
+	var b strings.Builder
+	for i := 0; i < width; i++ {
+		switch {
+		case i == marker:
+			b.WriteString("┃")
+		case i < filled:
+			b.WriteString("█")
+		default:
+			b.WriteString("░")
+		}
+	}
+	return b.String()
+}
+
+type rebalanceStatusUI struct {
+	spinner  spinner.Model
+	quitting bool
+	status   madmin.RebalanceStatus
+	capacity map[int]uint64
+}
+
+// rebalanceStatusResult is a status of the rebalance, final once no
+// pool is rebalancing anymore.
+type rebalanceStatusResult struct {
+	status madmin.RebalanceStatus
+	final  bool
+}
+
+func initRebalanceStatusUI(capacity map[int]uint64) *rebalanceStatusUI {
+	s := spinner.New()
+	s.Spinner = spinner.Points
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	return &rebalanceStatusUI{
+		spinner:  s,
+		capacity: capacity,
+	}
+}
+
+func (m *rebalanceStatusUI) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m *rebalanceStatusUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case rebalanceStatusResult:
+		m.status = msg.status
+		if msg.final {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		return m, nil
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	default:
+		return m, nil
+	}
+}
+
+func (m *rebalanceStatusUI) View() string {
+	var s strings.Builder
+	s.WriteString("\n")
+
+	if m.status.ID == "" {
+		if !m.quitting {
+			s.WriteString(fmt.Sprintf("%s Fetching the rebalance status...\n", m.spinner.View()))
+		}
+		return s.String()
+	}
+
+	progress, elapsed, eta := rebalanceProgress(m.status.Pools)
+	target := rebalanceTarget(m.status.Pools, m.capacity)
+	var totalBytes, totalObjects uint64
+	for _, pool := range m.status.Pools {
+		totalBytes += pool.Progress.Bytes
+		totalObjects += pool.Progress.NumObjects
+	}
+
+	s.WriteString(whiteStyle.Render(fmt.Sprintf("Rebalance %s: %.1f%% done, %s elapsed, %s to completion",
+		m.status.ID, progress, elapsed.Round(time.Second), eta.Round(time.Second))))
+	s.WriteString(fmt.Sprintf("\nMoved %s (%d objects), target usage %.2f%% per pool\n\n",
+		humanize.IBytes(totalBytes), totalObjects, target))
+
+	for _, pool := range m.status.Pools {
+		distance := pool.Used - target
+		style := rebalanceUnbalancedStyle
+		if math.Abs(distance) <= rebalanceTolerance {
+			style = rebalanceBalancedStyle
+		}
+		status := pool.Status
+		if status == "" {
+			status = "-"
+		}
+		s.WriteString(fmt.Sprintf("Pool-%-3d %s %6.2f%% %s %s\n", pool.ID,
+			style.Render(rebalanceBar(pool.Used, target, rebalanceBarWidth)), pool.Used,
+			style.Render(fmt.Sprintf("%+6.2f%%", distance)), status))
+	}
+
+	if !m.quitting {
+		s.WriteString(fmt.Sprintf("\n%s ┃ marks the target usage, press q to quit", m.spinner.View()))
+	}
+	return s.String() + "\n"
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestRebalanceStatusUI(t *testing.T) {
+	pools := []madmin.RebalancePoolStatus{
+		{ID: 0, Status: "Started", Used: 80},
+		{ID: 1, Status: "Started", Used: 20},
+	}
+	pools[0].Progress.Elapsed = time.Hour
+	pools[0].Progress.ETA = 3 * time.Hour
+	pools[1].Progress.Elapsed = 30 * time.Minute
+
+	if target := rebalanceTarget(pools, nil); target != 50 {
+		t.Errorf("expected an unweighted target of 50%%, got %v", target)
+	}
+	// The second pool is three times larger.
+	if target := rebalanceTarget(pools, map[int]uint64{0: 100, 1: 300}); math.Abs(target-35) > 1e-9 {
+		t.Errorf("expected a weighted target of 35%%, got %v", target)
+	}
+
+	progress, elapsed, eta := rebalanceProgress(pools)
+	if progress != 25 || elapsed != time.Hour || eta != 3*time.Hour {
+		t.Errorf("unexpected progress %v, elapsed %v, eta %v", progress, elapsed, eta)
+	}
+
+	bar := rebalanceBar(80, 50, 10)
+	if bar != "█████┃██░░" {
+		t.Errorf("unexpected bar %q", bar)
+	}
+	if bar = rebalanceBar(0, 100, 10); strings.Count(bar, "┃") != 1 || !strings.HasSuffix(bar, "┃") {
+		t.Errorf("unexpected bar %q", bar)
+	}
+
+	if isRebalanceDone(madmin.RebalanceStatus{Pools: pools}) {
+		t.Errorf("expected the rebalance to be in progress")
+	}
+	pools[0].Status, pools[1].Status = "Completed", "Completed"
+	if !isRebalanceDone(madmin.RebalanceStatus{Pools: pools}) {
+		t.Errorf("expected the rebalance to be done")
+	}
+}
@@ -18,14 +18,18 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
@@ -36,7 +40,12 @@ var adminRebalanceStatusCmd = cli.Command{
 	Action:       mainAdminRebalanceStatus,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags: append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "watch, w",
+			Usage: "show the usage of every pool converging to the target usage until the rebalance is done",
+		},
+	}, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -49,6 +58,9 @@ FLAGS:
 EXAMPLES:
   1. Summarize ongoing rebalance on a MinIO deployment with alias myminio
      {{.Prompt}} {{.HelpName}} myminio
+
+  2. Watch the pools of a MinIO deployment with alias myminio converging to the same usage, until the rebalance is done
+     {{.Prompt}} {{.HelpName}} --watch myminio
 `,
 }
 
@@ -66,6 +78,11 @@ func mainAdminRebalanceStatus(ctx *cli.Context) error {
 		return err.ToGoError()
 	}
 
+	if ctx.Bool("watch") {
+		watchRebalanceStatus(client)
+		return nil
+	}
+
 	rInfo, e := client.RebalanceStatus(globalContext)
 	fatalIf(probe.NewError(e), "Unable to get rebalance status")
 
@@ -124,3 +141,73 @@ func mainAdminRebalanceStatus(ctx *cli.Context) error {
 	console.Println(b.String())
 	return nil
 }
+
+// isRebalanceDone returns whether no pool is rebalancing anymore.
+func isRebalanceDone(rInfo madmin.RebalanceStatus) bool {
+	for _, pool := range rInfo.Pools {
+		if pool.Status == "Started" {
+			return false
+		}
+	}
+	return true
+}
+
+// watchRebalanceStatus refreshes the status of a rebalance until it is
+// done, as JSON lines with --json.
+func watchRebalanceStatus(client *madmin.AdminClient) {
+	ctxt, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	var ui *tea.Program
+	if !globalJSON {
+		// Pools are weighted by their capacity in the target usage.
+		capacity := make(map[int]uint64)
+		if info, e := client.ServerInfo(ctxt); e == nil {
+			for _, srv := range info.Servers {
+				for _, disk := range srv.Disks {
+					capacity[disk.PoolIndex] += disk.TotalSpace
+				}
+			}
+		}
+		ui = tea.NewProgram(initRebalanceStatusUI(capacity))
+	}
+
+	poll := func() {
+		ticker := time.NewTicker(rebalanceWatchInterval)
+		defer ticker.Stop()
+		for {
+			rInfo, e := client.RebalanceStatus(ctxt)
+			if e != nil && errors.Is(e, context.Canceled) {
+				return
+			}
+			fatalIf(probe.NewError(e), "Unable to get rebalance status")
+
+			done := isRebalanceDone(rInfo)
+			if ui != nil {
+				ui.Send(rebalanceStatusResult{status: rInfo, final: done})
+			} else {
+				b, e := json.Marshal(rInfo)
+				fatalIf(probe.NewError(e), "Unable to marshal json")
+				console.Println(string(b))
+			}
+			if done {
+				return
+			}
+			select {
+			case <-ctxt.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+
+	if ui == nil {
+		poll()
+		return
+	}
+	go poll()
+	if e := ui.Start(); e != nil {
+		cancel()
+		fatalIf(probe.NewError(e), "Unable to show the rebalance status")
+	}
+}
@@ -106,6 +106,9 @@ EXAMPLES:
 
     4. Drill down and view site replication status of user "foo"
        {{.Prompt}} {{.HelpName}} minio1 --user foo
+
+    5. Display site replication status as JSON, including the divergent entities and the pending counts
+       {{.Prompt}} {{.HelpName}} minio1 --json
 `,
 }
 
@@ -115,7 +118,15 @@ type srStatus struct {
 }
 
 func (i srStatus) JSON() string {
-	bs, e := json.MarshalIndent(madmin.SRStatusInfo(i.SRStatusInfo), "", " ")
+	bs, e := json.MarshalIndent(struct {
+		madmin.SRStatusInfo
+		Divergences []srDivergence       `json:"divergences,omitempty"`
+		Pending     map[string]srPending `json:"pending,omitempty"`
+	}{
+		SRStatusInfo: i.SRStatusInfo,
+		Divergences:  srDivergences(i.SRStatusInfo),
+		Pending:      srPendingCounts(i.SRStatusInfo),
+	}, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(bs)
 }
@@ -308,6 +319,10 @@ func (i srStatus) String() string {
 		}
 	}
 
+	if i.opts.Entity == madmin.Unspecified {
+		messages = append(messages, i.getDivergenceSummary()...)
+	}
+
 	switch i.opts.Entity {
 	case madmin.SRBucketEntity:
 		messages = append(messages, i.getBucketStatusSummary(siteNames, nameIDMap, "Bucket")...)
@@ -323,6 +338,44 @@ func (i srStatus) String() string {
 	return console.Colorize("UserMessage", strings.Join(messages, "\n"))
 }
 
+// getDivergenceSummary flags the entities displayed which are not in sync
+// across the sites, and the entities pending replication on every site.
+func (i srStatus) getDivergenceSummary() []string {
+	var messages []string
+	var divergences []string
+	for _, d := range srDivergences(i.SRStatusInfo) {
+		switch {
+		case d.Type == "Bucket" && i.opts.Buckets,
+			d.Type == "Policy" && i.opts.Policies,
+			d.Type == "User" && i.opts.Users,
+			d.Type == "Group" && i.opts.Groups:
+			divergences = append(divergences, "   "+console.Colorize("WarningMessage", d.String()))
+		}
+	}
+	if len(divergences) > 0 {
+		messages = append(messages, console.Colorize("SummaryHdr", "Divergent entities:"))
+		messages = append(messages, divergences...)
+		messages = append(messages, "")
+	}
+
+	pending := srPendingCounts(i.SRStatusInfo)
+	if len(pending) > 0 {
+		messages = append(messages, console.Colorize("SummaryHdr", "Pending replication:"))
+		var names []string
+		for name := range pending {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := pending[name]
+			messages = append(messages, fmt.Sprintf("   %s: %d bucket(s), %d policy(ies), %d user(s), %d group(s)",
+				strings.ToTitle(name), p.Buckets, p.Policies, p.Users, p.Groups))
+		}
+		messages = append(messages, "")
+	}
+	return messages
+}
+
 func (i srStatus) siteHeader(siteNames []string, legend string) string {
 	legendHdr := []string{legend}
 	legendFields := []Field{{"Entity", 15}}
@@ -721,3 +774,119 @@ func syncStatus(mismatch, set bool) (string, string) {
 
 	return "Entity", tickCell
 }
+
+// srDivergence is an entity which is not in sync across the sites,
+// either missing on some sites or with a different configuration.
+type srDivergence struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	MissingOn  []string `json:"missingOn,omitempty"`
+	MismatchOn []string `json:"mismatchOn,omitempty"`
+}
+
+func (d srDivergence) String() string {
+	var details []string
+	if len(d.MissingOn) > 0 {
+		details = append(details, "missing on "+strings.Join(d.MissingOn, ", "))
+	}
+	if len(d.MismatchOn) > 0 {
+		details = append(details, "out of sync on "+strings.Join(d.MismatchOn, ", "))
+	}
+	return fmt.Sprintf("%s %s `%s`: %s", crossTickCell, d.Type, d.Name, strings.Join(details, "; "))
+}
+
+// srDivergences returns the entities not in sync across the sites, sorted by type and name.
+func srDivergences(info madmin.SRStatusInfo) (divergences []srDivergence) {
+	var deploymentIDs []string
+	for dID := range info.Sites {
+		deploymentIDs = append(deploymentIDs, dID)
+	}
+	sort.Slice(deploymentIDs, func(i, j int) bool {
+		return info.Sites[deploymentIDs[i]].Name < info.Sites[deploymentIDs[j]].Name
+	})
+
+	// check returns whether the entity is present on a site
+	// and whether it is out of sync there.
+	add := func(entityType, name string, check func(dID string) (has, mismatch bool)) {
+		d := srDivergence{Type: entityType, Name: name}
+		for _, dID := range deploymentIDs {
+			switch has, mismatch := check(dID); {
+			case !has:
+				d.MissingOn = append(d.MissingOn, info.Sites[dID].Name)
+			case mismatch:
+				d.MismatchOn = append(d.MismatchOn, info.Sites[dID].Name)
+			}
+		}
+		if len(d.MissingOn) > 0 || len(d.MismatchOn) > 0 {
+			divergences = append(divergences, d)
+		}
+	}
+
+	for name := range info.BucketStats {
+		add("Bucket", name, func(dID string) (bool, bool) {
+			ss := info.BucketStats[name][dID]
+			return ss.HasBucket, ss.TagMismatch || ss.VersioningConfigMismatch || ss.OLockConfigMismatch ||
+				ss.PolicyMismatch || ss.SSEConfigMismatch || ss.ReplicationCfgMismatch || ss.QuotaCfgMismatch
+		})
+	}
+	for name := range info.PolicyStats {
+		add("Policy", name, func(dID string) (bool, bool) {
+			ss := info.PolicyStats[name][dID]
+			return ss.HasPolicy, ss.PolicyMismatch
+		})
+	}
+	for name := range info.UserStats {
+		add("User", name, func(dID string) (bool, bool) {
+			ss := info.UserStats[name][dID]
+			return ss.HasUser, ss.UserInfoMismatch || ss.PolicyMismatch
+		})
+	}
+	for name := range info.GroupStats {
+		add("Group", name, func(dID string) (bool, bool) {
+			ss := info.GroupStats[name][dID]
+			return ss.HasGroup, ss.GroupDescMismatch || ss.PolicyMismatch
+		})
+	}
+	sort.SliceStable(divergences, func(i, j int) bool {
+		if divergences[i].Type != divergences[j].Type {
+			return divergences[i].Type < divergences[j].Type
+		}
+		return divergences[i].Name < divergences[j].Name
+	})
+	return divergences
+}
+
+// srPending is the count of entities of a site not yet replicated to the other sites.
+type srPending struct {
+	Buckets  int `json:"buckets"`
+	Policies int `json:"policies"`
+	Users    int `json:"users"`
+	Groups   int `json:"groups"`
+}
+
+// srPendingCounts returns the pending entities of every site which has any, by site name.
+func srPendingCounts(info madmin.SRStatusInfo) map[string]srPending {
+	pending := func(total, replicated int) int {
+		if total > replicated {
+			return total - replicated
+		}
+		return 0
+	}
+	counts := make(map[string]srPending)
+	for dID, summary := range info.StatsSummary {
+		p := srPending{
+			Buckets:  pending(summary.TotalBucketsCount, summary.ReplicatedBuckets),
+			Policies: pending(summary.TotalIAMPoliciesCount, summary.ReplicatedIAMPolicies),
+			Users:    pending(summary.TotalUsersCount, summary.ReplicatedUsers),
+			Groups:   pending(summary.TotalGroupsCount, summary.ReplicatedGroups),
+		}
+		if p != (srPending{}) {
+			name := dID
+			if site, ok := info.Sites[dID]; ok {
+				name = site.Name
+			}
+			counts[name] = p
+		}
+	}
+	return counts
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestSRDivergences(t *testing.T) {
+	info := madmin.SRStatusInfo{
+		Enabled: true,
+		Sites: map[string]madmin.PeerInfo{
+			"d1": {Name: "site1"},
+			"d2": {Name: "site2"},
+			"d3": {Name: "site3"},
+		},
+		BucketStats: map[string]map[string]madmin.SRBucketStatsSummary{
+			"photos": {
+				"d1": {HasBucket: true},
+				"d2": {HasBucket: true, TagMismatch: true},
+				"d3": {HasBucket: true},
+			},
+		},
+		PolicyStats: map[string]map[string]madmin.SRPolicyStatsSummary{
+			"readonly": {
+				"d1": {HasPolicy: true},
+				"d2": {HasPolicy: true},
+				"d3": {HasPolicy: true},
+			},
+			"readwrite-x": {
+				"d1": {HasPolicy: true},
+				"d3": {HasPolicy: true, PolicyMismatch: true},
+			},
+		},
+		StatsSummary: map[string]madmin.SRSiteSummary{
+			"d1": {TotalBucketsCount: 3, ReplicatedBuckets: 3, TotalIAMPoliciesCount: 5, ReplicatedIAMPolicies: 4},
+			"d2": {TotalBucketsCount: 3, ReplicatedBuckets: 3},
+		},
+	}
+
+	expected := []srDivergence{
+		{Type: "Bucket", Name: "photos", MismatchOn: []string{"site2"}},
+		{Type: "Policy", Name: "readwrite-x", MissingOn: []string{"site2"}, MismatchOn: []string{"site3"}},
+	}
+	if divergences := srDivergences(info); !reflect.DeepEqual(divergences, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, divergences)
+	}
+
+	expectedPending := map[string]srPending{"site1": {Policies: 1}}
+	if pending := srPendingCounts(info); !reflect.DeepEqual(pending, expectedPending) {
+		t.Errorf("Expected %+v, got %+v", expectedPending, pending)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// scannerSpeeds are the values accepted by the 'speed' key of the scanner sub-system.
+var scannerSpeeds = []string{"fastest", "fast", "default", "slow", "slowest"}
+
+var adminScannerSpeedCmd = cli.Command{
+	Name:            "speed",
+	Usage:           "display or change the scanner speed",
+	Action:          mainAdminScannerSpeed,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [SPEED]
+
+SPEED:
+  fastest, fast, default, slow or slowest. Slower speeds pause longer between
+  objects to leave more resources to the S3 API, the change applies immediately.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Display the scanner speed of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Slow down the scanner of 'myminio' during peak load.
+     {{.Prompt}} {{.HelpName}} myminio/ slow
+`,
+}
+
+// scannerSpeedMessage container for the scanner speed.
+type scannerSpeedMessage struct {
+	Status  string `json:"status"`
+	Speed   string `json:"speed"`
+	Updated bool   `json:"updated"`
+}
+
+// String colorized scanner speed message.
+func (s scannerSpeedMessage) String() string {
+	if s.Updated {
+		return console.Colorize("ScannerSpeed", "Scanner speed set to `"+s.Speed+"`.")
+	}
+	return console.Colorize("ScannerSpeed", "Scanner speed is `"+s.Speed+"`.")
+}
+
+// JSON jsonified scanner speed message.
+func (s scannerSpeedMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminScannerSpeedSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+	if speed := ctx.Args().Get(1); speed != "" {
+		for _, s := range scannerSpeeds {
+			if strings.EqualFold(speed, s) {
+				return
+			}
+		}
+		fatalIf(errInvalidArgument().Trace(speed), fmt.Sprintf("Invalid scanner speed, expected one of %s.", strings.Join(scannerSpeeds, ", ")))
+	}
+}
+
+// getScannerSpeed returns the configured speed of the scanner.
+func getScannerSpeed(ctx context.Context, client *madmin.AdminClient) (string, error) {
+	buf, e := client.GetConfigKV(ctx, "scanner")
+	if e != nil {
+		return "", e
+	}
+	subSysConfigs, e := madmin.ParseServerConfigOutput(string(buf))
+	if e != nil {
+		return "", e
+	}
+	for _, subSysConfig := range subSysConfigs {
+		if speed, ok := subSysConfig.Lookup("speed"); ok {
+			return speed, nil
+		}
+	}
+	return "", fmt.Errorf("scanner speed is not reported by the server")
+}
+
+func mainAdminScannerSpeed(ctx *cli.Context) error {
+	checkAdminScannerSpeedSyntax(ctx)
+
+	console.SetColor("ScannerSpeed", color.New(color.FgGreen, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin client.")
+
+	speed := strings.ToLower(ctx.Args().Get(1))
+	if speed == "" {
+		current, e := getScannerSpeed(globalContext, client)
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the scanner speed.")
+		printMsg(scannerSpeedMessage{Speed: current})
+		return nil
+	}
+
+	_, e := client.SetConfigKV(globalContext, "scanner speed="+speed)
+	fatalIf(probe.NewError(e).Trace(aliasedURL, speed), "Unable to set the scanner speed.")
+	printMsg(scannerSpeedMessage{Speed: speed, Updated: true})
+	return nil
+}
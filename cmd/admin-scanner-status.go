@@ -111,10 +111,13 @@ func mainAdminScannerInfo(ctx *cli.Context) error {
 		Hosts:    strings.Split(ctx.String("nodes"), ","),
 		ByHost:   false,
 	}
-	ui := tea.NewProgram(initScannerMetricsUI(ctx.Int("max-paths")))
+	// The speed is informative, older servers may not report it.
+	speed, _ := getScannerSpeed(ctxt, client)
+
+	ui := tea.NewProgram(initScannerMetricsUI(ctx.Int("max-paths"), speed))
 	if globalJSON {
 		e := client.Metrics(ctxt, opts, func(metrics madmin.RealtimeMetrics) {
-			printMsg(metricsMessage{RealtimeMetrics: metrics})
+			printMsg(metricsMessage{RealtimeMetrics: metrics, Speed: speed})
 		})
 
 		if e != nil && !errors.Is(e, context.Canceled) {
@@ -143,6 +146,7 @@ func mainAdminScannerInfo(ctx *cli.Context) error {
 
 type metricsMessage struct {
 	madmin.RealtimeMetrics
+	Speed string `json:"speed,omitempty"`
 }
 
 func (s metricsMessage) JSON() string {
@@ -159,7 +163,7 @@ func (s metricsMessage) String() string {
 	return s.JSON()
 }
 
-func initScannerMetricsUI(maxPaths int) *scannerMetricsUI {
+func initScannerMetricsUI(maxPaths int, speed string) *scannerMetricsUI {
 	s := spinner.New()
 	s.Spinner = spinner.Points
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -174,6 +178,7 @@ func initScannerMetricsUI(maxPaths int) *scannerMetricsUI {
 	return &scannerMetricsUI{
 		spinner:  s,
 		maxPaths: maxPaths,
+		speed:    speed,
 	}
 }
 
@@ -182,6 +187,7 @@ type scannerMetricsUI struct {
 	spinner  spinner.Model
 	quitting bool
 	maxPaths int
+	speed    string
 }
 
 func (m *scannerMetricsUI) Init() tea.Cmd {
@@ -266,6 +272,9 @@ func (m *scannerMetricsUI) View() string {
 			addRowF(title("Est. full scan time:")+"   %s; Estimated %s/month", cycleTime, perms)
 		}
 	}
+	if m.speed != "" {
+		addRowF(title("Speed:")+"                 %s", console.Colorize("metrics-number", m.speed))
+	}
 	if sc.CurrentCycle > 0 {
 		addRowF(title("Current cycle:")+"         %s; Started: %v", ui(sc.CurrentCycle), console.Colorize("metrics-date", sc.CurrentStarted))
 		addRowF(title("Active drives:")+"          %s", ui(uint64(len(sc.ActivePaths))))
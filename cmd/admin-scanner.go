@@ -22,6 +22,7 @@ import "github.com/minio/cli"
 var adminScannerSubcommands = []cli.Command{
 	adminScannerInfo,
 	adminScannerTraceCmd,
+	adminScannerSpeedCmd,
 }
 
 var adminScannerCmd = cli.Command{
@@ -18,33 +18,58 @@
 package cmd
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prom2json"
 )
 
+var adminServiceFreezeFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "yes",
+		Usage: "confirm freezing the S3 API calls without prompting",
+	},
+}
+
 var adminServiceFreezeCmd = cli.Command{
 	Name:         "freeze",
 	Usage:        "freeze S3 API calls on MinIO cluster",
 	Action:       mainAdminServiceFreeze,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminServiceFreezeFlags, globalFlags...),
 	Hidden:       true, // this command is hidden on purpose, please do not enable it.
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  While frozen, the cluster does not serve S3 API calls, the admin API calls are
+  still served. Freezing an already frozen cluster has no effect, use 'mc admin
+  service unfreeze' to serve the S3 API calls again.
+
 EXAMPLES:
   1. Freeze all S3 API calls on MinIO server at 'myminio/'.
      {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Freeze all S3 API calls on MinIO server at 'myminio/' without prompting for a confirmation.
+     {{.Prompt}} {{.HelpName}} --yes myminio/
 `,
 }
 
@@ -52,11 +77,19 @@ EXAMPLES:
 type serviceFreezeCommand struct {
 	Status    string `json:"status"`
 	ServerURL string `json:"serverURL"`
+	Frozen    bool   `json:"frozen"`
+	// Number of S3 API calls in flight when the cluster was frozen,
+	// not set when the cluster metrics are not available.
+	InflightRequests *int64 `json:"inflightRequests,omitempty"`
 }
 
 // String colorized service freeze command message.
 func (s serviceFreezeCommand) String() string {
-	return console.Colorize("ServiceFreeze", "Freeze command successfully sent to `"+s.ServerURL+"`.")
+	msg := console.Colorize("ServiceFreeze", "Freeze command successfully sent to `"+s.ServerURL+"`, S3 API calls are frozen.")
+	if s.InflightRequests != nil {
+		msg += "\n" + console.Colorize("ServiceFreezeInflight", fmt.Sprintf("%d S3 API call(s) in flight.", *s.InflightRequests))
+	}
+	return msg
 }
 
 // JSON jsonified service freeze command message.
@@ -74,6 +107,86 @@ func checkAdminServiceFreezeSyntax(ctx *cli.Context) {
 	}
 }
 
+// confirmServiceFreeze asks to confirm freezing the cluster on a terminal,
+// unless --yes or --json is passed. Without a terminal the cluster is frozen as
+// before, so that scripts keep working.
+func confirmServiceFreeze(ctx *cli.Context, aliasedURL string) bool {
+	if ctx.Bool("yes") || globalJSON || !isTerminal() {
+		return true
+	}
+	console.Print(console.Colorize("FailedServiceFreeze", fmt.Sprintf(
+		"Freezing `%s` stops serving all S3 API calls until it is unfrozen.\n", aliasedURL)))
+	console.Print("Please confirm [y/N]: ")
+	answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	fatalIf(probe.NewError(e), "Unable to parse user input.")
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// s3RequestsInflightMetric is the cluster metric of the S3 API calls in flight.
+const s3RequestsInflightMetric = "minio_s3_requests_inflight_total"
+
+// getS3RequestsInflight returns the number of S3 API calls in flight in
+// the cluster, from its Prometheus metrics.
+func getS3RequestsInflight(aliasedURL string) (int64, error) {
+	alias, _ := url2Alias(aliasedURL)
+	hostConfig := mustGetHostConfig(alias)
+	if hostConfig == nil {
+		return 0, errInvalidAliasedURL(aliasedURL).ToGoError()
+	}
+
+	token, e := getPrometheusToken(hostConfig)
+	if e != nil {
+		return 0, e
+	}
+
+	req, e := http.NewRequest(http.MethodGet, hostConfig.URL+metricsEndPoint, nil)
+	if e != nil {
+		return 0, e
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+	resp, e := httpClient(10 * time.Second).Do(req)
+	if e != nil {
+		return 0, e
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	return sumPrometheusMetric(io.LimitReader(resp.Body, metricsRespBodyLimit), s3RequestsInflightMetric)
+}
+
+// sumPrometheusMetric sums the values of a gauge or counter metric over
+// all its labels, it fails when the metric is not found.
+func sumPrometheusMetric(r io.Reader, name string) (int64, error) {
+	mfChan := make(chan *dto.MetricFamily)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- prom2json.ParseReader(r, mfChan)
+	}()
+	var (
+		found bool
+		sum   float64
+	)
+	for mf := range mfChan {
+		if mf.GetName() != name {
+			continue
+		}
+		found = true
+		for _, m := range mf.GetMetric() {
+			sum += m.GetGauge().GetValue() + m.GetCounter().GetValue() + m.GetUntyped().GetValue()
+		}
+	}
+	if e := <-errCh; e != nil {
+		return 0, e
+	}
+	if !found {
+		return 0, fmt.Errorf("metric %s not found", name)
+	}
+	return int64(sum), nil
+}
+
 func mainAdminServiceFreeze(ctx *cli.Context) error {
 	// Validate serivce freeze syntax.
 	checkAdminServiceFreezeSyntax(ctx)
@@ -81,6 +194,7 @@ func mainAdminServiceFreeze(ctx *cli.Context) error {
 	// Set color.
 	console.SetColor("ServiceFreeze", color.New(color.FgGreen, color.Bold))
 	console.SetColor("FailedServiceFreeze", color.New(color.FgRed, color.Bold))
+	console.SetColor("ServiceFreezeInflight", color.New(color.FgYellow))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -89,11 +203,22 @@ func mainAdminServiceFreeze(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	if !confirmServiceFreeze(ctx, aliasedURL) {
+		return nil
+	}
+
 	// Freeze the specified MinIO server
 	fatalIf(probe.NewError(client.ServiceFreeze(globalContext)), "Unable to freeze the server.")
 
+	msg := serviceFreezeCommand{Status: "success", ServerURL: aliasedURL, Frozen: true}
+	// The S3 API calls in flight are reported on a best effort basis,
+	// the metrics may not be readable with the alias credentials.
+	if inflight, e := getS3RequestsInflight(aliasedURL); e == nil {
+		msg.InflightRequests = &inflight
+	}
+
 	// Success..
-	printMsg(serviceFreezeCommand{Status: "success", ServerURL: aliasedURL})
+	printMsg(msg)
 
 	return nil
 }
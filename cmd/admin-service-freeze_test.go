@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSumPrometheusMetric(t *testing.T) {
+	metrics := `# HELP minio_s3_requests_inflight_total Total number of S3 requests currently in flight
+# TYPE minio_s3_requests_inflight_total gauge
+minio_s3_requests_inflight_total{server="node1:9000"} 3
+minio_s3_requests_inflight_total{server="node2:9000"} 4
+# HELP minio_s3_requests_total Total number S3 requests
+# TYPE minio_s3_requests_total counter
+minio_s3_requests_total{api="getobject",server="node1:9000"} 100
+`
+	n, e := sumPrometheusMetric(strings.NewReader(metrics), s3RequestsInflightMetric)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if n != 7 {
+		t.Errorf("expected 7 requests in flight, got %d", n)
+	}
+
+	n, e = sumPrometheusMetric(strings.NewReader(metrics), "minio_s3_requests_total")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if n != 100 {
+		t.Errorf("expected 100 requests, got %d", n)
+	}
+
+	if _, e = sumPrometheusMetric(strings.NewReader(metrics), "minio_unknown"); e == nil {
+		t.Error("expected an error for a missing metric")
+	}
+}
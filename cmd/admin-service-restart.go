@@ -129,14 +129,28 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 	printMsg(serviceRestartCommand{Status: "success", ServerURL: aliasedURL})
 
 	// Start pinging the service until it is ready
+	t := time.Now()
+	if e := waitForServerHealthy(ctxt, aliasedURL); e != nil {
+		return e
+	}
+	printMsg(serviceRestartMessage{
+		Status:    "success",
+		ServerURL: aliasedURL,
+		TimeTaken: time.Since(t),
+	})
+	return nil
+}
 
+// waitForServerHealthy pings the server every second, printing the progress,
+// until it reports healthy or ctx is canceled.
+func waitForServerHealthy(ctx context.Context, aliasedURL string) error {
 	anonClient, err := newAnonymousClient(aliasedURL)
 	fatalIf(err.Trace(aliasedURL), "Could not ping `"+aliasedURL+"`.")
 
 	coloring := color.New(color.FgRed)
 	mark := "..."
 
-	// Print restart progress
+	// Print progress
 	printProgress := func() {
 		if !globalQuiet && !globalJSON {
 			coloring.Printf(mark)
@@ -149,23 +163,17 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 	timer := time.NewTimer(time.Second)
 	defer timer.Stop()
 
-	t := time.Now()
 	for {
 		select {
-		case <-ctxt.Done():
-			return ctxt.Err()
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-timer.C:
-			healthCtx, healthCancel := context.WithTimeout(ctxt, 3*time.Second)
+			healthCtx, healthCancel := context.WithTimeout(ctx, 3*time.Second)
 			// Fetch the health status of the specified MinIO server
 			healthResult, healthErr := anonClient.Healthy(healthCtx, madmin.HealthOpts{})
 			healthCancel()
 			switch {
 			case healthErr == nil && healthResult.Healthy:
-				printMsg(serviceRestartMessage{
-					Status:    "success",
-					ServerURL: aliasedURL,
-					TimeTaken: time.Since(t),
-				})
 				return nil
 			case healthErr == nil && !healthResult.Healthy:
 				coloring = color.New(color.FgYellow)
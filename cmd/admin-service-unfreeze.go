@@ -41,6 +41,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  Unfreezing a cluster which is not frozen has no effect.
+
 EXAMPLES:
   1. Unfreeze all S3 API calls on MinIO server at 'myminio/'.
      {{.Prompt}} {{.HelpName}} myminio/
@@ -51,11 +54,12 @@ EXAMPLES:
 type serviceUnfreezeCommand struct {
 	Status    string `json:"status"`
 	ServerURL string `json:"serverURL"`
+	Frozen    bool   `json:"frozen"`
 }
 
 // String colorized service unfreeze command message.
 func (s serviceUnfreezeCommand) String() string {
-	return console.Colorize("ServiceUnfreeze", "Unfreeze command successfully sent to `"+s.ServerURL+"`.")
+	return console.Colorize("ServiceUnfreeze", "Unfreeze command successfully sent to `"+s.ServerURL+"`, S3 API calls are served.")
 }
 
 // JSON jsonified service unfreeze command message.
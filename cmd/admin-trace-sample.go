@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// traceSampler shows a random fraction of the matching traces, set by
+// `admin trace --sample`. The server has no sampling, all the traces are
+// still received.
+type traceSampler struct {
+	rate    float64
+	random  *rand.Rand
+	matched int64
+	shown   int64
+}
+
+func newTraceSampler(rate float64) *traceSampler {
+	return &traceSampler{
+		rate:   rate,
+		random: rand.New(rand.NewSource(UTCNow().UnixNano())),
+	}
+}
+
+// sample returns true if a matching trace is to be shown.
+func (s *traceSampler) sample() bool {
+	s.matched++
+	if s.rate < 1 && s.random.Float64() >= s.rate {
+		return false
+	}
+	s.shown++
+	return true
+}
+
+// traceSampleMessage notes the sampling of the traces shown, once when
+// tracing starts and with the counts when it ends.
+type traceSampleMessage struct {
+	Status     string  `json:"status"`
+	SampleRate float64 `json:"sampleRate"`
+	Matched    int64   `json:"matched,omitempty"`
+	Shown      int64   `json:"shown,omitempty"`
+	summary    bool
+}
+
+func (s traceSampleMessage) String() string {
+	rate := strconv.FormatFloat(s.SampleRate*100, 'f', -1, 64) + "%"
+	if !s.summary {
+		return fmt.Sprintf("Showing a %s sample of the matching traces, counts seen are not totals.", rate)
+	}
+	return fmt.Sprintf("Showed %d of %d matching traces, sampled at %s.", s.Shown, s.Matched, rate)
+}
+
+func (s traceSampleMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
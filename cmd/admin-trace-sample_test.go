@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTraceSampler(t *testing.T) {
+	sampler := newTraceSampler(1)
+	for i := 0; i < 100; i++ {
+		if !sampler.sample() {
+			t.Fatal("expected every trace to be shown without sampling")
+		}
+	}
+
+	sampler = newTraceSampler(0.1)
+	sampler.random = rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		sampler.sample()
+	}
+	if sampler.matched != 10000 {
+		t.Fatalf("expected 10000 matched traces, got %d", sampler.matched)
+	}
+	if sampler.shown < 900 || sampler.shown > 1100 {
+		t.Fatalf("expected about 1000 traces shown, got %d", sampler.shown)
+	}
+}
+
+func TestTraceSampleMessage(t *testing.T) {
+	msg := traceSampleMessage{SampleRate: 0.01}
+	if expected := "Showing a 1% sample of the matching traces, counts seen are not totals."; msg.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, msg.String())
+	}
+	msg = traceSampleMessage{SampleRate: 0.25, Matched: 8, Shown: 2, summary: true}
+	if expected := "Showed 2 of 8 matching traces, sampled at 25%."; msg.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, msg.String())
+	}
+}
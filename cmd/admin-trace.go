@@ -94,6 +94,11 @@ var adminTraceFlags = []cli.Flag{
 		Name:  "filter-size",
 		Usage: "filter size, use with filter (see UNITS)",
 	},
+	cli.Float64Flag{
+		Name:  "sample",
+		Usage: "show only a random fraction of the matching traces (e.g. 0.01 for 1%)",
+		Value: 1,
+	},
 }
 
 // traceCallTypes contains all call types and flags to apply when selected.
@@ -201,6 +206,9 @@ EXAMPLES:
   
   8. Show trace only for requests operations duration greater than 5ms
      {{.Prompt}} {{.HelpName}} --response-duration 5ms myminio
+
+  9. Show a 1% sample of the failed GET requests on a busy cluster
+     {{.Prompt}} {{.HelpName}} --sample 0.01 --method GET --errors myminio
 `,
 }
 
@@ -221,6 +229,10 @@ func checkAdminTraceSyntax(ctx *cli.Context) {
 	if ctx.Bool("all") && len(ctx.StringSlice("call")) > 0 {
 		fatalIf(errDummy().Trace(), "You cannot specify both --all and --call flags at the same time.")
 	}
+
+	if sample := ctx.Float64("sample"); sample <= 0 || sample > 1 {
+		fatalIf(errInvalidArgument().Trace(), "`--sample` must be greater than 0 and at most 1.")
+	}
 }
 
 func printTrace(verbose bool, traceInfo madmin.ServiceTraceInfo) {
@@ -455,17 +467,32 @@ func mainAdminTrace(ctx *cli.Context) error {
 
 	mopts := matchingOpts(ctx)
 
+	sampler := newTraceSampler(ctx.Float64("sample"))
+	if sampler.rate < 1 {
+		printMsg(traceSampleMessage{Status: "success", SampleRate: sampler.rate})
+	}
+
 	// Start listening on all trace activity.
 	traceCh := client.ServiceTrace(ctxt, opts)
 	for traceInfo := range traceCh {
 		if traceInfo.Err != nil {
 			fatalIf(probe.NewError(traceInfo.Err), "Unable to listen to http trace")
 		}
-		if matchTrace(mopts, traceInfo) {
+		if matchTrace(mopts, traceInfo) && sampler.sample() {
 			printTrace(verbose, traceInfo)
 		}
 	}
 
+	if sampler.rate < 1 {
+		printMsg(traceSampleMessage{
+			Status:     "success",
+			SampleRate: sampler.rate,
+			Matched:    sampler.matched,
+			Shown:      sampler.shown,
+			summary:    true,
+		})
+	}
+
 	return nil
 }
 
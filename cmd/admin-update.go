@@ -19,15 +19,25 @@ package cmd
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	"github.com/minio/selfupdate"
 )
 
 var adminUpdateFlags = []cli.Flag{
@@ -35,6 +45,18 @@ var adminUpdateFlags = []cli.Flag{
 		Name:  "yes, y",
 		Usage: "Confirms the server update",
 	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "check the servers and the release without updating",
+	},
+	cli.BoolFlag{
+		Name:  "wait",
+		Usage: "wait for the servers to be back online and healthy after the update",
+	},
+	cli.StringFlag{
+		Name:  "minisign-pubkey",
+		Usage: "verify the minisign signature of the release at UPDATE-URL with this public key",
+	},
 }
 
 var adminServerUpdateCmd = cli.Command{
@@ -48,7 +70,10 @@ var adminServerUpdateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [UPDATE-URL]
+
+  UPDATE-URL is the URL of the 'minio.sha256sum' release file, when set
+  the checksum of the release binary is verified before updating.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -59,6 +84,12 @@ EXAMPLES:
 
   2. Update all MinIO servers in a distributed setup, represented by its alias 'mydist'.
      {{.Prompt}} {{.HelpName}} mydist/
+
+  3. Check that all MinIO servers of 'mydist' are reachable and verify the release of a mirror, without updating.
+     {{.Prompt}} {{.HelpName}} --dry-run mydist/ https://mirror.example.com/minio/release/linux-amd64/minio.sha256sum
+
+  4. Update all MinIO servers of 'mydist' and wait for them to be back online.
+     {{.Prompt}} {{.HelpName}} --wait mydist/
 `,
 }
 
@@ -90,19 +121,184 @@ func (s serverUpdateMessage) JSON() string {
 	return string(serverUpdateJSONBytes)
 }
 
+// serverUpdateCheckMessage is container for the checks done before updating.
+type serverUpdateCheckMessage struct {
+	Status          string   `json:"status"`
+	ServerURL       string   `json:"serverURL"`
+	Servers         int      `json:"servers"`
+	CurrentVersions []string `json:"currentVersions"`
+	TargetVersion   string   `json:"targetVersion,omitempty"`
+	TargetRelease   string   `json:"targetRelease,omitempty"`
+	Verified        bool     `json:"verified"`
+	Signed          bool     `json:"signed"`
+}
+
+func (s serverUpdateCheckMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d server(s) of `%s` are online, running %s", s.Servers, s.ServerURL, strings.Join(s.CurrentVersions, ", "))
+	if len(s.CurrentVersions) > 1 {
+		b.WriteString(console.Colorize("ServerUpdateWarning", "\nServers are running different versions"))
+	}
+	switch {
+	case s.TargetRelease == "":
+		b.WriteString("\nTarget version: latest release, verified by the servers")
+	case s.Signed:
+		fmt.Fprintf(&b, "\nTarget version: %s, checksum and signature of %s verified", s.TargetVersion, s.TargetRelease)
+	default:
+		fmt.Fprintf(&b, "\nTarget version: %s, checksum of %s verified", s.TargetVersion, s.TargetRelease)
+	}
+	return console.Colorize("ServerUpdate", b.String())
+}
+
+func (s serverUpdateCheckMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// serverUpdateReadyMessage is container for the servers back online after an update.
+type serverUpdateReadyMessage struct {
+	Status    string        `json:"status"`
+	ServerURL string        `json:"serverURL"`
+	TimeTaken time.Duration `json:"timeTaken"`
+}
+
+func (s serverUpdateReadyMessage) String() string {
+	return console.Colorize("ServerUpdate", fmt.Sprintf("\nServer `%s` is back online in %s", s.ServerURL, s.TimeTaken.Round(time.Second)))
+}
+
+func (s serverUpdateReadyMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
 // checkAdminServerUpdateSyntax - validate all the passed arguments
 func checkAdminServerUpdateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.String("minisign-pubkey") != "" && ctx.Args().Get(1) == "" {
+		fatalIf(errInvalidArgument().Trace(), "`--minisign-pubkey` requires UPDATE-URL.")
+	}
+}
+
+// parseServerReleaseData parses the content of a 'minio.sha256sum' release file,
+// a single line such as:
+//
+// 8bd5d0d5b7ea2a2e6aa1ec2bf9e2e4e4b8b4e5c2... minio.RELEASE.2023-01-02T09-40-09Z
+func parseServerReleaseData(data string) (sha256Hex, releaseInfo string, releaseTime time.Time, err *probe.Error) {
+	fields := strings.Fields(data)
+	if len(fields) != 2 {
+		return "", "", releaseTime, probe.NewError(fmt.Errorf("Unknown release data `%s`", data))
+	}
+	sha256Hex, releaseInfo = fields[0], fields[1]
+	if _, e := hex.DecodeString(sha256Hex); e != nil || len(sha256Hex) != sha256.Size*2 {
+		return "", "", releaseTime, probe.NewError(fmt.Errorf("Invalid checksum `%s`", sha256Hex))
+	}
+	releaseTag := strings.TrimPrefix(releaseInfo, "minio.")
+	if releaseTag == releaseInfo {
+		return "", "", releaseTime, probe.NewError(fmt.Errorf("Unknown release `%s`", releaseInfo))
+	}
+	releaseTime, err = releaseTagToReleaseTime(releaseTag)
+	if err != nil {
+		return "", "", releaseTime, err.Trace(releaseInfo)
+	}
+	return sha256Hex, releaseInfo, releaseTime, nil
+}
+
+// verifyServerRelease downloads the release at updateURL and verifies its
+// checksum, and its minisign signature if a public key is provided.
+func verifyServerRelease(updateURL, minisignPubkey string) (releaseInfo string, releaseTime time.Time, err *probe.Error) {
+	data, err := downloadReleaseURL(updateURL, 30*time.Second)
+	if err != nil {
+		return "", releaseTime, err.Trace(updateURL)
+	}
+	sha256Hex, releaseInfo, releaseTime, err := parseServerReleaseData(data)
+	if err != nil {
+		return "", releaseTime, err.Trace(updateURL)
+	}
+
+	u, e := url.Parse(updateURL)
+	if e != nil {
+		return "", releaseTime, probe.NewError(e)
+	}
+	// The binary is next to the release file.
+	u.Path = path.Dir(u.Path) + "/" + releaseInfo
+
+	transport := getUpdateTransport(30 * time.Second)
+	rc, e := getUpdateReaderFromURL(u, transport)
+	if e != nil {
+		return "", releaseTime, probe.NewError(e).Trace(u.String())
+	}
+	defer rc.Close()
+
+	bin, e := io.ReadAll(rc)
+	if e != nil {
+		return "", releaseTime, probe.NewError(e).Trace(u.String())
+	}
+	if sum := sha256.Sum256(bin); hex.EncodeToString(sum[:]) != sha256Hex {
+		return "", releaseTime, probe.NewError(fmt.Errorf("Checksum mismatch of `%s`, expected %s got %x", u, sha256Hex, sum)).Trace(u.String())
+	}
+
+	if minisignPubkey != "" {
+		v := selfupdate.NewVerifier()
+		sigURL := *u
+		sigURL.Path += ".minisig"
+		if e = v.LoadFromURL(sigURL.String(), minisignPubkey, transport); e != nil {
+			return "", releaseTime, probe.NewError(e).Trace(sigURL.String())
+		}
+		if e = v.Verify(bin); e != nil {
+			return "", releaseTime, probe.NewError(fmt.Errorf("Invalid signature of `%s`: %w", u, e)).Trace(u.String())
+		}
+	}
+	return releaseInfo, releaseTime, nil
+}
+
+// checkServerUpdateVersions returns the distinct versions run by the servers,
+// and an error if a server is offline or if the target release is older than
+// a version run by a server.
+func checkServerUpdateVersions(servers []madmin.ServerProperties, targetTime time.Time) ([]string, error) {
+	var offline []string
+	versionsSet := make(map[string]struct{})
+	for _, server := range servers {
+		if server.State != string(madmin.ItemOnline) {
+			offline = append(offline, server.Endpoint)
+			continue
+		}
+		versionsSet[server.Version] = struct{}{}
+	}
+	if len(offline) > 0 {
+		return nil, fmt.Errorf("%d server(s) are not online: %s", len(offline), strings.Join(offline, ", "))
+	}
+
+	versions := make([]string, 0, len(versionsSet))
+	for version := range versionsSet {
+		versions = append(versions, version)
+		if targetTime.IsZero() {
+			continue
+		}
+		// Versions which are not release times are development builds.
+		if t, e := time.Parse(time.RFC3339, version); e == nil && targetTime.Before(t) {
+			return nil, fmt.Errorf("target version %s is older than the version %s run by the servers", targetTime.Format(time.RFC3339), version)
+		}
+	}
+	sort.Strings(versions)
+	return versions, nil
 }
 
 func mainAdminServerUpdate(ctx *cli.Context) error {
 	// Validate serivce update syntax.
 	checkAdminServerUpdateSyntax(ctx)
 
+	ctxt, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
 	// Set color.
 	console.SetColor("ServerUpdate", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ServerUpdateWarning", color.New(color.FgYellow, color.Bold))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -113,6 +309,31 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 
 	updateURL := args.Get(1)
 
+	// Check all servers are reachable before updating any of them.
+	info, e := client.ServerInfo(ctxt)
+	fatalIf(probe.NewError(e), "Unable to get the server information.")
+
+	check := serverUpdateCheckMessage{
+		Status:    "success",
+		ServerURL: aliasedURL,
+		Servers:   len(info.Servers),
+	}
+	var targetTime time.Time
+	if updateURL != "" {
+		check.TargetRelease, targetTime, err = verifyServerRelease(updateURL, ctx.String("minisign-pubkey"))
+		fatalIf(err, "Unable to verify the release.")
+		check.TargetVersion = targetTime.Format(time.RFC3339)
+		check.Verified = true
+		check.Signed = ctx.String("minisign-pubkey") != ""
+	}
+	check.CurrentVersions, e = checkServerUpdateVersions(info.Servers, targetTime)
+	fatalIf(probe.NewError(e), "Unable to update the servers.")
+
+	printMsg(check)
+	if ctx.Bool("dry-run") {
+		return nil
+	}
+
 	autoConfirm := ctx.Bool("yes")
 
 	if isTerminal() && !autoConfirm {
@@ -128,7 +349,10 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 
 	// Update the specified MinIO server, optionally also
 	// with the provided update URL.
-	us, e := client.ServerUpdate(globalContext, updateURL)
+	us, e := client.ServerUpdate(ctxt, updateURL)
+	if e != nil && madmin.ToErrorResponse(e).Code == "MethodNotAllowed" {
+		fatalIf(probe.NewError(e), "Unable to update the server, in-place updates are disabled on servers running in a container or with a read-only binary. Update the container image instead.")
+	}
 	fatalIf(probe.NewError(e), "Unable to update the server.")
 
 	// Success..
@@ -138,5 +362,17 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 		CurrentVersion: us.CurrentVersion,
 		UpdatedVersion: us.UpdatedVersion,
 	})
+
+	if ctx.Bool("wait") && us.CurrentVersion != us.UpdatedVersion {
+		t := time.Now()
+		if e := waitForServerHealthy(ctxt, aliasedURL); e != nil {
+			return e
+		}
+		printMsg(serverUpdateReadyMessage{
+			Status:    "success",
+			ServerURL: aliasedURL,
+			TimeTaken: time.Since(t),
+		})
+	}
 	return nil
 }
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestParseServerReleaseData(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	testCases := []struct {
+		data        string
+		releaseInfo string
+		expectErr   bool
+	}{
+		{sum + " minio.RELEASE.2023-01-02T09-40-09Z\n", "minio.RELEASE.2023-01-02T09-40-09Z", false},
+		{sum + " mc.RELEASE.2023-01-02T09-40-09Z", "", true},
+		{sum + " minio.RELEASE.2023-01-02", "", true},
+		{"xyz minio.RELEASE.2023-01-02T09-40-09Z", "", true},
+		{sum, "", true},
+	}
+	for i, tc := range testCases {
+		sha256Hex, releaseInfo, releaseTime, err := parseServerReleaseData(tc.data)
+		if tc.expectErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.expectErr, err)
+		}
+		if tc.expectErr {
+			continue
+		}
+		if sha256Hex != sum || releaseInfo != tc.releaseInfo {
+			t.Errorf("Test %d: unexpected checksum %s or release %s", i+1, sha256Hex, releaseInfo)
+		}
+		if expected := time.Date(2023, 1, 2, 9, 40, 9, 0, time.UTC); !releaseTime.Equal(expected) {
+			t.Errorf("Test %d: expected release time %s, got %s", i+1, expected, releaseTime)
+		}
+	}
+}
+
+func TestCheckServerUpdateVersions(t *testing.T) {
+	servers := []madmin.ServerProperties{
+		{State: "online", Endpoint: "node1:9000", Version: "2023-01-02T09:40:09Z"},
+		{State: "online", Endpoint: "node2:9000", Version: "2022-12-12T19:27:27Z"},
+		{State: "online", Endpoint: "node3:9000", Version: "2023-01-02T09:40:09Z"},
+	}
+	versions, e := checkServerUpdateVersions(servers, time.Time{})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if strings.Join(versions, ",") != "2022-12-12T19:27:27Z,2023-01-02T09:40:09Z" {
+		t.Errorf("Unexpected versions %v", versions)
+	}
+
+	if _, e = checkServerUpdateVersions(servers, time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC)); e != nil {
+		t.Errorf("Expected a newer target to be accepted, got %v", e)
+	}
+	if _, e = checkServerUpdateVersions(servers, time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC)); e == nil {
+		t.Error("Expected a downgrade to be rejected")
+	}
+
+	servers[1].State = "offline"
+	if _, e = checkServerUpdateVersions(servers, time.Time{}); e == nil || !strings.Contains(e.Error(), "node2:9000") {
+		t.Errorf("Expected the offline server to be reported, got %v", e)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+
+	gojson "encoding/json"
+)
+
+var adminUserExportCmd = cli.Command{
+	Name:         "export",
+	Usage:        "export users to a JSON file for 'mc admin user import'",
+	Action:       mainAdminUserExport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET FILE
+
+  The server never returns the secret keys of the users, they are left out
+  of FILE and must be set before importing it.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Export the users of 'myminio' to users.json.
+     {{.Prompt}} {{.HelpName}} myminio users.json
+`,
+}
+
+// userExportMessage reports the users written by `admin user export`.
+type userExportMessage struct {
+	Status string `json:"status"`
+	File   string `json:"file"`
+	Users  int    `json:"users"`
+}
+
+func (u userExportMessage) String() string {
+	return console.Colorize("UserMessage", fmt.Sprintf("Exported %d user(s) to `%s`, set their secret keys before importing.", u.Users, u.File))
+}
+
+func (u userExportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminUserExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainAdminUserExport is the handle for "mc admin user export" command.
+func mainAdminUserExport(ctx *cli.Context) error {
+	checkAdminUserExportSyntax(ctx)
+
+	console.SetColor("UserMessage", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	file := args.Get(1)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	users, e := client.ListUsers(globalContext)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to list users")
+
+	entries := make([]userImportEntry, 0, len(users))
+	for accessKey, user := range users {
+		entries = append(entries, userImportEntry{
+			AccessKey:  accessKey,
+			Status:     user.Status,
+			PolicyName: user.PolicyName,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].AccessKey < entries[j].AccessKey })
+
+	data, e := gojson.MarshalIndent(entries, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	e = os.WriteFile(file, append(data, '\n'), 0o600)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to write the users.")
+
+	printMsg(userExportMessage{Status: "success", File: file, Users: len(entries)})
+	return nil
+}
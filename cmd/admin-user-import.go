@@ -0,0 +1,258 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+
+	gojson "encoding/json"
+)
+
+var adminUserImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "check the users for missing keys, duplicates and invalid statuses without importing them",
+	},
+	cli.BoolFlag{
+		Name:  "continue-on-error",
+		Usage: "import the valid users when others are invalid or fail to import",
+	},
+}
+
+var adminUserImportCmd = cli.Command{
+	Name:         "import",
+	Usage:        "add or update users in bulk from a JSON file",
+	Action:       mainAdminUserImport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminUserImportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET FILE
+
+FILE:
+  A JSON array of users as written by 'mc admin user export', each with a
+  "secretKey" set:
+    [{"accessKey": "foobar", "secretKey": "foobar12345", "status": "enabled", "policyName": "readwrite"}]
+
+  Every user is checked before anything is sent to the server for a missing key,
+  a duplicated access key or an invalid status. The server checks the rest, the
+  users it rejects are reported as failed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Check the users of users.json without importing them.
+     {{.Prompt}} {{.HelpName}} --dry-run myminio users.json
+
+  2. Import the users of users.json, nothing is imported if a user is invalid.
+     {{.Prompt}} {{.HelpName}} myminio users.json
+
+  3. Import the valid users of users.json, reporting the invalid and failed ones.
+     {{.Prompt}} {{.HelpName}} --continue-on-error myminio users.json
+`,
+}
+
+// userImportEntry is a user of the file read by `admin user import`.
+type userImportEntry struct {
+	AccessKey  string               `json:"accessKey"`
+	SecretKey  string               `json:"secretKey,omitempty"`
+	Status     madmin.AccountStatus `json:"status,omitempty"`
+	PolicyName string               `json:"policyName,omitempty"`
+}
+
+// userImportMessage is the result of importing a user.
+type userImportMessage struct {
+	Status    string   `json:"status"`
+	AccessKey string   `json:"accessKey"`
+	Result    string   `json:"result"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+func (u userImportMessage) String() string {
+	msg := fmt.Sprintf("`%s`: %s", u.AccessKey, u.Result)
+	if len(u.Errors) > 0 {
+		return console.Colorize("UserImportError", msg+", "+strings.Join(u.Errors, ", ")+".")
+	}
+	return console.Colorize("UserMessage", msg+".")
+}
+
+func (u userImportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// userImportSummaryMessage counts the results of `admin user import`.
+type userImportSummaryMessage struct {
+	Status   string `json:"status"`
+	DryRun   bool   `json:"dryRun,omitempty"`
+	Total    int    `json:"total"`
+	Imported int    `json:"imported"`
+	Invalid  int    `json:"invalid"`
+	Failed   int    `json:"failed"`
+}
+
+func (u userImportSummaryMessage) String() string {
+	if u.DryRun {
+		return fmt.Sprintf("Checked %d user(s), %d invalid.", u.Total, u.Invalid)
+	}
+	return fmt.Sprintf("Imported %d of %d user(s), %d invalid, %d failed.", u.Imported, u.Total, u.Invalid, u.Failed)
+}
+
+func (u userImportSummaryMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminUserImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// readUserImportFile reads the users to import.
+func readUserImportFile(path string) ([]userImportEntry, *probe.Error) {
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	var entries []userImportEntry
+	if e = gojson.Unmarshal(data, &entries); e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return entries, nil
+}
+
+// checkUserCredentials returns what is missing from the credentials of a
+// user to import. Their requirements are left to the server to enforce,
+// they depend on its version and configuration.
+func checkUserCredentials(accessKey, secretKey string) (violations []string) {
+	if accessKey == "" {
+		violations = append(violations, "access key is missing")
+	}
+	if secretKey == "" {
+		violations = append(violations, "secret key is missing")
+	}
+	return violations
+}
+
+// checkUserImportEntries returns the violations of each user, indexed as
+// the entries, before anything is imported.
+func checkUserImportEntries(entries []userImportEntry) [][]string {
+	violations := make([][]string, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		violations[i] = checkUserCredentials(entry.AccessKey, entry.SecretKey)
+		switch entry.Status {
+		case "", madmin.AccountEnabled, madmin.AccountDisabled:
+		default:
+			violations[i] = append(violations[i], fmt.Sprintf("status must be '%s' or '%s'", madmin.AccountEnabled, madmin.AccountDisabled))
+		}
+		if seen[entry.AccessKey] {
+			violations[i] = append(violations[i], "access key is duplicated")
+		}
+		seen[entry.AccessKey] = true
+	}
+	return violations
+}
+
+// mainAdminUserImport is the handle for "mc admin user import" command.
+func mainAdminUserImport(ctx *cli.Context) error {
+	checkAdminUserImportSyntax(ctx)
+
+	console.SetColor("UserMessage", color.New(color.FgGreen))
+	console.SetColor("UserImportError", color.New(color.FgRed))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	dryRun := ctx.Bool("dry-run")
+	continueOnError := ctx.Bool("continue-on-error")
+
+	entries, err := readUserImportFile(args.Get(1))
+	fatalIf(err, "Unable to read the users to import.")
+
+	summary := userImportSummaryMessage{Status: "success", DryRun: dryRun, Total: len(entries)}
+	violations := checkUserImportEntries(entries)
+	for i, entry := range entries {
+		if len(violations[i]) > 0 {
+			summary.Invalid++
+			printMsg(userImportMessage{Status: "error", AccessKey: entry.AccessKey, Result: "invalid", Errors: violations[i]})
+		} else if dryRun {
+			printMsg(userImportMessage{Status: "success", AccessKey: entry.AccessKey, Result: "valid"})
+		}
+	}
+	if dryRun || (summary.Invalid > 0 && !continueOnError) {
+		printMsg(summary)
+		if summary.Invalid > 0 {
+			if !dryRun {
+				console.Errorln("No user was imported, use `--continue-on-error` to import the valid users.")
+			}
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	for i, entry := range entries {
+		if len(violations[i]) > 0 {
+			continue
+		}
+		status := entry.Status
+		if status == "" {
+			status = madmin.AccountEnabled
+		}
+		e := client.SetUser(globalContext, entry.AccessKey, entry.SecretKey, status)
+		if e == nil && entry.PolicyName != "" {
+			e = client.SetPolicy(globalContext, entry.PolicyName, entry.AccessKey, false)
+		}
+		if e != nil {
+			summary.Failed++
+			printMsg(userImportMessage{Status: "error", AccessKey: entry.AccessKey, Result: "failed", Errors: []string{e.Error()}})
+			if !continueOnError {
+				break
+			}
+			continue
+		}
+		summary.Imported++
+		printMsg(userImportMessage{Status: "success", AccessKey: entry.AccessKey, Result: "imported"})
+	}
+
+	printMsg(summary)
+	if summary.Invalid > 0 || summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckUserCredentials(t *testing.T) {
+	testCases := []struct {
+		accessKey, secretKey string
+		violations           int
+	}{
+		{"foobar", "foobar12345", 0},
+		// Lengths and characters are left to the server.
+		{"fo", "short", 0},
+		{"foobar", "", 1},
+		{"", "", 2},
+	}
+	for i, testCase := range testCases {
+		if violations := checkUserCredentials(testCase.accessKey, testCase.secretKey); len(violations) != testCase.violations {
+			t.Fatalf("Test %d: expected %d violation(s), got %v", i+1, testCase.violations, violations)
+		}
+	}
+}
+
+func TestCheckUserImportEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	data := `[
+ {"accessKey": "alice", "secretKey": "alice12345", "policyName": "readwrite"},
+ {"accessKey": "bob"},
+ {"accessKey": "carol", "secretKey": "carol12345", "status": "locked"},
+ {"accessKey": "alice", "secretKey": "alice67890", "status": "disabled"}
+]`
+	if e := os.WriteFile(path, []byte(data), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	entries, err := readUserImportFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 || entries[0].PolicyName != "readwrite" {
+		t.Fatalf("unexpected entries %v", entries)
+	}
+
+	expected := [][]string{
+		nil,
+		{"secret key is missing"},
+		{"status must be 'enabled' or 'disabled'"},
+		{"access key is duplicated"},
+	}
+	if violations := checkUserImportEntries(entries); !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected violations %q, got %q", expected, violations)
+	}
+}
@@ -42,13 +42,17 @@ var adminUserSvcAcctAddFlags = []cli.Flag{
 		Usage: "set a secret key for the service account",
 	},
 	cli.StringFlag{
-		Name:  "policy",
+		Name:  "policy, policy-file",
 		Usage: "path to a JSON policy file",
 	},
 	cli.StringFlag{
 		Name:  "comment",
 		Usage: "personal note for the service account",
 	},
+	cli.BoolFlag{
+		Name:  "alias-set",
+		Usage: "print the generated credentials as an 'mc alias set' command",
+	},
 }
 
 var adminUserSvcAcctAddCmd = cli.Command{
@@ -73,6 +77,12 @@ FLAGS:
 EXAMPLES:
   1. Add a new service account for user 'foobar' to MinIO server.
      {{.Prompt}} {{.HelpName}} myminio foobar
+
+  2. Add a new service account for user 'foobar' restricted by the policy in 'policy.json'.
+     {{.Prompt}} {{.HelpName}} myminio foobar --policy-file policy.json
+
+  3. Add a new service account for user 'foobar' and print an alias command to use it elsewhere.
+     {{.Prompt}} {{.HelpName}} myminio foobar --alias-set
 `,
 }
 
@@ -95,6 +105,7 @@ type svcAcctMessage struct {
 	Comment       string          `json:"comment,omitempty"`
 	AccountStatus string          `json:"accountStatus,omitempty"`
 	MemberOf      []string        `json:"memberOf,omitempty"`
+	AliasCommand  string          `json:"aliasCommand,omitempty"`
 }
 
 const (
@@ -142,6 +153,9 @@ func (u svcAcctMessage) String() string {
 	case svcAccOpEnable:
 		return console.Colorize("SVCMessage", "Enabled service account `"+u.AccessKey+"` successfully.")
 	case svcAccOpAdd:
+		if u.AliasCommand != "" {
+			return u.AliasCommand
+		}
 		return console.Colorize("SVCMessage",
 			fmt.Sprintf("Access Key: %s\nSecret Key: %s", u.AccessKey, u.SecretKey))
 	case svcAccOpSet:
@@ -202,12 +216,28 @@ func mainAdminUserSvcAcctAdd(ctx *cli.Context) error {
 	creds, e := client.AddServiceAccount(globalContext, opts)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to add a new service account")
 
+	var aliasCommand string
+	if ctx.Bool("alias-set") {
+		alias, _, aliasCfg := mustExpandAlias(aliasedURL)
+		if aliasCfg == nil {
+			fatalIf(errInvalidAliasedURL(aliasedURL), "Unable to build the alias command.")
+		}
+		aliasCommand = svcAcctAliasCommand(alias, aliasCfg.URL, creds.AccessKey, creds.SecretKey)
+	}
+
 	printMsg(svcAcctMessage{
 		op:            svcAccOpAdd,
 		AccessKey:     creds.AccessKey,
 		SecretKey:     creds.SecretKey,
 		AccountStatus: "enabled",
+		AliasCommand:  aliasCommand,
 	})
 
 	return nil
 }
+
+// svcAcctAliasCommand returns an 'mc alias set' command line which configures
+// an alias with the given service account credentials.
+func svcAcctAliasCommand(alias, url, accessKey, secretKey string) string {
+	return fmt.Sprintf("mc alias set %s %s %s %s", alias, url, accessKey, secretKey)
+}
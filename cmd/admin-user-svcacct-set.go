@@ -31,7 +31,7 @@ var adminUserSvcAcctSetFlags = []cli.Flag{
 		Usage: "set a secret key for the service account",
 	},
 	cli.StringFlag{
-		Name:  "policy",
+		Name:  "policy, policy-file",
 		Usage: "path to a JSON policy file",
 	},
 	cli.StringFlag{
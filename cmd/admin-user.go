@@ -28,6 +28,8 @@ var adminUserSubcommands = []cli.Command{
 	adminUserInfoCmd,
 	adminUserPolicyCmd,
 	adminUserSvcAcctCmd,
+	adminUserImportCmd,
+	adminUserExportCmd,
 }
 
 var adminUserCmd = cli.Command{
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	gojson "encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var aliasExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "password",
+		Usage:  "encrypt the exported secrets with a password",
+		EnvVar: "MC_ALIAS_PASSWORD",
+	},
+}
+
+var aliasExportCmd = cli.Command{
+	Name:            "export",
+	ShortName:       "e",
+	Usage:           "export aliases with their credentials to a portable JSON document",
+	Action:          mainAliasExport,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasExportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [ALIAS...]
+
+  All aliases are exported when no ALIAS is given. The exported document
+  contains the secret keys, use --password to encrypt it.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ALIAS_PASSWORD: password to encrypt the exported aliases with
+
+EXAMPLES:
+  1. Export all aliases to a file:
+     {{ .Prompt }} {{ .HelpName }} > aliases.json
+
+  2. Export the aliases 'myminio' and 'play' encrypted with a password:
+     {{ .Prompt }} {{ .HelpName }} --password "my-secret-password" myminio play > aliases.json
+
+  3. Copy all aliases to another machine, encrypted with a password read from the environment:
+     {{ .Prompt }} export MC_ALIAS_PASSWORD="my-secret-password"
+     {{ .Prompt }} {{ .HelpName }} | ssh user@host 'MC_ALIAS_PASSWORD="my-secret-password" mc alias import'
+`,
+}
+
+// aliasExportVersion is the version of the alias export document.
+const aliasExportVersion = "1"
+
+// aliasExport is the document written by 'alias export' and read by
+// 'alias import'. Either Aliases or, with a password, Encrypted is set,
+// the latter holding the encrypted JSON form of the aliases.
+type aliasExport struct {
+	Version   string                    `json:"version"`
+	Aliases   map[string]aliasConfigV10 `json:"aliases,omitempty"`
+	Encrypted string                    `json:"encrypted,omitempty"`
+}
+
+// String returns the export document, it is JSON in any case.
+func (a aliasExport) String() string {
+	return a.JSON()
+}
+
+// JSON jsonified alias export document.
+func (a aliasExport) JSON() string {
+	data, e := gojson.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(data)
+}
+
+// newAliasExport creates an export document of the aliases, encrypted
+// when a password is given.
+func newAliasExport(aliases map[string]aliasConfigV10, password string) (aliasExport, *probe.Error) {
+	export := aliasExport{Version: aliasExportVersion}
+	if password == "" {
+		export.Aliases = aliases
+		return export, nil
+	}
+	data, e := gojson.Marshal(aliases)
+	if e != nil {
+		return export, probe.NewError(e)
+	}
+	data, e = madmin.EncryptData(password, data)
+	if e != nil {
+		return export, probe.NewError(e)
+	}
+	export.Encrypted = base64.StdEncoding.EncodeToString(data)
+	return export, nil
+}
+
+// parseAliasExport reports whether the input is an alias export document
+// and returns its aliases, decrypted with the password when needed.
+func parseAliasExport(input []byte, password string) (map[string]aliasConfigV10, bool, *probe.Error) {
+	var export aliasExport
+	if e := gojson.Unmarshal(input, &export); e != nil {
+		return nil, false, nil
+	}
+	if export.Aliases == nil && export.Encrypted == "" {
+		return nil, false, nil
+	}
+	if export.Version != aliasExportVersion {
+		return nil, true, errInvalidArgument().Trace(export.Version)
+	}
+	if export.Encrypted == "" {
+		return export.Aliases, true, nil
+	}
+	if password == "" {
+		return nil, true, probe.NewError(errors.New("the aliases are encrypted, a password is required"))
+	}
+	data, e := base64.StdEncoding.DecodeString(export.Encrypted)
+	if e != nil {
+		return nil, true, probe.NewError(e)
+	}
+	data, e = madmin.DecryptData(password, bytes.NewReader(data))
+	if e != nil {
+		return nil, true, probe.NewError(e)
+	}
+	var aliases map[string]aliasConfigV10
+	if e = gojson.Unmarshal(data, &aliases); e != nil {
+		return nil, true, probe.NewError(e)
+	}
+	return aliases, true, nil
+}
+
+// checkAliasExportSyntax - verifies input arguments to 'alias export'.
+func checkAliasExportSyntax(ctx *cli.Context) {
+	for _, arg := range ctx.Args() {
+		if alias := cleanAlias(arg); !isValidAlias(alias) {
+			fatalIf(errInvalidAlias(alias), "Invalid alias.")
+		}
+	}
+}
+
+func mainAliasExport(ctx *cli.Context) error {
+	checkAliasExportSyntax(ctx)
+
+	mcCfgV10, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	aliases := make(map[string]aliasConfigV10)
+	if ctx.NArg() == 0 {
+		for alias, aliasCfg := range mcCfgV10.Aliases {
+			aliases[alias] = aliasCfg
+		}
+	}
+	for _, arg := range ctx.Args() {
+		alias := cleanAlias(arg)
+		aliasCfg, ok := mcCfgV10.Aliases[alias]
+		if !ok {
+			fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+		}
+		aliases[alias] = aliasCfg
+	}
+
+	export, err := newAliasExport(aliases, ctx.String("password"))
+	fatalIf(err, "Unable to export aliases.")
+	printMsg(export)
+	return nil
+}
+
+// sortedAliases returns the names of the aliases in lexical order.
+func sortedAliases(aliases map[string]aliasConfigV10) []string {
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	return names
+}
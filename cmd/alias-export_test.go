@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAliasExportRoundTrip(t *testing.T) {
+	aliases := map[string]aliasConfigV10{
+		"myminio": {URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "minio123", API: "s3v4", Path: "auto"},
+		"play":    {URL: "https://play.min.io", AccessKey: "access", SecretKey: "secret", API: "S3v4", Path: "auto"},
+	}
+	for _, password := range []string{"", "my-secret-password"} {
+		export, err := newAliasExport(aliases, password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if password != "" && export.Aliases != nil {
+			t.Fatal("Expected the aliases to be encrypted")
+		}
+		got, isExport, err := parseAliasExport([]byte(export.JSON()), password)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isExport {
+			t.Fatal("Expected an alias export document")
+		}
+		if !reflect.DeepEqual(got, aliases) {
+			t.Errorf("Expected %v, got %v", aliases, got)
+		}
+		if password != "" {
+			if _, _, err = parseAliasExport([]byte(export.JSON()), "wrong-password"); err == nil {
+				t.Error("Expected an error with a wrong password")
+			}
+			if _, _, err = parseAliasExport([]byte(export.JSON()), ""); err == nil {
+				t.Error("Expected an error without a password")
+			}
+		}
+	}
+
+	// Single alias credentials are not an export document.
+	_, isExport, err := parseAliasExport([]byte(`{"url": "http://localhost:9000", "accessKey": "minio", "secretKey": "minio123"}`), "")
+	if err != nil || isExport {
+		t.Errorf("Expected credentials not to be an export document, got %v, %v", isExport, err)
+	}
+}
+
+func TestMergeAliases(t *testing.T) {
+	local := aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "minio123"}
+	remote := aliasConfigV10{URL: "http://remote:9000", AccessKey: "minio", SecretKey: "minio123"}
+
+	testCases := []struct {
+		overwrite       bool
+		confirm         func(string) bool
+		merged, skipped []string
+		want            aliasConfigV10
+	}{
+		{false, nil, []string{"new", "same"}, []string{"conflict"}, local},
+		{false, func(string) bool { return false }, []string{"new", "same"}, []string{"conflict"}, local},
+		{false, func(string) bool { return true }, []string{"conflict", "new", "same"}, nil, remote},
+		{true, nil, []string{"conflict", "new", "same"}, nil, remote},
+	}
+	for i, testCase := range testCases {
+		aliases := map[string]aliasConfigV10{"conflict": local, "same": local}
+		imported := map[string]aliasConfigV10{"conflict": remote, "same": local, "new": remote}
+		merged, skipped := mergeAliases(aliases, imported, testCase.overwrite, testCase.confirm)
+		if !reflect.DeepEqual(merged, testCase.merged) || !reflect.DeepEqual(skipped, testCase.skipped) {
+			t.Errorf("Test %d: expected merged %v skipped %v, got %v %v", i+1,
+				testCase.merged, testCase.skipped, merged, skipped)
+		}
+		if aliases["conflict"] != testCase.want || aliases["new"] != remote {
+			t.Errorf("Test %d: unexpected aliases %v", i+1, aliases)
+		}
+	}
+}
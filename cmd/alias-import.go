@@ -18,15 +18,30 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
 
 	"github.com/minio/cli"
 )
 
+var aliasImportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "password",
+		Usage:  "password to decrypt aliases exported with 'mc alias export --password'",
+		EnvVar: "MC_ALIAS_PASSWORD",
+	},
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "replace existing aliases with the exported ones without prompting",
+	},
+}
+
 var aliasImportCmd = cli.Command{
 	Name:            "import",
 	ShortName:       "i",
@@ -34,13 +49,14 @@ var aliasImportCmd = cli.Command{
 	Action:          mainAliasImport,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(aliasImportFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} ALIAS ./credentials.json
+  {{.HelpName}} [ALIAS] ./aliases.json
 
   Credentials to be imported must be in the following JSON format:
   
@@ -52,6 +68,10 @@ USAGE:
     "path": "auto"
   }
 
+  Aliases exported with 'mc alias export' are merged into the config, or only ALIAS
+  when given. Existing aliases with different settings are kept unless confirmed
+  or --overwrite is passed.
+
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
@@ -61,6 +81,12 @@ EXAMPLES:
 
   2. Import the credentials through standard input as 'myminio' to the config:
      {{ .Prompt }} cat credentials.json | {{ .HelpName }} myminio/
+
+  3. Import all aliases exported with 'mc alias export', replacing the existing ones:
+     {{ .Prompt }} {{ .HelpName }} --overwrite ./aliases.json
+
+  4. Import the alias 'myminio' from aliases exported with a password:
+     {{ .Prompt }} {{ .HelpName }} --password "my-secret-password" myminio ./aliases.json
 `,
 }
 
@@ -69,7 +95,7 @@ func checkAliasImportSyntax(ctx *cli.Context) {
 	args := ctx.Args()
 	argsNr := len(args)
 
-	if argsNr == 0 {
+	if argsNr == 0 && isTerminal() {
 		showCommandHelpAndExit(ctx, 1)
 	}
 	if argsNr > 2 {
@@ -77,13 +103,27 @@ func checkAliasImportSyntax(ctx *cli.Context) {
 			"Incorrect number of arguments for alias Import command.")
 	}
 
-	alias := cleanAlias(args.Get(0))
-
-	if !isValidAlias(alias) {
+	alias, _ := aliasImportArgs(args)
+	if alias != "" && !isValidAlias(alias) {
 		fatalIf(errInvalidAlias(alias), "Invalid alias.")
 	}
 }
 
+// aliasImportArgs returns the alias and the file to import from. A
+// single argument is the file when it exists, otherwise the alias.
+func aliasImportArgs(args cli.Args) (alias, file string) {
+	switch len(args) {
+	case 0:
+		return "", ""
+	case 1:
+		if st, e := os.Stat(args.Get(0)); e == nil && st.Mode().IsRegular() {
+			return "", args.Get(0)
+		}
+		return cleanAlias(args.Get(0)), ""
+	}
+	return cleanAlias(args.Get(0)), strings.TrimSpace(args.Get(1))
+}
+
 func checkCredentialsSyntax(credentials aliasConfigV10) {
 	if !isValidHostURL(credentials.URL) {
 		fatalIf(errInvalidURL(credentials.URL), "Invalid URL.")
@@ -129,22 +169,104 @@ func importAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 	}
 }
 
+// mergeAliases adds the imported aliases to the configured ones. An
+// existing alias with different settings is only replaced when
+// overwrite is set or confirm accepts it, otherwise it is skipped.
+func mergeAliases(aliases, imported map[string]aliasConfigV10, overwrite bool, confirm func(alias string) bool) (merged, skipped []string) {
+	for _, alias := range sortedAliases(imported) {
+		aliasCfg := imported[alias]
+		if current, ok := aliases[alias]; ok && current != aliasCfg {
+			if !overwrite && (confirm == nil || !confirm(alias)) {
+				skipped = append(skipped, alias)
+				continue
+			}
+		}
+		aliases[alias] = aliasCfg
+		merged = append(merged, alias)
+	}
+	return merged, skipped
+}
+
+// importAliases merges the aliases of an export document into the config.
+func importAliases(imported map[string]aliasConfigV10, overwrite bool, confirm func(alias string) bool) {
+	for _, alias := range sortedAliases(imported) {
+		if !isValidAlias(alias) {
+			fatalIf(errInvalidAlias(alias), "Invalid alias.")
+		}
+		checkCredentialsSyntax(imported[alias])
+	}
+
+	mcCfgV10, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	merged, skipped := mergeAliases(mcCfgV10.Aliases, imported, overwrite, confirm)
+	fatalIf(saveMcConfig(mcCfgV10).Trace(merged...), "Unable to import aliases to `"+mustGetMcConfigPath()+"`.")
+
+	for _, alias := range merged {
+		aliasCfg := mcCfgV10.Aliases[alias]
+		printMsg(aliasMessage{
+			op:        "import",
+			Alias:     alias,
+			URL:       aliasCfg.URL,
+			AccessKey: aliasCfg.AccessKey,
+			SecretKey: aliasCfg.SecretKey,
+			API:       aliasCfg.API,
+			Path:      aliasCfg.Path,
+		})
+	}
+	for _, alias := range skipped {
+		printMsg(aliasMessage{
+			op:     "import",
+			Status: "skipped",
+			Alias:  alias,
+		})
+	}
+}
+
+// confirmAliasOverwrite prompts to replace an existing alias, the
+// prompt needs a terminal which is not used for the input.
+func confirmAliasOverwrite(alias string) bool {
+	console.Print(fmt.Sprintf("Alias `%s` already exists with different settings, overwrite it? [y/N]: ", alias))
+	answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	fatalIf(probe.NewError(e), "Unable to parse user input.")
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func mainAliasImport(cli *cli.Context) error {
-	var (
-		args  = cli.Args()
-		alias = cleanAlias(args.Get(0))
-	)
+	args := cli.Args()
 
 	checkAliasImportSyntax(cli)
 	var credentialsJSON aliasConfigV10
 
-	credsFile := strings.TrimSpace(args.Get(1))
+	alias, credsFile := aliasImportArgs(args)
 	if credsFile == "" {
 		credsFile = os.Stdin.Name()
 	}
 	input, e := os.ReadFile(credsFile)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to parse credentials file")
 
+	imported, isExport, err := parseAliasExport(input, cli.String("password"))
+	fatalIf(err.Trace(args...), "Unable to parse exported aliases")
+	if isExport {
+		if alias != "" {
+			aliasCfg, ok := imported[alias]
+			if !ok {
+				fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found in the exported aliases.")
+			}
+			imported = map[string]aliasConfigV10{alias: aliasCfg}
+		}
+		var confirm func(string) bool
+		if credsFile != os.Stdin.Name() && isTerminal() {
+			confirm = confirmAliasOverwrite
+		}
+		importAliases(imported, cli.Bool("overwrite"), confirm)
+		return nil
+	}
+	if alias == "" {
+		fatalIf(errInvalidArgument().Trace(args...), "An alias is required to import credentials.")
+	}
+
 	e = json.Unmarshal(input, &credentialsJSON)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to parse input credentials")
 
@@ -42,6 +42,7 @@ var aliasSubcommands = []cli.Command{
 	aliasListCmd,
 	aliasRemoveCmd,
 	aliasImportCmd,
+	aliasExportCmd,
 }
 
 var aliasCmd = cli.Command{
@@ -104,6 +105,9 @@ func (h aliasMessage) String() string {
 	case "set":
 		return console.Colorize("AliasMessage", "Added `"+h.Alias+"` successfully.")
 	case "import":
+		if h.Status == "skipped" {
+			return console.Colorize("AliasMessage", "Skipped `"+h.Alias+"`, it already exists with different settings. Use --overwrite to replace it.")
+		}
 		return console.Colorize("AliasMessage", "Imported `"+h.Alias+"` successfully.")
 	default:
 		return ""
@@ -112,7 +116,9 @@ func (h aliasMessage) String() string {
 
 // JSON jsonified host message
 func (h aliasMessage) JSON() string {
-	h.Status = "success"
+	if h.Status == "" {
+		h.Status = "success"
+	}
 	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
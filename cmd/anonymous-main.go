@@ -85,7 +85,7 @@ EXAMPLES:
   6. Get bucket permissions.
      {{.Prompt}} {{.HelpName}} get s3/shared
 
-  7. Get bucket permissions in JSON format.
+  7. Get the bucket policy document exactly as applied, including principals and conditions.
      {{.Prompt}} {{.HelpName}} get-json s3/shared
 
   8. List policies set to a specified bucket.
@@ -116,11 +116,11 @@ func (s anonymousRules) JSON() string {
 
 // anonymousMessage is container for anonymous command on bucket success and failure messages.
 type anonymousMessage struct {
-	Operation string                 `json:"operation"`
-	Status    string                 `json:"status"`
-	Bucket    string                 `json:"bucket"`
-	Perms     accessPerms            `json:"permission"`
-	Anonymous map[string]interface{} `json:"anonymous,omitempty"`
+	Operation string          `json:"operation"`
+	Status    string          `json:"status"`
+	Bucket    string          `json:"bucket"`
+	Perms     accessPerms     `json:"permission"`
+	Anonymous json.RawMessage `json:"anonymous,omitempty"`
 }
 
 // String colorized access message.
@@ -138,9 +138,15 @@ func (s anonymousMessage) String() string {
 			"Access permission for `"+s.Bucket+"`"+" is set from `"+string(s.Perms)+"`")
 	}
 	if s.Operation == "get-json" {
-		anonymous, e := json.MarshalIndent(s.Anonymous, "", " ")
-		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
-		return string(anonymous)
+		if len(s.Anonymous) == 0 {
+			return "{}"
+		}
+		// Indent the policy as returned by the server, keeping
+		// its statements, principals and conditions in order.
+		var anonymous bytes.Buffer
+		e := json.Indent(&anonymous, s.Anonymous, "", " ")
+		fatalIf(probe.NewError(e), "Unable to indent the bucket policy.")
+		return anonymous.String()
 	}
 	// nothing to print
 	return ""
@@ -457,10 +463,12 @@ func runAnonymousCmd(args cli.Args) {
 				"Unable to "+operation+" anonymous `"+string(perms)+"` for `"+targetURL+"`.")
 		}
 	}
-	anonymousJSON := map[string]interface{}{}
+	var anonymousJSON json.RawMessage
 	if anonymousStr != "" {
-		e := json.Unmarshal([]byte(anonymousStr), &anonymousJSON)
-		fatalIf(probe.NewError(e), "Unable to unmarshal custom anonymous file.")
+		if !json.Valid([]byte(anonymousStr)) {
+			fatalIf(errInvalidArgument().Trace(targetURL), "Unable to parse the bucket policy of `"+targetURL+"`.")
+		}
+		anonymousJSON = json.RawMessage(anonymousStr)
 	}
 	printMsg(anonymousMessage{
 		Status:    "success",
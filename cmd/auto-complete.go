@@ -327,6 +327,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/config/export":  aliasCompleter,
 	"/admin/config/history": aliasCompleter,
 	"/admin/config/restore": aliasCompleter,
+	"/admin/config/env":     adminConfigCompleter,
 
 	"/admin/decom/start":         aliasCompleter,
 	"/admin/decom/status":        aliasCompleter,
@@ -349,6 +350,7 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/admin/scanner/status": aliasCompleter,
 	"/admin/scanner/trace":  aliasCompleter,
+	"/admin/scanner/speed":  aliasCompleter,
 
 	"/admin/service/stop":     aliasCompleter,
 	"/admin/service/restart":  aliasCompleter,
@@ -358,8 +360,9 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/prometheus/generate": aliasCompleter,
 	"/admin/prometheus/metrics":  aliasCompleter,
 
-	"/admin/profile/start": aliasCompleter,
-	"/admin/profile/stop":  aliasCompleter,
+	"/admin/profile/start":   aliasCompleter,
+	"/admin/profile/stop":    aliasCompleter,
+	"/admin/profile/compare": fsCompleter,
 
 	"/admin/idp/set":  aliasCompleter,
 	"/admin/idp/info": aliasCompleter,
@@ -386,13 +389,14 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/idp/ldap/policy/attach":   aliasCompleter,
 	"/admin/idp/ldap/policy/detach":   aliasCompleter,
 
-	"/admin/policy/info":   aliasCompleter,
-	"/admin/policy/set":    aliasCompleter,
-	"/admin/policy/unset":  aliasCompleter,
-	"/admin/policy/update": aliasCompleter,
-	"/admin/policy/add":    aliasCompleter,
-	"/admin/policy/list":   aliasCompleter,
-	"/admin/policy/remove": aliasCompleter,
+	"/admin/policy/info":     aliasCompleter,
+	"/admin/policy/set":      aliasCompleter,
+	"/admin/policy/unset":    aliasCompleter,
+	"/admin/policy/update":   aliasCompleter,
+	"/admin/policy/add":      aliasCompleter,
+	"/admin/policy/list":     aliasCompleter,
+	"/admin/policy/remove":   aliasCompleter,
+	"/admin/policy/simulate": aliasCompleter,
 
 	"/admin/user/add":     aliasCompleter,
 	"/admin/user/disable": aliasCompleter,
@@ -401,6 +405,8 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/user/remove":  aliasCompleter,
 	"/admin/user/info":    aliasCompleter,
 	"/admin/user/policy":  aliasCompleter,
+	"/admin/user/import":  aliasCompleter,
+	"/admin/user/export":  aliasCompleter,
 
 	"/admin/user/svcacct/add":     aliasCompleter,
 	"/admin/user/svcacct/list":    aliasCompleter,
@@ -421,6 +427,7 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/admin/bucket/remote/add":       aliasCompleter,
 	"/admin/bucket/remote/edit":      aliasCompleter,
+	"/admin/bucket/remote/ls":        aliasCompleter,
 	"/admin/bucket/remote/rm":        aliasCompleter,
 	"/admin/bucket/remote/bandwidth": aliasCompleter,
 	"/admin/bucket/quota":            aliasCompleter,
@@ -466,6 +473,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/alias/list":   aliasCompleter,
 	"/alias/remove": aliasCompleter,
 	"/alias/import": nil,
+	"/alias/export": aliasCompleter,
 
 	"/support/callhome":     aliasCompleter,
 	"/support/register":     aliasCompleter,
@@ -474,6 +482,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/support/proxy/set":    aliasCompleter,
 	"/support/proxy/show":   aliasCompleter,
 	"/support/proxy/remove": aliasCompleter,
+	"/support/health-check": aliasCompleter,
 	"/support/inspect":      aliasCompleter,
 	"/support/perf":         aliasCompleter,
 	"/support/metrics":      aliasCompleter,
@@ -0,0 +1,172 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Number of workers an auto tuned copy starts with.
+	autoParallelStartWorkers = 4
+
+	// Period between two adjustments of the number of workers.
+	autoParallelPeriod = 2 * time.Second
+
+	// Percentage of throttled or failed requests above which the
+	// number of workers is halved.
+	autoParallelThrottlePercent = 5
+
+	// Percentage of throughput gained by the last increase of the
+	// number of workers for the next one to be tried.
+	autoParallelGainPercent = 5
+
+	// Number of periods spent at the best number of workers before
+	// trying more workers again.
+	autoParallelHoldPeriods = 5
+)
+
+// globalRequestStats counts the requests and the throttled ones of a
+// cp run with --auto-parallel, nil otherwise.
+var globalRequestStats *requestStats
+
+type requestStats struct {
+	requests  int64
+	throttled int64
+}
+
+// requestStatsTransport counts the requests sent through a transport and
+// those answered with a status retried by minio-go or failed.
+type requestStatsTransport struct {
+	transport http.RoundTripper
+	stats     *requestStats
+}
+
+func (t requestStatsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.transport.RoundTrip(req)
+	atomic.AddInt64(&t.stats.requests, 1)
+	throttled := e != nil && !errors.Is(e, context.Canceled)
+	if resp != nil {
+		_, throttled = retryableHTTPStatus[resp.StatusCode]
+	}
+	if throttled {
+		atomic.AddInt64(&t.stats.throttled, 1)
+	}
+	return resp, e
+}
+
+// autoParallel picks the number of objects copied in parallel: the
+// number of workers is increased as long as the throughput grows, set
+// back to the best one once it does not and halved when too many
+// requests are throttled by the server.
+type autoParallel struct {
+	mutex sync.Mutex
+
+	workers, min, max int
+
+	bestWorkers int
+	bestRate    int64
+	holds       int
+	backoffs    int
+
+	prevBytes, prevRequests, prevThrottled int64
+}
+
+func newAutoParallel(min, max int) *autoParallel {
+	workers := autoParallelStartWorkers
+	if workers > max {
+		workers = max
+	}
+	return &autoParallel{workers: workers, min: min, max: max}
+}
+
+// adjust returns the number of workers for the next period from the
+// totals of bytes transferred, requests and throttled requests so far.
+func (a *autoParallel) adjust(bytes, requests, throttled int64) int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	rate := bytes - a.prevBytes
+	sent := requests - a.prevRequests
+	failed := throttled - a.prevThrottled
+	a.prevBytes, a.prevRequests, a.prevThrottled = bytes, requests, throttled
+
+	switch {
+	case sent > 0 && failed*100 >= sent*autoParallelThrottlePercent:
+		// The server is under pressure, back off and measure again.
+		a.workers /= 2
+		if a.workers < a.min {
+			a.workers = a.min
+		}
+		a.bestWorkers, a.bestRate, a.holds = a.workers, 0, 0
+		a.backoffs++
+	case rate == 0 && sent == 0:
+		// Nothing to measure, e.g. still listing.
+	case rate > a.bestRate+a.bestRate*autoParallelGainPercent/100:
+		a.bestWorkers, a.bestRate, a.holds = a.workers, rate, 0
+		a.workers += max(1, a.workers/2)
+	default:
+		// No gain from the last increase, hold the best number of
+		// workers and try more again from time to time.
+		a.workers = a.bestWorkers
+		a.holds++
+		if a.holds >= autoParallelHoldPeriods {
+			a.holds = 0
+			a.workers += max(1, a.workers/4)
+		}
+	}
+	if a.workers > a.max {
+		a.workers = a.max
+	}
+	if a.workers < a.min {
+		a.workers = a.min
+	}
+	return a.workers
+}
+
+// message returns the number of workers the tuning converged to.
+func (a *autoParallel) message() autoParallelMessage {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	workers := a.bestWorkers
+	if workers == 0 {
+		workers = a.workers
+	}
+	return autoParallelMessage{Workers: workers, Backoffs: a.backoffs}
+}
+
+// autoParallelMessage reports the number of objects copied in parallel
+// found by --auto-parallel, part of the cp summary.
+type autoParallelMessage struct {
+	Workers  int `json:"workers"`
+	Backoffs int `json:"backoffs"`
+}
+
+func (m autoParallelMessage) String() string {
+	msg := fmt.Sprintf("Auto-tuned to %d object(s) copied in parallel", m.Workers)
+	if m.Backoffs > 0 {
+		msg += fmt.Sprintf(", backed off %d time(s) under server pressure", m.Backoffs)
+	}
+	return msg + "."
+}
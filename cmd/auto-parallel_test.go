@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAutoParallelAdjust(t *testing.T) {
+	a := newAutoParallel(1, 16)
+	var bytes, requests, throttled int64
+	step := func(rate, sent, failed int64) int {
+		bytes += rate
+		requests += sent
+		throttled += failed
+		return a.adjust(bytes, requests, throttled)
+	}
+
+	// Still listing, nothing changes.
+	if n := step(0, 0, 0); n != 4 {
+		t.Fatalf("expected 4 workers while idle, got %d", n)
+	}
+	// The throughput grows with the workers.
+	if n := step(100, 10, 0); n != 6 {
+		t.Fatalf("expected 6 workers, got %d", n)
+	}
+	if n := step(150, 10, 0); n != 9 {
+		t.Fatalf("expected 9 workers, got %d", n)
+	}
+	// No gain, back to the best number of workers.
+	if n := step(152, 10, 0); n != 6 {
+		t.Fatalf("expected 6 workers, got %d", n)
+	}
+	for i := 1; i < autoParallelHoldPeriods-1; i++ {
+		if n := step(150, 10, 0); n != 6 {
+			t.Fatalf("expected to hold 6 workers, got %d", n)
+		}
+	}
+	// Try more workers again.
+	if n := step(150, 10, 0); n != 7 {
+		t.Fatalf("expected 7 workers, got %d", n)
+	}
+	// The server throttles, back off.
+	if n := step(100, 20, 2); n != 3 {
+		t.Fatalf("expected 3 workers, got %d", n)
+	}
+	if msg := a.message(); msg.Workers != 3 || msg.Backoffs != 1 {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+	for i := 0; i < 3; i++ {
+		step(10, 10, 10)
+	}
+	if a.workers != 1 {
+		t.Fatalf("expected the minimum of 1 worker, got %d", a.workers)
+	}
+	// Never above the maximum.
+	for i := 0; i < 20; i++ {
+		step(int64(1000*(i+1)), 10, 0)
+	}
+	if a.workers != 16 {
+		t.Fatalf("expected the maximum of 16 workers, got %d", a.workers)
+	}
+}
+
+func TestRequestStatsTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slowdown" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	stats := &requestStats{}
+	client := &http.Client{Transport: requestStatsTransport{transport: http.DefaultTransport, stats: stats}}
+	for _, path := range []string{"/", "/slowdown", "/"} {
+		resp, e := client.Get(server.URL + path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		resp.Body.Close()
+	}
+	if stats.requests != 3 || stats.throttled != 1 {
+		t.Fatalf("expected 3 requests and 1 throttled, got %d and %d", stats.requests, stats.throttled)
+	}
+}
+
+func TestParallelManagerSetWorkers(t *testing.T) {
+	resultCh := make(chan URLs)
+	p := newIdleParallelManager(resultCh)
+	p.setWorkers(8)
+	if n := atomic.LoadUint32(&p.workersNum); n != 8 {
+		t.Fatalf("expected 8 workers, got %d", n)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range resultCh {
+		}
+	}()
+
+	// Workers above the target stop after their task.
+	p.setWorkers(2)
+	for i := 0; i < 6; i++ {
+		p.queueTask(func() URLs { return URLs{} }, 0)
+	}
+	p.stopAndWait()
+	close(resultCh)
+	wg.Wait()
+	if n := atomic.LoadUint32(&p.workersNum); n != 2 {
+		t.Fatalf("expected 2 workers left, got %d", n)
+	}
+}
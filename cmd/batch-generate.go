@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/minio/cli"
@@ -26,13 +27,20 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
+var batchGenerateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "output, o",
+		Usage: "write the job definition to the given file instead of stdout",
+	},
+}
+
 var batchGenerateCmd = cli.Command{
 	Name:         "generate",
 	Usage:        "generate a new batch job definition",
 	Action:       mainBatchGenerate,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(batchGenerateFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -47,6 +55,9 @@ FLAGS:
 EXAMPLES:
   1. Generate a new batch 'replication' job definition:
      {{.Prompt}} {{.HelpName}} myminio replicate > replication.yaml
+
+  2. Generate a new batch 'replication' job definition and write it to 'replication.yaml':
+     {{.Prompt}} {{.HelpName}} myminio replicate --output replication.yaml
 `,
 }
 
@@ -60,6 +71,16 @@ func supportedJobTypes() string {
 	return builder.String()
 }
 
+// isSupportedJobType returns true if the server accepts jobs of this type.
+func isSupportedJobType(jobType string) bool {
+	for _, t := range madmin.SupportedJobTypes {
+		if string(t) == jobType {
+			return true
+		}
+	}
+	return false
+}
+
 // checkBatchGenerateSyntax - validate all the passed arguments
 func checkBatchGenerateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
@@ -80,9 +101,7 @@ func mainBatchGenerate(ctx *cli.Context) error {
 	adminClient, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	switch jobType {
-	case string(madmin.BatchJobReplicate):
-	default:
+	if !isSupportedJobType(jobType) {
 		fatalIf(errInvalidArgument().Trace(jobType), "Unable to generate a job template for the specified job type")
 	}
 
@@ -91,6 +110,12 @@ func mainBatchGenerate(ctx *cli.Context) error {
 	})
 	fatalIf(probe.NewError(e), "Unable to generate %s", args.Get(1))
 
+	if outputFile := ctx.String("output"); outputFile != "" {
+		e = os.WriteFile(outputFile, []byte(out), 0o600)
+		fatalIf(probe.NewError(e).Trace(outputFile), "Unable to write the job definition")
+		return nil
+	}
+
 	fmt.Println(string(out))
 	return nil
 }
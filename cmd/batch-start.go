@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var batchStartCmd = cli.Command{
@@ -72,6 +74,94 @@ func (c batchStartMessage) JSON() string {
 	return string(batchStartMessageBytes)
 }
 
+// batchJobKV is a key/value pair used by tag and metadata filters.
+type batchJobKV struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// batchJobCredentials is the credentials section of a remote source or target.
+type batchJobCredentials struct {
+	AccessKey    string `yaml:"accessKey"`
+	SecretKey    string `yaml:"secretKey"`
+	SessionToken string `yaml:"sessionToken"`
+}
+
+// batchJobEndpoint is the source or target section of a replicate job.
+type batchJobEndpoint struct {
+	Type        string              `yaml:"type"`
+	Bucket      string              `yaml:"bucket"`
+	Prefix      string              `yaml:"prefix"`
+	Endpoint    string              `yaml:"endpoint"`
+	Path        string              `yaml:"path"`
+	Credentials batchJobCredentials `yaml:"credentials"`
+}
+
+// batchJobReplicateV1 mirrors the 'replicate' job schema accepted by the server.
+type batchJobReplicateV1 struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Source     batchJobEndpoint `yaml:"source"`
+	Target     batchJobEndpoint `yaml:"target"`
+	Flags      struct {
+		Filter struct {
+			NewerThan     string       `yaml:"newerThan"`
+			OlderThan     string       `yaml:"olderThan"`
+			CreatedAfter  string       `yaml:"createdAfter"`
+			CreatedBefore string       `yaml:"createdBefore"`
+			Tags          []batchJobKV `yaml:"tags"`
+			Metadata      []batchJobKV `yaml:"metadata"`
+		} `yaml:"filter"`
+		Notify struct {
+			Endpoint string `yaml:"endpoint"`
+			Token    string `yaml:"token"`
+		} `yaml:"notify"`
+		Retry struct {
+			Attempts int    `yaml:"attempts"`
+			Delay    string `yaml:"delay"`
+		} `yaml:"retry"`
+	} `yaml:"flags"`
+}
+
+// batchJobRequest is the top level document of a job definition.
+type batchJobRequest struct {
+	Replicate *batchJobReplicateV1 `yaml:"replicate"`
+}
+
+// validateBatchJob validates a job definition against the job schema, unknown
+// fields and type mismatches are reported with the offending line number.
+func validateBatchJob(buf []byte) error {
+	var job batchJobRequest
+	if e := yaml.UnmarshalStrict(buf, &job); e != nil {
+		return e
+	}
+	if job.Replicate == nil {
+		return errors.New("no job definition found, supported job types are 'replicate'")
+	}
+
+	r := job.Replicate
+	if r.APIVersion != "v1" {
+		return fmt.Errorf("replicate.apiVersion: unsupported version '%s', expected 'v1'", r.APIVersion)
+	}
+	for _, ep := range []struct {
+		name string
+		ep   batchJobEndpoint
+	}{{"source", r.Source}, {"target", r.Target}} {
+		if ep.ep.Type != "" && ep.ep.Type != "minio" {
+			return fmt.Errorf("replicate.%s.type: unsupported type '%s', expected 'minio'", ep.name, ep.ep.Type)
+		}
+		if ep.ep.Bucket == "" {
+			return fmt.Errorf("replicate.%s.bucket: bucket cannot be empty", ep.name)
+		}
+	}
+	if r.Source.Endpoint != "" && r.Target.Endpoint != "" {
+		return errors.New("replicate: either source or target must be local, both have an endpoint")
+	}
+	if r.Flags.Retry.Attempts < 0 {
+		return errors.New("replicate.flags.retry.attempts: cannot be negative")
+	}
+	return nil
+}
+
 // checkBatchStartSyntax - validate all the passed arguments
 func checkBatchStartSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
@@ -96,6 +186,9 @@ func mainBatchStart(ctx *cli.Context) error {
 	buf, e := os.ReadFile(args.Get(1))
 	fatalIf(probe.NewError(e), "Unable to read %s", args.Get(1))
 
+	e = validateBatchJob(buf)
+	fatalIf(probe.NewError(e), "Invalid job definition in %s", args.Get(1))
+
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
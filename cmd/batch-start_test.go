@@ -0,0 +1,103 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBatchJob(t *testing.T) {
+	testCases := []struct {
+		job    string
+		errStr string
+	}{
+		{
+			job: `replicate:
+  apiVersion: v1
+  source:
+    type: minio
+    bucket: src
+  target:
+    type: minio
+    bucket: dst
+    endpoint: https://play.min.io
+  flags:
+    retry:
+      attempts: 3
+      delay: 500ms
+`,
+		},
+		{
+			job: `replicate:
+  apiVersion: v1
+  source:
+    bucket: src
+    bukcet: typo
+  target:
+    bucket: dst
+`,
+			errStr: "line 5",
+		},
+		{
+			job: `replicate:
+  apiVersion: v2
+  source:
+    bucket: src
+  target:
+    bucket: dst
+`,
+			errStr: "replicate.apiVersion",
+		},
+		{
+			job: `replicate:
+  apiVersion: v1
+  source:
+    bucket: src
+  target:
+    type: s3
+    bucket: dst
+`,
+			errStr: "replicate.target.type",
+		},
+		{
+			job: `replicate:
+  apiVersion: v1
+  source:
+    bucket: src
+  target:
+    prefix: dst
+`,
+			errStr: "replicate.target.bucket",
+		},
+		{
+			job:    `expire: {}`,
+			errStr: "line 1",
+		},
+	}
+
+	for i, testCase := range testCases {
+		e := validateBatchJob([]byte(testCase.job))
+		if testCase.errStr == "" && e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if testCase.errStr != "" && (e == nil || !strings.Contains(e.Error(), testCase.errStr)) {
+			t.Fatalf("Test %d: expected error containing %q, got %v", i+1, testCase.errStr, e)
+		}
+	}
+}
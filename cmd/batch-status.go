@@ -18,13 +18,25 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
+var batchStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:   "watch, w",
+		Usage:  "watch the job progress until it completes",
+		Hidden: true, // Hidden since this option is deprecated, watching is the default.
+	},
+	cli.BoolFlag{
+		Name:  "once",
+		Usage: "show the current job progress and exit",
+	},
+}
+
 var batchStatusCmd = cli.Command{
 	Name:            "status",
 	Usage:           "summarize job events on MinIO server in real-time",
 	Action:          mainBatchStatus,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(batchStatusFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -36,8 +48,11 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 EXAMPLES:
-   1. Display current in-progress JOB events.
+   1. Display in-progress JOB events until the job completes.
       {{.Prompt}} {{.HelpName}} myminio/ KwSysDpxcBU9FNhGkn2dCf
+
+   2. Display the current progress of the JOB once, for a script.
+      {{.Prompt}} {{.HelpName}} myminio/ KwSysDpxcBU9FNhGkn2dCf --once --json
 `,
 }
 
@@ -46,6 +61,9 @@ func checkBatchStatusSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("watch") && ctx.Bool("once") {
+		fatalIf(errInvalidArgument().Trace(), "`--watch` cannot be used with `--once`.")
+	}
 }
 
 func mainBatchStatus(ctx *cli.Context) error {
@@ -53,6 +71,7 @@ func mainBatchStatus(ctx *cli.Context) error {
 
 	aliasedURL := ctx.Args().Get(0)
 	jobID := ctx.Args().Get(1)
+	watch := !ctx.Bool("once")
 
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
@@ -66,7 +85,7 @@ func mainBatchStatus(ctx *cli.Context) error {
 	_, e := client.DescribeBatchJob(ctxt, jobID)
 	fatalIf(probe.NewError(e), "Unable to lookup job status")
 
-	ui := tea.NewProgram(initBatchJobMetricsUI(jobID))
+	ui := tea.NewProgram(initBatchJobMetricsUI(jobID, watch))
 	if !globalJSON {
 		go func() {
 			if e := ui.Start(); e != nil {
@@ -85,12 +104,17 @@ func mainBatchStatus(ctx *cli.Context) error {
 		e := client.Metrics(ctxt, opts, func(metrics madmin.RealtimeMetrics) {
 			if globalJSON {
 				printMsg(metricsMessage{RealtimeMetrics: metrics})
+				if !watch {
+					cancel()
+				} else if jobs := metrics.Aggregated.BatchJobs; jobs != nil && jobs.Jobs[jobID].Complete {
+					cancel()
+				}
 				return
 			}
 			if metrics.Aggregated.BatchJobs != nil {
 				job := metrics.Aggregated.BatchJobs.Jobs[jobID]
 				ui.Send(job)
-				if job.Complete {
+				if job.Complete || !watch {
 					cancel()
 				}
 			}
@@ -98,19 +122,23 @@ func mainBatchStatus(ctx *cli.Context) error {
 		if e != nil && !errors.Is(e, context.Canceled) {
 			fatalIf(probe.NewError(e).Trace(ctx.Args()...), "Unable to get current status")
 		}
+		if globalJSON {
+			close(done)
+		}
 	}()
 
 	<-done
 	return nil
 }
 
-func initBatchJobMetricsUI(jobID string) *batchJobMetricsUI {
+func initBatchJobMetricsUI(jobID string, watch bool) *batchJobMetricsUI {
 	s := spinner.New()
 	s.Spinner = spinner.Points
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	return &batchJobMetricsUI{
 		spinner: s,
 		jobID:   jobID,
+		watch:   watch,
 	}
 }
 
@@ -119,6 +147,7 @@ type batchJobMetricsUI struct {
 	spinner  spinner.Model
 	quitting bool
 	jobID    string
+	watch    bool
 }
 
 func (m *batchJobMetricsUI) Init() tea.Cmd {
@@ -137,7 +166,7 @@ func (m *batchJobMetricsUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case madmin.JobMetric:
 		m.current = msg
-		if msg.Complete {
+		if msg.Complete || !m.watch {
 			m.quitting = true
 			return m, tea.Quit
 		}
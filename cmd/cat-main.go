@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -55,6 +56,14 @@ var catFlags = []cli.Flag{
 		Name:  "tail",
 		Usage: "tail number of bytes at ending of file",
 	},
+	cli.BoolFlag{
+		Name:  "no-decompress",
+		Usage: "do not decompress objects with 'Content-Encoding: gzip'",
+	},
+	cli.BoolFlag{
+		Name:  "ignore-missing",
+		Usage: "skip the objects which do not exist instead of failing",
+	},
 }
 
 // Display contents of a file.
@@ -64,7 +73,7 @@ var catCmd = cli.Command{
 	Action:       mainCat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(catFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(catFlags, sseCKeyFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -99,6 +108,18 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Display the raw content of an object uploaded with 'mc cp --compress', without decompressing it.
+     {{.Prompt}} {{.HelpName}} --no-decompress play/my-bucket/app.log > app.log.gz
+
+  9. Reassemble the parts of a chunked upload, in order. No output is written if a part is missing.
+     {{.Prompt}} {{.HelpName}} play/my-bucket/part1 play/my-bucket/part2 play/my-bucket/part3 > whole
+
+  10. Concatenate the last 100 bytes of each log, skipping the logs which do not exist.
+      {{.Prompt}} {{.HelpName}} --tail 100 --ignore-missing play/my-bucket/app1.log play/my-bucket/app2.log
+
+  11. Decrypt an SSE-C encrypted object to standard output, reading the key from a file only its owner can access.
+      {{.Prompt}} {{.HelpName}} --sse-c-key-file ~/.keys/backup.key s3/mysql-backups/backups-201810.gz > recent.gz
 `,
 }
 
@@ -152,13 +173,15 @@ func (s prettyStdout) Write(input []byte) (int, error) {
 }
 
 type catOpts struct {
-	args      []string
-	versionID string
-	timeRef   time.Time
-	startO    int64
-	tailO     int64
-	isZip     bool
-	stdinMode bool
+	args          []string
+	versionID     string
+	timeRef       time.Time
+	startO        int64
+	tailO         int64
+	isZip         bool
+	stdinMode     bool
+	noDecompress  bool
+	ignoreMissing bool
 }
 
 // parseCatSyntax performs command-line input validation for cat command.
@@ -187,6 +210,8 @@ func parseCatSyntax(ctx *cli.Context) catOpts {
 
 	o.timeRef = parseRewindFlag(rewind)
 	o.isZip = ctx.Bool("zip")
+	o.noDecompress = ctx.Bool("no-decompress")
+	o.ignoreMissing = ctx.Bool("ignore-missing")
 	o.startO = ctx.Int64("offset")
 	o.tailO = ctx.Int64("tail")
 	if o.tailO != 0 && o.startO != 0 {
@@ -205,10 +230,41 @@ func parseCatSyntax(ctx *cli.Context) catOpts {
 	return o
 }
 
+// isMissingObjectErr returns true if the error is about an object or a file which does not exist.
+func isMissingObjectErr(err *probe.Error) bool {
+	switch err.ToGoError().(type) {
+	case ObjectMissing, PathNotFound, ObjectIsDeleteMarker:
+		return true
+	}
+	return false
+}
+
+// checkCatSources checks that all the sources exist before any of them is
+// displayed, so that a missing source does not leave a truncated output.
+// The missing sources are skipped instead with --ignore-missing.
+func checkCatSources(ctx context.Context, encKeyDB map[string][]prefixSSEPair, o catOpts) []string {
+	sources := make([]string, 0, len(o.args))
+	for _, sourceURL := range o.args {
+		if sourceURL == "-" {
+			sources = append(sources, sourceURL)
+			continue
+		}
+		_, _, err := url2Stat(ctx, sourceURL, o.versionID, false, encKeyDB, o.timeRef, o.isZip)
+		if err != nil && o.ignoreMissing && isMissingObjectErr(err) {
+			errorIf(err.Trace(sourceURL), "Skipping missing `"+sourceURL+"`.")
+			continue
+		}
+		fatalIf(err.Trace(sourceURL), "Unable to read from `"+sourceURL+"`.")
+		sources = append(sources, sourceURL)
+	}
+	return sources
+}
+
 // catURL displays contents of a URL to stdout.
 func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixSSEPair, o catOpts) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
+	decompress := false
 	switch sourceURL {
 	case "-":
 		reader = os.Stdin
@@ -233,6 +289,10 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 				}
 			}
 
+			// Objects with the gzip content-encoding are decompressed,
+			// unless a part of them only is requested.
+			decompress = !o.noDecompress && o.startO == 0 && isGzipContentEncoding(content.Metadata)
+
 			if client.GetURL().Type == objectStorage {
 				size = content.Size - o.startO
 				if size < 0 {
@@ -253,6 +313,18 @@ func catURL(ctx context.Context, sourceURL string, encKeyDB map[string][]prefixS
 		}
 		defer reader.Close()
 	}
+	if decompress {
+		var compressed io.Reader = reader
+		if size >= 0 {
+			compressed = io.LimitReader(reader, size)
+		}
+		gzReader, e := gzip.NewReader(compressed)
+		if e != nil {
+			return probe.NewError(e).Trace(sourceURL)
+		}
+		defer gzReader.Close()
+		return catOut(gzReader, -1).Trace(sourceURL)
+	}
 	return catOut(reader, size).Trace(sourceURL)
 }
 
@@ -313,6 +385,12 @@ func mainCat(cliCtx *cli.Context) error {
 	// check 'cat' cli arguments.
 	o := parseCatSyntax(cliCtx)
 
+	sseCKey, err := getSSECKey(cliCtx)
+	fatalIf(err, "Unable to read the SSE-C key.")
+	if sseCKey != nil {
+		fatalIf(addSSECKey(encKeyDB, o.args, sseCKey), "Unable to set the SSE-C key.")
+	}
+
 	// Set command flags from context.
 
 	// handle std input data.
@@ -332,9 +410,18 @@ func mainCat(cliCtx *cli.Context) error {
 		}
 	}
 
+	if len(o.args) > 1 || o.ignoreMissing {
+		o.args = checkCatSources(ctx, encKeyDB, o)
+	}
+
 	// Convert arguments to URLs: expand alias, fix format.
+	// --offset and --tail apply to each of them.
 	for _, url := range o.args {
-		fatalIf(catURL(ctx, url, encKeyDB, o).Trace(url), "Unable to read from `"+url+"`.")
+		err := catURL(ctx, url, encKeyDB, o)
+		if sseCKey != nil {
+			err = sseCKeyError(err)
+		}
+		fatalIf(err.Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
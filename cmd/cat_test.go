@@ -21,6 +21,8 @@ import (
 	"bytes"
 	"io"
 	"testing"
+
+	"github.com/minio/mc/pkg/probe"
 )
 
 func TestPrettyStdout(t *testing.T) {
@@ -62,3 +64,21 @@ func TestPrettyStdout(t *testing.T) {
 		}
 	}
 }
+
+func TestIsMissingObjectErr(t *testing.T) {
+	testCases := []struct {
+		err     error
+		missing bool
+	}{
+		{ObjectMissing{}, true},
+		{PathNotFound{Path: "/tmp/part3"}, true},
+		{ObjectIsDeleteMarker{}, true},
+		{BucketDoesNotExist{Bucket: "bucket"}, false},
+		{PathInsufficientPermission{Path: "/tmp/part3"}, false},
+	}
+	for i, tc := range testCases {
+		if missing := isMissingObjectErr(probe.NewError(tc.err)); missing != tc.missing {
+			t.Errorf("Test %d: expected %v for %v, got %v", i+1, tc.missing, tc.err, missing)
+		}
+	}
+}
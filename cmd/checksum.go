@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+	"strconv"
+	"strings"
+)
+
+// crc32cTable is the Castagnoli table of the S3 CRC32C checksums.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// multipartCRC32C computes, as it is written, the CRC32C checksum of a stream
+// uploaded in parts of partSize: the checksum of the concatenated checksums of
+// the parts, as recorded by the server for multipart uploads.
+type multipartCRC32C struct {
+	partSize int64
+	partLen  int64
+	part     hash.Hash32
+	sums     []byte
+	parts    int
+}
+
+func newMultipartCRC32C(partSize int64) *multipartCRC32C {
+	return &multipartCRC32C{
+		partSize: partSize,
+		part:     crc32.New(crc32cTable),
+	}
+}
+
+func (m *multipartCRC32C) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		chunk := p
+		if remaining := m.partSize - m.partLen; int64(len(chunk)) > remaining {
+			chunk = chunk[:remaining]
+		}
+		m.part.Write(chunk)
+		m.partLen += int64(len(chunk))
+		p = p[len(chunk):]
+		if m.partLen == m.partSize {
+			m.endPart()
+		}
+	}
+	return n, nil
+}
+
+// endPart adds the checksum of the current part.
+func (m *multipartCRC32C) endPart() {
+	m.sums = m.part.Sum(m.sums)
+	m.parts++
+	m.part.Reset()
+	m.partLen = 0
+}
+
+// Sum returns the base64 encoded checksum followed by the number of parts,
+// such as "5/d4hA==-3". An empty stream is uploaded as one empty part.
+func (m *multipartCRC32C) Sum() string {
+	sums, parts := m.sums, m.parts
+	if m.partLen > 0 || parts == 0 {
+		sums = m.part.Sum(sums)
+		parts++
+	}
+	crc := crc32.New(crc32cTable)
+	crc.Write(sums)
+	return base64.StdEncoding.EncodeToString(crc.Sum(nil)) + "-" + strconv.Itoa(parts)
+}
+
+// checksumMatches compares the checksum returned by the server with the one
+// computed locally, the server may omit the number of parts.
+func checksumMatches(server, local string) bool {
+	if !strings.Contains(server, "-") {
+		local, _, _ = strings.Cut(local, "-")
+	}
+	return server != "" && server == local
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMultipartCRC32C(t *testing.T) {
+	// The composite checksum as computed by the multipart uploads.
+	composite := func(parts ...string) string {
+		var sums []byte
+		for _, part := range parts {
+			crc := crc32.New(crc32cTable)
+			crc.Write([]byte(part))
+			sums = crc.Sum(sums)
+		}
+		crc := crc32.New(crc32cTable)
+		crc.Write(sums)
+		return base64.StdEncoding.EncodeToString(crc.Sum(nil)) + "-" + strconv.Itoa(len(parts))
+	}
+
+	testCases := []struct {
+		data     string
+		partSize int64
+		expected string
+	}{
+		{"", 4, composite("")},
+		{"abc", 4, composite("abc")},
+		{"abcd", 4, composite("abcd")},
+		{"abcdefghij", 4, composite("abcd", "efgh", "ij")},
+		{"abcdefgh", 4, composite("abcd", "efgh")},
+	}
+	for i, tc := range testCases {
+		crc := newMultipartCRC32C(tc.partSize)
+		// Write in chunks which do not match the parts.
+		if _, e := io.CopyBuffer(crc, strings.NewReader(tc.data), make([]byte, 3)); e != nil {
+			t.Fatal(e)
+		}
+		if sum := crc.Sum(); sum != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, sum)
+		}
+	}
+}
+
+func TestChecksumMatches(t *testing.T) {
+	testCases := []struct {
+		server, local string
+		matches       bool
+	}{
+		{"5/d4hA==-3", "5/d4hA==-3", true},
+		{"5/d4hA==", "5/d4hA==-3", true},
+		{"5/d4hA==-2", "5/d4hA==-3", false},
+		{"AAAAAA==", "5/d4hA==-3", false},
+		{"", "5/d4hA==-3", false},
+	}
+	for i, tc := range testCases {
+		if matches := checksumMatches(tc.server, tc.local); matches != tc.matches {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.matches, matches)
+		}
+	}
+}
@@ -143,6 +143,16 @@ func (e TooManyLevelsSymlink) Error() string {
 	return "Requested file `" + e.Path + "` has too many levels of symlinks"
 }
 
+// SkippedSymlink - symlink not followed while listing.
+type SkippedSymlink struct {
+	Path   string
+	Reason string
+}
+
+func (e SkippedSymlink) Error() string {
+	return "Skipped symlink `" + e.Path + "`, " + e.Reason
+}
+
 // EmptyPath (EINVAL) - invalid argument.
 type EmptyPath struct{}
 
@@ -162,6 +172,49 @@ func (e ObjectMissing) Error() string {
 	return "Object does not exist"
 }
 
+// ChecksumMismatch - checksum recorded by the server differs from the one computed locally.
+type ChecksumMismatch struct {
+	Path     string
+	Expected string
+	Got      string
+}
+
+func (e ChecksumMismatch) Error() string {
+	if e.Got == "" {
+		return "Server did not return a checksum for `" + e.Path + "`, expected " + e.Expected
+	}
+	return "Checksum mismatch for `" + e.Path + "`, expected " + e.Expected + " but the server recorded " + e.Got
+}
+
+// DownloadMismatch - MD5 of a downloaded file differs from the ETag of its source.
+type DownloadMismatch struct {
+	Path     string
+	Expected string
+	Got      string
+}
+
+func (e DownloadMismatch) Error() string {
+	return "Downloaded content of `" + e.Path + "` does not match its source, expected MD5 " + e.Expected + " but got " + e.Got
+}
+
+// ContentMD5Mismatch - server rejected an upload, its data does not match the Content-MD5 sent with it.
+type ContentMD5Mismatch struct {
+	Path string
+}
+
+func (e ContentMD5Mismatch) Error() string {
+	return "Integrity check failed for `" + e.Path + "`, the data received by the server does not match its Content-MD5 (BadDigest)"
+}
+
+// SourceChanged - source object changed since it was listed.
+type SourceChanged struct {
+	Path string
+}
+
+func (e SourceChanged) Error() string {
+	return "Object `" + e.Path + "` changed since it was listed, use --refresh-cache to list the source again"
+}
+
 // ObjectIsDeleteMarker - object is a delete marker as latest
 type ObjectIsDeleteMarker struct{}
 
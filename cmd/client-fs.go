@@ -56,6 +56,7 @@ const (
 	partSuffix       = ".part.minio"
 	slashSeperator   = "/"
 	metadataKey      = "X-Amz-Meta-Mc-Attrs"
+	symlinkTargetKey = "X-Amz-Meta-Mc-Symlink-Target"
 	metadataKeyS3Cmd = "X-Amz-Meta-S3cmd-Attrs"
 )
 
@@ -298,16 +299,22 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 		}
 	}
 
+	// Objects uploaded by cp --store-symlinks are links again with --preserve.
+	if target, ok := opts.metadata[symlinkTargetKey]; ok && opts.isPreserve {
+		return 0, f.putSymlink(target, opts.symlinkRoot)
+	}
+
 	objectPath := f.PathURL.Path
 
 	// Write to a temporary file "object.part.minio" before commit.
 	objectPartPath := objectPath + partSuffix
 
-	// We cannot resume this operation, then we
-	// should remove any partial download if any.
-	defer os.Remove(objectPartPath)
+	// Remove any partial download, unless it can be resumed.
+	if !opts.resumable {
+		defer os.Remove(objectPartPath)
+	}
 
-	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	tmpFile, e := openPartFile(objectPartPath, opts, progress)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
 		return 0, err.Trace(f.PathURL.Path)
@@ -320,7 +327,7 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 			tmpFile.Close()
 			return 0, probe.NewError(e)
 		}
-		err := preserveAttributes(tmpFile, attr)
+		err := preserveAttributes(tmpFile.file, attr)
 		if err != nil {
 			console.Println(console.Colorize("Error", fmt.Sprintf("unable to preserve attributes, continuing to copy the content %s\n", err.ToGoError())))
 		}
@@ -366,6 +373,10 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 		}
 	}
 
+	if err := tmpFile.verify(objectPartPath, opts.verifyMD5, objectPath); err != nil {
+		return totalWritten, err.Trace(objectPath)
+	}
+
 	// Safely completed put. Now commit by renaming to actual filename.
 	if e = os.Rename(objectPartPath, objectPath); e != nil {
 		err := f.toClientError(e, objectPath)
@@ -417,11 +428,12 @@ func (f *fsClient) putN(ctx context.Context, reader io.Reader, size int64, progr
 	// Write to a temporary file "object.part.minio" before commit.
 	objectPartPath := objectPath + partSuffix
 
-	// We cannot resume this operation, then we
-	// should remove any partial download if any.
-	defer os.Remove(objectPartPath)
+	// Remove any partial download, unless it can be resumed.
+	if !opts.resumable {
+		defer os.Remove(objectPartPath)
+	}
 
-	tmpFile, e := os.OpenFile(objectPartPath, os.O_CREATE|os.O_WRONLY, 0o666)
+	tmpFile, e := openPartFile(objectPartPath, opts, progress)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
 		return 0, err.Trace(f.PathURL.Path)
@@ -434,7 +446,7 @@ func (f *fsClient) putN(ctx context.Context, reader io.Reader, size int64, progr
 			tmpFile.Close()
 			return 0, probe.NewError(e)
 		}
-		err := preserveAttributes(tmpFile, attr)
+		err := preserveAttributes(tmpFile.file, attr)
 		if err != nil {
 			console.Println(console.Colorize("Error", fmt.Sprintf("unable to preserve attributes, continuing to copy the content %s\n", err.ToGoError())))
 		}
@@ -473,6 +485,10 @@ func (f *fsClient) putN(ctx context.Context, reader io.Reader, size int64, progr
 		}
 	}
 
+	if err := tmpFile.verify(objectPartPath, opts.verifyMD5, objectPath); err != nil {
+		return totalWritten, err.Trace(objectPath)
+	}
+
 	// Safely completed put. Now commit by renaming to actual filename.
 	if e = os.Rename(objectPartPath, objectPath); e != nil {
 		err := f.toClientError(e, objectPath)
@@ -675,7 +691,7 @@ func (f *fsClient) List(ctx context.Context, opts ListOptions) <-chan *ClientCon
 
 	if opts.Recursive {
 		if opts.ShowDir == DirNone {
-			go f.listRecursiveInRoutine(contentCh, opts.WithMetadata)
+			go f.listRecursiveInRoutine(contentCh, opts.WithMetadata, opts.Symlinks)
 		} else {
 			go f.listDirOpt(contentCh, opts.Incomplete, opts.WithMetadata, opts.ShowDir)
 		}
@@ -944,7 +960,7 @@ func (f *fsClient) listDirOpt(contentCh chan *ClientContent, isIncomplete bool,
 	}
 }
 
-func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetadata bool) {
+func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetadata bool, symlinks SymlinkOpt) {
 	// close channels upon return.
 	defer close(contentCh)
 	var dirName string
@@ -954,7 +970,40 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 		pathURL.Path = filepath.FromSlash(pathURL.Path)
 		pathURL.Separator = os.PathSeparator
 	}
-	visitFS := func(fp string, fi os.FileInfo, e error) error {
+	// Listed folder and its resolved path, used to follow symlinks.
+	var rootDir, resolvedRootDir string
+	var visitFS func(fp string, fi os.FileInfo, e error) error
+	// followSymlink reports whether the link fp can be followed.
+	followSymlink := func(fp string) (os.FileInfo, *probe.Error) {
+		if symlinks == SymlinksSkip {
+			return nil, probe.NewError(SkippedSymlink{Path: fp, Reason: "symlinks are not followed"})
+		}
+		fi, e := os.Stat(fp)
+		if e != nil {
+			return nil, probe.NewError(SkippedSymlink{Path: fp, Reason: "link target is not accessible"})
+		}
+		target, e := filepath.EvalSymlinks(fp)
+		if e != nil {
+			return nil, probe.NewError(SkippedSymlink{Path: fp, Reason: "link target is not accessible"})
+		}
+		if symlinks == SymlinksFollowInternal && !isPathWithin(target, resolvedRootDir) {
+			return nil, probe.NewError(SkippedSymlink{Path: fp, Reason: "link points outside of the source folder"})
+		}
+		if fi.IsDir() {
+			// A link to a folder containing any of the folders walked
+			// to reach it, including through other links, is a loop.
+			for dir := filepath.Dir(fp); ; dir = filepath.Dir(dir) {
+				if resolved, e := filepath.EvalSymlinks(dir); e == nil && isPathWithin(resolved, target) {
+					return nil, probe.NewError(SkippedSymlink{Path: fp, Reason: "link creates a loop"})
+				}
+				if len(dir) <= len(rootDir) || dir == filepath.Dir(dir) {
+					break
+				}
+			}
+		}
+		return fi, nil
+	}
+	visitFS = func(fp string, fi os.FileInfo, e error) error {
 		// If file path ends with filepath.Separator and equals to root path, skip it.
 		if strings.HasSuffix(fp, string(pathURL.Separator)) {
 			if fp == dirName {
@@ -1010,11 +1059,34 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 			return e
 		}
 		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
-			fi, e = os.Stat(fp)
-			if e != nil {
-				// Ignore any errors for symlink
+			if symlinks == SymlinksStore {
+				target, err := symlinkStoreTarget(fp, rootDir)
+				if err != nil {
+					contentCh <- &ClientContent{URL: *newClientURL(fp), Type: fi.Mode(), Err: err}
+					return nil
+				}
+				contentCh <- &ClientContent{URL: *newClientURL(fp), Time: fi.ModTime(), Type: fi.Mode(), SymlinkTarget: target}
 				return nil
 			}
+			if symlinks == SymlinksDefault {
+				fi, e = os.Stat(fp)
+				if e != nil {
+					// Ignore any errors for symlink
+					return nil
+				}
+			} else {
+				target, err := followSymlink(fp)
+				if err != nil {
+					contentCh <- &ClientContent{URL: *newClientURL(fp), Type: fi.Mode(), Err: err}
+					return nil
+				}
+				// Report the followed link before its content.
+				contentCh <- &ClientContent{URL: *newClientURL(fp), Type: fi.Mode()}
+				if target.IsDir() {
+					return xfilepath.Walk(fp+string(pathURL.Separator), visitFS)
+				}
+				fi = target
+			}
 		}
 		if fi.Mode().IsRegular() {
 			contentCh <- &ClientContent{
@@ -1043,6 +1115,13 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 		// filePrefix is kept for filtering incoming contents through WalkFunc.
 		filePrefix = pathURL.Path
 	}
+	rootDir = filepath.Clean(dirName)
+	if symlinks != SymlinksDefault {
+		var e error
+		if resolvedRootDir, e = filepath.EvalSymlinks(rootDir); e != nil {
+			resolvedRootDir = rootDir
+		}
+	}
 	// walks invokes our custom function.
 	e := xfilepath.Walk(dirName, visitFS)
 	if e != nil {
@@ -1052,6 +1131,70 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 	}
 }
 
+// symlinkStoreTarget returns the target of the link fp relative to its
+// folder, with slashes, the target must be within rootDir.
+func symlinkStoreTarget(fp, rootDir string) (string, *probe.Error) {
+	target, e := os.Readlink(fp)
+	if e != nil {
+		return "", probe.NewError(SkippedSymlink{Path: fp, Reason: "link is not readable"})
+	}
+	linkDir, e := filepath.Abs(filepath.Dir(fp))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	absRootDir, e := filepath.Abs(rootDir)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(linkDir, target)
+	}
+	if !isPathWithin(filepath.Clean(target), absRootDir) {
+		return "", probe.NewError(SkippedSymlink{Path: fp, Reason: "link points outside of the source folder"})
+	}
+	rel, e := filepath.Rel(linkDir, target)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// putSymlink creates the link of an object stored by cp --store-symlinks,
+// its target must be relative and within root, or within the folder of
+// the link when root is not set.
+func (f *fsClient) putSymlink(target, root string) *probe.Error {
+	linkPath := f.PathURL.Path
+	if root == "" {
+		root = filepath.Dir(linkPath)
+	}
+	target = filepath.FromSlash(target)
+	linkDir, e := filepath.Abs(filepath.Dir(linkPath))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	absRoot, e := filepath.Abs(root)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if filepath.IsAbs(target) || !isPathWithin(filepath.Join(linkDir, target), absRoot) {
+		return probe.NewError(fmt.Errorf("refusing to create symlink `%s` to `%s` outside of `%s`", linkPath, target, root))
+	}
+	if fi, e := os.Lstat(linkPath); e == nil && !fi.IsDir() {
+		if e = os.Remove(linkPath); e != nil {
+			return f.toClientError(e, linkPath).Trace(linkPath)
+		}
+	}
+	if e = os.Symlink(target, linkPath); e != nil {
+		return f.toClientError(e, linkPath).Trace(linkPath)
+	}
+	return nil
+}
+
+// isPathWithin returns true if the path is dir or one of its descendants.
+func isPathWithin(path, dir string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(os.PathSeparator))+string(os.PathSeparator))
+}
+
 // MakeBucket - create a new bucket.
 func (f *fsClient) MakeBucket(ctx context.Context, region string, ignoreExisting, withLock bool) *probe.Error {
 	// TODO: ignoreExisting has no effect currently. In the future, we want
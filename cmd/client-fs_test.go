@@ -24,7 +24,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
+	"github.com/minio/mc/pkg/probe"
 	. "gopkg.in/check.v1"
 )
 
@@ -194,6 +196,115 @@ func (s *TestSuite) TestList(c *C) {
 	}
 }
 
+// Test the recursive listing of symlinks.
+func (s *TestSuite) TestListSymlinks(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("symlinks require privileges on windows")
+	}
+	root, e := os.MkdirTemp(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	// src/x/toy -> src/y and src/y/tox -> src/x create a loop,
+	// src/ext -> external is outside of the listed folder.
+	for _, dir := range []string{"src/x", "src/y", "external"} {
+		c.Assert(os.MkdirAll(filepath.Join(root, dir), 0o700), IsNil)
+	}
+	for _, file := range []string{"src/x/a", "src/y/b", "external/c"} {
+		c.Assert(os.WriteFile(filepath.Join(root, file), []byte("hello"), 0o600), IsNil)
+	}
+	c.Assert(os.Symlink(filepath.Join(root, "src/y"), filepath.Join(root, "src/x/toy")), IsNil)
+	c.Assert(os.Symlink(filepath.Join(root, "src/x"), filepath.Join(root, "src/y/tox")), IsNil)
+	c.Assert(os.Symlink(filepath.Join(root, "external"), filepath.Join(root, "src/ext")), IsNil)
+
+	src := filepath.Join(root, "src") + string(os.PathSeparator)
+	fsClient, err := fsNew(src)
+	c.Assert(err, IsNil)
+
+	stored := map[string]string{}
+	list := func(symlinks SymlinkOpt) (files []string, followed, skipped int) {
+		for content := range fsClient.List(context.Background(), ListOptions{Recursive: true, ShowDir: DirNone, Symlinks: symlinks}) {
+			switch {
+			case content.Err != nil:
+				_, ok := content.Err.ToGoError().(SkippedSymlink)
+				c.Assert(ok, Equals, true)
+				skipped++
+			case content.SymlinkTarget != "":
+				stored[filepath.ToSlash(strings.TrimPrefix(content.URL.Path, src))] = content.SymlinkTarget
+			case content.Type&os.ModeSymlink != 0:
+				followed++
+			default:
+				files = append(files, filepath.ToSlash(strings.TrimPrefix(content.URL.Path, src)))
+			}
+		}
+		return files, followed, skipped
+	}
+
+	files, followed, skipped := list(SymlinksSkip)
+	c.Assert(files, DeepEquals, []string{"x/a", "y/b"})
+	c.Assert(followed, Equals, 0)
+	c.Assert(skipped, Equals, 3)
+
+	files, followed, skipped = list(SymlinksFollow)
+	c.Assert(files, DeepEquals, []string{"ext/c", "x/a", "x/toy/b", "y/b", "y/tox/a"})
+	c.Assert(followed, Equals, 3)
+	c.Assert(skipped, Equals, 2)
+
+	files, followed, skipped = list(SymlinksFollowInternal)
+	c.Assert(files, DeepEquals, []string{"x/a", "x/toy/b", "y/b", "y/tox/a"})
+	c.Assert(followed, Equals, 2)
+	c.Assert(skipped, Equals, 3)
+
+	// Stored links are not followed, their target is relative to their folder.
+	files, followed, skipped = list(SymlinksStore)
+	c.Assert(files, DeepEquals, []string{"x/a", "y/b"})
+	c.Assert(followed, Equals, 0)
+	c.Assert(skipped, Equals, 1)
+	c.Assert(stored, DeepEquals, map[string]string{"x/toy": "../y", "y/tox": "../x"})
+}
+
+// Test objects stored by cp --store-symlinks are links again with --preserve.
+func (s *TestSuite) TestPutSymlink(c *C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("symlinks require privileges on windows")
+	}
+	root, e := os.MkdirTemp(os.TempDir(), "fs-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	put := func(name, target string, preserve bool) *probe.Error {
+		fsClient, err := fsNew(filepath.Join(root, name))
+		c.Assert(err, IsNil)
+		_, err = fsClient.Put(context.Background(), bytes.NewReader(nil), 0, nil, PutOptions{
+			metadata:    map[string]string{symlinkTargetKey: target},
+			isPreserve:  preserve,
+			symlinkRoot: root,
+		})
+		return err
+	}
+
+	c.Assert(put("x/toy", "../y", true), IsNil)
+	target, e := os.Readlink(filepath.Join(root, "x/toy"))
+	c.Assert(e, IsNil)
+	c.Assert(target, Equals, filepath.FromSlash("../y"))
+
+	// An existing link is replaced.
+	c.Assert(put("x/toy", "../z", true), IsNil)
+	target, e = os.Readlink(filepath.Join(root, "x/toy"))
+	c.Assert(e, IsNil)
+	c.Assert(target, Equals, filepath.FromSlash("../z"))
+
+	// Links pointing outside of the root are refused.
+	c.Assert(put("x/up", "../../etc", true), NotNil)
+	c.Assert(put("x/abs", "/etc", true), NotNil)
+
+	// Without --preserve the object is an empty file.
+	c.Assert(put("x/plain", "../y", false), IsNil)
+	fi, e := os.Lstat(filepath.Join(root, "x/plain"))
+	c.Assert(e, IsNil)
+	c.Assert(fi.Mode().IsRegular(), Equals, true)
+}
+
 // Test put bucket aka 'mkdir()' operation.
 func (s *TestSuite) TestPutBucket(c *C) {
 	root, e := os.MkdirTemp(os.TempDir(), "fs-")
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// listHandler is an http.Handler serving ListObjectsV2 for a fixed set of keys.
+type listHandler struct {
+	keys []string
+}
+
+func (h listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	type content struct {
+		Key          string
+		ETag         string
+		Size         int64
+		LastModified string
+	}
+	type commonPrefix struct {
+		Prefix string
+	}
+	type listBucketResult struct {
+		XMLName        xml.Name `xml:"ListBucketResult"`
+		Name           string
+		Prefix         string
+		Delimiter      string
+		KeyCount       int
+		IsTruncated    bool
+		Contents       []content
+		CommonPrefixes []commonPrefix
+	}
+	prefix, delimiter := r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter")
+	startAfter := r.URL.Query().Get("start-after")
+	result := listBucketResult{Name: "bucket", Prefix: prefix, Delimiter: delimiter}
+	seen := map[string]bool{}
+	for _, key := range h.keys {
+		if !strings.HasPrefix(key, prefix) || key <= startAfter {
+			continue
+		}
+		if i := strings.Index(key[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			if p := key[:len(prefix)+i+1]; !seen[p] {
+				seen[p] = true
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: p})
+			}
+			continue
+		}
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			ETag:         "\"9af2f8218b150c351ad802c6f3d66abe\"",
+			Size:         1,
+			LastModified: "2015-05-21T18:24:21.097Z",
+		})
+	}
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+	response, _ := xml.Marshal(result)
+	w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+	w.Write(response)
+}
+
+func TestListConcurrent(t *testing.T) {
+	handler := listHandler{keys: []string{
+		"a", "dir/", "dir/b", "dir/c/d", "dir/c/e/f", "dir/c/e/g", "dirx/h", "i/j/k/l",
+	}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	list := func(url string, concurrency int) (keys []string) {
+		conf := new(Config)
+		conf.HostURL = server.URL + url
+		conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+		conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+		conf.Signature = "S3v4"
+		s3c, err := S3New(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for content := range s3c.List(globalContext, ListOptions{Recursive: true, ShowDir: DirNone, Concurrency: concurrency}) {
+			if content.Err != nil {
+				t.Fatal(content.Err)
+			}
+			keys = append(keys, content.URL.Path)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	for _, url := range []string{"/bucket/", "/bucket/dir", "/bucket/dir/", "/bucket/dir/c/"} {
+		want := list(url, 1)
+		if len(want) == 0 {
+			t.Fatalf("%s: no objects listed", url)
+		}
+		for _, concurrency := range []int{2, 8} {
+			if got := list(url, concurrency); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s with %d listings in parallel: expected %v, got %v", url, concurrency, want, got)
+			}
+		}
+	}
+}
+
+func TestListKeyRange(t *testing.T) {
+	handler := listHandler{keys: []string{
+		"a", "logs/2021/x", "logs/2022/x", "logs/2022/y", "logs/2023/x", "z",
+	}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		after, before string
+		keys          []string
+	}{
+		{"", "", []string{"/bucket/a", "/bucket/logs/2021/x", "/bucket/logs/2022/x", "/bucket/logs/2022/y", "/bucket/logs/2023/x", "/bucket/z"}},
+		{"logs/2022", "logs/2023", []string{"/bucket/logs/2022/x", "/bucket/logs/2022/y"}},
+		{"logs/2022/x", "", []string{"/bucket/logs/2022/y", "/bucket/logs/2023/x", "/bucket/z"}},
+		{"", "logs", []string{"/bucket/a"}},
+	}
+	for i, tc := range testCases {
+		var keys []string
+		for content := range s3c.List(globalContext, ListOptions{Recursive: true, ShowDir: DirNone, StartAfter: tc.after, EndBefore: tc.before}) {
+			if content.Err != nil {
+				t.Fatal(content.Err)
+			}
+			keys = append(keys, content.URL.Path)
+		}
+		if !reflect.DeepEqual(keys, tc.keys) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.keys, keys)
+		}
+	}
+}
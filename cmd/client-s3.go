@@ -214,6 +214,17 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 			}
 
 			transport = limiter.New(config.UploadLimit, config.DownloadLimit, transport)
+			if globalCountPartRetries {
+				transport = newPartRetryTransport(transport)
+			}
+			if globalRetryBudget != nil {
+				transport = retryBudgetTransport{transport: transport, budget: globalRetryBudget}
+			}
+			if globalRequestStats != nil {
+				transport = requestStatsTransport{transport: transport, stats: globalRequestStats}
+			}
+			transport = responseHeadersTransport{transport: transport}
+			transport = sqlProgressTransport{transport: transport}
 
 			if config.Debug {
 				if strings.EqualFold(config.Signature, "S3v4") {
@@ -668,6 +679,7 @@ func (c *S3Client) Select(ctx context.Context, expression string, sse encrypt.Se
 
 	opts.InputSerialization = selectObjectInputOpts(selOpts, object)
 	opts.OutputSerialization = selectObjectOutputOpts(selOpts, opts.InputSerialization)
+	opts.RequestProgress.Enabled = selOpts.RequestProgress
 	reader, e := c.api.SelectObjectContent(ctx, bucket, object, opts)
 	if e != nil {
 		return nil, probe.NewError(e)
@@ -750,6 +762,7 @@ func (c *S3Client) notificationToEventsInfo(ninfo notification.Info) []EventInfo
 				UserAgent:    record.Source.UserAgent,
 			}
 		}
+		eventsInfo[i].ETag = record.S3.Object.ETag
 	}
 	return eventsInfo
 }
@@ -908,6 +921,7 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 		Object:     tokens[2],
 		Encryption: opts.srcSSE,
 		VersionID:  opts.versionID,
+		MatchETag:  opts.matchETag,
 	}
 
 	destOpts := minio.CopyDestOptions{
@@ -936,6 +950,7 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 	}
 
 	// Assign metadata after irrelevant parts are delete above
+	encodeMetadataValues(metadata)
 	destOpts.UserMetadata = metadata
 	destOpts.ReplaceMetadata = len(metadata) > 0
 
@@ -1041,6 +1056,7 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		}
 	}
 
+	encodeMetadataValues(metadata)
 	opts := minio.PutObjectOptions{
 		UserMetadata:          metadata,
 		UserTags:              tagsMap,
@@ -1074,7 +1090,28 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		opts.SendContentMd5 = true
 	}
 
+	// Streams of unknown size are uploaded in parts with their CRC32C
+	// checksum, unless MD5 is sent, compute the checksum of the parts.
+	var crc *multipartCRC32C
+	if putOpts.checksum != "" && size < 0 && !opts.SendContentMd5 {
+		_, partSize, _, e := minio.OptimalPartInfo(-1, opts.PartSize)
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		crc = newMultipartCRC32C(partSize)
+		reader = io.TeeReader(reader, crc)
+	} else if putOpts.checksum != "" {
+		return 0, probe.NewError(errors.New("checksum verification is only supported for streams of unknown size uploaded without MD5"))
+	}
+
 	ui, e := c.api.PutObject(ctx, bucket, object, reader, size, opts)
+	if e == nil && crc != nil && !checksumMatches(ui.ChecksumCRC32C, crc.Sum()) {
+		return ui.Size, probe.NewError(ChecksumMismatch{
+			Path:     c.targetURL.String(),
+			Expected: crc.Sum(),
+			Got:      ui.ChecksumCRC32C,
+		})
+	}
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
@@ -1111,6 +1148,11 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		if errResponse.Code == "NoSuchKey" {
 			return ui.Size, probe.NewError(ObjectMissing{})
 		}
+		if errResponse.Code == "BadDigest" {
+			return ui.Size, probe.NewError(ContentMD5Mismatch{
+				Path: c.targetURL.String(),
+			})
+		}
 		return ui.Size, probe.NewError(e)
 	}
 	return ui.Size, nil
@@ -1508,7 +1550,10 @@ func (c *S3Client) SetAccess(ctx context.Context, bucketPolicy string, isJSON bo
 }
 
 // listObjectWrapper - select ObjectList mode depending on arguments
-func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string, isRecursive bool, timeRef time.Time, withVersions, withDeleteMarkers bool, metadata bool, maxKeys int, zip bool) <-chan minio.ObjectInfo {
+func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string, isRecursive bool, timeRef time.Time, withVersions, withDeleteMarkers bool, metadata bool, maxKeys int, startAfter string, zip bool) <-chan minio.ObjectInfo {
+	if maxKeys < 0 {
+		maxKeys = globalListPageSize
+	}
 	if !timeRef.IsZero() || withVersions {
 		return c.listVersions(ctx, bucket, object, isRecursive, timeRef, withVersions, withDeleteMarkers)
 	}
@@ -1516,9 +1561,9 @@ func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string,
 	if isGoogle(c.targetURL.Host) {
 		// Google Cloud S3 layer doesn't implement ListObjectsV2 implementation
 		// https://github.com/minio/mc/issues/3073
-		return c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, UseV1: true, MaxKeys: maxKeys})
+		return c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, UseV1: true, MaxKeys: maxKeys, StartAfter: startAfter})
 	}
-	opts := minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, WithMetadata: metadata, MaxKeys: maxKeys}
+	opts := minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, WithMetadata: metadata, MaxKeys: maxKeys, StartAfter: startAfter}
 	if zip {
 		// If prefix ends with .zip, add a slash.
 		if strings.HasSuffix(object, ".zip") {
@@ -1605,6 +1650,8 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 	// If the object is not found, continue to look for a directory marker or a prefix
 	if !strings.HasSuffix(path, string(c.targetURL.Separator)) && opts.timeRef.IsZero() {
 		o := minio.StatObjectOptions{ServerSideEncryption: opts.sse, VersionID: opts.versionID}
+		// Ask for the object checksums, servers without checksums ignore it.
+		o.Checksum = true
 		if opts.isZip {
 			o.Set("x-minio-extract", "true")
 		}
@@ -1622,7 +1669,7 @@ func (c *S3Client) Stat(ctx context.Context, opts StatOptions) (*ClientContent,
 
 	nonRecursive := false
 	maxKeys := 1
-	for objectStat := range c.listObjectWrapper(ctx, bucket, path, nonRecursive, opts.timeRef, false, false, false, maxKeys, opts.isZip) {
+	for objectStat := range c.listObjectWrapper(ctx, bucket, path, nonRecursive, opts.timeRef, false, false, false, maxKeys, "", opts.isZip) {
 		if objectStat.Err != nil {
 			return nil, probe.NewError(objectStat.Err)
 		}
@@ -1774,6 +1821,7 @@ func (c *S3Client) listVersionsRoutine(ctx context.Context, b, o string, isRecur
 			Prefix:       o,
 			Recursive:    isRecursive,
 			WithVersions: true,
+			MaxKeys:      globalListPageSize,
 		}) {
 			if objectVersion.Err != nil {
 				objectInfoCh <- objectVersion
@@ -1889,7 +1937,9 @@ func (c *S3Client) unversionedList(ctx context.Context, contentCh chan *ClientCo
 			c.listIncompleteInRoutine(ctx, contentCh, opts)
 		}
 	} else {
-		if opts.Recursive {
+		if opts.Recursive && opts.Concurrency > 1 && !opts.ListZip {
+			c.listConcurrentInRoutine(ctx, contentCh, opts)
+		} else if opts.Recursive {
 			c.listRecursiveInRoutine(ctx, contentCh, opts)
 		} else {
 			c.listInRoutine(ctx, contentCh, opts)
@@ -2098,10 +2148,23 @@ func (c *S3Client) objectInfo2ClientContent(bucket string, entry minio.ObjectInf
 	content.UserMetadata = map[string]string{}
 	content.ReplicationStatus = entry.ReplicationStatus
 	for k, v := range entry.UserMetadata {
-		content.UserMetadata[k] = v
+		content.UserMetadata[k] = decodeMetadataValue(v)
 	}
 	for k := range entry.Metadata {
 		content.Metadata[k] = entry.Metadata.Get(k)
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			content.Metadata[k] = decodeMetadataValue(content.Metadata[k])
+		}
+	}
+	for k, v := range map[string]string{
+		"X-Amz-Checksum-Crc32":  entry.ChecksumCRC32,
+		"X-Amz-Checksum-Crc32c": entry.ChecksumCRC32C,
+		"X-Amz-Checksum-Sha1":   entry.ChecksumSHA1,
+		"X-Amz-Checksum-Sha256": entry.ChecksumSHA256,
+	} {
+		if v != "" {
+			content.Metadata[k] = v
+		}
 	}
 	attr, _ := parseAttribute(content.UserMetadata)
 	if len(attr) > 0 {
@@ -2174,7 +2237,7 @@ func (c *S3Client) listInRoutine(ctx context.Context, contentCh chan *ClientCont
 		contentCh <- content
 	default:
 		isRecursive := false
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, "", opts.ListZip) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
@@ -2209,6 +2272,10 @@ func sortBucketsNameWithSlash(bucketsInfo []minio.BucketInfo) {
 }
 
 func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *ClientContent, opts ListOptions) {
+	// Stop the listing when returning early at opts.EndBefore.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// get bucket and object from URL.
 	b, o := c.url2BucketAndObject()
 	switch {
@@ -2227,13 +2294,16 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 			}
 
 			isRecursive := true
-			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.StartAfter, opts.ListZip) {
 				if object.Err != nil {
 					contentCh <- &ClientContent{
 						Err: probe.NewError(object.Err),
 					}
 					return
 				}
+				if opts.EndBefore != "" && object.Key >= opts.EndBefore {
+					break
+				}
 				contentCh <- c.objectInfo2ClientContent(bucket.Name, object)
 			}
 
@@ -2243,18 +2313,96 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 		}
 	default:
 		isRecursive := true
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.ListZip) {
+		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1, opts.StartAfter, opts.ListZip) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
 				}
 				return
 			}
+			if opts.EndBefore != "" && object.Key >= opts.EndBefore {
+				// Keys are listed in lexical order, nothing else to list.
+				return
+			}
 			contentCh <- c.objectInfo2ClientContent(b, object)
 		}
 	}
 }
 
+// listConcurrentInRoutine lists a bucket recursively like listRecursiveInRoutine,
+// but walks the prefixes of the bucket one level at a time with up to
+// opts.Concurrency listings in flight. Every key is listed exactly once,
+// in no particular order.
+func (c *S3Client) listConcurrentInRoutine(ctx context.Context, contentCh chan *ClientContent, opts ListOptions) {
+	b, o := c.url2BucketAndObject()
+	if b == "" {
+		// Listing all buckets, keep the bucket ordering.
+		c.listRecursiveInRoutine(ctx, contentCh, opts)
+		return
+	}
+
+	var (
+		mu      sync.Mutex
+		cond    = sync.NewCond(&mu)
+		queue   = []string{o}
+		pending = 1 // prefixes queued or being listed.
+		failed  bool
+	)
+
+	listPrefix := func(prefix string) (prefixes []string, ok bool) {
+		for object := range c.listObjectWrapper(ctx, b, prefix, false, time.Time{}, false, false, opts.WithMetadata, -1, "", false) {
+			if object.Err != nil {
+				contentCh <- &ClientContent{
+					Err: probe.NewError(object.Err),
+				}
+				return nil, false
+			}
+			// Common prefixes are returned without an ETag, unlike
+			// the objects whose name ends with a slash.
+			if object.Key != prefix && object.ETag == "" && strings.HasSuffix(object.Key, "/") {
+				prefixes = append(prefixes, object.Key)
+				continue
+			}
+			contentCh <- c.objectInfo2ClientContent(b, object)
+		}
+		return prefixes, true
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				for len(queue) == 0 && pending > 0 && !failed {
+					cond.Wait()
+				}
+				if pending == 0 || failed {
+					mu.Unlock()
+					return
+				}
+				// Depth first, to keep the queue short.
+				prefix := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				mu.Unlock()
+
+				prefixes, ok := listPrefix(prefix)
+
+				mu.Lock()
+				queue = append(queue, prefixes...)
+				pending += len(prefixes) - 1
+				if !ok {
+					failed = true
+				}
+				cond.Broadcast()
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // ShareDownload - get a usable presigned object url to share.
 func (c *S3Client) ShareDownload(ctx context.Context, versionID string, expires time.Duration) (string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
@@ -2785,6 +2933,12 @@ func (c *S3Client) GetBucketInfo(ctx context.Context) (BucketInfo, *probe.Error)
 	if rcfg, err := c.GetReplication(ctx); err == nil {
 		if !rcfg.Empty() {
 			b.Replication.Enabled = true
+			b.Replication.Config = rcfg
+			targets := make(map[string]struct{})
+			for _, rule := range rcfg.Rules {
+				targets[rule.Destination.Bucket] = struct{}{}
+			}
+			b.Replication.Targets = len(targets)
 		}
 	}
 	if algo, keyID, err := c.GetEncryption(ctx); err == nil {
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 
 	minio "github.com/minio/minio-go/v7"
 	. "gopkg.in/check.v1"
@@ -240,6 +241,99 @@ func (s *TestSuite) TestObjectOperations(c *C) {
 	}
 }
 
+// badDigestHandler rejects the uploads sent with a Content-MD5, as if their
+// data was corrupted on the way.
+type badDigestHandler struct {
+	objectHandler
+}
+
+func (h badDigestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "PUT" && r.Header.Get("Content-Md5") != "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("<Error><Code>BadDigest</Code><Message>The Content-MD5 you specified did not match what we received.</Message></Error>"))
+		return
+	}
+	h.objectHandler.ServeHTTP(w, r)
+}
+
+// Test uploads rejected for their Content-MD5 are reported as such.
+func (s *TestSuite) TestPutContentMD5Mismatch(c *C) {
+	object := badDigestHandler{objectHandler{
+		resource: "/bucket/object",
+		data:     []byte("Hello, World"),
+	}}
+	server := httptest.NewServer(object)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + object.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	_, err = s3c.Put(context.Background(), bytes.NewReader(object.data), int64(len(object.data)), nil, PutOptions{})
+	c.Assert(err, IsNil)
+
+	_, err = s3c.Put(context.Background(), bytes.NewReader(object.data), int64(len(object.data)), nil, PutOptions{md5: true})
+	c.Assert(err, NotNil)
+	_, ok := err.ToGoError().(ContentMD5Mismatch)
+	c.Assert(ok, Equals, true)
+}
+
+// metadataHandler keeps the user metadata of the last upload and returns
+// it on HEAD.
+type metadataHandler struct {
+	objectHandler
+	header http.Header
+}
+
+func (h metadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "PUT":
+		for k, v := range r.Header {
+			if strings.HasPrefix(k, "X-Amz-Meta-") {
+				h.header[k] = v
+			}
+		}
+	case "HEAD":
+		for k, v := range h.header {
+			w.Header()[k] = v
+		}
+	}
+	h.objectHandler.ServeHTTP(w, r)
+}
+
+// Test non ASCII metadata values are preserved by an upload and a stat.
+func (s *TestSuite) TestPutMetadataCharset(c *C) {
+	object := metadataHandler{objectHandler{
+		resource: "/bucket/object",
+		data:     []byte("Hello, World"),
+	}, http.Header{}}
+	server := httptest.NewServer(object)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + object.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	metadata := map[string]string{"X-Amz-Meta-Title": "日本語", "X-Amz-Meta-Dish": "Crème brûlée"}
+	_, err = s3c.Put(context.Background(), bytes.NewReader(object.data), int64(len(object.data)), nil, PutOptions{metadata: metadata})
+	c.Assert(err, IsNil)
+	c.Assert(object.header.Get("X-Amz-Meta-Title"), Equals, "=?UTF-8?b?5pel5pys6Kqe?=")
+
+	content, err := s3c.Stat(context.Background(), StatOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(content.Metadata["X-Amz-Meta-Title"], Equals, "日本語")
+	c.Assert(content.Metadata["X-Amz-Meta-Dish"], Equals, "Crème brûlée")
+	c.Assert(content.UserMetadata["Title"], Equals, "日本語")
+}
+
 var testSelectCompressionTypeCases = []struct {
 	opts            SelectObjectOpts
 	object          string
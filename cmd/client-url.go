@@ -18,8 +18,11 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -241,10 +244,52 @@ func url2Alias(aliasedURL string) (alias, path string) {
 	return urlParts[0], ""
 }
 
+// builtinContentTypes fills in modern formats missing or
+// outdated in mimedb, commonly served from web buckets.
+var builtinContentTypes = map[string]string{
+	".avif":  "image/avif",
+	".avifs": "image/avif-sequence",
+	".jxl":   "image/jxl",
+	".mjs":   "text/javascript",
+	".opus":  "audio/ogg",
+	".toml":  "application/toml",
+	".wasm":  "application/wasm",
+	".webp":  "image/webp",
+}
+
 // guessURLContentType - guess content-type of the URL.
 // on failure just return 'application/octet-stream'.
 func guessURLContentType(urlStr string) string {
 	url := newClientURL(urlStr)
-	contentType := mimedb.TypeByExtension(filepath.Ext(url.Path))
-	return contentType
+	ext := strings.ToLower(filepath.Ext(url.Path))
+	if contentType, ok := globalContentTypeMap[ext]; ok {
+		return contentType
+	}
+	if contentType, ok := builtinContentTypes[ext]; ok {
+		return contentType
+	}
+	return mimedb.TypeByExtension(ext)
+}
+
+// parseContentTypeMap parses extension to content-type mappings in the
+// mime.types format, i.e. 'content-type ext1 ext2 ...' per line. Blank
+// lines and lines starting with '#' are ignored.
+func parseContentTypeMap(r io.Reader) (map[string]string, error) {
+	contentTypes := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) < 2 || !strings.Contains(fields[0], "/") {
+			return nil, fmt.Errorf("line %d: expected 'content-type ext1 ext2 ...'", line)
+		}
+		for _, ext := range fields[1:] {
+			contentTypes["."+strings.ToLower(strings.TrimPrefix(ext, "."))] = fields[0]
+		}
+	}
+	return contentTypes, scanner.Err()
 }
@@ -17,7 +17,11 @@
 
 package cmd
 
-import . "gopkg.in/check.v1"
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
 
 // TestURL - tests url parsing and fields.
 func (s *TestSuite) TestURL(c *C) {
@@ -52,3 +56,20 @@ func (s *TestSuite) TestURLJoinPath(c *C) {
 	url = urlJoinPath(url1, url2)
 	c.Assert(url, Equals, "http://s3.mycompany.io/dev/mybucket/bin/")
 }
+
+// TestParseContentTypeMap - tests parsing of content-type maps.
+func (s *TestSuite) TestParseContentTypeMap(c *C) {
+	contentTypes, e := parseContentTypeMap(strings.NewReader("# comment\n\nimage/avif avif .AVIFS\napplication/wasm wasm\n"))
+	c.Assert(e, IsNil)
+	c.Assert(contentTypes, DeepEquals, map[string]string{
+		".avif":  "image/avif",
+		".avifs": "image/avif",
+		".wasm":  "application/wasm",
+	})
+
+	_, e = parseContentTypeMap(strings.NewReader("image/avif"))
+	c.Assert(e, NotNil)
+
+	_, e = parseContentTypeMap(strings.NewReader("avif image/avif"))
+	c.Assert(e, NotNil)
+}
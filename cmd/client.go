@@ -43,6 +43,27 @@ const (
 	DirLast
 )
 
+// SymlinkOpt - list symbolic links option, only applies to
+// the recursive listing of the local filesystem.
+type SymlinkOpt int8
+
+const (
+	// SymlinksDefault - list the targets of links to files, ignore links to folders.
+	SymlinksDefault SymlinkOpt = iota
+	// SymlinksSkip - do not follow links, report them as skipped.
+	SymlinksSkip
+	// SymlinksFollow - follow links to files and folders, links
+	// creating a loop are reported as skipped.
+	SymlinksFollow
+	// SymlinksFollowInternal - same as SymlinksFollow, links pointing
+	// outside of the listed folder are reported as skipped.
+	SymlinksFollowInternal
+	// SymlinksStore - list links with their target instead of following
+	// them, links pointing outside of the listed folder are reported as
+	// skipped.
+	SymlinksStore
+)
+
 // GetOptions holds options of the GET operation
 type GetOptions struct {
 	SSE        encrypt.ServerSide
@@ -61,6 +82,18 @@ type PutOptions struct {
 	multipartSize         uint64
 	multipartThreads      uint
 	concurrentStream      bool
+	// checksum verifies the CRC32C checksum recorded by the
+	// server of a stream of unknown size when set to "crc32c".
+	checksum string
+	// resumable keeps the partial file of a failed download to the
+	// local filesystem, resumeOffset appends to it on the next run
+	// and verifyMD5 checks the content before it is renamed.
+	resumable    bool
+	resumeOffset int64
+	verifyMD5    string
+	// symlinkRoot is the folder the symlinks recreated by a download
+	// with --preserve must point within.
+	symlinkRoot string
 }
 
 // StatOptions holds options of the HEAD operation
@@ -83,7 +116,11 @@ type ListOptions struct {
 	ListZip           bool
 	TimeRef           time.Time
 	ShowDir           DirOpt
+	Symlinks          SymlinkOpt
 	Count             int
+	Concurrency       int
+	StartAfter        string
+	EndBefore         string
 }
 
 // CopyOptions holds options for copying operation
@@ -95,6 +132,8 @@ type CopyOptions struct {
 	disableMultipart bool
 	isPreserve       bool
 	storageClass     string
+	// matchETag fails the copy if the source ETag differs.
+	matchETag string
 }
 
 // Client - client interface
@@ -209,6 +248,10 @@ type ClientContent struct {
 
 	Restore *minio.RestoreInfo
 
+	// SymlinkTarget is the target of a link listed with SymlinksStore,
+	// relative to the folder of the link.
+	SymlinkTarget string
+
 	Err *probe.Error
 }
 
@@ -236,4 +279,5 @@ type SelectObjectOpts struct {
 	InputSerOpts    map[string]map[string]string
 	OutputSerOpts   map[string]map[string]string
 	CompressionType minio.SelectCompressionType
+	RequestProgress bool
 }
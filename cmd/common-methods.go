@@ -18,6 +18,8 @@
 package cmd
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -26,7 +28,6 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -35,6 +36,7 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
@@ -172,6 +174,8 @@ type getSourceOpts struct {
 	GetOptions
 	fetchStat bool
 	preserve  bool
+	// matchETag fails if the fetched source ETag differs.
+	matchETag string
 }
 
 // getSourceStreamFromURL gets a reader from URL.
@@ -185,7 +189,10 @@ func getSourceStreamFromURL(ctx context.Context, urlStr string, encKeyDB map[str
 	return reader, err
 }
 
-func probeContentType(reader io.Reader) (ctype string, err *probe.Error) {
+// probeContentType detects the content-type of a local file from its first
+// bytes. With sniffText, content not matching a known file signature goes
+// through the WHATWG sniffing algorithm too, which notably detects text.
+func probeContentType(reader io.Reader, sniffText bool) (ctype string, err *probe.Error) {
 	ctype = "application/octet-stream"
 	// Read a chunk to decide between utf-8 text and binary
 	if s, ok := reader.(io.Seeker); ok {
@@ -204,6 +211,8 @@ func probeContentType(reader io.Reader) (ctype string, err *probe.Error) {
 		}
 		if kind.MIME.Value != "" {
 			ctype = kind.MIME.Value
+		} else if sniffText {
+			ctype = http.DetectContentType(buf[:n])
 		}
 	}
 	return ctype, nil
@@ -270,6 +279,11 @@ func getSourceStream(ctx context.Context, alias, urlStr string, opts getSourceOp
 			}
 		}
 
+		if opts.matchETag != "" && st.ETag != "" && strings.Trim(st.ETag, "\"") != strings.Trim(opts.matchETag, "\"") {
+			reader.Close()
+			return nil, nil, probe.NewError(SourceChanged{Path: urlStr}).Trace(alias, urlStr)
+		}
+
 		for k, v := range st.Metadata {
 			if httpguts.ValidHeaderFieldName(k) &&
 				httpguts.ValidHeaderFieldValue(v) {
@@ -281,8 +295,8 @@ func getSourceStream(ctx context.Context, alias, urlStr string, opts getSourceOp
 		// So we continue our detection process.
 		if ctype := metadata["Content-Type"]; ctype == "application/octet-stream" {
 			// Continue probing content-type if its filesystem stream.
-			if !mok {
-				metadata["Content-Type"], err = probeContentType(reader)
+			if !mok && !globalNoSniff {
+				metadata["Content-Type"], err = probeContentType(reader, globalSniffText)
 				if err != nil {
 					return nil, nil, err.Trace(alias, urlStr)
 				}
@@ -385,7 +399,13 @@ func filterMetadata(metadata map[string]string) map[string]string {
 		}
 	}
 	for k := range metadata {
-		if strings.HasPrefix(http.CanonicalHeaderKey(k), http.CanonicalHeaderKey(serverEncryptionKeyPrefix)) {
+		canonicalKey := http.CanonicalHeaderKey(k)
+		if strings.HasPrefix(canonicalKey, http.CanonicalHeaderKey(serverEncryptionKeyPrefix)) {
+			delete(newMetadata, k)
+		}
+		// The checksums of the source are not valid for the target, the
+		// checksum of a multipart object is a checksum of its parts.
+		if strings.HasPrefix(canonicalKey, "X-Amz-Checksum-") {
 			delete(newMetadata, k)
 		}
 	}
@@ -433,6 +453,12 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	srcSSE := getSSE(sourcePath, encKeyDB[sourceAlias])
 	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
 
+	// Copies replayed from a list cache fail if their source changed since it was listed.
+	var matchETag string
+	if urls.verifySourceETag {
+		matchETag = strings.Trim(urls.SourceContent.ETag, "\"")
+	}
+
 	var err *probe.Error
 	metadata := map[string]string{}
 	var mode, until, legalHold string
@@ -474,6 +500,23 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		legalHold = urls.TargetContent.LegalHold
 	}
 
+	// Symlinks listed with --store-symlinks are uploaded as empty objects
+	// recording their target.
+	if urls.SourceContent.SymlinkTarget != "" {
+		for k, v := range urls.TargetContent.UserMetadata {
+			metadata[http.CanonicalHeaderKey(k)] = v
+		}
+		metadata[symlinkTargetKey] = urls.SourceContent.SymlinkTarget
+		_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until, legalHold,
+			bytes.NewReader(nil), 0, progress, PutOptions{
+				metadata:     filterMetadata(metadata),
+				sse:          tgtSSE,
+				storageClass: urls.TargetContent.StorageClass,
+				md5:          urls.MD5,
+			})
+		return urls.WithError(err.Trace(sourceURL.String()))
+	}
+
 	for k, v := range urls.SourceContent.UserMetadata {
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
@@ -481,8 +524,11 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 
+	// Compress only uploads to object storage, of objects which do not have a compressed format.
+	compress := urls.Compress && targetAlias != "" && isCompressibleContentType(guessURLContentType(sourceURL.String()))
+
 	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias && !isZip {
+	if sourceAlias == targetAlias && !isZip && !compress {
 		// preserve new metadata and save existing ones.
 		if preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, urls)
@@ -518,9 +564,13 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			isPreserve:       preserve,
 			storageClass:     urls.TargetContent.StorageClass,
 		}
+		opts.matchETag = matchETag
 
 		err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
 			legalHold, length, progress, opts)
+		if err != nil && opts.matchETag != "" && minio.ToErrorResponse(err.ToGoError()).Code == "PreconditionFailed" {
+			err = probe.NewError(SourceChanged{Path: sourceURL.String()})
+		}
 	} else {
 		if urls.SourceContent.RetentionEnabled {
 			// preserve new metadata and save existing ones.
@@ -548,16 +598,29 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 
+		// Downloads to the local filesystem resume from the partial
+		// file left by an interrupted copy.
+		var resumeOffset int64
+		resumable := urls.resumeDownload && targetAlias == "" && sourceAlias != "" && !isZip && length > 0
+		if resumable {
+			resumeOffset = resumeDownloadOffset(ctx, urls, srcSSE)
+			if resumeOffset > 0 && matchETag == "" {
+				matchETag = strings.Trim(urls.SourceContent.ETag, "\"")
+			}
+		}
+
 		var reader io.ReadCloser
 		// Proceed with regular stream copy.
 		reader, metadata, err = getSourceStream(ctx, sourceAlias, sourceURL.String(), getSourceOpts{
 			GetOptions: GetOptions{
-				VersionID: sourceVersion,
-				SSE:       srcSSE,
-				Zip:       isZip,
+				VersionID:  sourceVersion,
+				SSE:        srcSSE,
+				Zip:        isZip,
+				RangeStart: resumeOffset,
 			},
 			fetchStat: true,
 			preserve:  preserve,
+			matchETag: matchETag,
 		})
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
@@ -574,6 +637,26 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			metadata[http.CanonicalHeaderKey(k)] = v
 		}
 
+		sourceLength := length
+		// Compress while uploading, or decompress while downloading objects with
+		// the gzip content-encoding to the local filesystem. The progress is then
+		// accounted on the source, since the size of the result is unknown.
+		switch {
+		case compress && metadata["Content-Encoding"] == "" && isCompressibleContentType(metadata["Content-Type"]):
+			gzReader := newGzipCompressReader(hookreader.NewHook(io.LimitReader(reader, length), progress))
+			defer gzReader.Close()
+			reader, length, progress = gzReader, -1, nil
+			metadata["Content-Encoding"] = "gzip"
+		case urls.Decompress && targetAlias == "" && isGzipContentEncoding(metadata):
+			gzReader, e := gzip.NewReader(hookreader.NewHook(io.LimitReader(reader, length), progress))
+			if e != nil {
+				return urls.WithError(probe.NewError(e).Trace(sourceURL.String()))
+			}
+			defer gzReader.Close()
+			reader, length, progress = gzReader, -1, nil
+			delete(metadata, "Content-Encoding")
+		}
+
 		var e error
 		var multipartSize uint64
 		if v := env.Get("MC_UPLOAD_MULTIPART_SIZE", ""); v != "" {
@@ -583,9 +666,19 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			}
 		}
 
-		multipartThreads, e := strconv.Atoi(env.Get("MC_UPLOAD_MULTIPART_THREADS", "4"))
-		if e != nil {
-			return urls.WithError(probe.NewError(e))
+		if multipartSize == 0 && length < 0 && sourceLength > 0 {
+			// Size the parts of compressed uploads after the source which is
+			// larger, instead of the default for streams of unknown size.
+			_, partSize, _, e := minio.OptimalPartInfo(sourceLength, 0)
+			if e != nil {
+				return urls.WithError(probe.NewError(e))
+			}
+			multipartSize = uint64(partSize)
+		}
+
+		multipartThreads, perr := getPartConcurrency()
+		if perr != nil {
+			return urls.WithError(perr)
 		}
 
 		putOpts := PutOptions{
@@ -597,9 +690,19 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			isPreserve:       preserve,
 			multipartSize:    multipartSize,
 			multipartThreads: uint(multipartThreads),
+			// Upload the parts of streams of unknown size in parallel
+			// too, when asked explicitly.
+			concurrentStream: globalPartConcurrency > 1,
+			symlinkRoot:      urls.symlinkRoot,
+		}
+		if resumable && length > 0 {
+			putOpts.resumable = true
+			putOpts.resumeOffset = resumeOffset
+			putOpts.verifyMD5 = downloadMD5(urls.SourceContent.ETag, metadata, srcSSE)
+			length -= resumeOffset
 		}
 
-		if isReadAt(reader) {
+		if isReadAt(reader) || length < 0 {
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, reader, length, progress, putOpts)
 		} else {
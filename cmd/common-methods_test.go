@@ -20,6 +20,7 @@ package cmd
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -69,3 +70,43 @@ func TestGetDecodedKey(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterMetadata(t *testing.T) {
+	metadata := filterMetadata(map[string]string{
+		"Content-Type":                       "text/plain",
+		"X-Amz-Meta-Owner":                   "alice",
+		"X-Amz-Server-Side-Encryption":       "AES256",
+		"X-Amz-Checksum-Crc32c":              "yZRlqg==-3",
+		"x-amz-checksum-sha256":              "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+		"X-Amz-Meta-Invalid\nHeader":         "value",
+		"X-Amz-Server-Side-Encryption-Bogus": "value",
+	})
+	expected := map[string]string{
+		"Content-Type":     "text/plain",
+		"X-Amz-Meta-Owner": "alice",
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Fatalf("expected %v, got %v", expected, metadata)
+	}
+}
+
+func TestProbeContentType(t *testing.T) {
+	testCases := []struct {
+		content   string
+		sniffText bool
+		ctype     string
+	}{
+		{"hello world", false, "application/octet-stream"},
+		{"hello world", true, "text/plain; charset=utf-8"},
+		{"%PDF-1.4", false, "application/pdf"},
+	}
+	for i, testCase := range testCases {
+		ctype, err := probeContentType(strings.NewReader(testCase.content), testCase.sniffText)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if ctype != testCase.ctype {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.ctype, ctype)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+)
+
+// incompressibleContentTypes lists the content-types, or their prefix, of
+// formats already compressed which are not worth compressing again.
+var incompressibleContentTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"font/woff",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/x-bzip2",
+	"application/x-xz",
+	"application/x-7z-compressed",
+	"application/x-rar-compressed",
+	"application/vnd.rar",
+	"application/zstd",
+	"application/x-zstd",
+	"application/x-compress",
+	"application/java-archive",
+	"application/pdf",
+}
+
+// isCompressibleContentType returns false for content-types of compressed formats.
+func isCompressibleContentType(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	// SVG images are text.
+	if contentType == "image/svg+xml" {
+		return true
+	}
+	for _, incompressible := range incompressibleContentTypes {
+		if strings.HasPrefix(contentType, incompressible) {
+			return false
+		}
+	}
+	return true
+}
+
+// isGzipContentEncoding returns true if the metadata has the gzip content-encoding.
+func isGzipContentEncoding(metadata map[string]string) bool {
+	return strings.EqualFold(strings.TrimSpace(metadata["Content-Encoding"]), "gzip")
+}
+
+// newGzipCompressReader returns a reader of the gzip compressed content of r,
+// compressed while it is read. Closing it stops the compression.
+func newGzipCompressReader(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		gw := gzip.NewWriter(pw)
+		_, e := io.Copy(gw, r)
+		if e == nil {
+			e = gw.Close()
+		}
+		pw.CloseWithError(e)
+	}()
+	return pr
+}
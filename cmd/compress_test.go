@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestIsCompressibleContentType(t *testing.T) {
+	testCases := []struct {
+		contentType  string
+		compressible bool
+	}{
+		{"text/plain; charset=utf-8", true},
+		{"application/json", true},
+		{"application/octet-stream", true},
+		{"image/svg+xml", true},
+		{"image/jpeg", false},
+		{"video/mp4", false},
+		{"Application/Zip", false},
+		{"application/gzip", false},
+	}
+	for i, testCase := range testCases {
+		if compressible := isCompressibleContentType(testCase.contentType); compressible != testCase.compressible {
+			t.Errorf("Test %d: %s expected %v, got %v", i+1, testCase.contentType, testCase.compressible, compressible)
+		}
+	}
+}
+
+func TestGzipCompressReader(t *testing.T) {
+	data := strings.Repeat("mc compresses text-heavy objects on upload\n", 10000)
+
+	rc := newGzipCompressReader(strings.NewReader(data))
+	defer rc.Close()
+	compressed, e := io.ReadAll(rc)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(compressed) >= len(data) {
+		t.Fatalf("Expected compressed data, got %d bytes out of %d", len(compressed), len(data))
+	}
+
+	gz, e := gzip.NewReader(bytes.NewReader(compressed))
+	if e != nil {
+		t.Fatal(e)
+	}
+	decompressed, e := io.ReadAll(gz)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if string(decompressed) != data {
+		t.Fatal("Decompressed data does not match the original data")
+	}
+}
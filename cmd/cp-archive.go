@@ -0,0 +1,448 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	archiveTar = "tar"
+	archiveZip = "zip"
+)
+
+// errArchiveNotRegular is reported for the links and special
+// files of an archive, only regular files are extracted.
+var errArchiveNotRegular = errors.New("only regular files are extracted")
+
+// archiveMessage container for a folder archived into
+// an object, or an archive extracted to a folder.
+type archiveMessage struct {
+	Status  string `json:"status"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Format  string `json:"format"`
+	Files   int64  `json:"files"`
+	Size    int64  `json:"size"`
+	Extract bool   `json:"extract,omitempty"`
+}
+
+func (a archiveMessage) String() string {
+	if a.Extract {
+		return console.Colorize("Copy", fmt.Sprintf("Extracted %d file(s), %s, from `%s` to `%s`.",
+			a.Files, humanize.IBytes(uint64(a.Size)), a.Source, a.Target))
+	}
+	return console.Colorize("Copy", fmt.Sprintf("Archived %d file(s) from `%s` into `%s`, %s %s.",
+		a.Files, a.Source, a.Target, humanize.IBytes(uint64(a.Size)), a.Format))
+}
+
+func (a archiveMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// archiveFormatOf returns the format of an archive from its name.
+func archiveFormatOf(name string) (format string, gzipped bool) {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return archiveZip, false
+	case strings.HasSuffix(name, ".tar"):
+		return archiveTar, false
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return archiveTar, true
+	}
+	return "", false
+}
+
+func checkCopyArchiveSyntax(cliCtx *cli.Context, args []string) {
+	format, extract := cliCtx.String("archive"), cliCtx.Bool("extract")
+	switch {
+	case format != "" && extract:
+		fatalIf(errInvalidArgument().Trace(args...), "`--archive` cannot be used with `--extract`.")
+	case format != "" && format != archiveTar && format != archiveZip:
+		fatalIf(errInvalidArgument().Trace(format), "`--archive` must be `tar` or `zip`.")
+	case len(args) != 2:
+		fatalIf(errInvalidArgument().Trace(args...), "`--archive` and `--extract` take exactly one source and one target.")
+	case cliCtx.String("from-file") != "" || cliCtx.Bool("continue") || cliCtx.Bool("zip") || cliCtx.Bool("store-symlinks") || cliCtx.String("list-cache") != "":
+		fatalIf(errInvalidArgument().Trace(args...), "`--archive` and `--extract` cannot be used with `--from-file`, `--continue`, `--zip`, `--store-symlinks` or `--list-cache`.")
+	}
+
+	source, target := args[0], args[1]
+	if format != "" {
+		clnt, err := newClient(source)
+		if err != nil || clnt.GetURL().Type != fileSystem {
+			fatalIf(errInvalidArgument().Trace(source), "`--archive` requires a local source folder.")
+		}
+		if st, e := os.Stat(source); e != nil || !st.IsDir() {
+			fatalIf(errInvalidArgument().Trace(source), "`--archive` requires a local source folder.")
+		}
+		if strings.HasSuffix(target, "/") || strings.HasSuffix(target, string(os.PathSeparator)) {
+			fatalIf(errInvalidArgument().Trace(target), "`--archive` requires the name of the target object, e.g. mybucket/archive.tar.")
+		}
+		return
+	}
+
+	if f, _ := archiveFormatOf(source); f == "" {
+		fatalIf(errInvalidArgument().Trace(source), "`--extract` supports .tar, .tar.gz, .tgz and .zip objects.")
+	}
+	if clnt, err := newClient(target); err != nil || clnt.GetURL().Type != fileSystem {
+		fatalIf(errInvalidArgument().Trace(target), "`--extract` requires a local target folder.")
+	}
+}
+
+// archiveWriter adds files to a tar or a zip archive. Without preserve,
+// the mode and ownership of the files are not kept and their time is the
+// time of the archive.
+type archiveWriter interface {
+	add(name string, fi os.FileInfo, r io.Reader) error
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	*tar.Writer
+	preserve bool
+	modTime  time.Time
+}
+
+func (t tarArchiveWriter) add(name string, fi os.FileInfo, r io.Reader) error {
+	hdr, e := tar.FileInfoHeader(fi, "")
+	if e != nil {
+		return e
+	}
+	hdr.Name = name
+	if !t.preserve {
+		hdr.Mode = 0o644
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		hdr.ModTime, hdr.AccessTime, hdr.ChangeTime = t.modTime, time.Time{}, time.Time{}
+	}
+	if e = t.WriteHeader(hdr); e != nil {
+		return e
+	}
+	// The header records the size, a file which changed size fails.
+	_, e = io.CopyN(t.Writer, r, hdr.Size)
+	return e
+}
+
+type zipArchiveWriter struct {
+	*zip.Writer
+	preserve bool
+	modTime  time.Time
+}
+
+func (z zipArchiveWriter) add(name string, fi os.FileInfo, r io.Reader) error {
+	hdr, e := zip.FileInfoHeader(fi)
+	if e != nil {
+		return e
+	}
+	hdr.Name = name
+	hdr.Method = zip.Deflate
+	if !z.preserve {
+		hdr.SetMode(0o644)
+		hdr.Modified = z.modTime
+	}
+	w, e := z.CreateHeader(hdr)
+	if e != nil {
+		return e
+	}
+	_, e = io.Copy(w, r)
+	return e
+}
+
+func newArchiveWriter(w io.Writer, format string, preserve bool) archiveWriter {
+	if format == archiveZip {
+		return zipArchiveWriter{Writer: zip.NewWriter(w), preserve: preserve, modTime: time.Now()}
+	}
+	return tarArchiveWriter{Writer: tar.NewWriter(w), preserve: preserve, modTime: time.Now()}
+}
+
+// archiveFolder writes the files below a local folder into an archive,
+// the symlinks are listed as cp lists them. Empty folders are not kept.
+func archiveFolder(ctx context.Context, w io.Writer, format, dir string, preserve bool, symlinks SymlinkOpt, links *copySymlinks) (files int64, err *probe.Error) {
+	dir = filepath.Clean(dir)
+	clnt, err := newClient(dir + string(os.PathSeparator))
+	if err != nil {
+		return 0, err.Trace(dir)
+	}
+	aw := newArchiveWriter(w, format, preserve)
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone, Symlinks: symlinks}) {
+		if content.Err != nil {
+			if _, ok := content.Err.ToGoError().(SkippedSymlink); ok {
+				links.skip(content.Err)
+				continue
+			}
+			return files, content.Err.Trace(dir)
+		}
+		if content.Type&os.ModeSymlink != 0 {
+			// A followed symlink, its target is listed next.
+			links.follow()
+			continue
+		}
+		fp := content.URL.Path
+		rel, e := filepath.Rel(dir, fp)
+		if e != nil {
+			return files, probe.NewError(e).Trace(fp)
+		}
+		if e = archiveFile(aw, filepath.ToSlash(rel), fp); e != nil {
+			return files, probe.NewError(e).Trace(fp)
+		}
+		files++
+	}
+	return files, probe.NewError(aw.Close())
+}
+
+func archiveFile(aw archiveWriter, name, fp string) error {
+	f, e := os.Open(fp)
+	if e != nil {
+		return e
+	}
+	defer f.Close()
+	fi, e := f.Stat()
+	if e != nil {
+		return e
+	}
+	return aw.add(name, fi, f)
+}
+
+// copyArchive uploads a local folder as a single tar or zip object, the
+// archive is streamed without a local temporary file.
+func copyArchive(ctx context.Context, cliCtx *cli.Context, source, target string, encKeyDB map[string][]prefixSSEPair) error {
+	format := cliCtx.String("archive")
+	symlinks := copySymlinkOpt(cliCtx.Bool("follow-symlinks"), cliCtx.Bool("no-follow-external"), false, false)
+	links := &copySymlinks{}
+
+	pr, pw := io.Pipe()
+	var files int64
+	archiveErrCh := make(chan *probe.Error, 1)
+	go func() {
+		n, err := archiveFolder(ctx, pw, format, source, cliCtx.Bool("preserve"), symlinks, links)
+		files = n
+		if err != nil {
+			pw.CloseWithError(err.ToGoError())
+		} else {
+			pw.Close()
+		}
+		archiveErrCh <- err
+	}()
+
+	var reader io.Reader = pr
+	var pg *progressBar
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+		reader = io.TeeReader(pr, pg)
+	}
+	counter := &archiveCounter{Reader: reader}
+	reader = counter
+
+	alias, _ := url2Alias(target)
+	_, err := putTargetStreamWithURL(target, reader, -1, PutOptions{
+		sse:          getSSE(target, encKeyDB[alias]),
+		storageClass: cliCtx.String("storage-class"),
+	})
+	// Stop the archive if the upload failed.
+	pr.Close()
+	archiveErr := <-archiveErrCh
+	if pg != nil {
+		pg.Finish()
+	}
+	// A failed upload closes the pipe and fails the archive as well, report
+	// the upload error first. A failed archive fails the upload with its error.
+	fatalIf(err.Trace(source, target), "Unable to upload the archive to `"+target+"`.")
+	fatalIf(archiveErr, "Unable to archive `"+source+"`.")
+
+	if summary := links.summary(); summary != nil {
+		printMsg(summary)
+	}
+	printMsg(archiveMessage{Source: source, Target: target, Format: format, Files: files, Size: atomic.LoadInt64(&counter.n)})
+	return nil
+}
+
+// archiveCounter counts the bytes read from a reader.
+type archiveCounter struct {
+	io.Reader
+	n int64
+}
+
+func (c *archiveCounter) Read(p []byte) (int, error) {
+	n, e := c.Reader.Read(p)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, e
+}
+
+// archiveEntryPath returns the local path of an archive entry below dir,
+// entries escaping dir are refused.
+func archiveEntryPath(dir, name string) (string, error) {
+	clean := path.Clean(filepath.ToSlash(name))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", errors.New("it is outside of the target folder")
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// extractFile writes an archive entry to fp, with the mode, ownership
+// and time of the entry when preserve is set.
+func extractFile(fp string, r io.Reader, mode os.FileMode, uid, gid int, modTime time.Time, preserve bool) error {
+	if e := os.MkdirAll(filepath.Dir(fp), 0o777); e != nil {
+		return e
+	}
+	perm := os.FileMode(0o666)
+	if preserve {
+		perm = mode.Perm()
+	}
+	f, e := os.OpenFile(fp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if e != nil {
+		return e
+	}
+	if _, e = io.Copy(f, r); e != nil {
+		f.Close()
+		return e
+	}
+	if e = f.Close(); e != nil {
+		return e
+	}
+	if !preserve {
+		return nil
+	}
+	if e = os.Chmod(fp, mode.Perm()); e != nil {
+		return e
+	}
+	if uid >= 0 && gid >= 0 {
+		if e = os.Lchown(fp, uid, gid); e != nil && !os.IsPermission(e) {
+			return e
+		}
+	}
+	return os.Chtimes(fp, modTime, modTime)
+}
+
+// extractArchive extracts a tar or zip object to a local folder, only the
+// regular files are extracted.
+func extractArchive(ctx context.Context, cliCtx *cli.Context, source, target string, encKeyDB map[string][]prefixSSEPair) error {
+	format, gzipped := archiveFormatOf(source)
+	preserve := cliCtx.Bool("preserve")
+	target = filepath.Clean(target)
+
+	alias, _ := url2Alias(source)
+	clnt, err := newClient(source)
+	fatalIf(err.Trace(source), "Unable to initialize source `"+source+"`.")
+	sse := getSSE(source, encKeyDB[alias])
+	st, err := clnt.Stat(ctx, StatOptions{sse: sse})
+	fatalIf(err.Trace(source), "Unable to stat `"+source+"`.")
+	reader, err := clnt.Get(ctx, GetOptions{SSE: sse})
+	fatalIf(err.Trace(source), "Unable to download `"+source+"`.")
+	defer reader.Close()
+
+	msg := archiveMessage{Source: source, Target: target, Format: format, Extract: true}
+	skip := func(name string, reason error) {
+		errorIf(probe.NewError(reason).Trace(source, name), "Skipping `"+name+"`.")
+	}
+	extract := func(name string, r io.Reader, mode os.FileMode, uid, gid int, modTime time.Time, size int64) {
+		fp, e := archiveEntryPath(target, name)
+		if e != nil {
+			skip(name, e)
+			return
+		}
+		e = extractFile(fp, r, mode, uid, gid, modTime, preserve)
+		fatalIf(probe.NewError(e).Trace(source, fp), "Unable to extract `"+name+"`.")
+		msg.Files++
+		msg.Size += size
+	}
+
+	if format == archiveZip {
+		readerAt, ok := reader.(io.ReaderAt)
+		if !ok {
+			fatalIf(errInvalidArgument().Trace(source), "Unable to read `"+source+"` as a zip archive.")
+		}
+		zr, e := zip.NewReader(readerAt, st.Size)
+		fatalIf(probe.NewError(e).Trace(source), "Unable to read `"+source+"` as a zip archive.")
+		for _, zf := range zr.File {
+			if !zf.Mode().IsRegular() {
+				if !zf.Mode().IsDir() {
+					skip(zf.Name, errArchiveNotRegular)
+				}
+				continue
+			}
+			r, e := zf.Open()
+			fatalIf(probe.NewError(e).Trace(source, zf.Name), "Unable to extract `"+zf.Name+"`.")
+			extract(zf.Name, r, zf.Mode(), -1, -1, zf.Modified, int64(zf.UncompressedSize64))
+			r.Close()
+		}
+		printMsg(msg)
+		return nil
+	}
+
+	var r io.Reader = reader
+	if gzipped {
+		gz, e := gzip.NewReader(reader)
+		fatalIf(probe.NewError(e).Trace(source), "Unable to read `"+source+"` as a gzip stream.")
+		defer gz.Close()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, e := tr.Next()
+		if e == io.EOF {
+			break
+		}
+		fatalIf(probe.NewError(e).Trace(source), "Unable to read `"+source+"` as a tar archive.")
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			extract(hdr.Name, tr, os.FileMode(hdr.Mode), hdr.Uid, hdr.Gid, hdr.ModTime, hdr.Size)
+		case tar.TypeDir:
+		default:
+			skip(hdr.Name, errArchiveNotRegular)
+		}
+	}
+	printMsg(msg)
+	return nil
+}
+
+// mainCopyArchive archives a local folder into an object, or
+// extracts an archive object to a local folder.
+func mainCopyArchive(ctx context.Context, cliCtx *cli.Context, args []string, encKeyDB map[string][]prefixSSEPair) error {
+	checkCopyArchiveSyntax(cliCtx, args)
+
+	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SymlinkSkipped", color.New(color.FgYellow))
+
+	if cliCtx.Bool("extract") {
+		return extractArchive(ctx, cliCtx, args[0], args[1], encKeyDB)
+	}
+	return copyArchive(ctx, cliCtx, args[0], args[1], encKeyDB)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestArchiveFolder(t *testing.T) {
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	for name, data := range map[string]string{"a.txt": "hello", "sub/b.txt": "world"} {
+		fp := filepath.Join(dir, filepath.FromSlash(name))
+		if e := os.MkdirAll(filepath.Dir(fp), 0o755); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.WriteFile(fp, []byte(data), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	expected := map[string]string{"a.txt": "hello", "sub/b.txt": "world"}
+
+	for _, format := range []string{archiveTar, archiveZip} {
+		var buf bytes.Buffer
+		files, err := archiveFolder(context.Background(), &buf, format, dir, false, SymlinksSkip, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if files != 2 {
+			t.Errorf("%s: expected 2 files, got %d", format, files)
+		}
+
+		got := make(map[string]string)
+		var modes []os.FileMode
+		if format == archiveTar {
+			tr := tar.NewReader(&buf)
+			for {
+				hdr, e := tr.Next()
+				if e == io.EOF {
+					break
+				}
+				if e != nil {
+					t.Fatal(e)
+				}
+				data, _ := io.ReadAll(tr)
+				got[hdr.Name] = string(data)
+				modes = append(modes, os.FileMode(hdr.Mode))
+			}
+		} else {
+			zr, e := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if e != nil {
+				t.Fatal(e)
+			}
+			for _, zf := range zr.File {
+				r, _ := zf.Open()
+				data, _ := io.ReadAll(r)
+				got[zf.Name] = string(data)
+				modes = append(modes, zf.Mode().Perm())
+			}
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected %v, got %v", format, expected, got)
+		}
+		// The mode is not kept without --preserve.
+		for _, mode := range modes {
+			if mode != 0o644 {
+				t.Errorf("%s: expected mode 0644, got %o", format, mode)
+			}
+		}
+	}
+}
+
+func TestArchiveEntryPath(t *testing.T) {
+	var accepted []string
+	for _, name := range []string{"a.txt", "sub/./b.txt", "sub/../c.txt", "../d.txt", "/etc/passwd", "sub/../../e.txt", ".."} {
+		if fp, e := archiveEntryPath("/tmp/target", name); e == nil {
+			accepted = append(accepted, filepath.ToSlash(fp))
+		}
+	}
+	sort.Strings(accepted)
+	if expected := []string{"/tmp/target/a.txt", "/tmp/target/c.txt", "/tmp/target/sub/b.txt"}; !reflect.DeepEqual(accepted, expected) {
+		t.Errorf("Expected %v, got %v", expected, accepted)
+	}
+}
+
+func TestArchiveFormatOf(t *testing.T) {
+	testCases := map[string]string{"a.tar": "tar", "a.TGZ": "tar", "a.tar.gz": "tar", "a.zip": "zip", "a.gz": ""}
+	for name, expected := range testCases {
+		if format, _ := archiveFormatOf(name); format != expected {
+			t.Errorf("%s: expected %q, got %q", name, expected, format)
+		}
+	}
+}
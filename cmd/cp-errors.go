@@ -0,0 +1,219 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
+)
+
+// Categories of the errors reported by cp --skip-errors.
+const (
+	copyErrAccessDenied = "access-denied"
+	copyErrNotFound     = "not-found"
+	copyErrTransient    = "transient"
+	copyErrStalled      = "stalled"
+	copyErrTimedOut     = "timed-out"
+	copyErrOther        = "other"
+)
+
+// copyErrorCategory classifies an error, transient errors are
+// the ones which may succeed when the copy is retried.
+func copyErrorCategory(err *probe.Error) string {
+	e := err.ToGoError()
+	switch e.(type) {
+	case PathInsufficientPermission:
+		return copyErrAccessDenied
+	case PathNotFound, BrokenSymlink, ObjectMissing, BucketDoesNotExist:
+		return copyErrNotFound
+	case ObjectStalled:
+		return copyErrStalled
+	case ObjectTimedOut:
+		return copyErrTimedOut
+	}
+	if os.IsPermission(e) {
+		return copyErrAccessDenied
+	}
+	if os.IsNotExist(e) {
+		return copyErrNotFound
+	}
+
+	errResp := minio.ToErrorResponse(e)
+	switch errResp.Code {
+	case "AccessDenied", "AllAccessDisabled", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return copyErrAccessDenied
+	case "NoSuchKey", "NoSuchBucket", "NoSuchVersion":
+		return copyErrNotFound
+	case "SlowDown", "RequestTimeout", "InternalError", "ServiceUnavailable", "XMinioServerNotInitialized":
+		return copyErrTransient
+	}
+	if errResp.StatusCode >= 500 {
+		return copyErrTransient
+	}
+
+	// Match the network errors by type, syscall errors also implement net.Error.
+	var urlErr *url.Error
+	var opErr *net.OpError
+	if errors.As(e, &urlErr) || errors.As(e, &opErr) || errors.Is(e, io.ErrUnexpectedEOF) || errors.Is(e, context.DeadlineExceeded) {
+		return copyErrTransient
+	}
+	return copyErrOther
+}
+
+// copyFailure is an object which failed to be copied.
+type copyFailure struct {
+	source   string
+	target   string
+	category string
+}
+
+// copyErrorReport collects the failures of a copy run with --skip-errors,
+// a nil report records nothing.
+type copyErrorReport struct {
+	mutex    sync.Mutex
+	failures []copyFailure
+}
+
+// add records a failure, the source and target are empty when the
+// failure happened while listing the source.
+func (r *copyErrorReport) add(cpURLs URLs) {
+	if r == nil {
+		return
+	}
+	failure := copyFailure{category: copyErrorCategory(cpURLs.Error)}
+	if cpURLs.SourceContent != nil {
+		failure.source = filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path))
+	}
+	if cpURLs.TargetContent != nil {
+		failure.target = filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path))
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failures = append(r.failures, failure)
+}
+
+// empty returns true if no failure was recorded.
+func (r *copyErrorReport) empty() bool {
+	if r == nil {
+		return true
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.failures) == 0
+}
+
+// writeTo writes the failed objects as a copy manifest, one
+// 'source,target' line per object, to retry them with --from-file.
+func (r *copyErrorReport) writeTo(w io.Writer) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cw := csv.NewWriter(w)
+	for _, failure := range r.failures {
+		if failure.source == "" {
+			continue
+		}
+		record := []string{failure.source}
+		if failure.target != "" {
+			record = append(record, failure.target)
+		}
+		if e := cw.Write(record); e != nil {
+			return e
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeFile writes the failed objects to the given file.
+func (r *copyErrorReport) writeFile(errorFile string) *probe.Error {
+	f, e := os.Create(errorFile)
+	if e != nil {
+		return probe.NewError(e).Trace(errorFile)
+	}
+	if e = r.writeTo(f); e != nil {
+		f.Close()
+		return probe.NewError(e).Trace(errorFile)
+	}
+	if e = f.Close(); e != nil {
+		return probe.NewError(e).Trace(errorFile)
+	}
+	return nil
+}
+
+// message returns the summary of the failures.
+func (r *copyErrorReport) message(errorFile string) copyErrorReportMessage {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	msg := copyErrorReportMessage{
+		Status:     "error",
+		Failed:     len(r.failures),
+		Categories: make(map[string]int),
+		ErrorFile:  errorFile,
+	}
+	for _, failure := range r.failures {
+		msg.Categories[failure.category]++
+	}
+	return msg
+}
+
+// copyErrorReportMessage container for the summary of the objects which failed to be copied.
+type copyErrorReportMessage struct {
+	Status     string         `json:"status"`
+	Failed     int            `json:"failed"`
+	Categories map[string]int `json:"categories"`
+	ErrorFile  string         `json:"errorFile,omitempty"`
+}
+
+func (c copyErrorReportMessage) String() string {
+	var categories []string
+	for _, category := range []string{copyErrAccessDenied, copyErrNotFound, copyErrTransient, copyErrStalled, copyErrTimedOut, copyErrOther} {
+		if count := c.Categories[category]; count > 0 {
+			categories = append(categories, fmt.Sprintf("%d %s", count, category))
+		}
+	}
+
+	msg := fmt.Sprintf("Failed to copy %d object(s): %s.", c.Failed, strings.Join(categories, ", "))
+	if c.ErrorFile != "" {
+		msg += fmt.Sprintf(" Failed objects written to `%s`, retry them with `--from-file`.", c.ErrorFile)
+	}
+	return console.Colorize("CopyErrorReport", msg)
+}
+
+func (c copyErrorReportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
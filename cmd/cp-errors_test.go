@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestCopyErrorCategory(t *testing.T) {
+	testCases := []struct {
+		err      error
+		category string
+	}{
+		{PathInsufficientPermission{Path: "/tmp/a"}, copyErrAccessDenied},
+		{&os.PathError{Op: "open", Path: "/tmp/a", Err: os.ErrPermission}, copyErrAccessDenied},
+		{minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}, copyErrAccessDenied},
+		{PathNotFound{Path: "/tmp/a"}, copyErrNotFound},
+		{minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}, copyErrNotFound},
+		{minio.ErrorResponse{Code: "SlowDown", StatusCode: 503}, copyErrTransient},
+		{minio.ErrorResponse{Code: "XMinioStorageFull", StatusCode: 507}, copyErrTransient},
+		{&url.Error{Op: "Put", URL: "http://localhost:9000", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, copyErrTransient},
+		{&os.PathError{Op: "mkdir", Path: "/tmp/a", Err: os.ErrExist}, copyErrOther},
+		{errors.New("unexpected"), copyErrOther},
+	}
+	for i, testCase := range testCases {
+		if category := copyErrorCategory(probe.NewError(testCase.err)); category != testCase.category {
+			t.Errorf("Test %d: expected %s for %v, got %s", i+1, testCase.category, testCase.err, category)
+		}
+	}
+}
+
+func TestCopyErrorReport(t *testing.T) {
+	var nilReport *copyErrorReport
+	nilReport.add(URLs{Error: probe.NewError(errors.New("ignored"))})
+	if !nilReport.empty() {
+		t.Fatal("Expected an empty nil report")
+	}
+
+	report := &copyErrorReport{}
+	report.add(URLs{
+		SourceAlias:   "play",
+		SourceContent: &ClientContent{URL: *newClientURL("https://play.min.io/bucket/a,b")},
+		TargetContent: &ClientContent{URL: *newClientURL("/tmp/dir/a,b")},
+		Error:         probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}),
+	})
+	// A listing failure has no source.
+	report.add(URLs{Error: probe.NewError(PathNotFound{Path: "/tmp/src"})})
+
+	var buf bytes.Buffer
+	if e := report.writeTo(&buf); e != nil {
+		t.Fatal(e)
+	}
+	if expected := "\"play/bucket/a,b\",\"/tmp/dir/a,b\"\n"; buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+
+	msg := report.message("failed.csv")
+	if msg.Failed != 2 || msg.Categories[copyErrAccessDenied] != 1 || msg.Categories[copyErrNotFound] != 1 {
+		t.Errorf("Unexpected report %+v", msg)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/wildcard"
+)
+
+// hasSourceGlob returns true if the argument contains a wildcard
+// character that mc expands on its own.
+func hasSourceGlob(arg string) bool {
+	return strings.ContainsAny(arg, "*?")
+}
+
+// expandSourceGlobs expands wildcards in source arguments, the target
+// is always kept literally. The expansion is done by mc itself so that it
+// behaves the same whether or not the shell already expanded the pattern:
+//
+//   - '*' matches any sequence of characters and '?' matches a single one.
+//   - Wildcards are only allowed in the last path element, which is
+//     matched against the entries of its parent, without recursing.
+//   - Matching folders and prefixes are kept, they are copied only with --recursive.
+//   - A pattern that matches nothing is an error.
+//
+// Arguments without wildcards are returned as they are.
+func expandSourceGlobs(ctx context.Context, args []string) ([]string, *probe.Error) {
+	if len(args) < 2 {
+		return args, nil
+	}
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args[:len(args)-1] {
+		if !hasSourceGlob(arg) {
+			expanded = append(expanded, arg)
+			continue
+		}
+		matches, err := expandSourceGlob(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, matches...)
+	}
+	return append(expanded, args[len(args)-1]), nil
+}
+
+// splitSourceGlob splits a glob argument into its parent and the
+// pattern of its last path element.
+func splitSourceGlob(arg string) (parent, pattern string, err *probe.Error) {
+	separator := string(newClientURL(arg).Separator)
+	i := strings.LastIndex(arg, separator)
+	parent, pattern = arg[:i+1], arg[i+1:]
+	if hasSourceGlob(parent) || pattern == "" {
+		return "", "", errInvalidArgument().Trace(arg)
+	}
+	return parent, pattern, nil
+}
+
+func expandSourceGlob(ctx context.Context, arg string) ([]string, *probe.Error) {
+	parent, pattern, err := splitSourceGlob(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	listURL := parent
+	if listURL == "" {
+		listURL = "."
+	}
+	clnt, err := newClient(listURL)
+	if err != nil {
+		return nil, err.Trace(arg)
+	}
+
+	var matches []string
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirNone}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(arg)
+		}
+		separator := string(content.URL.Separator)
+		name := path.Base(strings.ReplaceAll(strings.TrimSuffix(content.URL.Path, separator), separator, "/"))
+		if !wildcard.Match(pattern, name) {
+			continue
+		}
+		if content.Type.IsDir() {
+			name += separator
+		}
+		matches = append(matches, parent+name)
+	}
+	if len(matches) == 0 {
+		return nil, probe.NewError(ObjectMissing{}).Trace(arg)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
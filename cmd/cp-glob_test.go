@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestExpandSourceGlobs(t *testing.T) {
+	// Local paths only, an empty config is enough.
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.log", "b.log", "c.txt", "literal*.csv"} {
+		if e := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e := os.Mkdir(filepath.Join(dir, "d.log"), 0o700); e != nil {
+		t.Fatal(e)
+	}
+	prefix := dir + string(filepath.Separator)
+
+	testCases := []struct {
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{[]string{prefix + "c.txt", "target/"}, []string{prefix + "c.txt", "target/"}, false},
+		{[]string{prefix + "*.log", "target*/"}, []string{prefix + "a.log", prefix + "b.log", prefix + "d.log" + string(filepath.Separator), "target*/"}, false},
+		{[]string{prefix + "?.txt", prefix + "literal*", "target/"}, []string{prefix + "c.txt", prefix + "literal*.csv", "target/"}, false},
+		{[]string{prefix + "*.none", "target/"}, nil, true},
+		{[]string{dir + "*" + string(filepath.Separator) + "a.log", "target/"}, nil, true},
+	}
+	for i, testCase := range testCases {
+		got, err := expandSourceGlobs(context.Background(), testCase.args)
+		if testCase.wantErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error, got %v", i+1, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(got, testCase.want) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+}
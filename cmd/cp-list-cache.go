@@ -0,0 +1,297 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+
+	gojson "encoding/json"
+)
+
+// copyListCacheVersion is the version of the list cache format.
+const copyListCacheVersion = 1
+
+// copyListCacheFlags are the flags consumed by prepareCopyURLsOpts, and the
+// flags changing the arguments or the copies prepared from them, a cache
+// recorded with other values is stale.
+var copyListCacheFlags = []string{
+	"recursive", "older-than", "newer-than", "newer-than-file", "rewind", "version-id", "zip",
+	"include-from", "exclude-from", "follow-symlinks", "no-follow-external", "store-symlinks",
+	"min-size", "max-size", "order", "tag-filter", "tag-filter-workers", "list-concurrency",
+	"no-glob", "compress", "metadata-charset",
+}
+
+// copyListCacheSliceFlags are the flags of copyListCacheFlags taking multiple values.
+var copyListCacheSliceFlags = map[string]bool{
+	"tag-filter": true,
+}
+
+// copyListCacheHeader is the first line of a list cache, it identifies
+// the copy command the listing was recorded for.
+type copyListCacheHeader struct {
+	Version int               `json:"version"`
+	Args    []string          `json:"args"`
+	Flags   map[string]string `json:"flags"`
+	Created time.Time         `json:"created"`
+}
+
+// newCopyListCacheHeader returns the header of the list cache of a copy command.
+func newCopyListCacheHeader(cliCtx *cli.Context, args []string) copyListCacheHeader {
+	header := copyListCacheHeader{
+		Version: copyListCacheVersion,
+		Args:    args,
+		Flags:   make(map[string]string),
+		Created: UTCNow(),
+	}
+	for _, name := range copyListCacheFlags {
+		if !cliCtx.IsSet(name) {
+			continue
+		}
+		value := cliCtx.String(name)
+		switch {
+		case copyListCacheSliceFlags[name]:
+			data, _ := gojson.Marshal(cliCtx.StringSlice(name))
+			value = string(data)
+		case name == "newer-than-file":
+			// The listing depends on the reference time, not on the file name.
+			if ref, err := readNewerThanFile(value); err == nil && !ref.IsZero() {
+				value += "@" + ref.UTC().Format(time.RFC3339Nano)
+			}
+		}
+		header.Flags[name] = value
+	}
+	return header
+}
+
+// matches returns true if the cache was recorded for the same copy command.
+func (h copyListCacheHeader) matches(other copyListCacheHeader) bool {
+	return h.Version == other.Version &&
+		reflect.DeepEqual(h.Args, other.Args) &&
+		reflect.DeepEqual(h.Flags, other.Flags)
+}
+
+// readCopyListCacheHeader reads the header of a list cache, returns false if
+// there is no cache.
+func readCopyListCacheHeader(path string) (header copyListCacheHeader, found bool, err *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return header, false, nil
+		}
+		return header, false, probe.NewError(e).Trace(path)
+	}
+	defer f.Close()
+
+	line, e := bufio.NewReader(f).ReadBytes('\n')
+	if e != nil {
+		return header, false, probe.NewError(e).Trace(path)
+	}
+	if e = gojson.Unmarshal(line, &header); e != nil {
+		return header, false, probe.NewError(e).Trace(path)
+	}
+	return header, true, nil
+}
+
+// readCopyListCache sends the copies recorded in a list cache which are not
+// done yet. Each copy references its key in the state to be marked as done,
+// and verifies that its source did not change since it was listed.
+func readCopyListCache(path string, state *copyManifestState) (<-chan URLs, *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		line := 0
+		for scanner.Scan() {
+			line++
+			// Skip the header.
+			if line == 1 {
+				continue
+			}
+			var cpURLs URLs
+			if e := gojson.Unmarshal(scanner.Bytes(), &cpURLs); e != nil {
+				copyURLsCh <- URLs{Error: probe.NewError(e).Trace(path)}
+				continue
+			}
+			cpURLs.ManifestKey = copyListCacheKey(cpURLs)
+			if state.isDone(cpURLs.ManifestKey) {
+				continue
+			}
+			cpURLs.verifySourceETag = true
+			copyURLsCh <- cpURLs
+		}
+		if e := scanner.Err(); e != nil {
+			copyURLsCh <- URLs{Error: probe.NewError(e).Trace(path)}
+		}
+	}()
+	return copyURLsCh, nil
+}
+
+// copyListCacheWriter records the copies prepared from a listing. The cache
+// is only committed once the listing is complete, an interrupted listing
+// leaves no cache behind.
+type copyListCacheWriter struct {
+	path string
+	f    *os.File
+	w    *bufio.Writer
+}
+
+// newCopyListCacheWriter starts recording a list cache with the given header.
+func newCopyListCacheWriter(path string, header copyListCacheHeader) (*copyListCacheWriter, *probe.Error) {
+	f, e := os.OpenFile(path+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	w := &copyListCacheWriter{path: path, f: f, w: bufio.NewWriter(f)}
+	if err := w.writeLine(header); err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *copyListCacheWriter) writeLine(v interface{}) *probe.Error {
+	data, e := gojson.Marshal(v)
+	if e != nil {
+		return probe.NewError(e).Trace(w.path)
+	}
+	if _, e = w.w.Write(append(data, '\n')); e != nil {
+		return probe.NewError(e).Trace(w.path)
+	}
+	return nil
+}
+
+// add records a prepared copy, and sets its key in the state of the cache.
+func (w *copyListCacheWriter) add(cpURLs *URLs) *probe.Error {
+	if err := w.writeLine(cpURLs); err != nil {
+		return err
+	}
+	cpURLs.ManifestKey = copyListCacheKey(*cpURLs)
+	return nil
+}
+
+// copyListCacheKey identifies a copy of the list cache in its state.
+func copyListCacheKey(cpURLs URLs) string {
+	return copyManifestKey(cpURLs.SourceContent.URL.String(), cpURLs.TargetContent.URL.String())
+}
+
+// commit makes the recorded listing the list cache.
+func (w *copyListCacheWriter) commit() *probe.Error {
+	if e := w.w.Flush(); e != nil {
+		w.abort()
+		return probe.NewError(e).Trace(w.path)
+	}
+	if e := w.f.Close(); e != nil {
+		os.Remove(w.f.Name())
+		return probe.NewError(e).Trace(w.path)
+	}
+	if e := os.Rename(w.f.Name(), w.path); e != nil {
+		return probe.NewError(e).Trace(w.path)
+	}
+	return nil
+}
+
+// abort discards the recorded listing.
+func (w *copyListCacheWriter) abort() {
+	w.f.Close()
+	os.Remove(w.f.Name())
+}
+
+// copyListCacheMessage reports whether the source listing is replayed from the list cache.
+type copyListCacheMessage struct {
+	Status   string    `json:"status"`
+	Cache    string    `json:"listCache"`
+	Created  time.Time `json:"created"`
+	Replayed bool      `json:"replayed"`
+	Stale    bool      `json:"stale,omitempty"`
+}
+
+func (m copyListCacheMessage) String() string {
+	switch {
+	case m.Replayed:
+		return console.Colorize("ListCache", fmt.Sprintf("Resuming from the listing recorded in `%s` on %s, use --refresh-cache to list the source again.",
+			m.Cache, m.Created.Local().Format(printDate)))
+	case m.Stale:
+		return console.Colorize("ListCache", fmt.Sprintf("The listing recorded in `%s` was made for different arguments, listing the source again.", m.Cache))
+	}
+	return console.Colorize("ListCache", fmt.Sprintf("Recording the listing of the source in `%s`.", m.Cache))
+}
+
+func (m copyListCacheMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// openCopyListCache returns the copies recorded in the list cache when it
+// matches the copy command, otherwise a writer to record a new listing. The
+// state of the copies already done is reset along with the listing.
+func openCopyListCache(cliCtx *cli.Context, args []string) (cachedURLs <-chan URLs, writer *copyListCacheWriter, state *copyManifestState, err *probe.Error) {
+	path := cliCtx.String("list-cache")
+	header := newCopyListCacheHeader(cliCtx, args)
+	msg := copyListCacheMessage{Cache: path, Created: header.Created}
+
+	if !cliCtx.Bool("refresh-cache") {
+		cached, found, err := readCopyListCacheHeader(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if found && cached.matches(header) {
+			if state, err = loadCopyManifestState(path); err != nil {
+				return nil, nil, nil, err
+			}
+			if cachedURLs, err = readCopyListCache(path, state); err != nil {
+				state.Close()
+				return nil, nil, nil, err
+			}
+			msg.Created, msg.Replayed = cached.Created, true
+			printMsg(msg)
+			return cachedURLs, nil, state, nil
+		}
+		msg.Stale = found
+	}
+
+	if e := os.Remove(path + copyManifestStateSuffix); e != nil && !os.IsNotExist(e) {
+		return nil, nil, nil, probe.NewError(e).Trace(path)
+	}
+	if state, err = loadCopyManifestState(path); err != nil {
+		return nil, nil, nil, err
+	}
+	if writer, err = newCopyListCacheWriter(path, header); err != nil {
+		state.Close()
+		return nil, nil, nil, err
+	}
+	printMsg(msg)
+	return nil, writer, state, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+func TestCopyListCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	header := copyListCacheHeader{
+		Version: copyListCacheVersion,
+		Args:    []string{"play/mybucket/", "s3/mybucket/"},
+		Flags:   map[string]string{"recursive": "true"},
+	}
+
+	// An aborted listing leaves no cache behind.
+	w, err := newCopyListCacheWriter(path, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.abort()
+	if _, found, err := readCopyListCacheHeader(path); err != nil || found {
+		t.Fatalf("Expected no cache after abort, got %v, %v", found, err)
+	}
+
+	w, err = newCopyListCacheWriter(path, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, key := range []string{"a", "b", "c"} {
+		cpURLs := URLs{
+			SourceAlias:   "play",
+			SourceContent: &ClientContent{URL: *newClientURL("https://play.min.io/mybucket/" + key), Size: 10, ETag: "etag-" + key},
+			TargetAlias:   "s3",
+			TargetContent: &ClientContent{URL: *newClientURL("https://s3.amazonaws.com/mybucket/" + key)},
+		}
+		if err := w.add(&cpURLs); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, cpURLs.ManifestKey)
+	}
+	if expected := copyManifestKey("https://play.min.io/mybucket/b", "https://s3.amazonaws.com/mybucket/b"); keys[1] != expected {
+		t.Fatalf("Expected key %s, got %s", expected, keys[1])
+	}
+	if err := w.commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, e := os.Stat(path + ".tmp"); !os.IsNotExist(e) {
+		t.Errorf("Expected the temporary cache to be renamed, got %v", e)
+	}
+
+	cached, found, err := readCopyListCacheHeader(path)
+	if err != nil || !found {
+		t.Fatalf("Expected the cache header, got %v, %v", found, err)
+	}
+	if !cached.matches(header) {
+		t.Errorf("Expected the cache to match %+v, got %+v", header, cached)
+	}
+	other := header
+	other.Args = []string{"play/mybucket/", "s3/other/"}
+	if cached.matches(other) {
+		t.Error("Expected the cache not to match other arguments")
+	}
+
+	state, err := loadCopyManifestState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer state.Close()
+	if err := state.markDone(keys[1]); err != nil {
+		t.Fatal(err)
+	}
+	cachedURLs, err := readCopyListCache(path, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var etags []string
+	for cpURLs := range cachedURLs {
+		if cpURLs.Error != nil {
+			t.Fatal(cpURLs.Error)
+		}
+		if !cpURLs.verifySourceETag {
+			t.Errorf("Expected the source ETag of %s to be verified", cpURLs.ManifestKey)
+		}
+		etags = append(etags, cpURLs.SourceContent.ETag)
+	}
+	if expected := []string{"etag-a", "etag-c"}; !reflect.DeepEqual(etags, expected) {
+		t.Errorf("Expected the copies not done %v, got %v", expected, etags)
+	}
+}
+
+func TestNewCopyListCacheHeader(t *testing.T) {
+	refFile := filepath.Join(t.TempDir(), ".last-run")
+	newHeader := func(args ...string) copyListCacheHeader {
+		set := flag.NewFlagSet("cp", flag.ContinueOnError)
+		for _, f := range append(append([]cli.Flag{}, cpFlags...), tagFilterFlags...) {
+			f.Apply(set)
+		}
+		if e := set.Parse(args); e != nil {
+			t.Fatal(e)
+		}
+		return newCopyListCacheHeader(cli.NewContext(nil, set, nil), []string{"play/mybucket/", "s3/mybucket/"})
+	}
+
+	header := newHeader("--recursive", "--tag-filter", "env=prod", "--tag-filter", "team=ml", "--min-size", "1MiB")
+	expected := map[string]string{
+		"recursive":  "true",
+		"tag-filter": `["env=prod","team=ml"]`,
+		"min-size":   "1MiB",
+	}
+	if !reflect.DeepEqual(header.Flags, expected) {
+		t.Fatalf("Expected flags %v, got %v", expected, header.Flags)
+	}
+
+	for _, args := range [][]string{
+		{"--recursive", "--tag-filter", "env=prod"},
+		{"--recursive", "--tag-filter", "env=prod", "--tag-filter", "team=ml", "--min-size", "1MiB", "--store-symlinks"},
+		{"--recursive", "--tag-filter", "env=prod", "--tag-filter", "team=ml", "--min-size", "1MiB", "--no-glob"},
+		{"--recursive", "--tag-filter", "env=prod", "--tag-filter", "team=ml", "--min-size", "1MiB", "--metadata-charset", "iso-8859-1"},
+	} {
+		if other := newHeader(args...); header.matches(other) {
+			t.Errorf("Expected %v not to match %v", args, header.Flags)
+		}
+	}
+
+	// A new reference time of --newer-than-file makes the cache stale.
+	if err := touchNewerThanFile(refFile, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	first := newHeader("--recursive", "--newer-than-file", refFile)
+	if err := touchNewerThanFile(refFile, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if first.matches(newHeader("--recursive", "--newer-than-file", refFile)) {
+		t.Error("Expected a new reference time to make the cache stale")
+	}
+}
@@ -25,13 +25,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/fatih/color"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/console"
 )
 
@@ -58,9 +61,21 @@ var (
 			Name:  "newer-than",
 			Usage: "copy objects newer than value in duration string (e.g. 7d10h31s)",
 		},
+		cli.StringFlag{
+			Name:  "newer-than-file",
+			Usage: "copy objects modified after the modification time of a file, set to the start of the copy on success",
+		},
+		cli.StringFlag{
+			Name:  "min-size",
+			Usage: "copy objects of at least this size, e.g. 64KiB",
+		},
+		cli.StringFlag{
+			Name:  "max-size",
+			Usage: "copy objects of at most this size, e.g. 1GiB",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
-			Usage: "set storage class for new object(s) on target",
+			Usage: "set storage class for new object(s) on target, validated for Amazon S3 targets",
 		},
 		cli.StringFlag{
 			Name:  "encrypt",
@@ -83,8 +98,8 @@ var (
 			Usage: "disable multipart upload feature",
 		},
 		cli.BoolFlag{
-			Name:  "md5",
-			Usage: "force all upload(s) to calculate md5sum checksum",
+			Name:  "md5, content-md5",
+			Usage: "send the Content-MD5 of each object, or of each part, for the server to verify the uploaded data",
 		},
 		cli.StringFlag{
 			Name:  "tags",
@@ -102,10 +117,142 @@ var (
 			Name:  lhFlag,
 			Usage: "apply legal hold to the copied object (on, off)",
 		},
+		cli.StringFlag{
+			Name:  "retention",
+			Usage: "apply retention to the copied object as MODE:DURATION (e.g. governance:30d)",
+		},
 		cli.BoolFlag{
 			Name:  "zip",
 			Usage: "Extract from remote zip file (MinIO server source only)",
 		},
+		cli.StringFlag{
+			Name:  "from-file",
+			Usage: "copy the objects listed in a manifest file, one 'source[,target]' per line",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "fail on malformed lines in the manifest instead of skipping them",
+		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "exclude object(s) that match any pattern in the specified file",
+		},
+		cli.StringFlag{
+			Name:  "include-from",
+			Usage: "include only object(s) that match any pattern in the specified file",
+		},
+		cli.StringFlag{
+			Name:  "content-type-map",
+			Usage: "override content-type detection with 'content-type ext1 ext2 ...' lines from the specified file",
+		},
+		cli.StringFlag{
+			Name:  "metadata-from-json",
+			Usage: "apply content-type, cache-control, user metadata and tags from the specified JSON document, overridden by --attr and --tags",
+		},
+		cli.StringFlag{
+			Name:  "progress",
+			Usage: "progress reporting style (bar, plain, none), defaults to bar on a terminal and plain otherwise",
+		},
+		cli.BoolFlag{
+			Name:  "no-sniff",
+			Usage: "do not detect content-type from file content when its extension is not recognized",
+		},
+		cli.BoolFlag{
+			Name:  "compress",
+			Usage: "gzip compress objects on upload, except already compressed formats, and set 'Content-Encoding: gzip'",
+		},
+		cli.BoolFlag{
+			Name:  "no-decompress",
+			Usage: "do not decompress objects with 'Content-Encoding: gzip' downloaded to the local filesystem",
+		},
+		cli.BoolFlag{
+			Name:  "skip-errors, continue-on-error",
+			Usage: "report the objects which fail to be copied and continue with the others",
+		},
+		cli.StringFlag{
+			Name:  "error-file",
+			Usage: "with --skip-errors, write the objects which failed to be copied to a file usable with --from-file",
+		},
+		cli.BoolFlag{
+			Name:  "follow-symlinks",
+			Usage: "follow symlinks of a local folder copied recursively, skipped by default",
+		},
+		cli.BoolFlag{
+			Name:  "no-follow-external",
+			Usage: "with --follow-symlinks, skip symlinks pointing outside of the source folder",
+		},
+		cli.BoolFlag{
+			Name:  "store-symlinks",
+			Usage: "upload the symlinks of a local folder copied recursively as empty objects with their target, recreated by downloads with --preserve",
+		},
+		cli.IntFlag{
+			Name:  "part-retries",
+			Value: minio.MaxRetry - 1,
+			Usage: "retry every failed request of the copy N times with backoff, a failed part of a multipart upload is retried alone",
+		},
+		cli.IntFlag{
+			Name:  "part-concurrency",
+			Usage: "upload N parts of each multipart object in parallel, buffering up to N x part size in memory (default: 4)",
+		},
+		cli.StringFlag{
+			Name:  "list-cache",
+			Usage: "record the source listing of a recursive copy in a file, and resume from it instead of listing again",
+		},
+		cli.BoolFlag{
+			Name:  "refresh-cache",
+			Usage: "list the source again and replace the listing recorded by --list-cache",
+		},
+		cli.StringFlag{
+			Name:  "order",
+			Value: copyOrderNone,
+			Usage: "order of the objects copied recursively (none, lexical, mtime, size), any order but none buffers the whole listing in memory",
+		},
+		cli.BoolFlag{
+			Name:  "auto-parallel",
+			Usage: "adjust the number of objects copied in parallel to the measured throughput, backing off when the server throttles",
+		},
+		cli.IntFlag{
+			Name:  "list-concurrency",
+			Value: 1,
+			Usage: "number of prefixes listed in parallel by a recursive copy from S3, the objects are listed in no particular order",
+		},
+		cli.BoolFlag{
+			Name:  "no-glob",
+			Usage: "treat '*' and '?' in source arguments literally instead of expanding them",
+		},
+		cli.BoolFlag{
+			Name:  "metadata-sync",
+			Usage: "only update the metadata of the targets with the same content as their source, with a server side copy",
+		},
+		cli.BoolFlag{
+			Name:  "no-overwrite-newer",
+			Usage: "skip the objects whose target was modified after the source, checking the target before each copy",
+		},
+		cli.DurationFlag{
+			Name:  "stall-timeout",
+			Usage: "abort the copy of an object when no data was transferred for the given duration, e.g. 2m",
+		},
+		cli.DurationFlag{
+			Name:  "object-timeout",
+			Usage: "abort the copy of an object taking longer than the given duration, e.g. 1h",
+		},
+		cli.StringFlag{
+			Name:  "archive",
+			Usage: "upload a local folder as a single 'tar' or 'zip' object, streamed without a temporary file",
+		},
+		cli.BoolFlag{
+			Name:  "extract",
+			Usage: "extract a .tar, .tar.gz, .tgz or .zip object to a local folder",
+		},
+		cli.DurationFlag{
+			Name:  "retry-budget",
+			Usage: "cap the total time spent retrying failed requests, later failures are not retried, e.g. 10m",
+		},
+		cli.StringFlag{
+			Name:  "metadata-charset",
+			Value: metadataCharsetUTF8,
+			Usage: "charset of the non ASCII metadata values: utf-8, RFC 2047 encoded, or iso-8859-1 for legacy backends",
+		},
 	}
 )
 
@@ -125,12 +272,13 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(cpFlags, ioFlags...), listFlags...), tagFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --from-file MANIFEST [TARGET]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -138,6 +286,31 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT:      list of comma delimited prefixes
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+  MC_UPLOAD_MULTIPART_THREADS: default number of parts uploaded in parallel, overridden by --part-concurrency
+
+PART CONCURRENCY:
+  --part-concurrency controls how many parts of a single multipart object are uploaded
+  in parallel. It speeds up the transfer of huge objects, independently of how many
+  objects are transferred at once. Each upload buffers up to part size x part concurrency
+  in memory, where the part size is set by MC_UPLOAD_MULTIPART_SIZE or computed from the
+  object size. Lower either one when memory is constrained.
+
+LIST CACHE:
+  --list-cache FILE records the copies prepared from the source listing in FILE, with the
+  key, size and ETag of every object, once the listing completes. Copies done are tracked
+  in FILE.state. A new run with the same arguments skips the listing and only copies the
+  objects not done yet, failing for any object whose ETag changed since it was listed.
+  A cache recorded with different arguments is ignored, use --refresh-cache to list the
+  source again anyway.
+
+WILDCARDS:
+  '*' and '?' in SOURCE arguments are expanded by mc itself, on every platform and
+  regardless of the shell. Quote patterns so that the shell does not expand them first.
+  '*' matches any sequence of characters and '?' matches a single character. Wildcards
+  are only allowed in the last path element, which is matched against the entries of
+  its parent folder without recursing. Matching folders are copied only with --recursive.
+  A pattern that matches nothing is an error. TARGET is never expanded. Use --no-glob
+  to copy keys that contain '*' or '?' literally.
 
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -192,15 +365,123 @@ EXAMPLES:
   17. Copy a text file to an object storage with legal-hold enabled.
       {{.Prompt}} {{.HelpName}} --legal-hold on locked.txt play/locked-bucket/
 
-  18. Copy a text file to an object storage and disable multipart upload feature.
+  18. Copy a text file to an object storage with legal-hold enabled and object lock mode set to 'GOVERNANCE' for 30 days.
+      {{.Prompt}} {{.HelpName}} --legal-hold on --retention governance:30d locked.txt play/locked-bucket/
+
+  19. Copy a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
 
-  19. Roll back 10 days in the past to copy the content of 'mybucket'
+  20. Roll back 10 days in the past to copy the content of 'mybucket'
       {{.Prompt}} {{.HelpName}} --rewind 10d -r play/mybucket/ /tmp/dest/
 
-  20. Set tags to the uploaded objects
+  21. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod&type=backup" ./data/ play/another-bucket/
 
+  22. Copy a folder recursively, skipping all object name patterns listed in 'excludes.txt'.
+      {{.Prompt}} {{.HelpName}} -r --exclude-from excludes.txt ./data/ play/another-bucket/
+
+  23. Copy the objects listed in 'manifest.csv', lines without a target are copied under 'play/archive/'.
+      Copied objects are recorded in 'manifest.csv.state' and skipped when the command is run again
+      after a failure, the state is removed once all of them are copied.
+      {{.Prompt}} {{.HelpName}} --from-file manifest.csv play/archive/
+
+  24. Copy a website recursively, using the content-types listed in 'mime.types' for the extensions it contains.
+      {{.Prompt}} {{.HelpName}} --recursive --content-type-map mime.types ./public/ play/website/
+
+  25. Copy a folder recursively, applying the metadata and tags of 'metadata.json' to every object.
+      The document accepts contentType, cacheControl, contentDisposition, contentEncoding,
+      contentLanguage, expires, userMetadata and tags keys.
+      {{.Prompt}} {{.HelpName}} --recursive --metadata-from-json metadata.json ./reports/ play/mybucket/reports/
+
+  26. Copy a folder recursively from a cron job, printing a plain progress line every few seconds.
+      Plain progress is the default when the output is not a terminal.
+      {{.Prompt}} {{.HelpName}} --recursive --progress plain ./backups/ play/mybucket/backups/
+
+  27. Copy a folder of logs recursively, compressing them with gzip on upload.
+      Objects are decompressed when copied back to the local filesystem, unless --no-decompress is given.
+      {{.Prompt}} {{.HelpName}} --recursive --compress ./logs/ play/mybucket/logs/
+
+  28. Copy a local folder recursively, uploading the content of its symlinks unless they point outside of the folder.
+      Symlinks are skipped with a warning without --follow-symlinks, links creating a loop are always skipped.
+      {{.Prompt}} {{.HelpName}} --recursive --follow-symlinks --no-follow-external ./website/ play/mybucket/website/
+
+  29. Copy a folder recursively, continuing past the objects which cannot be copied, then retry only the failed ones.
+      {{.Prompt}} {{.HelpName}} --recursive --skip-errors --error-file failed.csv ./data/ play/mybucket/data/
+      {{.Prompt}} {{.HelpName}} --from-file failed.csv
+
+  30. Copy a folder recursively, oldest objects first.
+      {{.Prompt}} {{.HelpName}} --recursive --order mtime play/mybucket/logs/ /mnt/logs/
+
+  31. Copy a large file over an unreliable network, retrying each failed request, and so each failed part, up to 20 times.
+      {{.Prompt}} {{.HelpName}} --part-retries 20 ./backup.tar play/mybucket/
+
+  32. Copy all the '.log' objects of a folder, the pattern is expanded by mc the same way on every platform.
+      {{.Prompt}} {{.HelpName}} 'play/mybucket/logs/*.log' /mnt/logs/
+
+  33. Copy an object whose name literally contains a '*'.
+      {{.Prompt}} {{.HelpName}} --no-glob 'play/mybucket/report*.csv' /tmp/
+
+  34. Copy only the objects tagged 'class=archive' and not owned by 'ops', fetching their tags with 32 workers.
+      {{.Prompt}} {{.HelpName}} --recursive --tag-filter "class=archive&owner!=ops" --tag-filter-workers 32 play/mybucket/ play/archive/
+
+  35. Copy a single huge file, uploading 16 of its parts in parallel.
+      {{.Prompt}} {{.HelpName}} --part-concurrency 16 ./backup.tar play/mybucket/
+
+  36. Copy a bucket of millions of objects, recording the listing so that an interrupted copy resumes without listing again.
+      {{.Prompt}} {{.HelpName}} --recursive --list-cache /tmp/mybucket.cache play/mybucket/ s3/mybucket/
+
+  37. Copy a folder recursively without replacing the objects which were updated at the target after the source.
+      {{.Prompt}} {{.HelpName}} --recursive --no-overwrite-newer ./docs/ play/mybucket/docs/
+
+  38. Copy a bucket recursively, only updating the metadata of the objects already copied with the same content.
+      Objects from or to a local filesystem are always copied.
+      {{.Prompt}} {{.HelpName}} --recursive --metadata-sync play/website/ s3/website/
+
+  39. Copy a bucket with a deep hierarchy of prefixes recursively, listing up to 16 prefixes in parallel.
+      {{.Prompt}} {{.HelpName}} --recursive --list-concurrency 16 play/datalake/ s3/datalake/
+
+  40. Copy a folder recursively, skipping the objects with no data transferred for 2 minutes or taking more than
+      an hour, and writing them to a file to retry them later.
+      {{.Prompt}} {{.HelpName}} --recursive --stall-timeout 2m --object-timeout 1h --skip-errors --error-file retry.csv ./data/ play/mybucket/data/
+
+  41. Copy a folder recursively to Amazon S3, letting S3 Intelligent-Tiering move the objects between access tiers.
+      {{.Prompt}} {{.HelpName}} --recursive --storage-class INTELLIGENT_TIERING ./archive/ s3/mybucket/archive/
+
+  42. Upload the files of a folder modified since the last run, the first run uploads all the files.
+      {{.Prompt}} {{.HelpName}} --recursive --newer-than-file ./backup.last-run ./data/ s3/backup/data/
+
+  43. Copy the small and the large log files of a folder in two passes, uploading the large ones with more parts in parallel.
+      {{.Prompt}} {{.HelpName}} --max-size 16MiB './logs/*.log' s3/mybucket/logs/
+      {{.Prompt}} {{.HelpName}} --min-size 16MiB --part-concurrency 8 './logs/*.log' s3/mybucket/logs/
+
+  44. Copy a folder recursively within a maintenance window, spending at most 15 minutes retrying failed requests
+      in total on top of the 20 retries of each part.
+      {{.Prompt}} {{.HelpName}} --recursive --part-retries 20 --retry-budget 15m ./data/ s3/mybucket/data/
+
+  45. Upload a folder of small files as a single tar object, keeping their mode, ownership and time, then
+      extract it back to another folder.
+      {{.Prompt}} {{.HelpName}} --archive tar --preserve ./photos/ s3/mybucket/photos.tar
+      {{.Prompt}} {{.HelpName}} --extract --preserve s3/mybucket/photos.tar ./restored/
+
+  46. Copy a folder recursively, sending the MD5 of each object, or of each part of the large ones, for the server to
+      reject corrupted uploads. Computing the MD5 costs CPU time, it is unrelated to the --checksum algorithms.
+      {{.Prompt}} {{.HelpName}} --recursive --content-md5 ./data/ s3/mybucket/data/
+
+  47. Upload a folder keeping its symlinks as empty objects with their target, then download it back with the
+      symlinks recreated. Symlinks pointing outside of the folder are skipped.
+      {{.Prompt}} {{.HelpName}} --recursive --store-symlinks ./website/ s3/mybucket/website/
+      {{.Prompt}} {{.HelpName}} --recursive --preserve s3/mybucket/website/ ./restored/
+
+  48. Copy a bucket recursively, adjusting the number of objects copied in parallel to the throughput and the server load.
+      {{.Prompt}} {{.HelpName}} --recursive --auto-parallel play/mybucket/ s3/mybucket/
+
+  49. Copy a file with a non ASCII metadata value to a legacy backend expecting ISO-8859-1 metadata.
+      {{.Prompt}} {{.HelpName}} --attr "City=Zürich" --metadata-charset iso-8859-1 report.pdf legacy/mybucket/
+
+  50. Copy a folder of cold data to the remote tier 'WARM' of a MinIO server. Objects cannot be transitioned one by one,
+      a lifecycle rule on the target prefix transitions them once they are one day old.
+      {{.Prompt}} mc ilm rule add --prefix "cold/" --transition-days 1 --transition-tier WARM myminio/mybucket
+      {{.Prompt}} {{.HelpName}} --recursive ./cold/ myminio/mybucket/cold/
 `,
 }
 
@@ -269,9 +550,14 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 		})
 	}
 
-	urls := uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB, preserve, isZip)
+	urls := cpURLs.timeouts.run(ctx, pg, isServerSideCopy(cpURLs, isZip), func(ctx context.Context, progress io.Reader) URLs {
+		return uploadSourceToTargetURL(ctx, cpURLs, progress, encKeyDB, preserve, isZip)
+	})
 	if isMvCmd && urls.Error == nil {
-		rmManager.add(ctx, sourceAlias, sourceURL.String())
+		mvJournal.copied(sourceAlias, sourceURL, targetAlias, targetURL)
+		if !mvJournal.deferRemoval {
+			rmManager.add(ctx, sourceAlias, sourceURL.String())
+		}
 	}
 
 	return urls
@@ -287,7 +573,7 @@ func doCopyFake(ctx context.Context, cpURLs URLs, pg Progress) URLs {
 }
 
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
-func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy context.CancelFunc) (totalBytes, totalObjects int64) {
+func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy context.CancelFunc, symlinkStats *copySymlinks, errReport *copyErrorReport, isMvCmd bool) (totalBytes, totalObjects int64) {
 	// Separate source and target. 'cp' can take only one target,
 	// but any number of sources.
 	sourceURLs := session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
@@ -303,6 +589,10 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	encrypt := session.Header.CommandStringFlags["encrypt"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
+	includeOptions, err := readPatternsFile(session.Header.CommandStringFlags["include-from"])
+	fatalIf(err, "Unable to read include patterns.")
+	excludeOptions, err := readPatternsFile(session.Header.CommandStringFlags["exclude-from"])
+	fatalIf(err, "Unable to read exclude patterns.")
 
 	// Create a session data file to store the processed URLs.
 	dataFP := session.NewDataWriter()
@@ -313,14 +603,30 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	}
 
 	opts := prepareCopyURLsOpts{
-		sourceURLs:  sourceURLs,
-		targetURL:   targetURL,
-		isRecursive: isRecursive,
-		encKeyDB:    encKeyDB,
-		olderThan:   olderThan,
-		newerThan:   newerThan,
-		timeRef:     parseRewindFlag(rewind),
-		versionID:   versionID,
+		sourceURLs:     sourceURLs,
+		targetURL:      targetURL,
+		isRecursive:    isRecursive,
+		encKeyDB:       encKeyDB,
+		olderThan:      olderThan,
+		newerThan:      newerThan,
+		timeRef:        parseRewindFlag(rewind),
+		versionID:      versionID,
+		includeOptions: includeOptions,
+		excludeOptions: excludeOptions,
+		symlinks: copySymlinkOpt(session.Header.CommandBoolFlags["follow-symlinks"],
+			session.Header.CommandBoolFlags["no-follow-external"], session.Header.CommandBoolFlags["store-symlinks"], isMvCmd),
+		symlinkStats: symlinkStats,
+		order:        session.Header.CommandStringFlags["order"],
+	}
+	opts.listConcurrency, _ = strconv.Atoi(session.Header.CommandStringFlags["list-concurrency"])
+	opts.newerThanRef, err = readNewerThanFile(session.Header.CommandStringFlags["newer-than-file"])
+	fatalIf(err, "Unable to read --newer-than-file.")
+	opts.sizeRange, err = parseSizeRange(session.Header.CommandStringFlags["min-size"], session.Header.CommandStringFlags["max-size"])
+	fatalIf(err, "Unable to parse --min-size and --max-size.")
+	if tagFilter := session.Header.CommandStringFlags["tag-filter"]; tagFilter != "" {
+		opts.tagFilters, err = parseTagFilters(strings.Split(tagFilter, "\n"))
+		fatalIf(err, "Unable to parse --tag-filter.")
+		opts.tagFilterWorkers, _ = strconv.Atoi(session.Header.CommandStringFlags["tag-filter-workers"])
 	}
 
 	URLsCh := prepareCopyURLs(ctx, opts)
@@ -342,6 +648,7 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 				} else {
 					errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
 				}
+				errReport.add(cpURLs)
 				break
 			}
 
@@ -376,35 +683,76 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	return
 }
 
-func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
+func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, args []string, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
 	var isCopied func(string) bool
 	var totalObjects, totalBytes int64
+	symlinkStats := &copySymlinks{}
+
+	// Objects skipped because the target is newer, with --no-overwrite-newer.
+	var newerTargets *copyNewerTargets
+	if cli.Bool("no-overwrite-newer") {
+		newerTargets = &copyNewerTargets{}
+	}
+
+	// Objects handled with --metadata-sync.
+	var metadataSync *metadataSyncStats
+	if cli.Bool("metadata-sync") {
+		metadataSync = &metadataSyncStats{}
+	}
+
+	// Only objects modified after the reference time are copied with
+	// --newer-than-file, which is set to the start of the copy on success.
+	newerThanFile, copyStart := cli.String("newer-than-file"), UTCNow()
+	if session != nil {
+		newerThanFile, copyStart = session.Header.CommandStringFlags["newer-than-file"], session.Header.When
+	}
+	completion := &copyCompletion{}
+
+	// Failures are collected to be reported at the end with --skip-errors.
+	var errReport *copyErrorReport
+	skipErrors := cli.Bool("skip-errors")
+	if skipErrors {
+		errReport = &copyErrorReport{}
+	}
 
 	cpURLsCh := make(chan URLs, 10000)
 
 	// Store a progress bar or an accounter
 	var pg ProgressReader
 
-	// Enable progress bar reader only during default mode.
-	if !globalQuiet && !globalJSON { // set up progress bar
-		pg = newProgressBar(totalBytes)
-	} else {
-		pg = newAccounter(totalBytes)
-	}
+	// Enable progress bar reader only during default mode
+	// on a terminal, unless another style is requested.
+	quiet := cli.IsSet("quiet") || cli.GlobalIsSet("quiet")
+	pg = newProgressReaderWithStyle(cli.String("progress"), quiet, totalBytes)
 
-	sourceURLs := cli.Args()[:len(cli.Args())-1]
-	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
+	var sourceURLs []string
+	var targetURL string
+	if len(args) > 0 {
+		sourceURLs = args[:len(args)-1]
+		targetURL = args[len(args)-1] // Last one is target
+	}
 
 	// Check if the target path has object locking enabled
 	withLock, _ := isBucketLockEnabled(ctx, targetURL)
 
+	// Metadata and tags applied to every object, before --attr and --tags.
+	var docMetadata map[string]string
+	var docTagging string
+	if metadataDoc := cli.String("metadata-from-json"); metadataDoc != "" {
+		docMetadata, docTagging = mustParseCopyMetadataDocument(metadataDoc)
+	}
+
+	// Copy URLs which could not be prepared from a manifest.
+	var manifestState *copyManifestState
+	var manifestErrs int64
+
 	if session != nil {
 		// isCopied returns true if an object has been already copied
 		// or not. This is useful when we resume from a session.
 		isCopied = isLastFactory(session.Header.LastCopied)
 
 		if !session.HasData() {
-			totalBytes, totalObjects = doPrepareCopyURLs(ctx, session, cancelCopy)
+			totalBytes, totalObjects = doPrepareCopyURLs(ctx, session, cancelCopy, symlinkStats, errReport, isMvCmd)
 		} else {
 			totalBytes, totalObjects = session.Header.TotalBytes, session.Header.TotalObjects
 		}
@@ -430,6 +778,31 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				cpURLsCh <- cpURLs
 			}
 		}()
+	} else if manifest := cli.String("from-file"); manifest != "" {
+		entries := mustParseCopyManifest(manifest, targetURL, cli.Bool("strict"))
+		var err *probe.Error
+		manifestState, err = loadCopyManifestState(manifest)
+		fatalIf(err, "Unable to load the manifest state.")
+		defer manifestState.Close()
+
+		go func() {
+			totalBytes := int64(0)
+			for cpURLs := range prepareCopyManifestURLs(ctx, entries, manifestState, encKeyDB) {
+				if cpURLs.Error != nil {
+					if !globalQuiet && !globalJSON {
+						console.Eraseline()
+					}
+					errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
+					atomic.AddInt64(&manifestErrs, 1)
+					continue
+				}
+				totalBytes += cpURLs.SourceContent.Size
+				pg.SetTotal(totalBytes)
+				totalObjects++
+				cpURLsCh <- cpURLs
+			}
+			close(cpURLsCh)
+		}()
 	} else {
 		// Access recursive flag inside the session header.
 		isRecursive := cli.Bool("recursive")
@@ -437,51 +810,123 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		newerThan := cli.String("newer-than")
 		rewind := cli.String("rewind")
 		versionID := cli.String("version-id")
+		includeOptions, excludeOptions := mustGetPatternsFromContext(cli)
+		newerThanRef, err := readNewerThanFile(newerThanFile)
+		fatalIf(err, "Unable to read --newer-than-file.")
+
+		// Replay the listing recorded by a previous run in the list cache, or record it.
+		var cachedURLs <-chan URLs
+		var listCache *copyListCacheWriter
+		if cli.String("list-cache") != "" {
+			var err *probe.Error
+			cachedURLs, listCache, manifestState, err = openCopyListCache(cli, args)
+			fatalIf(err, "Unable to open the list cache.")
+			defer manifestState.Close()
+		}
 
-		go func() {
-			totalBytes := int64(0)
-			opts := prepareCopyURLsOpts{
-				sourceURLs:  sourceURLs,
-				targetURL:   targetURL,
-				isRecursive: isRecursive,
-				encKeyDB:    encKeyDB,
-				olderThan:   olderThan,
-				newerThan:   newerThan,
-				timeRef:     parseRewindFlag(rewind),
-				versionID:   versionID,
-				isZip:       cli.Bool("zip"),
-			}
-			for cpURLs := range prepareCopyURLs(ctx, opts) {
-				if cpURLs.Error != nil {
-					// Print in new line and adjust to top so that we
-					// don't print over the ongoing scan bar
-					if !globalQuiet && !globalJSON {
-						console.Eraseline()
+		if cachedURLs != nil {
+			go func() {
+				totalBytes := int64(0)
+				for cpURLs := range cachedURLs {
+					if cpURLs.Error != nil {
+						errorIf(cpURLs.Error.Trace(), "Unable to read the list cache.")
+						atomic.AddInt64(&manifestErrs, 1)
+						continue
 					}
-					if strings.Contains(cpURLs.Error.ToGoError().Error(),
-						" is a folder.") {
-						errorIf(cpURLs.Error.Trace(),
-							"Folder cannot be copied. Please use `...` suffix.")
-					} else {
-						errorIf(cpURLs.Error.Trace(),
-							"Unable to start copying.")
-					}
-					break
-				} else {
 					totalBytes += cpURLs.SourceContent.Size
 					pg.SetTotal(totalBytes)
 					totalObjects++
+					cpURLsCh <- cpURLs
 				}
-				cpURLsCh <- cpURLs
-			}
-			close(cpURLsCh)
-		}()
+				close(cpURLsCh)
+			}()
+		} else {
+			go func() {
+				// The listing is only recorded in the list cache if it completes.
+				listingFailed := false
+				totalBytes := int64(0)
+				opts := prepareCopyURLsOpts{
+					sourceURLs:     sourceURLs,
+					targetURL:      targetURL,
+					isRecursive:    isRecursive,
+					encKeyDB:       encKeyDB,
+					olderThan:      olderThan,
+					newerThan:      newerThan,
+					timeRef:        parseRewindFlag(rewind),
+					versionID:      versionID,
+					isZip:          cli.Bool("zip"),
+					includeOptions: includeOptions,
+					excludeOptions: excludeOptions,
+					symlinks:       copySymlinkOpt(cli.Bool("follow-symlinks"), cli.Bool("no-follow-external"), cli.Bool("store-symlinks"), isMvCmd),
+					symlinkStats:   symlinkStats,
+					order:          cli.String("order"),
+				}
+				opts.listConcurrency = cli.Int("list-concurrency")
+				opts.newerThanRef = newerThanRef
+				opts.sizeRange, _ = parseSizeRange(cli.String("min-size"), cli.String("max-size"))
+				if tagFilters := cli.StringSlice("tag-filter"); len(tagFilters) > 0 {
+					opts.tagFilters, _ = parseTagFilters(tagFilters)
+					opts.tagFilterWorkers = cli.Int("tag-filter-workers")
+				}
+				for cpURLs := range prepareCopyURLs(ctx, opts) {
+					if cpURLs.Error != nil {
+						// Print in new line and adjust to top so that we
+						// don't print over the ongoing scan bar
+						if !globalQuiet && !globalJSON {
+							console.Eraseline()
+						}
+						if strings.Contains(cpURLs.Error.ToGoError().Error(),
+							" is a folder.") {
+							errorIf(cpURLs.Error.Trace(),
+								"Folder cannot be copied. Please use `...` suffix.")
+						} else {
+							errorIf(cpURLs.Error.Trace(),
+								"Unable to start copying.")
+						}
+						listingFailed = true
+						completion.fail()
+						if skipErrors {
+							errReport.add(cpURLs)
+							continue
+						}
+						break
+					} else {
+						totalBytes += cpURLs.SourceContent.Size
+						pg.SetTotal(totalBytes)
+						totalObjects++
+					}
+					if listCache != nil {
+						if err := listCache.add(&cpURLs); err != nil {
+							errorIf(err, "Unable to record the list cache.")
+							listCache.abort()
+							listCache = nil
+						}
+					}
+					cpURLsCh <- cpURLs
+				}
+				if listCache != nil {
+					if listingFailed || ctx.Err() != nil {
+						listCache.abort()
+					} else {
+						errorIf(listCache.commit(), "Unable to record the list cache.")
+					}
+				}
+				close(cpURLsCh)
+			}()
+		}
 	}
 
 	quitCh := make(chan struct{})
 	statusCh := make(chan URLs)
 
-	parallel := newParallelManager(statusCh)
+	var parallel *ParallelManager
+	var tuner *autoParallel
+	if cli.Bool("auto-parallel") && globalRequestStats != nil {
+		tuner = newAutoParallel(1, maxParallelWorkers)
+		parallel = newAutoParallelManager(statusCh, tuner, pg, globalRequestStats)
+	} else {
+		parallel = newParallelManager(statusCh)
+	}
 
 	go func() {
 		gracefulStop := func() {
@@ -500,6 +945,8 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					return
 				}
 
+				completion.queue()
+
 				// Save total count.
 				cpURLs.TotalCount = totalObjects
 
@@ -524,11 +971,26 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				if rd := cli.String(rdFlag); rd != "" {
 					cpURLs.TargetContent.RetentionDuration = rd
 				}
+				if r := cli.String("retention"); r != "" {
+					// Validated by checkCopySyntax, the retain until date
+					// is computed per object at upload time.
+					mode, duration, _ := parseRetentionModeDuration(r)
+					cpURLs.TargetContent.RetentionMode = string(mode)
+					cpURLs.TargetContent.RetentionDuration = duration
+					cpURLs.TargetContent.RetentionEnabled = true
+				}
 				if lh := cli.String(lhFlag); lh != "" {
 					cpURLs.TargetContent.LegalHold = strings.ToUpper(lh)
 					cpURLs.TargetContent.LegalHoldEnabled = true
 				}
 
+				for metadataKey, metaDataVal := range docMetadata {
+					cpURLs.TargetContent.UserMetadata[metadataKey] = metaDataVal
+				}
+				if docTagging != "" {
+					cpURLs.TargetContent.Metadata["X-Amz-Tagging"] = docTagging
+				}
+
 				if tags := cli.String("tags"); tags != "" {
 					cpURLs.TargetContent.Metadata["X-Amz-Tagging"] = tags
 				}
@@ -544,6 +1006,10 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 
 				cpURLs.MD5 = cli.Bool("md5") || withLock
 				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
+				cpURLs.Compress = cli.Bool("compress")
+				cpURLs.Decompress = !isMvCmd && !cli.Bool("no-decompress")
+				cpURLs.resumeDownload = true
+				cpURLs.timeouts = copyTimeouts{stall: cli.Duration("stall-timeout"), object: cli.Duration("object-timeout")}
 
 				// Verify if previously copied, notify progress bar.
 				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
@@ -552,6 +1018,41 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					}, 0)
 				} else {
 					parallel.queueTask(func() URLs {
+						if newerTargets != nil {
+							newer, err := targetIsNewer(ctx, cpURLs, encKeyDB)
+							if err != nil {
+								cpURLs.Error = err
+								return cpURLs
+							}
+							if newer {
+								newerTargets.skip(cpURLs)
+								return doCopyFake(ctx, cpURLs, pg)
+							}
+						}
+						if metadataSync != nil {
+							action, source, target, err := checkMetadataSync(ctx, cpURLs, encKeyDB)
+							if err == nil && action == metadataSyncUpdate {
+								err = updateTargetMetadata(ctx, cpURLs, source, target, encKeyDB)
+							}
+							if err != nil {
+								cpURLs.Error = err
+								return cpURLs
+							}
+							if action == metadataSyncUpdate {
+								if _, ok := pg.(*progressBar); !ok {
+									printMsg(newMetadataUpdateMessage(cpURLs))
+								}
+							}
+							if action != metadataSyncCopy {
+								metadataSync.add(action)
+								return doCopyFake(ctx, cpURLs, pg)
+							}
+							urls := doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve, isZip)
+							if urls.Error == nil {
+								metadataSync.add(action)
+							}
+							return urls
+						}
 						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve, isZip)
 					}, cpURLs.SourceContent.Size)
 				}
@@ -562,15 +1063,17 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	var retErr error
 	errSeen := false
 	cpAllFilesErr := true
+	var storageClassObjects int64
 
 loop:
 	for {
 		select {
 		case <-globalContext.Done():
+			completion.interrupt()
 			close(quitCh)
 			cancelCopy()
 			// Receive interrupt notification.
-			if !globalQuiet && !globalJSON {
+			if _, ok := pg.(*progressBar); ok {
 				console.Eraseline()
 			}
 			if session != nil {
@@ -582,24 +1085,33 @@ loop:
 			if !ok {
 				break loop
 			}
+			completion.process()
 			if cpURLs.Error == nil {
 				if session != nil {
 					session.Header.LastCopied = cpURLs.SourceContent.URL.String()
 					session.Save()
 				}
+				if manifestState != nil {
+					errorIf(manifestState.markDone(cpURLs.ManifestKey), "Unable to update the manifest state.")
+				}
+				if cpURLs.TargetContent != nil && cpURLs.TargetContent.StorageClass != "" && cpURLs.TargetContent.URL.Type == objectStorage {
+					storageClassObjects++
+				}
 				cpAllFilesErr = false
 			} else {
 
 				// Set exit status for any copy error
 				retErr = exitStatus(globalErrorExitStatus)
+				completion.fail()
 
 				// Print in new line and adjust to top so that we
 				// don't print over the ongoing progress bar.
-				if !globalQuiet && !globalJSON {
+				if _, ok := pg.(*progressBar); ok {
 					console.Eraseline()
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
 					fmt.Sprintf("Failed to copy `%s`.", cpURLs.SourceContent.URL.String()))
+				errReport.add(cpURLs)
 				if isErrIgnored(cpURLs.Error) {
 					cpAllFilesErr = false
 					continue loop
@@ -618,7 +1130,7 @@ loop:
 					}
 				}
 
-				if session != nil {
+				if session != nil && !skipErrors {
 					// For critical errors we should exit. Session
 					// can be resumed after the user figures out
 					// the  problem.
@@ -628,18 +1140,78 @@ loop:
 		}
 	}
 
+	if atomic.LoadInt64(&manifestErrs) > 0 {
+		retErr = exitStatus(globalErrorExitStatus)
+		completion.fail()
+	}
+	if ctx.Err() != nil {
+		completion.interrupt()
+	}
+
 	if progressReader, ok := pg.(*progressBar); ok {
 		if (errSeen && totalObjects == 1) || (cpAllFilesErr && totalObjects > 1) {
 			console.Eraseline()
 		} else if progressReader.ProgressBar.Get() > 0 {
 			progressReader.ProgressBar.Finish()
 		}
+	} else if plain, ok := pg.(*plainProgress); ok {
+		plain.Finish()
 	} else {
 		if accntReader, ok := pg.(*accounter); ok {
 			printMsg(accntReader.Stat())
 		}
 	}
 
+	summary := copySummaryMessage{
+		Symlinks:     symlinkStats.summary(),
+		NewerTargets: newerTargets.summary(),
+		MetadataSync: metadataSync.summary(),
+	}
+	if storageClassObjects > 0 {
+		summary.StorageClass = &storageClassMessage{StorageClass: cli.String("storage-class"), Objects: storageClassObjects}
+	}
+	if retries := atomic.LoadInt64(&globalPartRetries); retries > 0 {
+		summary.PartRetries = &partRetriesMessage{Retries: retries}
+	}
+	if globalRetryBudget != nil {
+		budget := globalRetryBudget.message()
+		summary.RetryBudget = &budget
+	}
+	if tuner != nil {
+		autoParallel := tuner.message()
+		summary.AutoParallel = &autoParallel
+	}
+	if uploads := atomic.LoadInt64(&globalMultipartUploads); uploads > 0 {
+		if partConcurrency, err := getPartConcurrency(); err == nil {
+			summary.PartConcurrency = &partConcurrencyMessage{PartConcurrency: partConcurrency, MultipartUploads: uploads}
+		}
+	}
+	if !summary.empty() {
+		printMsg(summary)
+	}
+
+	if !errReport.empty() {
+		errorFile := cli.String("error-file")
+		if errorFile != "" {
+			if err := errReport.writeFile(errorFile); err != nil {
+				errorIf(err, "Unable to write the failed objects.")
+				errorFile = ""
+			}
+		}
+		printMsg(errReport.message(errorFile))
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	// Every copy of the manifest is done, a new run copies them again.
+	if cli.String("from-file") != "" && manifestState != nil && retErr == nil && completion.complete() {
+		errorIf(manifestState.remove(), "Unable to remove the manifest state.")
+	}
+
+	if newerThanFile != "" && retErr == nil && completion.complete() {
+		fatalIf(touchNewerThanFile(newerThanFile, copyStart), "Unable to set the reference time of --newer-than-file.")
+		printMsg(newerThanFileMessage{Status: "success", File: newerThanFile, Time: copyStart})
+	}
+
 	return retErr
 }
 
@@ -659,10 +1231,60 @@ func mainCopy(cliCtx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", cliCtx.String("attr"))
 	}
 
+	// Requests are counted by the transport of the S3 clients, which are
+	// cached once created, first by the glob expansion.
+	if cliCtx.Bool("auto-parallel") {
+		globalRequestStats = &requestStats{}
+	}
+	globalCountPartRetries = true
+	if budget := cliCtx.Duration("retry-budget"); budget > 0 {
+		globalRetryBudget = newRetryBudget(budget)
+	}
+
+	// minio-go retries each failed request up to MaxRetry attempts, there
+	// is no separate count for the parts so this applies to all requests.
+	if cliCtx.IsSet("part-retries") {
+		minio.MaxRetry = cliCtx.Int("part-retries") + 1
+	}
+
+	// Expand wildcards in the sources, unless asked to keep them literally.
+	args := []string(cliCtx.Args())
+	if !cliCtx.Bool("no-glob") && cliCtx.String("from-file") == "" {
+		args, err = expandSourceGlobs(ctx, args)
+		fatalIf(err, "Unable to expand source arguments.")
+	}
+
+	setMetadataCharset(cliCtx)
+
+	// A folder archived into one object, or extracted back, is not
+	// copied as a session.
+	if cliCtx.String("archive") != "" || cliCtx.Bool("extract") {
+		return mainCopyArchive(ctx, cliCtx, args, encKeyDB)
+	}
+
 	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, cliCtx, encKeyDB, false)
+	checkCopySyntax(ctx, cliCtx, args, encKeyDB, false)
+
+	globalPartConcurrency = cliCtx.Int("part-concurrency")
+
+	if contentTypeMap := cliCtx.String("content-type-map"); contentTypeMap != "" {
+		f, e := os.Open(contentTypeMap)
+		fatalIf(probe.NewError(e).Trace(contentTypeMap), "Unable to open the content-type map.")
+		globalContentTypeMap, e = parseContentTypeMap(f)
+		f.Close()
+		fatalIf(probe.NewError(e).Trace(contentTypeMap), "Unable to parse the content-type map.")
+	}
+	globalNoSniff = cliCtx.Bool("no-sniff")
+	globalSniffText = true
+
 	// Additional command specific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SymlinkSkipped", color.New(color.FgYellow))
+	console.SetColor("NewerTargetSkipped", color.New(color.FgYellow))
+	console.SetColor("MetadataUpdate", color.New(color.FgGreen, color.Bold))
+	console.SetColor("CopyOrder", color.New(color.FgYellow))
+	console.SetColor("TagFilter", color.New(color.FgYellow))
+	console.SetColor("ListCache", color.New(color.FgYellow))
 
 	recursive := cliCtx.Bool("recursive")
 	rewind := cliCtx.String("rewind")
@@ -687,6 +1309,10 @@ func mainCopy(cliCtx *cli.Context) error {
 
 	var session *sessionV8
 
+	if cliCtx.Bool("continue") && cliCtx.String("from-file") != "" {
+		fatalIf(errInvalidArgument().Trace(), "--continue cannot be used with --from-file, the manifest state is used to resume instead.")
+	}
+
 	if cliCtx.Bool("continue") {
 		sessionID := getHash("cp", os.Args[1:])
 		if isSessionExists(sessionID) {
@@ -700,13 +1326,24 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.CommandStringFlags["version-id"] = versionID
 			session.Header.CommandStringFlags["older-than"] = olderThan
 			session.Header.CommandStringFlags["newer-than"] = newerThan
+			session.Header.CommandStringFlags["newer-than-file"] = cliCtx.String("newer-than-file")
+			session.Header.CommandStringFlags["min-size"] = cliCtx.String("min-size")
+			session.Header.CommandStringFlags["max-size"] = cliCtx.String("max-size")
 			session.Header.CommandStringFlags["storage-class"] = storageClass
 			session.Header.CommandStringFlags["tags"] = tags
 			session.Header.CommandStringFlags[rmFlag] = retentionMode
 			session.Header.CommandStringFlags[rdFlag] = retentionDuration
 			session.Header.CommandStringFlags[lhFlag] = legalHold
+			session.Header.CommandStringFlags["retention"] = cliCtx.String("retention")
+			session.Header.CommandStringFlags["metadata-from-json"] = cliCtx.String("metadata-from-json")
 			session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 			session.Header.CommandStringFlags["encrypt"] = sse
+			session.Header.CommandStringFlags["order"] = cliCtx.String("order")
+			session.Header.CommandStringFlags["tag-filter"] = strings.Join(cliCtx.StringSlice("tag-filter"), "\n")
+			session.Header.CommandStringFlags["tag-filter-workers"] = strconv.Itoa(cliCtx.Int("tag-filter-workers"))
+			session.Header.CommandStringFlags["list-concurrency"] = strconv.Itoa(cliCtx.Int("list-concurrency"))
+			session.Header.CommandStringFlags["include-from"] = cliCtx.String("include-from")
+			session.Header.CommandStringFlags["exclude-from"] = cliCtx.String("exclude-from")
 			session.Header.CommandBoolFlags["session"] = cliCtx.Bool("continue")
 
 			if cliCtx.Bool("preserve") {
@@ -715,6 +1352,11 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.UserMetaData = userMetaMap
 			session.Header.CommandBoolFlags["md5"] = cliCtx.Bool("md5")
 			session.Header.CommandBoolFlags["disable-multipart"] = cliCtx.Bool("disable-multipart")
+			session.Header.CommandBoolFlags["compress"] = cliCtx.Bool("compress")
+			session.Header.CommandBoolFlags["no-decompress"] = cliCtx.Bool("no-decompress")
+			session.Header.CommandBoolFlags["follow-symlinks"] = cliCtx.Bool("follow-symlinks")
+			session.Header.CommandBoolFlags["no-follow-external"] = cliCtx.Bool("no-follow-external")
+			session.Header.CommandBoolFlags["store-symlinks"] = cliCtx.Bool("store-symlinks")
 
 			var e error
 			if session.Header.RootPath, e = os.Getwd(); e != nil {
@@ -723,11 +1365,11 @@ func mainCopy(cliCtx *cli.Context) error {
 			}
 
 			// extract URLs.
-			session.Header.CommandArgs = cliCtx.Args()
+			session.Header.CommandArgs = args
 		}
 	}
 
-	e := doCopySession(ctx, cancelCopy, cliCtx, session, encKeyDB, false)
+	e := doCopySession(ctx, cancelCopy, cliCtx, args, session, encKeyDB, false)
 	if session != nil {
 		session.Delete()
 	}
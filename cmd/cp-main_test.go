@@ -18,8 +18,21 @@
 package cmd
 
 import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -74,3 +87,116 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCopyMetadataDocument(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		metadata map[string]string
+		tagging  string
+		success  bool
+	}{
+		{`{}`, map[string]string{}, "", true},
+		{
+			`{"contentType": "image/webp", "cacheControl": "max-age=60", "userMetadata": {"owner": "web"}, "tags": {"team": "web"}}`,
+			map[string]string{"Content-Type": "image/webp", "Cache-Control": "max-age=60", "owner": "web"},
+			"team=web",
+			true,
+		},
+		// Typo in a top level key.
+		{`{"contentTyp": "image/webp"}`, nil, "", false},
+		{`{"tags": {"": "empty"}}`, nil, "", false},
+		{`{} {}`, nil, "", false},
+	}
+
+	for i, testCase := range testCases {
+		metadata, tagging, err := parseCopyMetadataDocument(strings.NewReader(testCase.doc))
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !reflect.DeepEqual(metadata, testCase.metadata) || tagging != testCase.tagging {
+			t.Fatalf("Test %d: expected %v %q, got %v %q", i+1, testCase.metadata, testCase.tagging, metadata, tagging)
+		}
+	}
+}
+
+// cp --recursive never copies the directory placeholders of the source, so
+// it needs no --exclude-bucket-markers unlike mirror.
+func TestCopyRecursiveSkipsBucketMarkers(t *testing.T) {
+	server := httptest.NewServer(listHandler{keys: []string{"a", "dir/", "dir/b", "dir/c/", "dir/c/d"}})
+	defer server.Close()
+
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		cfg := newMcConfig()
+		cfg.Aliases["cptest"] = aliasConfigV10{URL: server.URL, AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"}
+		return cfg, nil
+	}
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	var sources []string
+	for cpURLs := range prepareCopyURLsTypeC(globalContext, "cptest/bucket/", t.TempDir(), true, false, time.Time{}, nil, copySymlinkOpt(false, false, false, false), &copySymlinks{}, 1) {
+		if cpURLs.Error != nil {
+			t.Fatal(cpURLs.Error)
+		}
+		sources = append(sources, cpURLs.SourceContent.URL.Path)
+	}
+	sort.Strings(sources)
+	if expected := []string{"/bucket/a", "/bucket/dir/b", "/bucket/dir/c/d"}; !reflect.DeepEqual(sources, expected) {
+		t.Fatalf("expected %v, got %v", expected, sources)
+	}
+}
+
+// The clients used by the copy are created by the syntax checks, --retry-budget
+// must be applied to them.
+func TestCopyRetryBudget(t *testing.T) {
+	var puts int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Has("location"):
+			w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		case r.Method == http.MethodPut:
+			atomic.AddInt64(&puts, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/bucket" || r.URL.Path == "/bucket/":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		cfg := newMcConfig()
+		cfg.Aliases["cpbudget"] = aliasConfigV10{URL: server.URL, AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"}
+		return cfg, nil
+	}
+	defer func(maxRetry int, budget *retryBudget) {
+		loadMcConfig = savedLoadMcConfig
+		minio.MaxRetry = maxRetry
+		globalRetryBudget = budget
+	}(minio.MaxRetry, globalRetryBudget)
+
+	source := filepath.Join(t.TempDir(), "object")
+	if e := os.WriteFile(source, []byte("data"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+	set := flag.NewFlagSet("cp", flag.ContinueOnError)
+	for _, f := range cpCmd.Flags {
+		f.Apply(set)
+	}
+	if e := set.Parse([]string{"--part-retries", "4", "--retry-budget", "1ns", source, "cpbudget/bucket/object"}); e != nil {
+		t.Fatal(e)
+	}
+	if e := mainCopy(cli.NewContext(nil, set, nil)); e == nil {
+		t.Fatal("Expected the copy to fail")
+	}
+
+	// The first retry spends the budget, the next ones are refused.
+	if n := atomic.LoadInt64(&puts); n != 2 {
+		t.Errorf("Expected 2 upload attempts, got %d", n)
+	}
+	if msg := globalRetryBudget.message(); !msg.Limited {
+		t.Errorf("Expected the retries to be limited, got %+v", msg)
+	}
+}
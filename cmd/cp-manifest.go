@@ -0,0 +1,238 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+
+	gojson "encoding/json"
+)
+
+// copyManifestStateSuffix is appended to the manifest path to
+// name the sidecar file tracking the copies already done.
+const copyManifestStateSuffix = ".state"
+
+// copyManifestEntry is a single line of a copy manifest.
+type copyManifestEntry struct {
+	line   int
+	source string
+	target string
+	// targetIsDir is set when the object must be copied
+	// under target with its source name.
+	targetIsDir bool
+}
+
+// parseCopyManifest parses a manifest where each line is either 'source,target'
+// or 'source', in which case the object is copied under targetURL. Blank lines
+// and lines starting with '#' are ignored. Malformed lines are returned as
+// errors referencing their line number.
+func parseCopyManifest(r io.Reader, targetURL string) (entries []copyManifestEntry, malformed []error, e error) {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields, e := csv.NewReader(strings.NewReader(text)).Read()
+		if e != nil {
+			malformed = append(malformed, fmt.Errorf("line %d: %w", line, e))
+			continue
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		entry := copyManifestEntry{line: line, source: fields[0]}
+		switch {
+		case len(fields) > 2:
+			malformed = append(malformed, fmt.Errorf("line %d: expected 'source,target' but found %d fields", line, len(fields)))
+			continue
+		case entry.source == "":
+			malformed = append(malformed, fmt.Errorf("line %d: source cannot be empty", line))
+			continue
+		case len(fields) == 2 && fields[1] != "":
+			entry.target = fields[1]
+			entry.targetIsDir = strings.HasSuffix(entry.target, "/")
+		case targetURL == "":
+			malformed = append(malformed, fmt.Errorf("line %d: no target on the line and no TARGET argument", line))
+			continue
+		default:
+			entry.target = targetURL
+			entry.targetIsDir = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries, malformed, scanner.Err()
+}
+
+// copyManifestKey identifies a copy in the state of a manifest, by its
+// source and target, so that the state survives edits of the manifest.
+func copyManifestKey(source, target string) string {
+	key, _ := gojson.Marshal([]string{source, target})
+	return string(key)
+}
+
+// copyManifestState tracks the copies of a manifest which were done, so
+// that a new run of the same manifest skips them.
+type copyManifestState struct {
+	done map[string]struct{}
+	f    *os.File
+}
+
+// loadCopyManifestState loads or creates the sidecar state file of a manifest.
+func loadCopyManifestState(manifest string) (*copyManifestState, *probe.Error) {
+	statePath := manifest + copyManifestStateSuffix
+	f, e := os.OpenFile(statePath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(statePath)
+	}
+
+	s := &copyManifestState{done: make(map[string]struct{}), f: f}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			s.done[key] = struct{}{}
+		}
+	}
+	if e = scanner.Err(); e != nil {
+		f.Close()
+		return nil, probe.NewError(e).Trace(statePath)
+	}
+	return s, nil
+}
+
+// isDone returns true if the copy was already done.
+func (s *copyManifestState) isDone(key string) bool {
+	_, ok := s.done[key]
+	return ok
+}
+
+// markDone records the copy as done.
+func (s *copyManifestState) markDone(key string) *probe.Error {
+	s.done[key] = struct{}{}
+	if _, e := fmt.Fprintln(s.f, key); e != nil {
+		return probe.NewError(e).Trace(s.f.Name())
+	}
+	return nil
+}
+
+// Close closes the sidecar state file.
+func (s *copyManifestState) Close() error {
+	return s.f.Close()
+}
+
+// remove deletes the sidecar state file, once every copy of the
+// manifest is done a new run copies all of them again.
+func (s *copyManifestState) remove() *probe.Error {
+	s.f.Close()
+	if e := os.Remove(s.f.Name()); e != nil && !os.IsNotExist(e) {
+		return probe.NewError(e).Trace(s.f.Name())
+	}
+	return nil
+}
+
+// mustParseCopyManifest reads the manifest, reports malformed lines and
+// fails if any is found in strict mode.
+func mustParseCopyManifest(manifest, targetURL string, strict bool) []copyManifestEntry {
+	f, e := os.Open(manifest)
+	fatalIf(probe.NewError(e).Trace(manifest), "Unable to open the manifest.")
+	defer f.Close()
+
+	entries, malformed, e := parseCopyManifest(f, targetURL)
+	fatalIf(probe.NewError(e).Trace(manifest), "Unable to read the manifest.")
+	for _, m := range malformed {
+		if strict {
+			fatalIf(probe.NewError(m).Trace(manifest), "Malformed line in the manifest.")
+		}
+		errorIf(probe.NewError(m).Trace(manifest), "Skipping malformed line in the manifest.")
+	}
+	return entries
+}
+
+// copyManifestTargetBuckets returns the distinct buckets, or local folders,
+// the objects of a manifest are copied to, args holds the optional TARGET.
+// Malformed lines are reported when the copy starts.
+func copyManifestTargetBuckets(manifest string, args []string) []string {
+	var targetURL string
+	if len(args) > 0 {
+		targetURL = args[len(args)-1]
+	}
+	f, e := os.Open(manifest)
+	fatalIf(probe.NewError(e).Trace(manifest), "Unable to open the manifest.")
+	defer f.Close()
+
+	entries, _, e := parseCopyManifest(f, targetURL)
+	fatalIf(probe.NewError(e).Trace(manifest), "Unable to read the manifest.")
+
+	var buckets []string
+	seen := make(map[string]struct{})
+	for _, entry := range entries {
+		bucket := entry.target
+		if parts := strings.SplitN(entry.target, "/", 3); len(parts) == 3 {
+			bucket = parts[0] + "/" + parts[1]
+		}
+		if _, ok := seen[bucket]; !ok {
+			seen[bucket] = struct{}{}
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// prepareCopyManifestURLs prepares the copy URLs for every manifest entry not
+// yet copied. Entries which cannot be prepared are reported and skipped.
+func prepareCopyManifestURLs(ctx context.Context, entries []copyManifestEntry, state *copyManifestState, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		for _, entry := range entries {
+			key := copyManifestKey(entry.source, entry.target)
+			if state.isDone(key) {
+				continue
+			}
+			var cpURLs URLs
+			if entry.targetIsDir {
+				cpURLs = prepareCopyURLsTypeB(ctx, entry.source, "", entry.target, encKeyDB, false)
+			} else {
+				cpURLs = prepareCopyURLsTypeA(ctx, entry.source, "", entry.target, encKeyDB, false)
+			}
+			if cpURLs.Error != nil {
+				cpURLs.Error = cpURLs.Error.Trace(fmt.Sprintf("line %d", entry.line))
+			}
+			cpURLs.ManifestKey = key
+			select {
+			case copyURLsCh <- cpURLs:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return copyURLsCh
+}
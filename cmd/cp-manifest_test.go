@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCopyManifest(t *testing.T) {
+	manifest := `# source,target
+play/bucket/a.txt,play/archive/a.txt
+
+play/bucket/b.txt
+"play/bucket/c,d.txt",play/archive/
+play/bucket/e.txt,play/archive/e.txt,extra
+,play/archive/f.txt
+`
+	entries, malformed, e := parseCopyManifest(strings.NewReader(manifest), "play/default/")
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	expected := []copyManifestEntry{
+		{line: 2, source: "play/bucket/a.txt", target: "play/archive/a.txt"},
+		{line: 4, source: "play/bucket/b.txt", target: "play/default/", targetIsDir: true},
+		{line: 5, source: "play/bucket/c,d.txt", target: "play/archive/", targetIsDir: true},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %v, got %v", expected, entries)
+	}
+
+	if len(malformed) != 2 {
+		t.Fatalf("expected 2 malformed lines, got %v", malformed)
+	}
+	for i, line := range []string{"line 6:", "line 7:"} {
+		if !strings.HasPrefix(malformed[i].Error(), line) {
+			t.Fatalf("expected error for %s got %v", line, malformed[i])
+		}
+	}
+
+	// Lines without a target require a TARGET argument.
+	_, malformed, _ = parseCopyManifest(strings.NewReader("play/bucket/b.txt\n"), "")
+	if len(malformed) != 1 {
+		t.Fatalf("expected 1 malformed line, got %v", malformed)
+	}
+}
+
+func TestCopyManifestState(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.csv")
+	state, err := loadCopyManifestState(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := copyManifestKey("play/bucket/b.txt", "play/archive/")
+	if err = state.markDone(done); err != nil {
+		t.Fatal(err)
+	}
+	state.Close()
+
+	// Lines inserted before the copies done do not change their state.
+	entries, _, e := parseCopyManifest(strings.NewReader(`play/bucket/new.txt,play/archive/
+play/bucket/a.txt,play/archive/a.txt
+play/bucket/b.txt,play/archive/
+`), "")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if state, err = loadCopyManifestState(manifest); err != nil {
+		t.Fatal(err)
+	}
+	var pending []string
+	for _, entry := range entries {
+		if !state.isDone(copyManifestKey(entry.source, entry.target)) {
+			pending = append(pending, entry.source)
+		}
+	}
+	if expected := []string{"play/bucket/new.txt", "play/bucket/a.txt"}; !reflect.DeepEqual(pending, expected) {
+		t.Fatalf("expected %v to be copied, got %v", expected, pending)
+	}
+
+	// The state is removed once the manifest is done.
+	if err = state.remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, e := os.Stat(manifest + copyManifestStateSuffix); !os.IsNotExist(e) {
+		t.Fatalf("expected the state to be removed, got %v", e)
+	}
+}
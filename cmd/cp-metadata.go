@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+// copyMetadataDocument is the document accepted by 'cp --metadata-from-json'.
+type copyMetadataDocument struct {
+	ContentType        string            `json:"contentType"`
+	CacheControl       string            `json:"cacheControl"`
+	ContentDisposition string            `json:"contentDisposition"`
+	ContentEncoding    string            `json:"contentEncoding"`
+	ContentLanguage    string            `json:"contentLanguage"`
+	Expires            string            `json:"expires"`
+	UserMetadata       map[string]string `json:"userMetadata"`
+	Tags               map[string]string `json:"tags"`
+}
+
+// parseCopyMetadataDocument parses a metadata document into the metadata
+// and the URL encoded tags applied to the uploaded objects. Unknown keys
+// are rejected to catch typos before anything is uploaded.
+func parseCopyMetadataDocument(r io.Reader) (metadata map[string]string, tagging string, e error) {
+	var doc copyMetadataDocument
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if e = dec.Decode(&doc); e != nil {
+		return nil, "", e
+	}
+	if dec.More() {
+		return nil, "", errors.New("unexpected content after the metadata document")
+	}
+
+	metadata = make(map[string]string)
+	for k, v := range doc.UserMetadata {
+		metadata[k] = v
+	}
+	for k, v := range map[string]string{
+		"Content-Type":        doc.ContentType,
+		"Cache-Control":       doc.CacheControl,
+		"Content-Disposition": doc.ContentDisposition,
+		"Content-Encoding":    doc.ContentEncoding,
+		"Content-Language":    doc.ContentLanguage,
+		"Expires":             doc.Expires,
+	} {
+		if v != "" {
+			metadata[k] = v
+		}
+	}
+
+	if len(doc.Tags) > 0 {
+		t, e := tags.NewTags(doc.Tags, true)
+		if e != nil {
+			return nil, "", e
+		}
+		tagging = t.String()
+	}
+	return metadata, tagging, nil
+}
+
+// mustParseCopyMetadataDocument reads the metadata document at path.
+func mustParseCopyMetadataDocument(path string) (metadata map[string]string, tagging string) {
+	f, e := os.Open(path)
+	fatalIf(probe.NewError(e).Trace(path), "Unable to open the metadata document.")
+	defer f.Close()
+
+	metadata, tagging, e = parseCopyMetadataDocument(f)
+	fatalIf(probe.NewError(e).Trace(path), "Unable to parse the metadata document.")
+	return metadata, tagging
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// readNewerThanFile returns the reference time of `cp --newer-than-file`,
+// the modification time of the file. A missing file is a first run, and
+// returns a zero time to copy everything.
+func readNewerThanFile(path string) (time.Time, *probe.Error) {
+	st, e := os.Stat(path)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, probe.NewError(e).Trace(path)
+	}
+	return st.ModTime(), nil
+}
+
+// touchNewerThanFile sets the reference time of `cp --newer-than-file`,
+// the file is created if needed.
+func touchNewerThanFile(path string, t time.Time) *probe.Error {
+	f, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if e != nil {
+		return probe.NewError(e).Trace(path)
+	}
+	f.Close()
+	if e = os.Chtimes(path, t, t); e != nil {
+		return probe.NewError(e).Trace(path)
+	}
+	return nil
+}
+
+// copyCompletion tracks whether a copy ran to completion, the reference
+// time of `cp --newer-than-file` is only advanced then. Otherwise the next
+// run would skip the objects which were never copied.
+type copyCompletion struct {
+	queued      int64
+	processed   int64
+	failed      int64
+	interrupted int32
+}
+
+func (c *copyCompletion) queue()     { atomic.AddInt64(&c.queued, 1) }
+func (c *copyCompletion) process()   { atomic.AddInt64(&c.processed, 1) }
+func (c *copyCompletion) fail()      { atomic.AddInt64(&c.failed, 1) }
+func (c *copyCompletion) interrupt() { atomic.StoreInt32(&c.interrupted, 1) }
+
+// complete returns true if the copy was not interrupted, every queued URL
+// was processed and there was no error, including the skipped ones.
+func (c *copyCompletion) complete() bool {
+	return atomic.LoadInt32(&c.interrupted) == 0 &&
+		atomic.LoadInt64(&c.failed) == 0 &&
+		atomic.LoadInt64(&c.processed) == atomic.LoadInt64(&c.queued)
+}
+
+// newerThanFileMessage reports the reference time set for the next run.
+type newerThanFileMessage struct {
+	Status string    `json:"status"`
+	File   string    `json:"file"`
+	Time   time.Time `json:"time"`
+}
+
+func (n newerThanFileMessage) String() string {
+	return fmt.Sprintf("Set the reference time of `%s` to %s.", n.File, n.Time.Local().Format(printDate))
+}
+
+func (n newerThanFileMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(n, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestNewerThanFile(t *testing.T) {
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	refFile := filepath.Join(dir, ".last-run")
+
+	ref, err := readNewerThanFile(refFile)
+	if err != nil || !ref.IsZero() {
+		t.Fatalf("expected a zero reference time without a file, got %v, %v", ref, err)
+	}
+
+	lastRun := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err = touchNewerThanFile(refFile, lastRun); err != nil {
+		t.Fatal(err)
+	}
+	if ref, err = readNewerThanFile(refFile); err != nil || !ref.Equal(lastRun) {
+		t.Fatalf("expected reference time %v, got %v, %v", lastRun, ref, err)
+	}
+
+	src := filepath.Join(dir, "src")
+	if e := os.Mkdir(src, 0o755); e != nil {
+		t.Fatal(e)
+	}
+	for name, modTime := range map[string]time.Time{
+		"old": lastRun.Add(-time.Minute),
+		"new": lastRun.Add(time.Minute),
+	} {
+		name = filepath.Join(src, name)
+		if e := os.WriteFile(name, []byte("data"), 0o644); e != nil {
+			t.Fatal(e)
+		}
+		if e := os.Chtimes(name, modTime, modTime); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	var copied []string
+	for cpURLs := range prepareCopyURLs(context.Background(), prepareCopyURLsOpts{
+		sourceURLs:   []string{src + string(filepath.Separator)},
+		targetURL:    filepath.Join(dir, "dst") + string(filepath.Separator),
+		isRecursive:  true,
+		newerThanRef: ref,
+	}) {
+		if cpURLs.Error != nil {
+			t.Fatal(cpURLs.Error)
+		}
+		copied = append(copied, filepath.Base(cpURLs.SourceContent.URL.Path))
+	}
+	if len(copied) != 1 || copied[0] != "new" {
+		t.Fatalf("expected only `new` to be copied, got %v", copied)
+	}
+}
+
+func TestCopyCompletion(t *testing.T) {
+	run := func(queued, processed, failed int, interrupted bool) bool {
+		c := &copyCompletion{}
+		for i := 0; i < queued; i++ {
+			c.queue()
+		}
+		for i := 0; i < processed; i++ {
+			c.process()
+		}
+		for i := 0; i < failed; i++ {
+			c.fail()
+		}
+		if interrupted {
+			c.interrupt()
+		}
+		return c.complete()
+	}
+
+	if !run(3, 3, 0, false) {
+		t.Error("expected a complete run")
+	}
+	if !run(0, 0, 0, false) {
+		t.Error("expected a run without objects to be complete")
+	}
+	// Ctrl-C after one of three objects was copied.
+	if run(3, 1, 0, true) {
+		t.Error("expected an interrupted run to be incomplete")
+	}
+	// Interrupted after the last object was processed.
+	if run(3, 3, 0, true) {
+		t.Error("expected an interrupted run to be incomplete")
+	}
+	if run(3, 2, 0, false) {
+		t.Error("expected a run with unprocessed objects to be incomplete")
+	}
+	// An error skipped with --skip-errors.
+	if run(3, 3, 1, false) {
+		t.Error("expected a run with a skipped error to be incomplete")
+	}
+}
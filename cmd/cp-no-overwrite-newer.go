@@ -0,0 +1,115 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// targetIsNewer reports whether the target of the copy exists and was
+// modified after the source. A source without a modification time is
+// always copied.
+func targetIsNewer(ctx context.Context, cpURLs URLs, encKeyDB map[string][]prefixSSEPair) (bool, *probe.Error) {
+	if cpURLs.SourceContent.Time.IsZero() {
+		return false, nil
+	}
+	targetAlias := cpURLs.TargetAlias
+	targetURL := cpURLs.TargetContent.URL.String()
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return false, err.Trace(targetURL)
+	}
+	targetPath := filepath.ToSlash(filepath.Join(targetAlias, cpURLs.TargetContent.URL.Path))
+	st, err := clnt.Stat(ctx, StatOptions{sse: getSSE(targetPath, encKeyDB[targetAlias])})
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case PathNotFound, ObjectMissing:
+			return false, nil
+		}
+		return false, err.Trace(targetURL)
+	}
+	return st.Time.After(cpURLs.SourceContent.Time), nil
+}
+
+// copyNewerTargets counts the objects not copied with --no-overwrite-newer.
+type copyNewerTargets struct {
+	skipped int64
+}
+
+// skip records and reports an object whose target is newer.
+func (n *copyNewerTargets) skip(cpURLs URLs) {
+	atomic.AddInt64(&n.skipped, 1)
+	// Print in new line and adjust to top so that we
+	// don't print over the ongoing progress bar.
+	if !globalQuiet && !globalJSON {
+		console.Eraseline()
+	}
+	printMsg(newerTargetSkippedMessage{
+		Status: "success",
+		Source: filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path)),
+		Target: filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path)),
+	})
+}
+
+// summary returns the count of skipped objects, nil if none were skipped.
+func (n *copyNewerTargets) summary() *copyNewerTargetsMessage {
+	if n == nil {
+		return nil
+	}
+	skipped := atomic.LoadInt64(&n.skipped)
+	if skipped == 0 {
+		return nil
+	}
+	return &copyNewerTargetsMessage{Skipped: skipped}
+}
+
+// newerTargetSkippedMessage container for an object which is not copied
+// because its target is newer.
+type newerTargetSkippedMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func (s newerTargetSkippedMessage) String() string {
+	return console.Colorize("NewerTargetSkipped", fmt.Sprintf("Skipping `%s`, the target `%s` is newer.", s.Source, s.Target))
+}
+
+func (s newerTargetSkippedMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// copyNewerTargetsMessage container for the count of objects skipped
+// because of a newer target, part of the cp summary.
+type copyNewerTargetsMessage struct {
+	Skipped int64 `json:"skippedNewerTargets"`
+}
+
+func (s copyNewerTargetsMessage) String() string {
+	return fmt.Sprintf("Newer targets: %d objects skipped.", s.Skipped)
+}
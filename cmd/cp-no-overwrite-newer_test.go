@@ -0,0 +1,70 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestTargetIsNewer(t *testing.T) {
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "object")
+	if err := os.WriteFile(target, []byte("target"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(target, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		target     string
+		sourceTime time.Time
+		newer      bool
+	}{
+		{target, modTime.Add(-time.Minute), true},
+		{target, modTime.Add(time.Minute), false},
+		// A missing target is always copied.
+		{filepath.Join(dir, "missing"), modTime.Add(-time.Minute), false},
+		// A source without modification time is always copied.
+		{target, time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		cpURLs := URLs{
+			SourceContent: &ClientContent{URL: *newClientURL("/source"), Time: testCase.sourceTime},
+			TargetContent: &ClientContent{URL: *newClientURL(testCase.target)},
+		}
+		newer, err := targetIsNewer(context.Background(), cpURLs, nil)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if newer != testCase.newer {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.newer, newer)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// Orders of the objects copied recursively, see `cp --order`.
+const (
+	copyOrderNone    = "none"
+	copyOrderLexical = "lexical"
+	copyOrderMtime   = "mtime"
+	copyOrderSize    = "size"
+)
+
+// copyOrderWarnObjects is the number of buffered objects above
+// which sorting them warns about the memory used.
+const copyOrderWarnObjects = 1000000
+
+// isValidCopyOrder returns true if order is a supported `--order` value.
+func isValidCopyOrder(order string) bool {
+	switch order {
+	case "", copyOrderNone, copyOrderLexical, copyOrderMtime, copyOrderSize:
+		return true
+	}
+	return false
+}
+
+// sortCopyURLs sorts the objects to copy by the given order, the objects
+// with the same modification time or size are sorted by their source.
+func sortCopyURLs(urls []URLs, order string) {
+	source := func(i int) string {
+		return urls[i].SourceAlias + "|" + urls[i].SourceContent.URL.String()
+	}
+	sort.SliceStable(urls, func(i, j int) bool {
+		si, sj := urls[i].SourceContent, urls[j].SourceContent
+		switch order {
+		case copyOrderMtime:
+			if !si.Time.Equal(sj.Time) {
+				return si.Time.Before(sj.Time)
+			}
+		case copyOrderSize:
+			if si.Size != sj.Size {
+				return si.Size < sj.Size
+			}
+		}
+		return source(i) < source(j)
+	})
+}
+
+// copyOrderMessage container for the warning about the memory used to sort a large listing.
+type copyOrderMessage struct {
+	Status  string `json:"status"`
+	Order   string `json:"order"`
+	Objects int    `json:"objects"`
+}
+
+func (c copyOrderMessage) String() string {
+	return console.Colorize("CopyOrder", fmt.Sprintf("Sorting more than %d objects by %s, memory use grows with the listing. Use `--order none` to stream it instead.", c.Objects, c.Order))
+}
+
+func (c copyOrderMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
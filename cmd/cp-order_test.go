@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortCopyURLs(t *testing.T) {
+	now := time.Now()
+	newURLs := func(path string, size int64, mtime time.Time) URLs {
+		return URLs{SourceContent: &ClientContent{URL: *newClientURL("/src/" + path), Size: size, Time: mtime}}
+	}
+	testCases := []struct {
+		order    string
+		expected []string
+	}{
+		{copyOrderLexical, []string{"/src/a", "/src/b", "/src/c", "/src/d/e"}},
+		{copyOrderMtime, []string{"/src/d/e", "/src/b", "/src/c", "/src/a"}},
+		{copyOrderSize, []string{"/src/c", "/src/d/e", "/src/a", "/src/b"}},
+	}
+	for i, tc := range testCases {
+		urls := []URLs{
+			newURLs("c", 1, now.Add(-time.Hour)),
+			newURLs("a", 2, now),
+			newURLs("d/e", 1, now.Add(-2*time.Hour)),
+			newURLs("b", 3, now.Add(-time.Hour)),
+		}
+		sortCopyURLs(urls, tc.order)
+		for j, path := range tc.expected {
+			if urls[j].SourceContent.URL.Path != path {
+				t.Fatalf("Test %d: expected %s at %d, got %s", i+1, path, j, urls[j].SourceContent.URL.Path)
+			}
+		}
+	}
+
+	for _, order := range []string{"", "none", "lexical", "mtime", "size"} {
+		if !isValidCopyOrder(order) {
+			t.Errorf("Expected `%s` to be a valid order", order)
+		}
+	}
+	if isValidCopyOrder("name") {
+		t.Error("Expected `name` to be an invalid order")
+	}
+}
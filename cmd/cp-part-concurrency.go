@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/env"
+)
+
+const defaultPartConcurrency = 4
+
+// globalPartConcurrency is the number of parts of a single multipart
+// object uploaded in parallel, set by `cp --part-concurrency`.
+var globalPartConcurrency int
+
+// globalMultipartUploads counts the multipart uploads initiated.
+var globalMultipartUploads int64
+
+// getPartConcurrency returns the effective number of parts uploaded in
+// parallel, falling back to MC_UPLOAD_MULTIPART_THREADS.
+func getPartConcurrency() (int, *probe.Error) {
+	if globalPartConcurrency > 0 {
+		return globalPartConcurrency, nil
+	}
+	partConcurrency, e := strconv.Atoi(env.Get("MC_UPLOAD_MULTIPART_THREADS", strconv.Itoa(defaultPartConcurrency)))
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	return partConcurrency, nil
+}
+
+// partConcurrencyMessage container for the part concurrency of multipart
+// uploads by cp, part of the cp summary.
+type partConcurrencyMessage struct {
+	PartConcurrency  int   `json:"partConcurrency"`
+	MultipartUploads int64 `json:"multipartUploads"`
+}
+
+func (p partConcurrencyMessage) String() string {
+	return fmt.Sprintf("Uploaded %d multipart object(s) with %d part(s) in parallel.", p.MultipartUploads, p.PartConcurrency)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// globalPartRetries counts the parts of multipart uploads sent more than once.
+var globalPartRetries int64
+
+// globalCountPartRetries is set by the commands reporting the multipart
+// uploads and the retried parts, only their clients count the requests.
+var globalCountPartRetries bool
+
+// partRetryTransport counts the retries of the parts of multipart uploads.
+// The retries themselves are done by minio-go, which retries every failed
+// request up to minio.MaxRetry times, so a failed part is sent again alone
+// and the parts already uploaded are not.
+type partRetryTransport struct {
+	transport http.RoundTripper
+
+	mutex sync.Mutex
+	// attempts of each part number by upload ID
+	attempts map[string]map[int]struct{}
+}
+
+func newPartRetryTransport(transport http.RoundTripper) *partRetryTransport {
+	return &partRetryTransport{
+		transport: transport,
+		attempts:  make(map[string]map[int]struct{}),
+	}
+}
+
+func (t *partRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	if uploadID := query.Get("uploadId"); uploadID != "" {
+		t.mutex.Lock()
+		switch req.Method {
+		case http.MethodPut:
+			if partNumber, e := strconv.Atoi(query.Get("partNumber")); e == nil {
+				parts, ok := t.attempts[uploadID]
+				if !ok {
+					parts = make(map[int]struct{})
+					t.attempts[uploadID] = parts
+				}
+				if _, ok = parts[partNumber]; ok {
+					atomic.AddInt64(&globalPartRetries, 1)
+				}
+				parts[partNumber] = struct{}{}
+			}
+		case http.MethodPost, http.MethodDelete:
+			// The upload is completed or aborted.
+			delete(t.attempts, uploadID)
+		}
+		t.mutex.Unlock()
+	} else if req.Method == http.MethodPost && query.Has("uploads") {
+		// A multipart upload is initiated.
+		atomic.AddInt64(&globalMultipartUploads, 1)
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// partRetriesMessage container for the number of parts retried by cp,
+// part of the cp summary.
+type partRetriesMessage struct {
+	Retries int64 `json:"partRetries"`
+}
+
+func (p partRetriesMessage) String() string {
+	return fmt.Sprintf("Retried %d part(s) of multipart uploads.", p.Retries)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPartRetryTransport(t *testing.T) {
+	transport := newPartRetryTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	send := func(method, query string) {
+		req, e := http.NewRequest(method, "https://play.min.io/bucket/object?"+query, nil)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if _, e = transport.RoundTrip(req); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	before := atomic.LoadInt64(&globalPartRetries)
+	uploadsBefore := atomic.LoadInt64(&globalMultipartUploads)
+	send(http.MethodPost, "uploads=")
+	send(http.MethodPut, "partNumber=1&uploadId=u1")
+	send(http.MethodPut, "partNumber=2&uploadId=u1")
+	// Part 2 retried twice.
+	send(http.MethodPut, "partNumber=2&uploadId=u1")
+	send(http.MethodPut, "partNumber=2&uploadId=u1")
+	// Same part number of another upload.
+	send(http.MethodPut, "partNumber=1&uploadId=u2")
+	// Not a part.
+	send(http.MethodPut, "")
+	send(http.MethodPut, "")
+	send(http.MethodPost, "uploadId=u1")
+
+	if retries := atomic.LoadInt64(&globalPartRetries) - before; retries != 2 {
+		t.Errorf("Expected 2 part retries, got %d", retries)
+	}
+	if uploads := atomic.LoadInt64(&globalMultipartUploads) - uploadsBefore; uploads != 1 {
+		t.Errorf("Expected 1 multipart upload, got %d", uploads)
+	}
+	if _, ok := transport.attempts["u1"]; ok {
+		t.Error("Expected the completed upload to be forgotten")
+	}
+}
+
+func TestGetPartConcurrency(t *testing.T) {
+	defer func(partConcurrency int) { globalPartConcurrency = partConcurrency }(globalPartConcurrency)
+
+	t.Setenv("MC_UPLOAD_MULTIPART_THREADS", "")
+	globalPartConcurrency = 0
+	if n, err := getPartConcurrency(); err != nil || n != defaultPartConcurrency {
+		t.Errorf("Expected default part concurrency %d, got %d, %v", defaultPartConcurrency, n, err)
+	}
+	t.Setenv("MC_UPLOAD_MULTIPART_THREADS", "8")
+	if n, err := getPartConcurrency(); err != nil || n != 8 {
+		t.Errorf("Expected part concurrency 8 from the environment, got %d, %v", n, err)
+	}
+	globalPartConcurrency = 16
+	if n, err := getPartConcurrency(); err != nil || n != 16 {
+		t.Errorf("Expected part concurrency 16 from the flag, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// resumeDownloadOffset returns the size of the partial file left by an
+// interrupted download of the source to resume it from there, or zero
+// to download it from the beginning. A partial file older than the
+// source was downloaded from a previous version of it.
+func resumeDownloadOffset(ctx context.Context, urls URLs, srcSSE encrypt.ServerSide) int64 {
+	st, e := os.Stat(urls.TargetContent.URL.Path + partSuffix)
+	if e != nil || !st.Mode().IsRegular() {
+		return 0
+	}
+	if st.Size() == 0 || st.Size() >= urls.SourceContent.Size || st.ModTime().Before(urls.SourceContent.Time) {
+		return 0
+	}
+	if urls.Decompress {
+		// Objects decompressed while downloading cannot be resumed
+		// from an offset of their compressed content.
+		sourceClnt, err := newClientFromAlias(urls.SourceAlias, urls.SourceContent.URL.String())
+		if err != nil {
+			return 0
+		}
+		sourceSt, err := sourceClnt.Stat(ctx, StatOptions{sse: srcSSE, versionID: urls.SourceContent.VersionID})
+		if err != nil || isGzipContentEncoding(sourceSt.Metadata) {
+			return 0
+		}
+	}
+	return st.Size()
+}
+
+// downloadMD5 returns the ETag of a downloaded object when it is the
+// MD5 of its content, which is not the case for objects uploaded in
+// multiple parts or encrypted.
+func downloadMD5(etag string, metadata map[string]string, sse encrypt.ServerSide) string {
+	etag = strings.Trim(etag, "\"")
+	if sse != nil || len(etag) != md5.Size*2 {
+		return ""
+	}
+	if _, e := hex.DecodeString(etag); e != nil {
+		return ""
+	}
+	for k := range metadata {
+		if strings.HasPrefix(http.CanonicalHeaderKey(k), "X-Amz-Server-Side-Encryption") {
+			return ""
+		}
+	}
+	return strings.ToLower(etag)
+}
+
+// partFile is the temporary file written before it is renamed to
+// the target, the MD5 of its content is computed to verify it.
+type partFile struct {
+	file *os.File
+	md5  hash.Hash
+}
+
+// openPartFile opens the temporary file of a put. A resumed download
+// appends to the content already downloaded, which is read again to
+// compute its MD5 and account it in the progress.
+func openPartFile(path string, opts PutOptions, progress io.Reader) (*partFile, error) {
+	p := &partFile{}
+	if opts.verifyMD5 != "" {
+		p.md5 = md5.New()
+	}
+
+	if opts.resumeOffset <= 0 {
+		flag := os.O_CREATE | os.O_WRONLY
+		if opts.resumable {
+			flag |= os.O_TRUNC
+		}
+		var e error
+		p.file, e = os.OpenFile(path, flag, 0o666)
+		return p, e
+	}
+
+	f, e := os.OpenFile(path, os.O_RDWR, 0o666)
+	if e != nil {
+		return nil, e
+	}
+	if e = f.Truncate(opts.resumeOffset); e != nil {
+		f.Close()
+		return nil, e
+	}
+	var w io.Writer = io.Discard
+	if p.md5 != nil {
+		w = p.md5
+	}
+	if _, e = io.CopyN(w, hookreader.NewHook(f, progress), opts.resumeOffset); e != nil {
+		f.Close()
+		return nil, e
+	}
+	p.file = f
+	return p, nil
+}
+
+func (p *partFile) Write(b []byte) (int, error) {
+	n, e := p.file.Write(b)
+	if p.md5 != nil {
+		p.md5.Write(b[:n])
+	}
+	return n, e
+}
+
+func (p *partFile) Close() error {
+	return p.file.Close()
+}
+
+// verify checks the MD5 of the content written to the temporary file,
+// which is removed on a mismatch since it cannot be resumed.
+func (p *partFile) verify(path, expected, target string) *probe.Error {
+	if p.md5 == nil || expected == "" {
+		return nil
+	}
+	if got := hex.EncodeToString(p.md5.Sum(nil)); got != expected {
+		os.Remove(path)
+		return probe.NewError(DownloadMismatch{Path: target, Expected: expected, Got: got})
+	}
+	return nil
+}
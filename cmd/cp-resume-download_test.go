@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResumeDownload(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "object")
+	content := []byte("hello world")
+	sum := md5.Sum(content)
+	etag := hex.EncodeToString(sum[:])
+
+	// Partial file left by an interrupted download.
+	if e := os.WriteFile(target+partSuffix, content[:6], 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	urls := URLs{
+		SourceAlias:   "play",
+		SourceContent: &ClientContent{Size: int64(len(content)), Time: time.Now().Add(-time.Hour)},
+		TargetContent: &ClientContent{URL: *newClientURL(target)},
+	}
+	offset := resumeDownloadOffset(context.Background(), urls, nil)
+	if offset != 6 {
+		t.Fatalf("expected to resume from 6, got %d", offset)
+	}
+
+	// A partial file older than the source is downloaded again.
+	urls.SourceContent.Time = time.Now().Add(time.Hour)
+	if o := resumeDownloadOffset(context.Background(), urls, nil); o != 0 {
+		t.Fatalf("expected to download again, got %d", o)
+	}
+
+	clnt, err := fsNew(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := PutOptions{resumable: true, resumeOffset: offset, verifyMD5: etag}
+	if _, err = clnt.Put(context.Background(), bytes.NewReader(content[offset:]), int64(len(content))-offset, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+	got, e := os.ReadFile(target)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("expected %q, got %q", content, got)
+	}
+	if _, e = os.Stat(target + partSuffix); !os.IsNotExist(e) {
+		t.Fatalf("expected the partial file to be renamed, %v", e)
+	}
+
+	// A corrupted partial file fails the verification and is removed.
+	if e = os.WriteFile(target+partSuffix, []byte("HELLO "), 0o644); e != nil {
+		t.Fatal(e)
+	}
+	if _, err = clnt.Put(context.Background(), bytes.NewReader(content[offset:]), int64(len(content))-offset, nil, opts); err == nil {
+		t.Fatalf("expected a verification failure")
+	}
+	if _, e = os.Stat(target + partSuffix); !os.IsNotExist(e) {
+		t.Fatalf("expected the partial file to be removed, %v", e)
+	}
+}
+
+func TestDownloadMD5(t *testing.T) {
+	testCases := []struct {
+		etag     string
+		metadata map[string]string
+		expected string
+	}{
+		{`"5EB63BBBE01EEED093CB22BB8F5ACDC3"`, nil, "5eb63bbbe01eeed093cb22bb8f5acdc3"},
+		{"5eb63bbbe01eeed093cb22bb8f5acdc3-2", nil, ""},
+		{"5eb63bbbe01eeed093cb22bb8f5acdc3", map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}, ""},
+		{"zzb63bbbe01eeed093cb22bb8f5acdc3", nil, ""},
+	}
+	for i, testCase := range testCases {
+		if got := downloadMD5(testCase.etag, testCase.metadata, nil); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
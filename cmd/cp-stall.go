@@ -0,0 +1,147 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// ObjectStalled - no data was transferred for an object for too long.
+type ObjectStalled struct {
+	Timeout time.Duration
+}
+
+func (e ObjectStalled) Error() string {
+	return "Transfer stalled, no data transferred for " + e.Timeout.String()
+}
+
+// ObjectTimedOut - the transfer of an object took too long.
+type ObjectTimedOut struct {
+	Timeout time.Duration
+}
+
+func (e ObjectTimedOut) Error() string {
+	return "Transfer timed out after " + e.Timeout.String()
+}
+
+// copyTimeouts are the limits of the transfer of a single object,
+// a zero value disables the limit.
+type copyTimeouts struct {
+	stall  time.Duration
+	object time.Duration
+}
+
+// stallReader records when data was last transferred through a
+// progress reader.
+type stallReader struct {
+	io.Reader
+	last int64
+}
+
+func (r *stallReader) Read(p []byte) (int, error) {
+	n, e := r.Reader.Read(p)
+	if n > 0 {
+		atomic.StoreInt64(&r.last, time.Now().UnixNano())
+	}
+	return n, e
+}
+
+// idle returns how long no data was transferred.
+func (r *stallReader) idle() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&r.last)))
+}
+
+// watchInterval returns how often the transfer is checked.
+func (t copyTimeouts) watchInterval() time.Duration {
+	interval := t.object
+	if t.stall > 0 && (interval == 0 || t.stall < interval) {
+		interval = t.stall
+	}
+	interval /= 10
+	switch {
+	case interval < 10*time.Millisecond:
+		interval = 10 * time.Millisecond
+	case interval > time.Second:
+		interval = time.Second
+	}
+	return interval
+}
+
+// run transfers an object with transfer, canceling it when no data moves
+// through progress for the stall timeout or when it exceeds the object
+// timeout. Server side copies do not report progress until they are
+// done, only the object timeout applies to them.
+func (t copyTimeouts) run(ctx context.Context, progress io.Reader, serverSide bool, transfer func(context.Context, io.Reader) URLs) URLs {
+	stall := t.stall
+	if serverSide {
+		stall = 0
+	}
+	if stall <= 0 && t.object <= 0 {
+		return transfer(ctx, progress)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	reader := &stallReader{Reader: progress, last: time.Now().UnixNano()}
+	start := time.Now()
+	var timeoutErr atomic.Value
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(t.watchInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				switch {
+				case t.object > 0 && time.Since(start) > t.object:
+					timeoutErr.Store(probe.NewError(ObjectTimedOut{Timeout: t.object}))
+				case stall > 0 && reader.idle() > stall:
+					timeoutErr.Store(probe.NewError(ObjectStalled{Timeout: stall}))
+				default:
+					continue
+				}
+				cancel()
+				return
+			}
+		}
+	}()
+
+	urls := transfer(ctx, reader)
+	close(done)
+	if err, ok := timeoutErr.Load().(*probe.Error); ok && urls.Error != nil {
+		urls.Error = err.Trace(urls.SourceContent.URL.String())
+	}
+	return urls
+}
+
+// isServerSideCopy returns true if the object is copied by the server
+// from the source to the target, as done by uploadSourceToTargetURL.
+func isServerSideCopy(cpURLs URLs, isZip bool) bool {
+	if cpURLs.SourceAlias == "" || cpURLs.SourceAlias != cpURLs.TargetAlias || isZip {
+		return false
+	}
+	return !cpURLs.Compress || !isCompressibleContentType(guessURLContentType(cpURLs.SourceContent.URL.String()))
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// countingReader accounts the transferred bytes like a progress reader.
+type countingReader struct{}
+
+func (countingReader) Read(p []byte) (int, error) { return len(p), nil }
+
+func TestCopyTimeouts(t *testing.T) {
+	cpURLs := URLs{SourceContent: &ClientContent{URL: *newClientURL("/src/object")}}
+
+	// transfer moves data every tick until the transfer lasts for the
+	// given duration, or until it is canceled.
+	transfer := func(tick, duration time.Duration) func(context.Context, io.Reader) URLs {
+		return func(ctx context.Context, progress io.Reader) URLs {
+			deadline := time.After(duration)
+			for {
+				select {
+				case <-ctx.Done():
+					return cpURLs.WithError(probe.NewError(ctx.Err()))
+				case <-deadline:
+					return cpURLs
+				case <-time.After(tick):
+					if tick < duration {
+						progress.Read(make([]byte, 1))
+					}
+				}
+			}
+		}
+	}
+
+	testCases := []struct {
+		timeouts   copyTimeouts
+		serverSide bool
+		tick       time.Duration
+		duration   time.Duration
+		category   string
+	}{
+		// Slow but moving transfers are not stalled.
+		{copyTimeouts{stall: 100 * time.Millisecond}, false, 20 * time.Millisecond, 300 * time.Millisecond, ""},
+		{copyTimeouts{stall: 100 * time.Millisecond}, false, time.Second, time.Second, copyErrStalled},
+		{copyTimeouts{object: 100 * time.Millisecond}, false, 20 * time.Millisecond, time.Second, copyErrTimedOut},
+		// Server side copies report no progress until they are done.
+		{copyTimeouts{stall: 100 * time.Millisecond}, true, time.Second, 300 * time.Millisecond, ""},
+		{copyTimeouts{stall: 50 * time.Millisecond, object: 100 * time.Millisecond}, true, time.Second, time.Second, copyErrTimedOut},
+		{copyTimeouts{}, false, time.Second, 100 * time.Millisecond, ""},
+	}
+	for i, tc := range testCases {
+		urls := tc.timeouts.run(context.Background(), countingReader{}, tc.serverSide, transfer(tc.tick, tc.duration))
+		switch {
+		case tc.category == "" && urls.Error != nil:
+			t.Errorf("Test %d: unexpected error %v", i+1, urls.Error)
+		case tc.category != "" && urls.Error == nil:
+			t.Errorf("Test %d: expected a %s error", i+1, tc.category)
+		case tc.category != "" && copyErrorCategory(urls.Error) != tc.category:
+			t.Errorf("Test %d: expected a %s error, got %v", i+1, tc.category, urls.Error)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/words"
+)
+
+// amazonStorageClasses lists the storage classes accepted by Amazon S3,
+// INTELLIGENT_TIERING moves objects between access tiers automatically.
+var amazonStorageClasses = set.CreateStringSet(
+	"STANDARD",
+	"REDUCED_REDUNDANCY",
+	"STANDARD_IA",
+	"ONEZONE_IA",
+	"INTELLIGENT_TIERING",
+	"GLACIER",
+	"GLACIER_IR",
+	"DEEP_ARCHIVE",
+	"OUTPOSTS",
+)
+
+// minioStorageClasses lists the storage classes accepted by MinIO.
+var minioStorageClasses = set.CreateStringSet(
+	"STANDARD",
+	"REDUCED_REDUNDANCY",
+)
+
+// closestStorageClass returns the known storage class the given value
+// is most likely a typo of, or an empty string when none is close.
+func closestStorageClass(storageClass string, known set.StringSet) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(storageClass, "-", "_"))
+	closest, distance := "", 3 // more than 2 typos is not a typo anymore
+	for _, class := range known.ToSlice() {
+		if d := words.DamerauLevenshteinDistance(normalized, class); d < distance {
+			closest, distance = class, d
+		}
+	}
+	return closest
+}
+
+// checkStorageClass validates the storage class requested for a target.
+// Obvious typos of a known class are rejected, any other class unknown to
+// mc is passed through as is with a warning, backends add new classes and
+// the server is left to accept or reject them.
+func checkStorageClass(storageClass string, amazon bool) (warning string, err *probe.Error) {
+	known := minioStorageClasses
+	if amazon {
+		known = amazonStorageClasses
+	}
+	if known.Contains(storageClass) {
+		return "", nil
+	}
+	// Backend specific classes such as INTELLIGENT_TIERING are
+	// not typos on other backends.
+	if !amazonStorageClasses.Contains(storageClass) {
+		if closest := closestStorageClass(storageClass, amazonStorageClasses); closest != "" {
+			return "", probe.NewError(fmt.Errorf("unknown storage class `%s`, did you mean `%s`", storageClass, closest))
+		}
+	}
+	return fmt.Sprintf("Storage class `%s` is not known for this target, passing it through to the server as is.", storageClass), nil
+}
+
+// storageClassMessage reports the storage class requested for the
+// uploaded objects, part of the cp summary.
+type storageClassMessage struct {
+	StorageClass string `json:"storageClass"`
+	Objects      int64  `json:"objects"`
+}
+
+func (s storageClassMessage) String() string {
+	return fmt.Sprintf("Requested storage class `%s` for %d object(s).", s.StorageClass, s.Objects)
+}
+
+// checkTargetStorageClass validates `--storage-class` against the
+// backend of the target alias, local targets have no storage class.
+func checkTargetStorageClass(tgtURL, storageClass string) {
+	_, urlStr, aliasCfg := mustExpandAlias(tgtURL)
+	if aliasCfg == nil {
+		return
+	}
+	warning, err := checkStorageClass(storageClass, isAmazon(newClientURL(urlStr).Host))
+	fatalIf(err.Trace(tgtURL, storageClass), "Invalid `--storage-class` for `"+tgtURL+"`.")
+	if warning != "" && !globalJSON {
+		fmt.Fprintln(os.Stderr, warnText("WARNING: "+warning))
+	}
+}
+
+// targetStorageClasses returns the storage classes accepted by the backend
+// of the target alias, or nil when any class is passed through. Amazon S3
+// adds classes over time and validates them itself, other backends are
+// expected to accept only the classes of MinIO.
+func targetStorageClasses(tgtURL string) set.StringSet {
+	_, urlStr, _ := mustExpandAlias(tgtURL)
+	if isAmazon(newClientURL(urlStr).Host) {
+		return nil
+	}
+	return minioStorageClasses
+}
+
+// preservedStorageClass returns the storage class to set on the target
+// for a source object of the given class, supported is false when the
+// target does not accept it and the default class is used instead. Any
+// class is supported when classes is nil.
+func preservedStorageClass(storageClass string, classes set.StringSet) (class string, supported bool) {
+	class = strings.ToUpper(storageClass)
+	if class == "" || classes == nil || classes.Contains(class) {
+		return class, true
+	}
+	return "", false
+}
+
+// storageClassFallbackMessage warns about an object copied with the
+// default storage class, its own class being unsupported by the target.
+type storageClassFallbackMessage struct {
+	Status       string `json:"status"`
+	Source       string `json:"source"`
+	StorageClass string `json:"storageClass"`
+}
+
+func (s storageClassFallbackMessage) String() string {
+	return warnText(fmt.Sprintf("WARNING: storage class `%s` of `%s` is not supported by the target, using the default storage class.", s.StorageClass, s.Source))
+}
+
+func (s storageClassFallbackMessage) JSON() string {
+	s.Status = "warning"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
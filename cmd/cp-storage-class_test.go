@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+func TestCheckStorageClass(t *testing.T) {
+	testCases := []struct {
+		storageClass string
+		amazon       bool
+		warning      bool
+		err          bool
+	}{
+		{"STANDARD", true, false, false},
+		{"INTELLIGENT_TIERING", true, false, false},
+		{"GLACIER_IR", true, false, false},
+		// Typos are caught for every backend.
+		{"INTELLIGENT_TEIRING", true, false, true},
+		{"intelligent-tiering", true, false, true},
+		{"REDUCED_REDUNDANCY", false, false, false},
+		{"REDUCED_REDUNDANSY", false, false, true},
+		// Unknown classes are passed through with a warning.
+		{"EXPRESS_ONEZONE", true, true, false},
+		{"WARM", false, true, false},
+		{"INTELLIGENT_TIERING", false, true, false},
+	}
+
+	for i, testCase := range testCases {
+		warning, err := checkStorageClass(testCase.storageClass, testCase.amazon)
+		if (err != nil) != testCase.err {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if (warning != "") != testCase.warning {
+			t.Fatalf("Test %d: expected warning %v, got %q", i+1, testCase.warning, warning)
+		}
+	}
+}
+
+func TestClosestStorageClass(t *testing.T) {
+	if closest := closestStorageClass("INTELIGENT_TIERING", amazonStorageClasses); closest != "INTELLIGENT_TIERING" {
+		t.Fatalf("expected INTELLIGENT_TIERING, got %q", closest)
+	}
+	if closest := closestStorageClass("ARCHIVE", minioStorageClasses); closest != "" {
+		t.Fatalf("expected no close storage class, got %q", closest)
+	}
+}
+
+func TestPreservedStorageClass(t *testing.T) {
+	testCases := []struct {
+		storageClass string
+		classes      set.StringSet
+		class        string
+		supported    bool
+	}{
+		{"", minioStorageClasses, "", true},
+		{"STANDARD", minioStorageClasses, "STANDARD", true},
+		{"reduced_redundancy", minioStorageClasses, "REDUCED_REDUNDANCY", true},
+		{"GLACIER_IR", minioStorageClasses, "", false},
+		{"GLACIER_IR", amazonStorageClasses, "GLACIER_IR", true},
+		{"WARM", amazonStorageClasses, "", false},
+		{"EXPRESS_ONEZONE", nil, "EXPRESS_ONEZONE", true},
+	}
+
+	for i, testCase := range testCases {
+		class, supported := preservedStorageClass(testCase.storageClass, testCase.classes)
+		if class != testCase.class || supported != testCase.supported {
+			t.Fatalf("Test %d: expected (%q, %v), got (%q, %v)", i+1, testCase.class, testCase.supported, class, supported)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// copySummaryMessage container for the summary printed at the end of cp,
+// each part is only set when the matching feature was used.
+type copySummaryMessage struct {
+	Status          string                   `json:"status"`
+	Symlinks        *copySymlinksMessage     `json:"symlinks,omitempty"`
+	NewerTargets    *copyNewerTargetsMessage `json:"newerTargets,omitempty"`
+	MetadataSync    *metadataSyncMessage     `json:"metadataSync,omitempty"`
+	StorageClass    *storageClassMessage     `json:"storageClass,omitempty"`
+	PartRetries     *partRetriesMessage      `json:"partRetries,omitempty"`
+	PartConcurrency *partConcurrencyMessage  `json:"partConcurrency,omitempty"`
+	RetryBudget     *retryBudgetMessage      `json:"retryBudget,omitempty"`
+	AutoParallel    *autoParallelMessage     `json:"autoParallel,omitempty"`
+}
+
+// parts returns the parts of the summary which are set.
+func (s copySummaryMessage) parts() []fmt.Stringer {
+	var parts []fmt.Stringer
+	if s.Symlinks != nil {
+		parts = append(parts, s.Symlinks)
+	}
+	if s.NewerTargets != nil {
+		parts = append(parts, s.NewerTargets)
+	}
+	if s.MetadataSync != nil {
+		parts = append(parts, s.MetadataSync)
+	}
+	if s.StorageClass != nil {
+		parts = append(parts, s.StorageClass)
+	}
+	if s.PartRetries != nil {
+		parts = append(parts, s.PartRetries)
+	}
+	if s.RetryBudget != nil {
+		parts = append(parts, s.RetryBudget)
+	}
+	if s.AutoParallel != nil {
+		parts = append(parts, s.AutoParallel)
+	}
+	if s.PartConcurrency != nil {
+		parts = append(parts, s.PartConcurrency)
+	}
+	return parts
+}
+
+// empty returns true when there is nothing to summarize.
+func (s copySummaryMessage) empty() bool {
+	return len(s.parts()) == 0
+}
+
+func (s copySummaryMessage) String() string {
+	lines := make([]string, 0, 8)
+	for _, part := range s.parts() {
+		lines = append(lines, part.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s copySummaryMessage) JSON() string {
+	s.Status = "success"
+	// The status of the summary applies to all its parts.
+	if s.Symlinks != nil {
+		symlinks := *s.Symlinks
+		symlinks.Status = ""
+		s.Symlinks = &symlinks
+	}
+	if s.MetadataSync != nil {
+		metadataSync := *s.MetadataSync
+		metadataSync.Status = ""
+		s.MetadataSync = &metadataSync
+	}
+	if s.RetryBudget != nil {
+		retryBudget := *s.RetryBudget
+		retryBudget.Status = ""
+		s.RetryBudget = &retryBudget
+	}
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	gojson "encoding/json"
+)
+
+func TestCopySummaryMessage(t *testing.T) {
+	if !(copySummaryMessage{}).empty() {
+		t.Fatal("expected an empty summary")
+	}
+
+	summary := copySummaryMessage{
+		Symlinks:    &copySymlinksMessage{Status: "success", Followed: 2, Skipped: 1},
+		PartRetries: &partRetriesMessage{Retries: 3},
+	}
+	if lines := strings.Split(summary.String(), "\n"); len(lines) != 2 || lines[0] != "Symlinks: 2 followed, 1 skipped." {
+		t.Fatalf("unexpected summary %q", summary.String())
+	}
+
+	var decoded map[string]interface{}
+	if e := gojson.Unmarshal([]byte(summary.JSON()), &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if decoded["status"] != "success" {
+		t.Fatalf("expected a success status, got %v", decoded["status"])
+	}
+	symlinks, ok := decoded["symlinks"].(map[string]interface{})
+	if !ok || symlinks["followedSymlinks"] != float64(2) {
+		t.Fatalf("unexpected symlinks %v", decoded["symlinks"])
+	}
+	if _, ok = symlinks["status"]; ok {
+		t.Fatal("expected no status in the parts of the summary")
+	}
+	if _, ok = decoded["metadataSync"]; ok {
+		t.Fatal("expected the unset parts to be omitted")
+	}
+	// The parts are not modified by the JSON rendering.
+	if summary.Symlinks.Status != "success" {
+		t.Fatal("expected the symlinks status to be kept")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// copySymlinkOpt returns how symlinks of a local source are listed by cp.
+// mv keeps following the links to files, skipping a link would leave
+// it behind at the source.
+func copySymlinkOpt(followSymlinks, noFollowExternal, storeSymlinks, isMvCmd bool) SymlinkOpt {
+	switch {
+	case isMvCmd:
+		return SymlinksDefault
+	case storeSymlinks:
+		return SymlinksStore
+	case followSymlinks && noFollowExternal:
+		return SymlinksFollowInternal
+	case followSymlinks:
+		return SymlinksFollow
+	}
+	return SymlinksSkip
+}
+
+// copySymlinks counts the symlinks followed, stored and skipped while listing the sources.
+type copySymlinks struct {
+	followed int64
+	skipped  int64
+	stored   int64
+}
+
+// follow records a followed symlink.
+func (s *copySymlinks) follow() {
+	if s != nil {
+		atomic.AddInt64(&s.followed, 1)
+	}
+}
+
+// store records a symlink uploaded as an object.
+func (s *copySymlinks) store() {
+	if s != nil {
+		atomic.AddInt64(&s.stored, 1)
+	}
+}
+
+// skip records and warns about a skipped symlink.
+func (s *copySymlinks) skip(err *probe.Error) {
+	if s != nil {
+		atomic.AddInt64(&s.skipped, 1)
+	}
+	// Print in new line and adjust to top so that we
+	// don't print over the ongoing scan or progress bar.
+	if !globalQuiet && !globalJSON {
+		console.Eraseline()
+	}
+	if skipped, ok := err.ToGoError().(SkippedSymlink); ok {
+		printMsg(symlinkSkippedMessage{Status: "warning", Path: skipped.Path, Reason: skipped.Reason})
+	}
+}
+
+// summary returns the counts of symlinks, nil if none were seen.
+func (s *copySymlinks) summary() *copySymlinksMessage {
+	if s == nil {
+		return nil
+	}
+	followed, skipped, stored := atomic.LoadInt64(&s.followed), atomic.LoadInt64(&s.skipped), atomic.LoadInt64(&s.stored)
+	if followed == 0 && skipped == 0 && stored == 0 {
+		return nil
+	}
+	return &copySymlinksMessage{Status: "success", Followed: followed, Skipped: skipped, Stored: stored}
+}
+
+// symlinkSkippedMessage container for a symlink which is not copied.
+type symlinkSkippedMessage struct {
+	Status string `json:"status"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+func (s symlinkSkippedMessage) String() string {
+	return console.Colorize("SymlinkSkipped", fmt.Sprintf("Skipping symlink `%s`, %s.", s.Path, s.Reason))
+}
+
+func (s symlinkSkippedMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// copySymlinksMessage container for the counts of symlinks seen by cp.
+type copySymlinksMessage struct {
+	Status   string `json:"status,omitempty"`
+	Followed int64  `json:"followedSymlinks"`
+	Skipped  int64  `json:"skippedSymlinks"`
+	Stored   int64  `json:"storedSymlinks,omitempty"`
+}
+
+func (s copySymlinksMessage) String() string {
+	if s.Stored > 0 {
+		return fmt.Sprintf("Symlinks: %d stored, %d skipped.", s.Stored, s.Skipped)
+	}
+	return fmt.Sprintf("Symlinks: %d followed, %d skipped.", s.Followed, s.Skipped)
+}
+
+func (s copySymlinksMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
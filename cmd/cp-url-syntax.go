@@ -20,15 +20,42 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/console"
 )
 
-func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
+// copyListingFlags are the flags which only apply to the listing of the
+// sources, they do not apply to the objects of a manifest.
+var copyListingFlags = []string{
+	"recursive", "rewind", "version-id", "zip", "older-than", "newer-than", "newer-than-file",
+	"min-size", "max-size", "include-from", "exclude-from", "tag-filter", "order", "list-concurrency",
+	"follow-symlinks", "no-follow-external", "store-symlinks",
+}
+
+func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, args []string, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
+	if manifest := cliCtx.String("from-file"); !isMvCmd && manifest != "" {
+		// Sources and targets are read from the manifest,
+		// only an optional default target is accepted.
+		if len(cliCtx.Args()) > 1 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Only a TARGET argument is accepted with --from-file.")
+		}
+		// The manifest lists the objects to copy, the source is not listed.
+		for _, flag := range copyListingFlags {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(), "`--"+flag+"` cannot be used with `--from-file`.")
+			}
+		}
+		checkCopyFlagsSyntax(ctx, cliCtx, copyManifestTargetBuckets(manifest, args))
+		return
+	}
+
 	if len(cliCtx.Args()) < 2 {
 		if isMvCmd {
 			showCommandHelpAndExit(cliCtx, 1) // last argument is exit code.
@@ -37,7 +64,7 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	}
 
 	// extract URLs.
-	URLs := cliCtx.Args()
+	URLs := args
 	if len(URLs) < 2 {
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to parse source and target arguments.")
 	}
@@ -53,6 +80,8 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to pass --version flag with multiple copy sources arguments.")
 	}
 
+	checkTagFilterSyntax(cliCtx, srcURLs)
+
 	if isZip && cliCtx.String("rewind") != "" {
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "--zip and --rewind cannot be used together")
 	}
@@ -83,13 +112,7 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
-	if cliCtx.String(rdFlag) != "" && cliCtx.String(rmFlag) == "" {
-		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
-	}
-
-	if cliCtx.String(rdFlag) == "" && cliCtx.String(rmFlag) != "" {
-		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
-	}
+	checkCopyFlagsSyntax(ctx, cliCtx, []string{tgtURL})
 
 	operation := "copy"
 	if isMvCmd {
@@ -140,6 +163,148 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	}
 }
 
+// checkCopyFlagsSyntax validates the flags which do not depend on the
+// sources, tgtURLs are the targets of the copy.
+func checkCopyFlagsSyntax(ctx context.Context, cliCtx *cli.Context, tgtURLs []string) {
+	if storageClass := cliCtx.String("storage-class"); storageClass != "" {
+		for _, tgtURL := range tgtURLs {
+			checkTargetStorageClass(tgtURL, storageClass)
+		}
+	}
+
+	if cliCtx.String(rdFlag) != "" && cliCtx.String(rmFlag) == "" {
+		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
+	}
+
+	if cliCtx.String(rdFlag) == "" && cliCtx.String(rmFlag) != "" {
+		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
+	}
+
+	if retention := cliCtx.String("retention"); retention != "" {
+		if cliCtx.String(rmFlag) != "" || cliCtx.String(rdFlag) != "" {
+			fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("`--retention` cannot be used with `--%s` or `--%s`.", rmFlag, rdFlag))
+		}
+		_, _, err := parseRetentionModeDuration(retention)
+		fatalIf(err.Trace(retention), "Unable to parse `--retention`.")
+	}
+
+	if legalHold := cliCtx.String(lhFlag); legalHold != "" {
+		if !minio.LegalHoldStatus(strings.ToUpper(legalHold)).IsValid() {
+			fatalIf(errInvalidArgument().Trace(legalHold), fmt.Sprintf("Invalid `--%s` value, expected 'on' or 'off'.", lhFlag))
+		}
+	}
+
+	switch progress := cliCtx.String("progress"); progress {
+	case "", progressStyleBar, progressStylePlain, progressStyleNone:
+	default:
+		fatalIf(errInvalidArgument().Trace(progress), "Invalid `--progress` value, expected 'bar', 'plain' or 'none'.")
+	}
+
+	if cliCtx.String("error-file") != "" && !cliCtx.Bool("skip-errors") {
+		fatalIf(errInvalidArgument().Trace(), "`--error-file` requires `--skip-errors`.")
+	}
+
+	if newerThanFile := cliCtx.String("newer-than-file"); newerThanFile != "" {
+		if cliCtx.String("newer-than") != "" {
+			fatalIf(errInvalidArgument().Trace(newerThanFile), "`--newer-than-file` cannot be used with `--newer-than`.")
+		}
+		if st, e := os.Stat(newerThanFile); e == nil && st.IsDir() {
+			fatalIf(errInvalidArgument().Trace(newerThanFile), "`--newer-than-file` must be a file, not a folder.")
+		}
+	}
+
+	if _, err := parseSizeRange(cliCtx.String("min-size"), cliCtx.String("max-size")); err != nil {
+		fatalIf(err.Trace(), "Invalid `--min-size` or `--max-size`.")
+	}
+
+	if cliCtx.Int("part-retries") < 0 {
+		fatalIf(errInvalidArgument().Trace(), "`--part-retries` cannot be negative.")
+	}
+
+	if cliCtx.IsSet("retry-budget") && cliCtx.Duration("retry-budget") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "`--retry-budget` must be a positive duration.")
+	}
+
+	if listCache := cliCtx.String("list-cache"); listCache != "" {
+		switch {
+		case !cliCtx.Bool("recursive"):
+			fatalIf(errInvalidArgument().Trace(listCache), "`--list-cache` requires `--recursive`.")
+		case cliCtx.Bool("continue"):
+			fatalIf(errInvalidArgument().Trace(listCache), "`--list-cache` cannot be used with `--continue`, the list cache is used to resume instead.")
+		case cliCtx.String("from-file") != "":
+			fatalIf(errInvalidArgument().Trace(listCache), "`--list-cache` cannot be used with `--from-file`.")
+		}
+	} else if cliCtx.Bool("refresh-cache") {
+		fatalIf(errInvalidArgument().Trace(), "`--refresh-cache` requires `--list-cache`.")
+	}
+
+	if cliCtx.Bool("metadata-sync") {
+		// The target metadata would differ from the source by design.
+		for _, flag := range []string{"attr", "metadata-from-json", "tags", "compress", "zip"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(), "`--metadata-sync` cannot be used with `--"+flag+"`.")
+			}
+		}
+	}
+
+	for _, flag := range []string{"stall-timeout", "object-timeout"} {
+		if cliCtx.IsSet(flag) && cliCtx.Duration(flag) <= 0 {
+			fatalIf(errInvalidArgument().Trace(), "`--"+flag+"` must be a positive duration.")
+		}
+	}
+
+	if cliCtx.IsSet("part-concurrency") && cliCtx.Int("part-concurrency") < 1 {
+		fatalIf(errInvalidArgument().Trace(), "`--part-concurrency` must be at least 1.")
+	}
+
+	if order := cliCtx.String("order"); !isValidCopyOrder(order) {
+		fatalIf(errInvalidArgument().Trace(order), "Invalid `--order` value, expected 'none', 'lexical', 'mtime' or 'size'.")
+	} else if order != "" && order != copyOrderNone && !cliCtx.Bool("recursive") {
+		fatalIf(errInvalidArgument().Trace(order), "`--order` requires `--recursive`.")
+	}
+
+	if cliCtx.IsSet("list-concurrency") {
+		switch {
+		case cliCtx.Int("list-concurrency") < 1:
+			fatalIf(errInvalidArgument().Trace(), "`--list-concurrency` must be at least 1.")
+		case !cliCtx.Bool("recursive"):
+			fatalIf(errInvalidArgument().Trace(), "`--list-concurrency` requires `--recursive`.")
+		}
+		// Versioned and zip listings are not split by prefix.
+		for _, flag := range []string{"rewind", "version-id", "zip"} {
+			if cliCtx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(), "`--list-concurrency` cannot be used with `--"+flag+"`.")
+			}
+		}
+	}
+
+	if cliCtx.Bool("no-follow-external") && !cliCtx.Bool("follow-symlinks") {
+		fatalIf(errInvalidArgument().Trace(), "`--no-follow-external` requires `--follow-symlinks`.")
+	}
+
+	if cliCtx.Bool("store-symlinks") {
+		switch {
+		case cliCtx.Bool("follow-symlinks"):
+			fatalIf(errInvalidArgument().Trace(), "`--store-symlinks` cannot be used with `--follow-symlinks`.")
+		case !cliCtx.Bool("recursive"):
+			fatalIf(errInvalidArgument().Trace(), "`--store-symlinks` requires `--recursive`.")
+		}
+		for _, tgtURL := range tgtURLs {
+			if _, _, hostCfg, _ := expandAlias(tgtURL); hostCfg == nil {
+				fatalIf(errInvalidArgument().Trace(tgtURL), "`--store-symlinks` requires an object storage target.")
+			}
+		}
+	}
+
+	// Objects are locked from creation, make sure the target supports it before
+	// starting. Turning the legal hold off needs no lock.
+	if cliCtx.String("retention") != "" || cliCtx.String(rmFlag) != "" || strings.EqualFold(cliCtx.String(lhFlag), "on") {
+		for _, tgtURL := range tgtURLs {
+			fatalIfBucketLockNotEnabled(ctx, tgtURL)
+		}
+	}
+}
+
 // checkCopySyntaxTypeA verifies if the source and target are valid file arguments.
 func checkCopySyntaxTypeA(ctx context.Context, srcURL, versionID string, tgtURL string, keys map[string][]prefixSSEPair, isZip, isMvCmd bool, timeRef time.Time) {
 	_, srcContent, err := url2Stat(ctx, srcURL, versionID, false, keys, timeRef, isZip)
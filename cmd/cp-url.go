@@ -19,11 +19,13 @@ package cmd
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
 )
 
 type copyURLsType uint8
@@ -158,7 +160,7 @@ func makeCopyContentTypeB(sourceAlias string, sourceContent *ClientContent, targ
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive, isZip bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive, isZip bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair, symlinks SymlinkOpt, symlinkStats *copySymlinks, listConcurrency int) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -173,20 +175,42 @@ func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRe
 			return
 		}
 
-		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: isRecursive, TimeRef: timeRef, ShowDir: DirNone, ListZip: isZip}) {
+		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: isRecursive, TimeRef: timeRef, ShowDir: DirNone, ListZip: isZip, Symlinks: symlinks, Concurrency: listConcurrency}) {
 			if sourceContent.Err != nil {
+				if _, ok := sourceContent.Err.ToGoError().(SkippedSymlink); ok {
+					symlinkStats.skip(sourceContent.Err)
+					continue
+				}
 				// Listing failed.
 				copyURLsCh <- URLs{Error: sourceContent.Err.Trace(sourceClient.GetURL().String())}
 				continue
 			}
 
+			if sourceContent.SymlinkTarget != "" {
+				// Symlink uploaded as an object with its target.
+				symlinkStats.store()
+				copyURLsCh <- makeCopyContentTypeC(sourceAlias, sourceClient.GetURL(), sourceContent, targetAlias, targetURL, encKeyDB)
+				continue
+			}
+
+			if sourceContent.Type&os.ModeSymlink != 0 {
+				// Symlink followed, its content is listed next.
+				symlinkStats.follow()
+				continue
+			}
+
 			if !sourceContent.Type.IsRegular() {
 				// Source is not a regular file. Skip it for copy.
 				continue
 			}
 
 			// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
-			copyURLsCh <- makeCopyContentTypeC(sourceAlias, sourceClient.GetURL(), sourceContent, targetAlias, targetURL, encKeyDB)
+			cpURLs := makeCopyContentTypeC(sourceAlias, sourceClient.GetURL(), sourceContent, targetAlias, targetURL, encKeyDB)
+			if targetAlias == "" {
+				// Symlinks recreated by a download must point within the target folder.
+				cpURLs.symlinkRoot = targetURL
+			}
+			copyURLsCh <- cpURLs
 		}
 	}(sourceURL, targetURL, copyURLsCh)
 	return copyURLsCh
@@ -207,12 +231,12 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL ClientURL, sourceContent
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair, symlinks SymlinkOpt, symlinkStats *copySymlinks, listConcurrency int) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, false, timeRef, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, false, timeRef, encKeyDB, symlinks, symlinkStats, listConcurrency) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -220,6 +244,17 @@ func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL st
 	return copyURLsCh
 }
 
+// copyURLsSuffix returns the path of the object being copied relative to
+// the target URL, this is the name include/exclude patterns match against.
+func copyURLsSuffix(cpURLs URLs, targetURL string) string {
+	suffix := strings.TrimPrefix(cpURLs.TargetContent.URL.String(), targetURL)
+	suffix = strings.TrimPrefix(filepath.ToSlash(suffix), "/")
+	if suffix == "" {
+		suffix = filepath.Base(cpURLs.SourceContent.URL.Path)
+	}
+	return suffix
+}
+
 type prepareCopyURLsOpts struct {
 	sourceURLs           []string
 	targetURL            string
@@ -229,6 +264,16 @@ type prepareCopyURLsOpts struct {
 	timeRef              time.Time
 	versionID            string
 	isZip                bool
+	includeOptions       []string
+	excludeOptions       []string
+	symlinks             SymlinkOpt
+	symlinkStats         *copySymlinks
+	order                string
+	tagFilters           []tagFilter
+	tagFilterWorkers     int
+	listConcurrency      int
+	newerThanRef         time.Time
+	sizeRange            sizeRange
 }
 
 // prepareCopyURLs - prepares target and source clientURLs for copying.
@@ -245,11 +290,11 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 		case copyURLsTypeB:
 			copyURLsCh <- prepareCopyURLsTypeB(ctx, o.sourceURLs[0], cpVersion, o.targetURL, o.encKeyDB, o.isZip)
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(ctx, o.sourceURLs[0], o.targetURL, o.isRecursive, o.isZip, o.timeRef, o.encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(ctx, o.sourceURLs[0], o.targetURL, o.isRecursive, o.isZip, o.timeRef, o.encKeyDB, o.symlinks, o.symlinkStats, o.listConcurrency) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(ctx, o.sourceURLs, o.targetURL, o.isRecursive, o.timeRef, o.encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(ctx, o.sourceURLs, o.targetURL, o.isRecursive, o.timeRef, o.encKeyDB, o.symlinks, o.symlinkStats, o.listConcurrency) {
 				copyURLsCh <- cURLs
 			}
 		default:
@@ -257,10 +302,19 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 		}
 	}(o)
 
-	finalCopyURLsCh := make(chan URLs)
+	_, targetURLFull, _ := mustExpandAlias(o.targetURL)
+
+	filteredURLsCh := make(chan URLs)
 	go func() {
-		defer close(finalCopyURLsCh)
+		defer close(filteredURLsCh)
 		for cpURLs := range copyURLsCh {
+			// Skip objects excluded or not included by the patterns specified
+			if cpURLs.Error == nil && (len(o.includeOptions) > 0 || len(o.excludeOptions) > 0) {
+				if skipByPatterns(o.includeOptions, o.excludeOptions, copyURLsSuffix(cpURLs, targetURLFull)) {
+					continue
+				}
+			}
+
 			// Skip objects older than --older-than parameter if specified
 			if o.olderThan != "" && isOlder(cpURLs.SourceContent.Time, o.olderThan) {
 				continue
@@ -271,6 +325,46 @@ func prepareCopyURLs(ctx context.Context, o prepareCopyURLsOpts) chan URLs {
 				continue
 			}
 
+			// Skip objects not modified after the reference time of --newer-than-file
+			if cpURLs.Error == nil && !o.newerThanRef.IsZero() && !cpURLs.SourceContent.Time.After(o.newerThanRef) {
+				continue
+			}
+
+			// Skip objects out of the range of --min-size and --max-size
+			if cpURLs.Error == nil && !o.sizeRange.contains(cpURLs.SourceContent.Size) {
+				continue
+			}
+			filteredURLsCh <- cpURLs
+		}
+	}()
+
+	// Tags cost a request per object, only fetch them for what is left.
+	var selectedURLsCh <-chan URLs = filteredURLsCh
+	if len(o.tagFilters) > 0 {
+		selectedURLsCh = filterURLsByTags(ctx, filteredURLsCh, o.tagFilters, o.tagFilterWorkers, getSourceTags)
+	}
+
+	finalCopyURLsCh := make(chan URLs)
+	go func() {
+		defer close(finalCopyURLsCh)
+		var sortedURLs []URLs
+		for cpURLs := range selectedURLsCh {
+			if o.order == "" || o.order == copyOrderNone || cpURLs.Error != nil {
+				finalCopyURLsCh <- cpURLs
+				continue
+			}
+			// Buffer the listing to sort it, errors are not delayed.
+			sortedURLs = append(sortedURLs, cpURLs)
+			if len(sortedURLs) == copyOrderWarnObjects {
+				if !globalQuiet && !globalJSON {
+					console.Eraseline()
+				}
+				printMsg(copyOrderMessage{Status: "warning", Order: o.order, Objects: copyOrderWarnObjects})
+			}
+		}
+
+		sortCopyURLs(sortedURLs, o.order)
+		for _, cpURLs := range sortedURLs {
 			finalCopyURLsCh <- cpURLs
 		}
 	}()
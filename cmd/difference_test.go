@@ -18,6 +18,9 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -47,3 +50,57 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestSkipByPatterns(t *testing.T) {
+	testCases := []struct {
+		include []string
+		exclude []string
+		object  string
+		skip    bool
+	}{
+		{nil, nil, "file.txt", false},
+		{[]string{"*.txt"}, nil, "file.txt", false},
+		{[]string{"*.txt"}, nil, "file.csv", true},
+		{nil, []string{"*.txt"}, "file.txt", true},
+		// Excludes always win over includes.
+		{[]string{"*.txt"}, []string{"tmp/*"}, "tmp/file.txt", true},
+		{[]string{"*.txt"}, []string{"tmp/*"}, "data/file.txt", false},
+	}
+	for i, test := range testCases {
+		if skip := skipByPatterns(test.include, test.exclude, test.object); skip != test.skip {
+			t.Fatalf("Test %d: expected skip %t for %s, got %t", i+1, test.skip, test.object, skip)
+		}
+	}
+}
+
+func TestReadPatternsFile(t *testing.T) {
+	patternsFile := filepath.Join(t.TempDir(), "patterns")
+	content := "# temporary files\n*.tmp\n\n  .*  \n#*.txt\n"
+	if e := os.WriteFile(patternsFile, []byte(content), 0o600); e != nil {
+		t.Fatal(e)
+	}
+	patterns, err := readPatternsFile(patternsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"*.tmp", ".*"}; !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("expected %v, got %v", expected, patterns)
+	}
+}
+
+func TestIsDirMarker(t *testing.T) {
+	testCases := []struct {
+		content *ClientContent
+		want    bool
+	}{
+		{nil, false},
+		{&ClientContent{URL: *newClientURL("https://s3.amazonaws.com/bucket/foo/"), Type: os.ModeDir}, true},
+		{&ClientContent{URL: *newClientURL("https://s3.amazonaws.com/bucket/foo"), Type: 0o644}, false},
+		{&ClientContent{URL: *newClientURL("/tmp/foo/"), Type: os.ModeDir}, false},
+	}
+	for i, testCase := range testCases {
+		if got := isDirMarker(testCase.content); got != testCase.want {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -50,7 +52,11 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "versions",
-			Usage: "include all object versions",
+			Usage: "include all object versions and delete markers, breaking down the current and noncurrent size",
+		},
+		cli.BoolFlag{
+			Name:  "data-usage",
+			Usage: "use the data usage computed by the MinIO server instead of listing, results are an estimate",
 		},
 	}
 )
@@ -62,7 +68,7 @@ var duCmd = cli.Command{
 	Action:       mainDu,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(duFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(duFlags, ioFlags...), listFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -85,18 +91,26 @@ EXAMPLES:
   3. Summarize disk usage of 'jazz-songs' bucket at a fixed date/time
      {{.Prompt}} {{.HelpName}} --rewind "2020.01.01" s3/jazz-songs/
 
-  4. Summarize disk usage of 'jazz-songs' bucket with all objects versions
+  4. Summarize disk usage of 'jazz-songs' bucket with all objects versions, to show the noncurrent versions footprint
      {{.Prompt}} {{.HelpName}} --versions s3/jazz-songs/
+
+  5. Estimate the disk usage of 'jazz-songs' bucket near-instantly from the data usage of the server.
+     {{.Prompt}} {{.HelpName}} --data-usage myminio/jazz-songs
 `,
 }
 
 // Structured message depending on the type of console.
 type duMessage struct {
-	Prefix     string `json:"prefix"`
-	Size       int64  `json:"size"`
-	Objects    int64  `json:"objects"`
-	Status     string `json:"status"`
-	IsVersions bool   `json:"isVersions"`
+	Prefix            string     `json:"prefix"`
+	Size              int64      `json:"size"`
+	Objects           int64      `json:"objects"`
+	Status            string     `json:"status"`
+	IsVersions        bool       `json:"isVersions"`
+	CurrentSize       *int64     `json:"currentSize,omitempty"`
+	NoncurrentSize    *int64     `json:"noncurrentSize,omitempty"`
+	DeleteMarkerCount *int64     `json:"deleteMarkerCount,omitempty"`
+	Estimated         bool       `json:"estimated,omitempty"`
+	LastUpdate        *time.Time `json:"lastUpdate,omitempty"`
 }
 
 // Colorized message for console printing.
@@ -109,9 +123,27 @@ func (r duMessage) String() string {
 	if r.Objects != 1 {
 		cnt += "s" // pluralize
 	}
-	return fmt.Sprintf("%s\t%s\t%s", console.Colorize("Size", humanSize),
+	msg := fmt.Sprintf("%s\t%s\t%s", console.Colorize("Size", humanSize),
 		console.Colorize("Objects", cnt),
 		console.Colorize("Prefix", r.Prefix))
+	if r.CurrentSize != nil && r.NoncurrentSize != nil && r.DeleteMarkerCount != nil {
+		msg += fmt.Sprintf("\t(current %s, noncurrent %s, %d delete marker",
+			strings.Join(strings.Fields(humanize.IBytes(uint64(*r.CurrentSize))), ""),
+			strings.Join(strings.Fields(humanize.IBytes(uint64(*r.NoncurrentSize))), ""),
+			*r.DeleteMarkerCount)
+		if *r.DeleteMarkerCount != 1 {
+			msg += "s" // pluralize
+		}
+		msg += ")"
+	}
+	if r.Estimated {
+		msg += "\t(estimate"
+		if r.LastUpdate != nil {
+			msg += ", updated " + humanize.Time(*r.LastUpdate)
+		}
+		msg += ")"
+	}
+	return msg
 }
 
 // JSON'ified message for scripting.
@@ -121,7 +153,113 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int, encKeyDB map[string][]prefixSSEPair) (sz, objs int64, err error) {
+// duSpinnerFrames are the frames of the spinner shown while walking.
+// duUsage is the disk usage of a prefix, the current and noncurrent
+// sizes and the delete markers are only counted when listing versions.
+type duUsage struct {
+	size           int64
+	objects        int64
+	currentSize    int64
+	noncurrentSize int64
+	deleteMarkers  int64
+}
+
+// addContent accounts for a listed object or object version.
+func (u *duUsage) addContent(content *ClientContent) {
+	switch {
+	case content.Type.IsDir():
+	case content.IsDeleteMarker:
+		u.deleteMarkers++
+	default:
+		u.size += content.Size
+		u.objects++
+		// Unversioned listings, such as the filesystem, only have current objects.
+		if content.IsLatest || content.VersionID == "" {
+			u.currentSize += content.Size
+		} else {
+			u.noncurrentSize += content.Size
+		}
+	}
+}
+
+func (u *duUsage) add(o duUsage) {
+	u.size += o.size
+	u.objects += o.objects
+	u.currentSize += o.currentSize
+	u.noncurrentSize += o.noncurrentSize
+	u.deleteMarkers += o.deleteMarkers
+}
+
+var duSpinnerFrames = []string{"|", "/", "-", "\\"}
+
+// duProgress shows a spinner with the running count and size of the
+// objects while du walks, since huge buckets take minutes to list.
+// A nil *duProgress shows nothing.
+type duProgress struct {
+	objects int64
+	size    int64
+
+	mutex  sync.Mutex
+	doneCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newDuProgress() *duProgress {
+	p := &duProgress{doneCh: make(chan struct{})}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for frame := 0; ; frame++ {
+			select {
+			case <-p.doneCh:
+				return
+			case <-ticker.C:
+				p.mutex.Lock()
+				console.PrintC(fmt.Sprintf("\r%c[2K%s %d objects, %s", 27,
+					duSpinnerFrames[frame%len(duSpinnerFrames)],
+					atomic.LoadInt64(&p.objects),
+					humanize.IBytes(uint64(atomic.LoadInt64(&p.size)))))
+				p.mutex.Unlock()
+			}
+		}
+	}()
+	return p
+}
+
+// add accounts an object found by the walk.
+func (p *duProgress) add(size int64) {
+	if p == nil {
+		return
+	}
+	atomic.AddInt64(&p.objects, 1)
+	atomic.AddInt64(&p.size, size)
+}
+
+// printMsg prints a message over the spinner.
+func (p *duProgress) printMsg(msg message) {
+	if p == nil {
+		printMsg(msg)
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	console.PrintC(fmt.Sprintf("\r%c[2K", 27))
+	printMsg(msg)
+}
+
+// finish stops and erases the spinner.
+func (p *duProgress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.doneCh)
+	p.wg.Wait()
+	console.PrintC(fmt.Sprintf("\r%c[2K", 27))
+}
+
+func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool, depth int, encKeyDB map[string][]prefixSSEPair, progress *duProgress) (usage duUsage, err error) {
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 
 	if !strings.HasSuffix(targetURL, "/") {
@@ -131,7 +269,7 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
 		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `"+urlStr+"`.")
-		return 0, 0, exitStatus(globalErrorExitStatus) // End of journey.
+		return usage, exitStatus(globalErrorExitStatus) // End of journey.
 	}
 
 	// No disk usage details below this level,
@@ -143,11 +281,10 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 	contentCh := clnt.List(ctx, ListOptions{
 		TimeRef:           timeRef,
 		WithOlderVersions: withVersions,
+		WithDeleteMarkers: withVersions,
 		Recursive:         recursive,
 		ShowDir:           DirFirst,
 	})
-	size := int64(0)
-	objects := int64(0)
 	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
@@ -159,7 +296,7 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 				continue
 			}
 			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `"+urlStr+"` recursively.")
-			return 0, 0, exitStatus(globalErrorExitStatus)
+			return duUsage{}, exitStatus(globalErrorExitStatus)
 		}
 
 		if content.URL.Path == targetAbsolutePath {
@@ -176,16 +313,15 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, n, err := du(ctx, subDirAlias, timeRef, withVersions, depth, encKeyDB)
+			used, err := du(ctx, subDirAlias, timeRef, withVersions, depth, encKeyDB, progress)
 			if err != nil {
-				return 0, 0, err
+				return duUsage{}, err
 			}
-			size += used
-			objects += n
+			usage.add(used)
 		} else {
+			usage.addContent(content)
 			if !content.IsDeleteMarker && !content.Type.IsDir() {
-				size += content.Size
-				objects++
+				progress.add(content.Size)
 			}
 		}
 	}
@@ -196,16 +332,77 @@ func du(ctx context.Context, urlStr string, timeRef time.Time, withVersions bool
 			panic(e)
 		}
 
-		printMsg(duMessage{
+		msg := duMessage{
 			Prefix:     strings.Trim(u.Path, "/"),
-			Size:       size,
-			Objects:    objects,
+			Size:       usage.size,
+			Objects:    usage.objects,
 			Status:     "success",
 			IsVersions: withVersions,
-		})
+		}
+		if withVersions {
+			msg.CurrentSize = &usage.currentSize
+			msg.NoncurrentSize = &usage.noncurrentSize
+			msg.DeleteMarkerCount = &usage.deleteMarkers
+		}
+		progress.printMsg(msg)
+	}
+
+	return usage, nil
+}
+
+// duDataUsage prints the disk usage of a bucket, or of all buckets, from the
+// data usage computed by the server scanner instead of listing the objects.
+func duDataUsage(ctx context.Context, urlStr string, withVersions bool) error {
+	alias, urlStrFull, _ := mustExpandAlias(urlStr)
+	if alias == "" {
+		fatalIf(errInvalidArgument().Trace(urlStr), "`--data-usage` is only supported on a MinIO alias.")
+	}
+	bucket, object := url2BucketAndObject(newClientURL(urlStrFull))
+	if object != "" {
+		fatalIf(errInvalidArgument().Trace(urlStr), "`--data-usage` is only supported on buckets, not prefixes.")
+	}
+
+	client, err := newAdminClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize admin connection.")
+
+	info, e := client.DataUsageInfo(ctx)
+	if e != nil {
+		errorIf(probe.NewError(e).Trace(urlStr), "Unable to get the data usage of `"+urlStr+"`.")
+		return exitStatus(globalErrorExitStatus)
 	}
 
-	return size, objects, nil
+	msg := duMessage{
+		Prefix:     bucket,
+		Size:       int64(info.ObjectsTotalSize),
+		Objects:    int64(info.ObjectsTotalCount),
+		Status:     "success",
+		IsVersions: withVersions,
+		Estimated:  true,
+	}
+	if !info.LastUpdate.IsZero() {
+		msg.LastUpdate = &info.LastUpdate
+	}
+	if bucket != "" {
+		usage, ok := info.BucketsUsage[bucket]
+		if !ok {
+			errorIf(errInvalidArgument().Trace(urlStr), "No data usage found for `"+urlStr+"`, it may not be scanned yet.")
+			return exitStatus(globalErrorExitStatus)
+		}
+		msg.Size = int64(usage.Size)
+		msg.Objects = int64(usage.ObjectsCount)
+		if withVersions {
+			msg.Objects = int64(usage.VersionsCount)
+		}
+	} else if withVersions {
+		var versions uint64
+		for _, usage := range info.BucketsUsage {
+			versions += usage.VersionsCount
+		}
+		msg.Objects = int64(versions)
+	}
+
+	printMsg(msg)
+	return nil
 }
 
 // main for du command.
@@ -242,16 +439,36 @@ func mainDu(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	timeRef := parseRewindFlag(cliCtx.String("rewind"))
 
+	if cliCtx.Bool("data-usage") {
+		if cliCtx.IsSet("depth") || cliCtx.Bool("recursive") || cliCtx.IsSet("rewind") {
+			fatalIf(errInvalidArgument().Trace(), "`--data-usage` cannot be used with `--depth`, `--recursive` or `--rewind`.")
+		}
+		var duErr error
+		for _, urlStr := range cliCtx.Args() {
+			if err := duDataUsage(ctx, urlStr, withVersions); duErr == nil {
+				duErr = err
+			}
+		}
+		return duErr
+	}
+
+	// Show the progress of the walk only on terminals.
+	var progress *duProgress
+	if !globalQuiet && !globalJSON && isTerminal() {
+		progress = newDuProgress()
+	}
+
 	var duErr error
 	for _, urlStr := range cliCtx.Args() {
 		if !isAliasURLDir(ctx, urlStr, nil, time.Time{}) {
 			fatalIf(errInvalidArgument().Trace(urlStr), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by 'du' command.", urlStr))
 		}
 
-		if _, _, err := du(ctx, urlStr, timeRef, withVersions, depth, encKeyDB); duErr == nil {
+		if _, err := du(ctx, urlStr, timeRef, withVersions, depth, encKeyDB, progress); duErr == nil {
 			duErr = err
 		}
 	}
+	progress.finish()
 
 	return duErr
 }
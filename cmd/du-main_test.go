@@ -0,0 +1,46 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDuUsageAddContent(t *testing.T) {
+	var usage duUsage
+	for _, content := range []*ClientContent{
+		{Size: 10, VersionID: "v3", IsLatest: true},
+		{Size: 20, VersionID: "v2"},
+		{Size: 30, VersionID: "v1"},
+		{VersionID: "v5", IsLatest: true, IsDeleteMarker: true},
+		{VersionID: "v4", IsDeleteMarker: true},
+		{Size: 5},
+		{Type: os.ModeDir},
+	} {
+		usage.addContent(content)
+	}
+
+	var total duUsage
+	total.add(usage)
+	total.add(duUsage{size: 1, objects: 1, currentSize: 1})
+	want := duUsage{size: 66, objects: 5, currentSize: 16, noncurrentSize: 50, deleteMarkers: 2}
+	if total != want {
+		t.Errorf("expected %+v, got %+v", want, total)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const findAgeDay = 24 * time.Hour
+
+// findAgeBucket is a range of ages of `--age-histogram`, an object falls in
+// the first bucket whose maxAge is greater than its age, maxAge 0 is unbounded.
+type findAgeBucket struct {
+	name   string
+	maxAge time.Duration
+}
+
+var findAgeBuckets = []findAgeBucket{
+	{name: "0-1d", maxAge: findAgeDay},
+	{name: "1-7d", maxAge: 7 * findAgeDay},
+	{name: "7-30d", maxAge: 30 * findAgeDay},
+	{name: "30-90d", maxAge: 90 * findAgeDay},
+	{name: "90d+"},
+}
+
+// findAgeHistogram counts the objects and their size per age bucket, the
+// age is computed from the LastModified of each object relative to now.
+type findAgeHistogram struct {
+	now     time.Time
+	objects []int64
+	sizes   []int64
+}
+
+func newFindAgeHistogram(now time.Time) *findAgeHistogram {
+	return &findAgeHistogram{
+		now:     now,
+		objects: make([]int64, len(findAgeBuckets)),
+		sizes:   make([]int64, len(findAgeBuckets)),
+	}
+}
+
+// add accounts an object modified at modTime, objects modified in the
+// future because of clock skew are counted in the first bucket.
+func (h *findAgeHistogram) add(modTime time.Time, size int64) {
+	age := h.now.Sub(modTime)
+	i := 0
+	for ; i < len(findAgeBuckets)-1; i++ {
+		if age < findAgeBuckets[i].maxAge {
+			break
+		}
+	}
+	h.objects[i]++
+	h.sizes[i] += size
+}
+
+func (h *findAgeHistogram) message() findAgeHistogramMessage {
+	msg := findAgeHistogramMessage{Status: "success"}
+	for i, bucket := range findAgeBuckets {
+		msg.Buckets = append(msg.Buckets, findAgeBucketMessage{
+			Age:     bucket.name,
+			Objects: h.objects[i],
+			Size:    h.sizes[i],
+		})
+		msg.Objects += h.objects[i]
+		msg.Size += h.sizes[i]
+	}
+	return msg
+}
+
+type findAgeBucketMessage struct {
+	Age     string `json:"age"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+// findAgeHistogramMessage is the output of `mc find --age-histogram`.
+type findAgeHistogramMessage struct {
+	Status  string                 `json:"status"`
+	Buckets []findAgeBucketMessage `json:"buckets"`
+	Objects int64                  `json:"objects"`
+	Size    int64                  `json:"size"`
+}
+
+func (f findAgeHistogramMessage) String() string {
+	var b strings.Builder
+	row := func(age string, objects, size int64) {
+		fmt.Fprintf(&b, "%-7s %12d objects %12s\n", age, objects, humanize.IBytes(uint64(size)))
+	}
+	for _, bucket := range f.Buckets {
+		row(bucket.Age, bucket.Objects, bucket.Size)
+	}
+	row("Total", f.Objects, f.Size)
+	return console.Colorize("Find", strings.TrimSuffix(b.String(), "\n"))
+}
+
+func (f findAgeHistogramMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(f, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
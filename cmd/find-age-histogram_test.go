@@ -0,0 +1,63 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFindAgeHistogram(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	h := newFindAgeHistogram(now)
+	for _, obj := range []struct {
+		age  time.Duration
+		size int64
+	}{
+		{-time.Minute, 1}, // clock skew
+		{time.Hour, 2},
+		{findAgeDay, 4},
+		{6 * findAgeDay, 8},
+		{7 * findAgeDay, 16},
+		{45 * findAgeDay, 32},
+		{90 * findAgeDay, 64},
+		{400 * findAgeDay, 128},
+	} {
+		h.add(now.Add(-obj.age), obj.size)
+	}
+
+	msg := h.message()
+	expected := []findAgeBucketMessage{
+		{Age: "0-1d", Objects: 2, Size: 3},
+		{Age: "1-7d", Objects: 2, Size: 12},
+		{Age: "7-30d", Objects: 1, Size: 16},
+		{Age: "30-90d", Objects: 1, Size: 32},
+		{Age: "90d+", Objects: 2, Size: 192},
+	}
+	if len(msg.Buckets) != len(expected) {
+		t.Fatalf("expected %d buckets, got %d", len(expected), len(msg.Buckets))
+	}
+	for i, bucket := range expected {
+		if msg.Buckets[i] != bucket {
+			t.Errorf("bucket %d: expected %+v, got %+v", i, bucket, msg.Buckets[i])
+		}
+	}
+	if msg.Objects != 8 || msg.Size != 255 {
+		t.Errorf("expected 8 objects of 255 bytes in total, got %d objects of %d bytes", msg.Objects, msg.Size)
+	}
+}
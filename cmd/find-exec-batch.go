@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/google/shlex"
+	"github.com/minio/pkg/console"
+)
+
+// defaultFindBatchSize is the default maximum number of paths per --exec-batch command.
+const defaultFindBatchSize = 1000
+
+// findBatchMaxBytes bounds the size of the paths of one --exec-batch command,
+// well below the command line limits of the OS, which include the environment.
+var findBatchMaxBytes = func() int {
+	if runtime.GOOS == "windows" {
+		return 24 * 1024 // 32K characters
+	}
+	return 128 * 1024
+}()
+
+// findExecBatch runs the --exec-batch command with as many matching paths as
+// fit in a batch, like xargs. A batch runs as soon as it is full.
+type findExecBatch struct {
+	args      []string
+	hole      int // index of {} in args, replaced by the paths
+	batchSize int
+	maxBytes  int
+
+	paths  []string
+	nbytes int
+	// exit status of the last failed command
+	status int
+
+	// runs a command line, returns its exit status
+	run func(args []string) int
+}
+
+func newFindExecBatch(cmdLine string, batchSize int) (*findExecBatch, error) {
+	args, e := shlex.Split(cmdLine)
+	if e != nil {
+		return nil, e
+	}
+	if len(args) == 0 {
+		return nil, errors.New("empty command")
+	}
+	b := &findExecBatch{
+		args:      args,
+		hole:      len(args),
+		batchSize: batchSize,
+		maxBytes:  findBatchMaxBytes,
+		run:       runFindBatchCommand,
+	}
+	for i, arg := range args {
+		if arg == "{}" {
+			if b.hole != len(args) {
+				return nil, errors.New("`{}` can only be used once")
+			}
+			b.hole = i
+		}
+	}
+	if b.hole == 0 {
+		return nil, errors.New("`{}` cannot be the command")
+	}
+	return b, nil
+}
+
+// add queues a path, running the batch first if the path does not fit.
+func (b *findExecBatch) add(path string) {
+	if len(b.paths) > 0 && (len(b.paths) >= b.batchSize || b.nbytes+len(path)+1 > b.maxBytes) {
+		b.exec()
+	}
+	b.paths = append(b.paths, path)
+	b.nbytes += len(path) + 1
+}
+
+// flush runs the last batch, returns an error with the exit status of
+// the last failed command if any failed.
+func (b *findExecBatch) flush() error {
+	if len(b.paths) > 0 {
+		b.exec()
+	}
+	if b.status != 0 {
+		return exitStatus(b.status)
+	}
+	return nil
+}
+
+func (b *findExecBatch) exec() {
+	args := make([]string, 0, len(b.args)+len(b.paths))
+	args = append(args, b.args[:b.hole]...)
+	args = append(args, b.paths...)
+	if b.hole < len(b.args) {
+		args = append(args, b.args[b.hole+1:]...)
+	}
+	if status := b.run(args); status != 0 {
+		b.status = status
+	}
+	b.paths, b.nbytes = b.paths[:0], 0
+}
+
+// runFindBatchCommand runs a batch command with the standard output and
+// error of mc, reporting a failure without stopping the find.
+func runFindBatchCommand(args []string) int {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	e := cmd.Run()
+	if e != nil {
+		console.Println(console.Colorize("FindExecErr", "--exec-batch: "+e.Error()))
+	}
+	return getExitStatus(e)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+func TestFindExecBatch(t *testing.T) {
+	testCases := []struct {
+		cmdLine   string
+		batchSize int
+		maxBytes  int
+		paths     []string
+		commands  [][]string
+	}{
+		// Paths appended without {}.
+		{"rm -v", 2, 1024, []string{"a", "b", "c"}, [][]string{{"rm", "-v", "a", "b"}, {"rm", "-v", "c"}}},
+		// Paths in place of {}.
+		{"cp {} /tmp/dir", 5, 1024, []string{"a", "b"}, [][]string{{"cp", "a", "b", "/tmp/dir"}}},
+		// Batches cut by size, a single path larger than the limit is still run.
+		{"rm", 100, 8, []string{"aaa", "bbb", "cccccccccc", "d"}, [][]string{{"rm", "aaa", "bbb"}, {"rm", "cccccccccc"}, {"rm", "d"}}},
+		{"rm", 100, 8, nil, nil},
+	}
+	for i, testCase := range testCases {
+		b, e := newFindExecBatch(testCase.cmdLine, testCase.batchSize)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		b.maxBytes = testCase.maxBytes
+		var commands [][]string
+		b.run = func(args []string) int {
+			commands = append(commands, args)
+			return 0
+		}
+		for _, path := range testCase.paths {
+			b.add(path)
+		}
+		if e = b.flush(); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if !reflect.DeepEqual(commands, testCase.commands) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.commands, commands)
+		}
+	}
+}
+
+func TestFindExecBatchExitStatus(t *testing.T) {
+	b, e := newFindExecBatch("rm", 1)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var runs int
+	b.run = func(args []string) int {
+		runs++
+		if args[1] == "b" {
+			return 3
+		}
+		return 0
+	}
+	for _, path := range []string{"a", "b", "c"} {
+		b.add(path)
+	}
+	e = b.flush()
+	if runs != 3 {
+		t.Errorf("Expected the batches after a failure to run, got %d runs", runs)
+	}
+	exitErr, ok := e.(cli.ExitCoder)
+	if !ok {
+		t.Fatalf("Expected an exit status, got %v", e)
+	}
+	if status := exitErr.ExitCode(); status != 3 {
+		t.Errorf("Expected exit status 3, got %d", status)
+	}
+}
+
+func TestNewFindExecBatchErrors(t *testing.T) {
+	for _, cmdLine := range []string{"", "{}", "cp {} {} /tmp", "rm 'a"} {
+		if _, e := newFindExecBatch(cmdLine, 10); e == nil {
+			t.Errorf("Expected %q to be rejected", cmdLine)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// findFormatFields maps the fields supported by `--format` to their value,
+// the argument is the optional part following ':' in the field.
+var findFormatFields = map[string]func(c contentMessage, arg string) string{
+	"path": func(c contentMessage, _ string) string { return c.Key },
+	"base": func(c contentMessage, _ string) string { return filepath.Base(c.Key) },
+	"dir":  func(c contentMessage, _ string) string { return filepath.Dir(c.Key) },
+	"size": func(c contentMessage, _ string) string { return strconv.FormatInt(c.Size, 10) },
+	"hsize": func(c contentMessage, _ string) string {
+		return humanize.IBytes(uint64(c.Size))
+	},
+	"mtime": func(c contentMessage, layout string) string {
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return c.Time.Format(layout)
+	},
+	"etag":          func(c contentMessage, _ string) string { return c.ETag },
+	"version":       func(c contentMessage, _ string) string { return c.VersionID },
+	"storage-class": func(c contentMessage, _ string) string { return c.StorageClass },
+	"type":          func(c contentMessage, _ string) string { return c.Filetype },
+}
+
+// findFormatFieldNames returns the sorted names of the `--format` fields.
+func findFormatFieldNames() string {
+	names := make([]string, 0, len(findFormatFields))
+	for name := range findFormatFields {
+		if name == "mtime" {
+			name += "[:LAYOUT]"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// findFormatSegment is either a literal text or a field of the template.
+type findFormatSegment struct {
+	literal string
+	field   func(c contentMessage, arg string) string
+	arg     string
+}
+
+// findFormat is a parsed `--format` template.
+type findFormat []findFormatSegment
+
+// parseFindFormat parses a template such as "{path}\t{size}\t{mtime:2006-01-02}",
+// the escapes \t, \n, \\, \{ and \} are interpreted.
+func parseFindFormat(format string) (findFormat, error) {
+	var segments findFormat
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, findFormatSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); i++ {
+		switch c := format[i]; c {
+		case '\\':
+			if i+1 == len(format) {
+				literal.WriteByte(c)
+				continue
+			}
+			i++
+			switch format[i] {
+			case 't':
+				literal.WriteByte('\t')
+			case 'n':
+				literal.WriteByte('\n')
+			case '\\', '{', '}':
+				literal.WriteByte(format[i])
+			default:
+				literal.WriteByte(c)
+				literal.WriteByte(format[i])
+			}
+		case '{':
+			end := strings.IndexByte(format[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated field at offset %d", i)
+			}
+			name, arg := format[i+1:i+end], ""
+			if j := strings.IndexByte(name, ':'); j >= 0 {
+				name, arg = name[:j], name[j+1:]
+			}
+			field, ok := findFormatFields[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown field `{%s}`, available fields are: %s", name, findFormatFieldNames())
+			}
+			if arg != "" && name != "mtime" {
+				return nil, fmt.Errorf("field `{%s}` does not accept an argument", name)
+			}
+			flush()
+			segments = append(segments, findFormatSegment{field: field, arg: arg})
+			i += end
+		case '}':
+			return nil, fmt.Errorf("unexpected '}' at offset %d", i)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flush()
+	return segments, nil
+}
+
+// render returns the template filled with the fields of the content.
+func (f findFormat) render(c contentMessage) string {
+	var s strings.Builder
+	for _, segment := range f {
+		if segment.field == nil {
+			s.WriteString(segment.literal)
+			continue
+		}
+		s.WriteString(segment.field(c, segment.arg))
+	}
+	return s.String()
+}
+
+// findFormatMessage is a match printed with the `--format` template.
+type findFormatMessage struct {
+	contentMessage
+	line string
+}
+
+// String prints the line as is, to be consumed by other tools.
+func (f findFormatMessage) String() string {
+	return f.line
+}
+
+// JSON formats output to be JSON output.
+func (f findFormatMessage) JSON() string {
+	return f.contentMessage.JSON()
+}
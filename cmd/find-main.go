@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"time"
 
@@ -36,6 +37,15 @@ var (
 			Name:  "exec",
 			Usage: "spawn an external process for each matching object (see FORMAT)",
 		},
+		cli.StringFlag{
+			Name:  "exec-batch",
+			Usage: "spawn an external process with many matching objects at once, replacing '{}' with their paths or appending them",
+		},
+		cli.IntFlag{
+			Name:  "batch-size",
+			Usage: "maximum number of matching objects per --exec-batch process",
+			Value: defaultFindBatchSize,
+		},
 		cli.StringFlag{
 			Name:  "ignore",
 			Usage: "exclude objects matching the wildcard pattern",
@@ -56,6 +66,18 @@ var (
 			Name:  "older-than",
 			Usage: "match all objects older than value in duration string (e.g. 7d10h31s)",
 		},
+		cli.StringFlag{
+			Name:  "changed-within",
+			Usage: "match all objects modified within the duration, same as --newer-than (e.g. 7d)",
+		},
+		cli.StringFlag{
+			Name:  "changed-before",
+			Usage: "match all objects last modified before the duration, same as --older-than (e.g. 90d)",
+		},
+		cli.BoolFlag{
+			Name:  "age-histogram",
+			Usage: "print the number and size of matching objects per age range instead of listing them",
+		},
 		cli.StringFlag{
 			Name:  "path",
 			Usage: "match directory names matching wildcard pattern",
@@ -64,10 +86,18 @@ var (
 			Name:  "print",
 			Usage: "print in custom format to STDOUT (see FORMAT)",
 		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "print each matching object with a template of fields to STDOUT (see TEMPLATE)",
+		},
 		cli.StringFlag{
 			Name:  "regex",
 			Usage: "match directory and object name with PCRE regex pattern",
 		},
+		cli.BoolFlag{
+			Name:  "regex-basename",
+			Usage: "match the --regex pattern against the base name instead of the whole path",
+		},
 		cli.StringFlag{
 			Name:  "larger",
 			Usage: "match all objects larger than specified size in units (see UNITS)",
@@ -78,7 +108,11 @@ var (
 		},
 		cli.UintFlag{
 			Name:  "maxdepth",
-			Usage: "limit directory navigation to specified depth",
+			Usage: "descend at most specified levels below the starting prefix",
+		},
+		cli.UintFlag{
+			Name:  "mindepth",
+			Usage: "ignore all levels less than specified below the starting prefix",
 		},
 		cli.BoolFlag{
 			Name:  "watch",
@@ -93,7 +127,7 @@ var findCmd = cli.Command{
 	Action:       mainFind,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(findFlags, globalFlags...),
+	Flags:        append(append(findFlags, listFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -111,7 +145,12 @@ UNITS
   also accepted. Without suffixes the unit is bytes.
 
   --older-than, --newer-than flags accept the string for days, hours and minutes 
-  i.e. 1d2h30m states 1 day, 2 hours and 30 minutes.
+  i.e. 1d2h30m states 1 day, 2 hours and 30 minutes. --changed-before and
+  --changed-within are aliases of --older-than and --newer-than.
+
+AGE HISTOGRAM
+  --age-histogram counts the matching objects and sums their size in the age
+  ranges 0-1d, 1-7d, 7-30d, 30-90d and 90d+, from their modification time.
 
 FORMAT
   Support string substitutions with special interpretations for following keywords.
@@ -128,6 +167,26 @@ FORMAT
 
      {url} --> Substitutes to a shareable URL of the path.
 
+  --exec-batch only supports {}, as a separate argument, replaced by the paths of
+  a batch. Without {} the paths are appended to the command. A batch holds up to
+  --batch-size paths and is cut earlier to stay within the command line limits.
+
+TEMPLATE
+  --format prints one line per matching object, replacing the following fields.
+  The escapes \t, \n, \\, \{ and \} are interpreted.
+
+     {path}           --> Full path.
+     {base}           --> Basename of the path.
+     {dir}            --> Dirname of the path.
+     {size}           --> Size in bytes.
+     {hsize}          --> Human readable size.
+     {mtime}          --> Modified time in RFC3339 format.
+     {mtime:LAYOUT}   --> Modified time in the Go time layout, e.g. {mtime:2006-01-02}.
+     {etag}           --> ETag of the object.
+     {version}        --> Version identifier of the object.
+     {storage-class}  --> Storage class of the object.
+     {type}           --> "file" or "folder".
+
 EXAMPLES:
   01. Find all "foo.jpg" in all buckets under "s3" account.
       {{.Prompt}} {{.HelpName}} s3 --name "foo.jpg"
@@ -162,6 +221,24 @@ EXAMPLES:
 
   11. Copy all versions of all objects in bucket in the local machine
       {{.Prompt}} {{.HelpName}} s3/bucket --versions --exec "mc cp --version-id {version} {} /tmp/dir/{}.{version}"
+
+  12. Find all objects exactly 2 levels deep under "s3/bucket".
+      {{.Prompt}} {{.HelpName}} s3/bucket --mindepth 2 --maxdepth 2
+
+  13. Print the path, size in bytes and modification day of all objects under "s3/bucket", separated by tabs.
+      {{.Prompt}} {{.HelpName}} s3/bucket --format "{path}\t{size}\t{mtime:2006-01-02}"
+
+  14. Find the ".log" objects of January and February 2024 larger than 1MB under "s3/logs", whatever their folder.
+      {{.Prompt}} {{.HelpName}} s3/logs --name "*.log" --regex "^2024-(01|02)-.*\.log$" --regex-basename --larger 1MB
+
+  15. Remove all ".tmp" objects under "s3/bucket" with one 'mc rm' per 500 objects instead of one per object.
+      {{.Prompt}} {{.HelpName}} s3/bucket --name "*.tmp" --exec-batch "mc rm {}" --batch-size 500
+
+  16. Show how many objects under "s3/bucket" and how much data were modified in each age range.
+      {{.Prompt}} {{.HelpName}} s3/bucket --age-histogram
+
+  17. Show the age ranges of the ".log" objects under "s3/logs" not modified within the last 30 days.
+      {{.Prompt}} {{.HelpName}} s3/logs --name "*.log" --changed-before 30d --age-histogram
 `,
 }
 
@@ -180,6 +257,59 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		}
 	}
 
+	if maxDepth := cliCtx.Uint("maxdepth"); maxDepth > 0 && cliCtx.Uint("mindepth") > maxDepth {
+		fatalIf(errInvalidArgument().Trace(), "`--mindepth` cannot be greater than `--maxdepth`.")
+	}
+
+	if regex := cliCtx.String("regex"); regex != "" {
+		_, e := regexp.Compile(regex)
+		fatalIf(probe.NewError(e).Trace(regex), "Invalid regular expression for `--regex`.")
+	} else if cliCtx.Bool("regex-basename") {
+		fatalIf(errInvalidArgument().Trace(), "`--regex-basename` requires `--regex`.")
+	}
+
+	if execBatch := cliCtx.String("exec-batch"); execBatch != "" {
+		switch {
+		case cliCtx.String("exec") != "" || cliCtx.String("print") != "" || cliCtx.String("format") != "":
+			fatalIf(errInvalidArgument().Trace(), "`--exec-batch` cannot be used with `--exec`, `--print` or `--format`.")
+		case cliCtx.Bool("watch"):
+			fatalIf(errInvalidArgument().Trace(), "`--exec-batch` cannot be used with `--watch`.")
+		case cliCtx.Int("batch-size") <= 0:
+			fatalIf(errInvalidArgument().Trace(), "`--batch-size` must be a positive number.")
+		}
+		_, e := newFindExecBatch(execBatch, cliCtx.Int("batch-size"))
+		fatalIf(probe.NewError(e).Trace(execBatch), "Unable to parse `--exec-batch`.")
+	}
+
+	for _, alias := range [][2]string{{"changed-within", "newer-than"}, {"changed-before", "older-than"}} {
+		if cliCtx.String(alias[0]) != "" && cliCtx.String(alias[1]) != "" {
+			fatalIf(errInvalidArgument().Trace(), "`--"+alias[0]+"` cannot be used with `--"+alias[1]+"`.")
+		}
+	}
+	for _, flag := range []string{"newer-than", "older-than", "changed-within", "changed-before"} {
+		if value := cliCtx.String(flag); value != "" {
+			_, e := ParseDuration(value)
+			fatalIf(probe.NewError(e).Trace(value), "Unable to parse `--"+flag+"`.")
+		}
+	}
+
+	if cliCtx.Bool("age-histogram") {
+		switch {
+		case cliCtx.String("exec") != "" || cliCtx.String("exec-batch") != "" || cliCtx.String("print") != "" || cliCtx.String("format") != "":
+			fatalIf(errInvalidArgument().Trace(), "`--age-histogram` cannot be used with `--exec`, `--exec-batch`, `--print` or `--format`.")
+		case cliCtx.Bool("watch"):
+			fatalIf(errInvalidArgument().Trace(), "`--age-histogram` cannot be used with `--watch`.")
+		}
+	}
+
+	if format := cliCtx.String("format"); format != "" {
+		if cliCtx.String("print") != "" || cliCtx.String("exec") != "" {
+			fatalIf(errInvalidArgument().Trace(), "`--format` cannot be used with `--print` or `--exec`.")
+		}
+		_, e := parseFindFormat(format)
+		fatalIf(probe.NewError(e).Trace(format), "Unable to parse `--format`.")
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{}, false)
@@ -199,16 +329,21 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 type findContext struct {
 	*cli.Context
 	execCmd           string
+	execBatch         *findExecBatch
 	ignorePattern     string
 	namePattern       string
 	pathPattern       string
-	regexPattern      string
+	regexPattern      *regexp.Regexp
+	regexBasename     bool
 	maxDepth          uint
+	minDepth          uint
 	printFmt          string
+	format            findFormat
 	olderThan         string
 	newerThan         string
 	largerSize        uint64
 	smallerSize       uint64
+	ageHistogram      *findAgeHistogram
 	watch             bool
 	withOlderVersions bool
 
@@ -252,6 +387,12 @@ func mainFind(cliCtx *cli.Context) error {
 	if cliCtx.String("newer-than") != "" {
 		newerThan = cliCtx.String("newer-than")
 	}
+	if cliCtx.String("changed-before") != "" {
+		olderThan = cliCtx.String("changed-before")
+	}
+	if cliCtx.String("changed-within") != "" {
+		newerThan = cliCtx.String("changed-within")
+	}
 
 	// Use 'e' to indicate Go error, this is a convention followed in `mc`. For probe.Error we call it
 	// 'err' and regular Go error is called as 'e'.
@@ -268,6 +409,18 @@ func mainFind(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(cliCtx.String("smaller")), "Unable to parse input bytes.")
 	}
 
+	var format findFormat
+	if cliCtx.String("format") != "" {
+		format, e = parseFindFormat(cliCtx.String("format"))
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("format")), "Unable to parse `--format`.")
+	}
+
+	// Compiled once, validated by checkFindSyntax.
+	var regexPattern *regexp.Regexp
+	if regex := cliCtx.String("regex"); regex != "" {
+		regexPattern = regexp.MustCompile(regex)
+	}
+
 	// Get --versions flag
 	withVersions := cliCtx.Bool("versions")
 
@@ -279,20 +432,35 @@ func mainFind(cliCtx *cli.Context) error {
 		targetFullURL = hostCfg.URL
 	}
 
+	var execBatch *findExecBatch
+	if cliCtx.String("exec-batch") != "" {
+		execBatch, _ = newFindExecBatch(cliCtx.String("exec-batch"), cliCtx.Int("batch-size"))
+	}
+
+	var ageHistogram *findAgeHistogram
+	if cliCtx.Bool("age-histogram") {
+		ageHistogram = newFindAgeHistogram(time.Now())
+	}
+
 	return doFind(ctx, &findContext{
 		Context:           cliCtx,
 		maxDepth:          cliCtx.Uint("maxdepth"),
+		minDepth:          cliCtx.Uint("mindepth"),
 		execCmd:           cliCtx.String("exec"),
+		execBatch:         execBatch,
 		printFmt:          cliCtx.String("print"),
+		format:            format,
 		namePattern:       cliCtx.String("name"),
 		pathPattern:       cliCtx.String("path"),
-		regexPattern:      cliCtx.String("regex"),
+		regexPattern:      regexPattern,
+		regexBasename:     cliCtx.Bool("regex-basename"),
 		ignorePattern:     cliCtx.String("ignore"),
 		withOlderVersions: withVersions,
 		olderThan:         olderThan,
 		newerThan:         newerThan,
 		largerSize:        largerSize,
 		smallerSize:       smallerSize,
+		ageHistogram:      ageHistogram,
 		watch:             cliCtx.Bool("watch"),
 		targetAlias:       targetAlias,
 		targetURL:         args[0],
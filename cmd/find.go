@@ -22,6 +22,7 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -192,6 +193,12 @@ func watchFind(ctxCtx context.Context, ctx *findContext) {
 					continue
 				}
 
+				clntURL := ctx.clnt.GetURL()
+				depth := findDepth(clntURL.Path, newClientURL(event.Path).Path, string(clntURL.Separator))
+				if !isWithinFindDepth(ctx, depth) {
+					continue
+				}
+
 				find(ctxCtx, ctx, contentMessage{
 					Key:  getAliasedPath(ctx, event.Path),
 					Time: time,
@@ -208,30 +215,37 @@ func watchFind(ctxCtx context.Context, ctx *findContext) {
 	}
 }
 
-// Descend at most (a non-negative integer) levels of files
-// below the starting-prefix and trims the suffix. This function
-// returns path as is without manipulation if the maxDepth is 0
-// i.e (not set).
-func trimSuffixAtMaxDepth(startPrefix, path, separator string, maxDepth uint) string {
-	if maxDepth == 0 {
-		return path
-	}
-	// Remove the requested prefix from consideration, maxDepth is
-	// only considered for all other levels excluding the starting prefix.
-	path = strings.TrimPrefix(path, startPrefix)
-	pathComponents := strings.SplitAfter(path, separator)
-	if len(pathComponents) >= int(maxDepth) {
-		pathComponents = pathComponents[:maxDepth]
-	}
-	pathComponents = append([]string{startPrefix}, pathComponents...)
-	return strings.Join(pathComponents, "")
+// findDepth returns how many levels path is below basePath, the
+// immediate children of basePath are at depth 1. A path sharing only a
+// name prefix with basePath, such as 'dir2/x' for 'dir', is listed along
+// as a sibling of basePath, at the same level, and 'dir2/x' is at depth 1.
+func findDepth(basePath, path, separator string) uint {
+	basePath = strings.TrimSuffix(basePath, separator)
+	path = strings.TrimSuffix(path, separator)
+	if path == basePath {
+		return 0
+	}
+	if strings.HasPrefix(path, basePath+separator) {
+		return uint(strings.Count(strings.TrimPrefix(path, basePath+separator), separator)) + 1
+	}
+	if i := strings.LastIndex(basePath, separator); i >= 0 {
+		path = strings.TrimPrefix(path, basePath[:i+1])
+	}
+	return uint(strings.Count(strings.Trim(path, separator), separator))
+}
+
+// isWithinFindDepth returns true if depth is within the requested
+// --mindepth and --maxdepth, a maxDepth of 0 means no limit.
+func isWithinFindDepth(ctx *findContext, depth uint) bool {
+	if ctx.maxDepth > 0 && depth > ctx.maxDepth {
+		return false
+	}
+	return depth >= ctx.minDepth
 }
 
 // Get aliased path used finally in printing, trim paths to ensure
 // that we have removed the fully qualified paths and original
-// start prefix (targetAlias) is retained. This function also honors
-// maxDepth if set then the resultant path will be trimmed at requested
-// maxDepth.
+// start prefix (targetAlias) is retained.
 func getAliasedPath(ctx *findContext, path string) string {
 	separator := string(ctx.clnt.GetURL().Separator)
 	prefixPath := ctx.clnt.GetURL().String()
@@ -247,7 +261,7 @@ func getAliasedPath(ctx *findContext, path string) string {
 			aliasedPath = path[i:]
 		}
 	}
-	return trimSuffixAtMaxDepth(ctx.targetURL, aliasedPath, separator, ctx.maxDepth)
+	return aliasedPath
 }
 
 func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage) {
@@ -261,6 +275,10 @@ func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage)
 		execFind(ctxCtx, ctx.execCmd, fileContent)
 		return
 	}
+	if ctx.format != nil {
+		printMsg(findFormatMessage{contentMessage: fileContent, line: ctx.format.render(fileContent)})
+		return
+	}
 	if ctx.printFmt != "" {
 		fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
 	}
@@ -278,12 +296,13 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 	lstOptions := ListOptions{
 		WithOlderVersions: ctx.withOlderVersions,
 		WithDeleteMarkers: false,
-		Recursive:         true,
 		ShowDir:           DirFirst,
 	}
 
+	clntURL := ctx.clnt.GetURL()
+
 	// iterate over all content which is within the given directory
-	for content := range ctx.clnt.List(globalContext, lstOptions) {
+	for content := range listFind(globalContext, ctx, lstOptions) {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -306,13 +325,22 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 		if content.StorageClass == s3StorageClassGlacier {
 			continue
 		}
+		if !isWithinFindDepth(ctx, findDepth(clntURL.Path, content.URL.Path, string(clntURL.Separator))) {
+			continue
+		}
 
 		fileKeyName := getAliasedPath(ctx, content.URL.String())
 		fileContent := contentMessage{
-			Key:       fileKeyName,
-			VersionID: content.VersionID,
-			Time:      content.Time.Local(),
-			Size:      content.Size,
+			Key:          fileKeyName,
+			VersionID:    content.VersionID,
+			Time:         content.Time.Local(),
+			Size:         content.Size,
+			ETag:         content.ETag,
+			StorageClass: content.StorageClass,
+			Filetype:     "file",
+		}
+		if content.Type.IsDir() {
+			fileContent.Filetype = "folder"
 		}
 
 		// Match the incoming content, didn't match return.
@@ -320,11 +348,26 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 			continue
 		} // For all matching content
 
+		if ctx.ageHistogram != nil {
+			if !content.Type.IsDir() {
+				ctx.ageHistogram.add(content.Time, content.Size)
+			}
+			continue
+		}
+
 		// proceed to either exec, format the output string.
+		if ctx.execBatch != nil {
+			ctx.execBatch.add(fileContent.Key)
+			continue
+		}
 		if ctx.execCmd != "" {
 			execFind(ctxCtx, ctx.execCmd, fileContent)
 			continue
 		}
+		if ctx.format != nil {
+			printMsg(findFormatMessage{contentMessage: fileContent, line: ctx.format.render(fileContent)})
+			continue
+		}
 		if ctx.printFmt != "" {
 			fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
 		}
@@ -332,11 +375,92 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 		printMsg(findMessage{fileContent})
 	}
 
+	if ctx.ageHistogram != nil {
+		printMsg(ctx.ageHistogram.message())
+	}
+	if ctx.execBatch != nil {
+		return ctx.execBatch.flush()
+	}
+
 	// Success, notice watch will execute in defer only if enabled and this call
 	// will return after watch is canceled.
 	return nil
 }
 
+// listFind lists all content below the target. When --maxdepth is set the
+// listing is done one prefix level at a time and does not descend past
+// maxDepth, instead of listing everything recursively.
+func listFind(ctxCtx context.Context, ctx *findContext, lstOptions ListOptions) <-chan *ClientContent {
+	if ctx.maxDepth == 0 {
+		lstOptions.Recursive = true
+		return ctx.clnt.List(ctxCtx, lstOptions)
+	}
+
+	contentCh := make(chan *ClientContent)
+	go func() {
+		defer close(contentCh)
+		listFindAtDepth(ctxCtx, ctx, ctx.clnt, 1, lstOptions, contentCh)
+	}()
+	return contentCh
+}
+
+// listFindAtDepth lists clnt non recursively, content found is at depth and
+// sub-directories are listed as long as they are within maxDepth.
+func listFindAtDepth(ctxCtx context.Context, ctx *findContext, clnt Client, depth uint, lstOptions ListOptions, contentCh chan<- *ClientContent) bool {
+	clntURL := clnt.GetURL()
+	targetAbsolutePath := path.Clean(clntURL.Path)
+	for content := range clnt.List(ctxCtx, lstOptions) {
+		isSelf := content.Err == nil && path.Clean(content.URL.Path) == targetAbsolutePath
+		// Sub-directories were already sent by their parent listing.
+		if isSelf && depth > 1 {
+			continue
+		}
+
+		select {
+		case contentCh <- content:
+		case <-ctxCtx.Done():
+			return false
+		}
+
+		if content.Err != nil || !content.Type.IsDir() {
+			continue
+		}
+
+		nextDepth := depth + 1
+		if isSelf {
+			// The starting prefix itself was listed, list its content
+			// unless it is the directory being listed already.
+			if strings.HasSuffix(clntURL.Path, string(clntURL.Separator)) {
+				continue
+			}
+			nextDepth = depth
+		} else if depth >= ctx.maxDepth {
+			continue
+		}
+
+		subDirAlias := content.URL.Path
+		if ctx.targetAlias != "" {
+			subDirAlias = ctx.targetAlias + "/" + content.URL.Path
+		}
+		if !strings.HasSuffix(subDirAlias, string(content.URL.Separator)) {
+			subDirAlias += string(content.URL.Separator)
+		}
+		subClnt, err := newClient(subDirAlias)
+		if err != nil {
+			select {
+			case contentCh <- &ClientContent{Err: err.Trace(subDirAlias)}:
+			case <-ctxCtx.Done():
+				return false
+			}
+			continue
+		}
+		if !listFindAtDepth(ctxCtx, ctx, subClnt, nextDepth, lstOptions, contentCh) {
+			return false
+		}
+	}
+	return true
+}
+
 // stringsReplace - formats the string to remove {} and replace each
 // with the appropriate argument
 func stringsReplace(ctx context.Context, args string, fileContent contentMessage) string {
@@ -412,8 +536,12 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && ctx.pathPattern != "" {
 		match = pathMatch(ctx.pathPattern, path)
 	}
-	if match && ctx.regexPattern != "" {
-		match = regexMatch(ctx.regexPattern, path)
+	if match && ctx.regexPattern != nil {
+		name := path
+		if ctx.regexBasename {
+			name = filepath.Base(path)
+		}
+		match = ctx.regexPattern.MatchString(name)
 	}
 	if match && ctx.olderThan != "" {
 		match = !isOlder(fileContent.Time, ctx.olderThan)
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"os/exec"
+	"regexp"
 	"runtime"
 	"strings"
 	"testing"
@@ -54,7 +55,7 @@ func TestMatchFind(t *testing.T) {
 			clnt: &S3Client{
 				targetURL: &ClientURL{},
 			},
-			regexPattern: `^(\d+\.){3}\d+$`,
+			regexPattern: regexp.MustCompile(`^(\d+\.){3}\d+$`),
 		},
 		{
 			clnt: &S3Client{
@@ -176,86 +177,53 @@ func TestMatchFind(t *testing.T) {
 	}
 }
 
-// Tests suffix strings trimmed off correctly at maxdepth.
-func TestSuffixTrimmingAtMaxDepth(t *testing.T) {
+// Tests depth of paths below the starting prefix.
+func TestFindDepth(t *testing.T) {
 	testCases := []struct {
-		startPrefix     string
-		path            string
-		separator       string
-		maxDepth        uint
-		expectedNewPath string
+		basePath string
+		path     string
+		depth    uint
 	}{
-		// Tests at max depth 0.
-		{
-			startPrefix:     "./",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        0,
-			expectedNewPath: ".git/refs/remotes",
-		},
-		// Tests at max depth 1.
-		{
-			startPrefix:     "./",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        1,
-			expectedNewPath: "./.git/",
-		},
-		// Tests at max depth 2.
-		{
-			startPrefix:     "./",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        2,
-			expectedNewPath: "./.git/refs/",
-		},
-		// Tests at max depth 3.
-		{
-			startPrefix:     "./",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        3,
-			expectedNewPath: "./.git/refs/remotes",
-		},
-		// Tests with startPrefix empty.
-		{
-			startPrefix:     "",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        2,
-			expectedNewPath: ".git/refs/",
-		},
-		// Tests with separator empty.
-		{
-			startPrefix:     "",
-			path:            ".git/refs/remotes",
-			separator:       "",
-			maxDepth:        2,
-			expectedNewPath: ".g",
-		},
-		// Tests with nested startPrefix paths - 1.
-		{
-			startPrefix:     ".git/refs/",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        1,
-			expectedNewPath: ".git/refs/remotes",
-		},
-		// Tests with nested startPrefix paths - 2.
-		{
-			startPrefix:     ".git/refs",
-			path:            ".git/refs/remotes",
-			separator:       "/",
-			maxDepth:        1,
-			expectedNewPath: ".git/refs/",
-		},
+		{"/bucket", "/bucket", 0},
+		{"/bucket/", "/bucket/", 0},
+		{"/bucket", "/bucket/object", 1},
+		{"/bucket/", "/bucket/dir/", 1},
+		{"/bucket", "/bucket/dir/object", 2},
+		{"/bucket/dir", "/bucket/dir/a/b/object", 3},
+		{"/tmp/src", "/tmp/src/a/b", 2},
+		// Siblings sharing a name prefix are at the level of the base.
+		{"/bucket/dir", "/bucket/dir2/object", 1},
+		{"/bucket/dir", "/bucket/directory", 0},
+		{"/bucket/dir", "/bucket/dir2/a/b", 2},
 	}
 
-	// Run all the test cases and validate for returned new path.
 	for i, testCase := range testCases {
-		gotNewPath := trimSuffixAtMaxDepth(testCase.startPrefix, testCase.path, testCase.separator, testCase.maxDepth)
-		if testCase.expectedNewPath != gotNewPath {
-			t.Errorf("Test: %d, expected path %s, got %s", i+1, testCase.expectedNewPath, gotNewPath)
+		if depth := findDepth(testCase.basePath, testCase.path, "/"); depth != testCase.depth {
+			t.Errorf("Test %d: expected depth %d, got %d", i+1, testCase.depth, depth)
+		}
+	}
+}
+
+// Tests --mindepth and --maxdepth bounds.
+func TestIsWithinFindDepth(t *testing.T) {
+	testCases := []struct {
+		minDepth, maxDepth uint
+		depth              uint
+		expected           bool
+	}{
+		{0, 0, 5, true},
+		{0, 1, 1, true},
+		{0, 1, 2, false},
+		{2, 0, 1, false},
+		{2, 0, 2, true},
+		{2, 3, 3, true},
+		{2, 3, 4, false},
+	}
+
+	for i, testCase := range testCases {
+		ctx := &findContext{minDepth: testCase.minDepth, maxDepth: testCase.maxDepth}
+		if got := isWithinFindDepth(ctx, testCase.depth); got != testCase.expected {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.expected, got)
 		}
 	}
 }
@@ -461,3 +429,70 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestFindFormat(t *testing.T) {
+	content := contentMessage{
+		Key:       "s3/bucket/dir/object.txt",
+		Size:      1024 * 1024,
+		Time:      time.Unix(2147483647, 0).UTC(),
+		ETag:      "abc",
+		VersionID: "v1",
+	}
+	testCases := []struct {
+		format   string
+		expected string
+		success  bool
+	}{
+		{`{path}\t{size}\t{mtime:2006-01-02}`, "s3/bucket/dir/object.txt\t1048576\t2038-01-19", true},
+		{`{base} {dir} {hsize}`, "object.txt s3/bucket/dir 1.0 MiB", true},
+		{`{mtime}\n`, "2038-01-19T03:14:07Z\n", true},
+		{`\{{etag}\} {version}\\`, `{abc} v1\`, true},
+		{`no fields`, "no fields", true},
+		{`{path} {name}`, "", false},
+		{`{size:2006}`, "", false},
+		{`{path`, "", false},
+		{`path}`, "", false},
+	}
+	for i, testCase := range testCases {
+		format, e := parseFindFormat(testCase.format)
+		if (e == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, e)
+		}
+		if e != nil {
+			continue
+		}
+		if line := format.render(content); line != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, line)
+		}
+	}
+}
+
+// Tests --regex matched against the path or the base name, along with --name.
+func TestMatchFindRegex(t *testing.T) {
+	testCases := []struct {
+		namePattern string
+		regex       string
+		basename    bool
+		key         string
+		match       bool
+	}{
+		{"", `^2024-(01|02)-.*\.log$`, false, "app/2024-01-02.log", false},
+		{"", `^2024-(01|02)-.*\.log$`, true, "app/2024-01-02.log", true},
+		{"", `^2024-(01|02)-.*\.log$`, true, "app/2024-03-02.log", false},
+		{"", `^app/2024-`, false, "app/2024-01-02.log", true},
+		// Both --name and --regex must match.
+		{"*.log", `^2024-`, true, "app/2024-01-02.log", true},
+		{"*.txt", `^2024-`, true, "app/2024-01-02.log", false},
+	}
+	for i, testCase := range testCases {
+		ctx := &findContext{
+			clnt:          &S3Client{targetURL: &ClientURL{}},
+			namePattern:   testCase.namePattern,
+			regexPattern:  regexp.MustCompile(testCase.regex),
+			regexBasename: testCase.basename,
+		}
+		if match := matchFind(ctx, contentMessage{Key: testCase.key}); match != testCase.match {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.match, match)
+		}
+	}
+}
@@ -79,3 +79,15 @@ var ioFlags = []cli.Flag{
 		Usage: "encrypt/decrypt objects (using server-side encryption with customer provided keys)",
 	},
 }
+
+// maxListPageSize is the largest number of keys per listing request allowed by
+// the S3 API, backends accepting larger pages are still limited to it.
+const maxListPageSize = 1000
+
+// Flags common across the commands listing objects such as ls, cp, mirror, rm etc.
+var listFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "page-size",
+		Usage: "number of keys requested per listing request, at most 1000, defaults to the server's page size",
+	},
+}
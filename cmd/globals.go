@@ -21,6 +21,7 @@ package cmd
 import (
 	"context"
 	"crypto/x509"
+	"fmt"
 	"net/url"
 	"time"
 
@@ -76,6 +77,13 @@ var (
 	globalLimitUpload   uint64
 	globalLimitDownload uint64
 
+	// Number of keys per listing request, the server's default if zero.
+	globalListPageSize int
+
+	globalContentTypeMap map[string]string // Extension to content-type overrides set via --content-type-map
+	globalNoSniff        bool              // Content-type sniffing disabled via --no-sniff
+	globalSniffText      bool              // Text content-types sniffed as well, only by cp
+
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
 
@@ -135,6 +143,13 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 		}
 	}
 
+	if pageSize := ctx.Int("page-size"); pageSize != 0 {
+		if pageSize < 0 || pageSize > maxListPageSize {
+			return fmt.Errorf("--page-size must be between 1 and %d", maxListPageSize)
+		}
+		globalListPageSize = pageSize
+	}
+
 	limitDownloadStr := ctx.String("limit-download")
 	if limitDownloadStr == "" {
 		limitDownloadStr = ctx.GlobalString("limit-download")
@@ -59,7 +59,7 @@ var headCmd = cli.Command{
 	Action:       mainHead,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(headFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(headFlags, sseCKeyFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -88,6 +88,9 @@ EXAMPLES:
 
   4. Display the first lines of a specific object version.
      {{.Prompt}} {{.HelpName}} --version-id "3ddac055-89a7-40fa-8cd3-530a5581b6b8" s3/json-data/population.json
+
+  5. Display the first lines of an SSE-C encrypted object, reading the key from a file only its owner can access.
+     {{.Prompt}} {{.HelpName}} --sse-c-key-file ~/.keys/csv.key s3/csv-data/population.csv
 `,
 }
 
@@ -192,6 +195,12 @@ func mainHead(ctx *cli.Context) error {
 
 	args, versionID, timeRef := parseHeadSyntax(ctx)
 
+	sseCKey, err := getSSECKey(ctx)
+	fatalIf(err, "Unable to read the SSE-C key.")
+	if sseCKey != nil {
+		fatalIf(addSSECKey(encKeyDB, args, sseCKey), "Unable to set the SSE-C key.")
+	}
+
 	stdinMode := len(args) == 0
 
 	// handle std input data.
@@ -202,7 +211,11 @@ func mainHead(ctx *cli.Context) error {
 
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range ctx.Args() {
-		fatalIf(headURL(url, versionID, timeRef, encKeyDB, ctx.Int64("lines"), ctx.Bool("zip")).Trace(url), "Unable to read from `"+url+"`.")
+		err := headURL(url, versionID, timeRef, encKeyDB, ctx.Int64("lines"), ctx.Bool("zip"))
+		if sseCKey != nil {
+			err = sseCKeyError(err)
+		}
+		fatalIf(err.Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
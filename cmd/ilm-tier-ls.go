@@ -151,6 +151,9 @@ type tierListMessage struct {
 	Status  string               `json:"status"`
 	Context *cli.Context         `json:"-"`
 	Tiers   []*madmin.TierConfig `json:"tiers"`
+	// Usage holds the objects and bytes transitioned to each tier,
+	// keyed by tier name, when the server reports them.
+	Usage map[string]madmin.TierStats `json:"usage,omitempty"`
 }
 
 // String method returns a tabular listing of remote tier configurations.
@@ -181,10 +184,20 @@ func mainAdminTierList(ctx *cli.Context) error {
 	tiers, e := client.ListTiers(globalContext)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to list configured remote tier targets")
 
-	printMsg(&tierListMessage{
+	msg := &tierListMessage{
 		Status:  "success",
 		Context: ctx,
 		Tiers:   tiers,
-	})
+	}
+	if globalJSON {
+		// Usage is best effort, older servers do not report tier statistics.
+		if tInfos, e := client.TierStats(globalContext); e == nil {
+			msg.Usage = make(map[string]madmin.TierStats, len(tInfos))
+			for _, tInfo := range tInfos {
+				msg.Usage[tInfo.Name] = tInfo.Stats
+			}
+		}
+	}
+	printMsg(msg)
 	return nil
 }
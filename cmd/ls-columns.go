@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/pkg/console"
+)
+
+// lsColumns are the columns 'ls --columns' can show, in the order
+// of the default listing.
+var lsColumns = []string{"mtime", "size", "storageclass", "checksum", "versionid", "etag", "key"}
+
+// lsHeadColumns are the columns not returned by a listing, showing
+// them sends a HEAD request per object.
+var lsHeadColumns = set.CreateStringSet("checksum")
+
+// parseLsColumns parses a comma separated list of column names.
+func parseLsColumns(value string) ([]string, *probe.Error) {
+	valid := set.CreateStringSet(lsColumns...)
+	seen := set.NewStringSet()
+	var columns []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !valid.Contains(name) {
+			return nil, probe.NewError(fmt.Errorf("unknown column `%s`, valid columns are %s", name, strings.Join(lsColumns, ",")))
+		}
+		if seen.Contains(name) {
+			return nil, probe.NewError(fmt.Errorf("column `%s` is listed more than once", name))
+		}
+		seen.Add(name)
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
+
+// lsColumnsNeedHead returns the selected columns which send
+// a HEAD request per object.
+func lsColumnsNeedHead(columns []string) (head []string) {
+	for _, name := range columns {
+		if lsHeadColumns.Contains(name) {
+			head = append(head, name)
+		}
+	}
+	return head
+}
+
+// columnsString renders the selected columns of a content message, an empty
+// value is shown as '-' to keep the same number of fields on every line.
+func (c contentMessage) columnsString() string {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	fields := make([]string, 0, len(c.columns))
+	for _, name := range c.columns {
+		switch name {
+		case "mtime":
+			fields = append(fields, console.Colorize("Time", fmt.Sprintf("[%s]", c.Time.Format(printDate))))
+		case "size":
+			fields = append(fields, console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), ""))))
+		case "storageclass":
+			fields = append(fields, console.Colorize("SC", orDash(c.StorageClass)))
+		case "checksum":
+			checksum := ""
+			if c.ChecksumAlgo != nil && *c.ChecksumAlgo != "" {
+				checksum = *c.ChecksumAlgo + ":" + *c.ChecksumValue
+			}
+			fields = append(fields, console.Colorize("Checksum", orDash(checksum)))
+		case "versionid":
+			fields = append(fields, console.Colorize("VersionID", orDash(c.VersionID)))
+		case "etag":
+			fields = append(fields, orDash(c.ETag))
+		case "key":
+			if c.Filetype == "folder" {
+				fields = append(fields, console.Colorize("Dir", c.Key))
+			} else {
+				fields = append(fields, console.Colorize("File", c.Key))
+			}
+		}
+	}
+	return strings.Join(fields, " ")
+}
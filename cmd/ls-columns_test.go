@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLsColumns(t *testing.T) {
+	testCases := []struct {
+		value    string
+		columns  []string
+		needHead []string
+		success  bool
+	}{
+		{"size,key", []string{"size", "key"}, nil, true},
+		{"ETag, storageclass,mtime ,key", []string{"etag", "storageclass", "mtime", "key"}, nil, true},
+		{"key,checksum", []string{"key", "checksum"}, []string{"checksum"}, true},
+		{"size,owner", nil, nil, false},
+		{"size,,key", nil, nil, false},
+		{"key,size,key", nil, nil, false},
+	}
+	for i, testCase := range testCases {
+		columns, err := parseLsColumns(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if !reflect.DeepEqual(columns, testCase.columns) {
+			t.Errorf("Test %d: expected columns %v, got %v", i+1, testCase.columns, columns)
+		}
+		if head := lsColumnsNeedHead(columns); !reflect.DeepEqual(head, testCase.needHead) {
+			t.Errorf("Test %d: expected HEAD columns %v, got %v", i+1, testCase.needHead, head)
+		}
+	}
+
+	_, err := parseLsColumns("owner")
+	if err == nil || !strings.Contains(err.ToGoError().Error(), strings.Join(lsColumns, ",")) {
+		t.Errorf("Expected the valid columns in the error, got %v", err)
+	}
+}
+
+func TestContentMessageColumns(t *testing.T) {
+	msg := contentMessage{Key: "a/b.txt", Size: 2048, ETag: "abc", Filetype: "file"}
+	msg.columns = []string{"size", "key", "storageclass", "etag"}
+	if fields := strings.Fields(msg.String()); !reflect.DeepEqual(fields, []string{"2.0KiB", "a/b.txt", "-", "abc"}) {
+		t.Errorf("Unexpected columns %q", fields)
+	}
+	if json := msg.JSON(); !strings.Contains(json, `"lastModified"`) || !strings.Contains(json, `"etag":"abc"`) {
+		t.Errorf("Expected the full JSON output, got %s", json)
+	}
+}
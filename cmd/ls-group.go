@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// prefixGroupMessage container for the objects of a prefix, rolled up by
+// 'ls --group-by-prefix'. The objects at a lower depth are grouped under
+// their own prefix, the empty prefix holds the objects of the listed folder.
+type prefixGroupMessage struct {
+	Status        string `json:"status"`
+	SchemaVersion int    `json:"schemaVersion"`
+	Prefix        string `json:"prefix"`
+	Objects       int64  `json:"objects"`
+	Size          int64  `json:"size"`
+}
+
+// String colorized prefix group message.
+func (p prefixGroupMessage) String() string {
+	prefix := p.Prefix
+	if prefix == "" {
+		prefix = "./"
+	}
+	message := console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(p.Size))), "")))
+	message += console.Colorize("VersionOrd", fmt.Sprintf("%10s objects", humanize.Comma(p.Objects)))
+	message += console.Colorize("Dir", " "+prefix)
+	return message
+}
+
+// JSON jsonified prefix group message.
+func (p prefixGroupMessage) JSON() string {
+	p.Status = "success"
+	p.SchemaVersion = lsJSONSchemaVersion
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// prefixGroups rolls up the objects of a listing by prefix, up to depth
+// levels below the listed folder.
+type prefixGroups struct {
+	depth  int
+	groups map[string]*prefixGroupMessage
+}
+
+func newPrefixGroups(depth int) *prefixGroups {
+	return &prefixGroups{depth: depth, groups: make(map[string]*prefixGroupMessage)}
+}
+
+// add counts an object, key is relative to the listed folder.
+func (g *prefixGroups) add(key string, size int64) {
+	dirs := strings.Split(key, "/")
+	dirs = dirs[:len(dirs)-1]
+	if len(dirs) > g.depth {
+		dirs = dirs[:g.depth]
+	}
+	prefix := ""
+	if len(dirs) > 0 {
+		prefix = strings.Join(dirs, "/") + "/"
+	}
+	group, ok := g.groups[prefix]
+	if !ok {
+		group = &prefixGroupMessage{Prefix: prefix}
+		g.groups[prefix] = group
+	}
+	group.Objects++
+	group.Size += size
+}
+
+// messages returns the groups ordered by prefix.
+func (g *prefixGroups) messages() []prefixGroupMessage {
+	msgs := make([]prefixGroupMessage, 0, len(g.groups))
+	for _, group := range g.groups {
+		msgs = append(msgs, *group)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].Prefix < msgs[j].Prefix
+	})
+	return msgs
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrefixGroups(t *testing.T) {
+	testCases := []struct {
+		depth    int
+		expected []prefixGroupMessage
+	}{
+		{1, []prefixGroupMessage{
+			{Prefix: "", Objects: 1, Size: 1},
+			{Prefix: "a/", Objects: 3, Size: 14},
+			{Prefix: "b/", Objects: 1, Size: 16},
+		}},
+		{2, []prefixGroupMessage{
+			{Prefix: "", Objects: 1, Size: 1},
+			{Prefix: "a/", Objects: 1, Size: 2},
+			{Prefix: "a/x/", Objects: 2, Size: 12},
+			{Prefix: "b/", Objects: 1, Size: 16},
+		}},
+	}
+	for i, testCase := range testCases {
+		g := newPrefixGroups(testCase.depth)
+		g.add("top.txt", 1)
+		g.add("a/one.txt", 2)
+		g.add("a/x/two.txt", 4)
+		g.add("a/x/y/three.txt", 8)
+		g.add("b/four.txt", 16)
+		if msgs := g.messages(); !reflect.DeepEqual(msgs, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, msgs)
+		}
+	}
+}
@@ -20,6 +20,8 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -40,6 +42,10 @@ var (
 			Name:  "versions",
 			Usage: "list all versions",
 		},
+		cli.BoolFlag{
+			Name:  "older-versions-count",
+			Usage: "show the number of versions and delete markers of each object, most versions first",
+		},
 		cli.BoolFlag{
 			Name:  "recursive, r",
 			Usage: "list recursively",
@@ -60,9 +66,45 @@ var (
 			Name:  "zip",
 			Usage: "list files inside zip archive (MinIO servers only)",
 		},
+		cli.BoolFlag{
+			Name:  "no-dir-object",
+			Usage: "hide zero-byte objects with a name ending with '/' from a recursive listing",
+		},
+		cli.BoolFlag{
+			Name:  "checksum",
+			Usage: "show the algorithm and value of the checksum stored with each object, sends a HEAD request per object",
+		},
+		cli.IntFlag{
+			Name:  "checksum-workers",
+			Usage: "number of parallel HEAD requests sent by --checksum",
+			Value: defaultChecksumWorkers,
+		},
+		cli.StringFlag{
+			Name:  "after",
+			Usage: "only list the object keys lexically after KEY, in a recursive listing",
+		},
+		cli.StringFlag{
+			Name:  "before",
+			Usage: "only list the object keys lexically before KEY, in a recursive listing",
+		},
+		cli.BoolFlag{
+			Name:  "group-by-prefix",
+			Usage: "with --recursive, show the number and the size of the objects of each prefix instead of the objects",
+		},
+		cli.IntFlag{
+			Name:  "depth",
+			Usage: "number of prefix levels below the listed folder to group by with --group-by-prefix",
+			Value: 1,
+		},
+		cli.StringFlag{
+			Name:  "columns",
+			Usage: "comma separated columns of the text output, in order, one of " + strings.Join(lsColumns, ","),
+		},
 	}
 )
 
+const defaultChecksumWorkers = 16
+
 // list files and folders.
 var lsCmd = cli.Command{
 	Name:         "ls",
@@ -70,7 +112,7 @@ var lsCmd = cli.Command{
 	Action:       mainList,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(lsFlags, globalFlags...),
+	Flags:        append(append(lsFlags, listFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -112,6 +154,36 @@ EXAMPLES:
   
   10. List all objects on mybucket, for the GLACIER storage class
      {{.Prompt}} {{.HelpName}} --storage-class 'GLACIER' s3/mybucket 
+
+  11. Find the objects with the most versions in mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --older-versions-count s3/mybucket
+
+  12. List all objects in mybucket recursively, without the zero-byte 'folder/' objects created by some
+      tools and consoles to represent folders. Prefixes are not objects and are never listed recursively.
+      {{.Prompt}} {{.HelpName}} --recursive --no-dir-object s3/mybucket
+
+  13. List a large bucket over a high latency link requesting the most keys allowed per request, 1000
+      per the S3 API, even for backends supporting larger pages.
+      {{.Prompt}} {{.HelpName}} --recursive --page-size 1000 s3/mybucket
+
+  14. Build an integrity manifest of a prefix from the checksums stored with the objects, without
+      downloading them.
+      {{.Prompt}} {{.HelpName}} --recursive --checksum --json s3/mybucket/backups/ > manifest.json
+
+  15. Split the listing of a large bucket across workers by key range, keys are relative to the bucket.
+      {{.Prompt}} {{.HelpName}} --recursive --before logs/2022 s3/mybucket
+      {{.Prompt}} {{.HelpName}} --recursive --after logs/2022 --before logs/2023 s3/mybucket
+      {{.Prompt}} {{.HelpName}} --recursive --after logs/2023 s3/mybucket
+
+  16. List only the size and the name of the objects in mybucket, to feed a script.
+      {{.Prompt}} {{.HelpName}} --recursive --columns size,key s3/mybucket
+
+  17. List the ETag, storage class, modification time and name of each object, the JSON output
+      is not affected by --columns.
+      {{.Prompt}} {{.HelpName}} --recursive --columns etag,storageclass,mtime,key s3/mybucket
+
+  18. Show the number and the size of the objects of each prefix two levels deep in mybucket.
+      {{.Prompt}} {{.HelpName}} --recursive --group-by-prefix --depth 2 s3/mybucket
 `,
 }
 
@@ -170,7 +242,8 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 
 	isRecursive := cliCtx.Bool("recursive")
 	isIncomplete := cliCtx.Bool("incomplete")
-	withOlderVersions := cliCtx.Bool("versions")
+	versionsCount := cliCtx.Bool("older-versions-count")
+	withOlderVersions := cliCtx.Bool("versions") || versionsCount
 	isSummary := cliCtx.Bool("summarize")
 	listZip := cliCtx.Bool("zip")
 
@@ -179,6 +252,68 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 	if listZip && (withOlderVersions || !timeRef.IsZero()) {
 		fatalIf(errInvalidArgument().Trace(args...), "Zip file listing can only be performed on the latest version")
 	}
+	if versionsCount && isIncomplete {
+		fatalIf(errInvalidArgument().Trace(args...), "Incomplete uploads do not have versions to count")
+	}
+	noDirObject := cliCtx.Bool("no-dir-object")
+	if noDirObject && !isRecursive {
+		fatalIf(errInvalidArgument().Trace(args...), "`--no-dir-object` can only be used with `--recursive`")
+	}
+	var columns []string
+	if cliCtx.IsSet("columns") {
+		var err *probe.Error
+		columns, err = parseLsColumns(cliCtx.String("columns"))
+		fatalIf(err.Trace(cliCtx.String("columns")), "Unable to parse --columns argument")
+		if versionsCount {
+			fatalIf(errInvalidArgument().Trace(args...), "`--columns` cannot be used with `--older-versions-count`")
+		}
+	}
+	var groupByPrefix int
+	if cliCtx.Bool("group-by-prefix") {
+		switch {
+		case !isRecursive:
+			fatalIf(errInvalidArgument().Trace(args...), "`--group-by-prefix` can only be used with `--recursive`")
+		case withOlderVersions || isIncomplete || cliCtx.Bool("checksum") || len(columns) > 0:
+			fatalIf(errInvalidArgument().Trace(args...), "`--group-by-prefix` cannot be used with `--versions`, `--older-versions-count`, `--incomplete`, `--checksum` or `--columns`")
+		case cliCtx.Int("depth") <= 0:
+			fatalIf(errInvalidArgument().Trace(args...), "`--depth` must be a positive number")
+		}
+		groupByPrefix = cliCtx.Int("depth")
+	} else if cliCtx.IsSet("depth") {
+		fatalIf(errInvalidArgument().Trace(args...), "`--depth` requires `--group-by-prefix`")
+	}
+	checksum := cliCtx.Bool("checksum")
+	if head := lsColumnsNeedHead(columns); len(head) > 0 && !globalJSON {
+		if !isRecursive || isIncomplete || listZip {
+			fatalIf(errInvalidArgument().Trace(args...), "The `"+strings.Join(head, "`, `")+"` column can only be used with `--recursive`, without `--incomplete` or `--zip`")
+		}
+		// Showing these columns fetches the metadata of every object, the
+		// same way --checksum does.
+		fmt.Fprintln(os.Stderr, warnText(fmt.Sprintf("WARNING: the `%s` column sends a HEAD request per object, listing will be slower and cost more requests.",
+			strings.Join(head, "`, `"))))
+		checksum = true
+	}
+	if checksum && !isRecursive {
+		fatalIf(errInvalidArgument().Trace(args...), "`--checksum` can only be used with `--recursive`")
+	}
+	if checksum && (isIncomplete || versionsCount || listZip) {
+		fatalIf(errInvalidArgument().Trace(args...), "`--checksum` cannot be used with `--incomplete`, `--older-versions-count` or `--zip`")
+	}
+	if cliCtx.Int("checksum-workers") <= 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "`--checksum-workers` must be a positive number")
+	}
+	after, before := cliCtx.String("after"), cliCtx.String("before")
+	if after != "" || before != "" {
+		if !isRecursive {
+			fatalIf(errInvalidArgument().Trace(args...), "`--after` and `--before` can only be used with `--recursive`")
+		}
+		if isIncomplete || withOlderVersions || !timeRef.IsZero() || listZip {
+			fatalIf(errInvalidArgument().Trace(args...), "`--after` and `--before` cannot be used with `--incomplete`, `--versions`, `--older-versions-count`, `--rewind` or `--zip`")
+		}
+		if after != "" && before != "" && after >= before {
+			fatalIf(errInvalidArgument().Trace(after, before), "`--after` must be lexically smaller than `--before`")
+		}
+	}
 	storageClasss := cliCtx.String("storage-class")
 	opts := doListOptions{
 		timeRef:           timeRef,
@@ -186,8 +321,16 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doList
 		isIncomplete:      isIncomplete,
 		isSummary:         isSummary,
 		withOlderVersions: withOlderVersions,
+		versionsCount:     versionsCount,
 		listZip:           listZip,
+		noDirObject:       noDirObject,
 		filter:            storageClasss,
+		checksum:          checksum,
+		checksumWorkers:   cliCtx.Int("checksum-workers"),
+		startAfter:        after,
+		endBefore:         before,
+		columns:           columns,
+		groupByPrefix:     groupByPrefix,
 	}
 	return args, opts
 }
@@ -208,6 +351,7 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("Time", color.New(color.FgGreen))
 	console.SetColor("Summarize", color.New(color.Bold))
 	console.SetColor("SC", color.New(color.FgBlue))
+	console.SetColor("Checksum", color.New(color.FgMagenta))
 
 	// check 'ls' cliCtx arguments.
 	args, opts := checkListSyntax(ctx, cliCtx)
@@ -225,6 +369,10 @@ func mainList(cliCtx *cli.Context) error {
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 			}
 		}
+		if (opts.startAfter != "" || opts.endBefore != "") && clnt.GetURL().Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(targetURL), "`--after` and `--before` are only supported on S3 targets")
+		}
+		opts.alias, _ = url2Alias(targetURL)
 		if e := doList(ctx, clnt, opts); e != nil {
 			cErr = e
 		}
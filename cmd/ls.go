@@ -36,25 +36,41 @@ const (
 	printDate = "2006-01-02 15:04:05 MST"
 )
 
+// lsJSONSchemaVersion is the version of the JSON output of 'ls'. Changes
+// within a version are additive only, renaming or removing a field requires
+// bumping the version.
+const lsJSONSchemaVersion = 1
+
 // contentMessage container for content message structure.
 type contentMessage struct {
-	Status   string    `json:"status"`
-	Filetype string    `json:"type"`
-	Time     time.Time `json:"lastModified"`
-	Size     int64     `json:"size"`
-	Key      string    `json:"key"`
-	ETag     string    `json:"etag"`
-	URL      string    `json:"url,omitempty"`
+	Status        string    `json:"status"`
+	SchemaVersion int       `json:"schemaVersion"`
+	Filetype      string    `json:"type"`
+	Time          time.Time `json:"lastModified"`
+	Size          int64     `json:"size"`
+	Key           string    `json:"key"`
+	ETag          string    `json:"etag"`
+	URL           string    `json:"url,omitempty"`
 
 	VersionID      string `json:"versionId,omitempty"`
 	VersionOrd     int    `json:"versionOrdinal,omitempty"`
 	VersionIndex   int    `json:"versionIndex,omitempty"`
 	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
 	StorageClass   string `json:"storageClass,omitempty"`
+
+	// Only set with --checksum, empty for objects without a stored checksum.
+	ChecksumAlgo  *string `json:"checksumAlgo,omitempty"`
+	ChecksumValue *string `json:"checksumValue,omitempty"`
+
+	// Columns of the text output selected with --columns, all when empty.
+	columns []string
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
+	if len(c.columns) > 0 {
+		return c.columnsString()
+	}
 	message := console.Colorize("Time", fmt.Sprintf("[%s]", c.Time.Format(printDate)))
 	message += console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
 	fileDesc := ""
@@ -63,6 +79,10 @@ func (c contentMessage) String() string {
 		message += " " + console.Colorize("SC", c.StorageClass)
 	}
 
+	if c.ChecksumAlgo != nil && *c.ChecksumAlgo != "" {
+		message += " " + console.Colorize("Checksum", *c.ChecksumAlgo+":"+*c.ChecksumValue)
+	}
+
 	if c.VersionID != "" {
 		fileDesc += console.Colorize("VersionID", " "+c.VersionID) + console.Colorize("VersionOrd", fmt.Sprintf(" v%d", c.VersionOrd))
 		if c.IsDeleteMarker {
@@ -85,6 +105,7 @@ func (c contentMessage) String() string {
 // JSON jsonified content message.
 func (c contentMessage) JSON() string {
 	c.Status = "success"
+	c.SchemaVersion = lsJSONSchemaVersion
 	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
@@ -192,22 +213,164 @@ func (s summaryMessage) JSON() string {
 }
 
 // Pretty print the list of versions belonging to one object
-func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary bool) {
+func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary, withChecksum bool, columns []string) {
 	sortObjectVersions(ctntVersions)
 	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions)
-	for _, msg := range msgs {
+	for i, msg := range msgs {
+		if withChecksum {
+			algo, value := contentChecksum(ctntVersions[i])
+			msg.ChecksumAlgo, msg.ChecksumValue = &algo, &value
+		}
+		msg.columns = columns
 		printMsg(msg)
 	}
 }
 
+// checksumHeaders are the S3 checksum algorithms and the metadata
+// holding their values, in order of preference.
+var checksumHeaders = []struct {
+	algo, header string
+}{
+	{"CRC32C", "X-Amz-Checksum-Crc32c"},
+	{"CRC32", "X-Amz-Checksum-Crc32"},
+	{"SHA256", "X-Amz-Checksum-Sha256"},
+	{"SHA1", "X-Amz-Checksum-Sha1"},
+}
+
+// contentChecksum returns the algorithm and the value of the
+// checksum stored with an object, empty if there is none.
+func contentChecksum(c *ClientContent) (algo, value string) {
+	for _, h := range checksumHeaders {
+		if value = c.Metadata[h.header]; value != "" {
+			return h.algo, value
+		}
+	}
+	return "", ""
+}
+
+// statChecksums fetches the stored checksums of the listed objects with a
+// HEAD request each, using the given number of workers. The listing order
+// is kept, folders, delete markers and errors are passed as is.
+func statChecksums(ctx context.Context, in <-chan *ClientContent, workers int,
+	stat func(context.Context, *ClientContent) (*ClientContent, *probe.Error),
+) <-chan *ClientContent {
+	// Results in listing order, bounded by the number of workers.
+	pending := make(chan chan *ClientContent, workers)
+	go func() {
+		defer close(pending)
+		for content := range in {
+			result := make(chan *ClientContent, 1)
+			pending <- result
+			if content.Err != nil || content.Type.IsDir() || content.IsDeleteMarker {
+				result <- content
+				continue
+			}
+			go func(content *ClientContent) {
+				st, err := stat(ctx, content)
+				if err != nil {
+					result <- &ClientContent{URL: content.URL, Err: err.Trace(content.URL.String())}
+					return
+				}
+				if content.Metadata == nil {
+					content.Metadata = make(map[string]string)
+				}
+				for k, v := range st.Metadata {
+					if strings.HasPrefix(k, "X-Amz-Checksum-") {
+						content.Metadata[k] = v
+					}
+				}
+				result <- content
+			}(content)
+		}
+	}()
+	out := make(chan *ClientContent)
+	go func() {
+		defer close(out)
+		for result := range pending {
+			out <- <-result
+		}
+	}()
+	return out
+}
+
+// versionsCountMessage container for the versions of one object.
+type versionsCountMessage struct {
+	Status            string `json:"status"`
+	SchemaVersion     int    `json:"schemaVersion"`
+	Key               string `json:"key"`
+	VersionCount      int    `json:"versionCount"`
+	DeleteMarkerCount int    `json:"deleteMarkerCount"`
+	TotalSize         int64  `json:"totalSize"`
+}
+
+// String colorized versions count message.
+func (v versionsCountMessage) String() string {
+	message := console.Colorize("VersionOrd", fmt.Sprintf("%6d versions", v.VersionCount))
+	message += console.Colorize("DEL", fmt.Sprintf("%6d delete markers", v.DeleteMarkerCount))
+	message += console.Colorize("Size", fmt.Sprintf("%8s", strings.Join(strings.Fields(humanize.IBytes(uint64(v.TotalSize))), "")))
+	message += console.Colorize("File", " "+v.Key)
+	return message
+}
+
+// JSON jsonified versions count message.
+func (v versionsCountMessage) JSON() string {
+	v.Status = "success"
+	v.SchemaVersion = lsJSONSchemaVersion
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// newVersionsCountMessage counts the versions and delete markers of one object.
+func newVersionsCountMessage(key string, ctntVersions []*ClientContent) versionsCountMessage {
+	msg := versionsCountMessage{Key: key}
+	for _, c := range ctntVersions {
+		if c.IsDeleteMarker {
+			msg.DeleteMarkerCount++
+			continue
+		}
+		msg.VersionCount++
+		msg.TotalSize += c.Size
+	}
+	return msg
+}
+
+// sortVersionsCount sorts objects with the most versions first.
+func sortVersionsCount(msgs []versionsCountMessage) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		if msgs[i].VersionCount+msgs[i].DeleteMarkerCount != msgs[j].VersionCount+msgs[j].DeleteMarkerCount {
+			return msgs[i].VersionCount+msgs[i].DeleteMarkerCount > msgs[j].VersionCount+msgs[j].DeleteMarkerCount
+		}
+		return msgs[i].Key < msgs[j].Key
+	})
+}
+
 type doListOptions struct {
 	timeRef           time.Time
 	isRecursive       bool
 	isIncomplete      bool
 	isSummary         bool
 	withOlderVersions bool
+	versionsCount     bool
 	listZip           bool
+	noDirObject       bool
 	filter            string
+	checksum          bool
+	checksumWorkers   int
+	startAfter        string
+	endBefore         string
+	alias             string
+	columns           []string
+	groupByPrefix     int
+}
+
+// isDirObject returns true for a zero-byte object whose name ends with
+// a separator, created by some tools to represent a folder. Such an object
+// is only listed recursively, listing non recursively rolls it up with
+// the prefix of the same name.
+func isDirObject(content *ClientContent) bool {
+	return content.Size == 0 && strings.HasSuffix(content.URL.Path, string(content.URL.Separator))
 }
 
 // doList - list all entities inside a folder.
@@ -215,12 +378,36 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 	var (
 		lastPath          string
 		perObjectVersions []*ClientContent
+		versionsCounts    []versionsCountMessage
+		prefixGroups      *prefixGroups
 		cErr              error
 		totalSize         int64
 		totalObjects      int64
 	)
 
-	for content := range clnt.List(ctx, ListOptions{
+	if o.groupByPrefix > 0 {
+		prefixGroups = newPrefixGroups(o.groupByPrefix)
+	}
+
+	// Print the versions of one object, or only count them when the
+	// versions count is requested, or add it to its prefix group.
+	flushObjectVersions := func() {
+		if prefixGroups != nil {
+			if msgs := generateContentMessages(clnt.GetURL(), perObjectVersions, false); len(msgs) > 0 && !msgs[0].IsDeleteMarker {
+				prefixGroups.add(msgs[0].Key, msgs[0].Size)
+			}
+			return
+		}
+		if !o.versionsCount {
+			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary, o.checksum, o.columns)
+			return
+		}
+		if msgs := generateContentMessages(clnt.GetURL(), perObjectVersions, false); len(msgs) > 0 {
+			versionsCounts = append(versionsCounts, newVersionsCountMessage(msgs[0].Key, perObjectVersions))
+		}
+	}
+
+	contentCh := clnt.List(ctx, ListOptions{
 		Recursive:         o.isRecursive,
 		Incomplete:        o.isIncomplete,
 		TimeRef:           o.timeRef,
@@ -228,7 +415,20 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		WithDeleteMarkers: true,
 		ShowDir:           DirNone,
 		ListZip:           o.listZip,
-	}) {
+		StartAfter:        o.startAfter,
+		EndBefore:         o.endBefore,
+	})
+	if o.checksum {
+		contentCh = statChecksums(ctx, contentCh, o.checksumWorkers, func(ctx context.Context, content *ClientContent) (*ClientContent, *probe.Error) {
+			objClnt, err := newClientFromAlias(o.alias, content.URL.String())
+			if err != nil {
+				return nil, err
+			}
+			return objClnt.Stat(ctx, StatOptions{versionID: content.VersionID})
+		})
+	}
+
+	for content := range contentCh {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
 			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
@@ -239,9 +439,13 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 			continue
 		}
 
+		if o.noDirObject && isDirObject(content) {
+			continue
+		}
+
 		if lastPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary)
+			flushObjectVersions()
 			lastPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
@@ -251,7 +455,18 @@ func doList(ctx context.Context, clnt Client, o doListOptions) error {
 		totalObjects++
 	}
 
-	printObjectVersions(clnt.GetURL(), perObjectVersions, o.withOlderVersions, o.isSummary)
+	flushObjectVersions()
+
+	sortVersionsCount(versionsCounts)
+	for _, msg := range versionsCounts {
+		printMsg(msg)
+	}
+
+	if prefixGroups != nil {
+		for _, msg := range prefixGroups.messages() {
+			printMsg(msg)
+		}
+	}
 
 	if o.isSummary {
 		printMsg(summaryMessage{
@@ -16,3 +16,136 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestVersionsCount(t *testing.T) {
+	msg := newVersionsCountMessage("a", []*ClientContent{
+		{Size: 10},
+		{Size: 0, IsDeleteMarker: true},
+		{Size: 5},
+	})
+	expected := versionsCountMessage{Key: "a", VersionCount: 2, DeleteMarkerCount: 1, TotalSize: 15}
+	if msg != expected {
+		t.Fatalf("expected %+v, got %+v", expected, msg)
+	}
+
+	msgs := []versionsCountMessage{
+		{Key: "c", VersionCount: 1},
+		{Key: "b", VersionCount: 2, DeleteMarkerCount: 3},
+		{Key: "a", VersionCount: 1},
+		{Key: "d", VersionCount: 4},
+	}
+	sortVersionsCount(msgs)
+	var keys []string
+	for _, msg := range msgs {
+		keys = append(keys, msg.Key)
+	}
+	if expectedKeys := []string{"b", "d", "a", "c"}; !reflect.DeepEqual(keys, expectedKeys) {
+		t.Fatalf("expected %v, got %v", expectedKeys, keys)
+	}
+}
+
+func TestIsDirObject(t *testing.T) {
+	testCases := []struct {
+		path      string
+		size      int64
+		dirObject bool
+	}{
+		{"/bucket/folder/", 0, true},
+		{"/bucket/folder/sub/", 0, true},
+		{"/bucket/folder/object", 0, false},
+		{"/bucket/folder/object", 10, false},
+		{"/bucket/folder/", 10, false},
+	}
+	for i, testCase := range testCases {
+		content := &ClientContent{URL: *newClientURL("https://play.min.io" + testCase.path), Size: testCase.size}
+		if dirObject := isDirObject(content); dirObject != testCase.dirObject {
+			t.Errorf("Test %d: expected %v for %s, got %v", i+1, testCase.dirObject, testCase.path, dirObject)
+		}
+	}
+}
+
+func TestStatChecksums(t *testing.T) {
+	in := make(chan *ClientContent)
+	go func() {
+		defer close(in)
+		for _, path := range []string{"/bucket/a", "/bucket/b/", "/bucket/c", "/bucket/d", "/bucket/e"} {
+			content := &ClientContent{URL: *newClientURL("https://play.min.io" + path)}
+			switch {
+			case strings.HasSuffix(path, "/"):
+				content.Type = os.ModeDir
+			case path == "/bucket/d":
+				content.IsDeleteMarker = true
+			}
+			in <- content
+		}
+	}()
+	stat := func(_ context.Context, content *ClientContent) (*ClientContent, *probe.Error) {
+		time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+		switch content.URL.Path {
+		case "/bucket/a":
+			return &ClientContent{Metadata: map[string]string{"X-Amz-Checksum-Sha256": "sha", "Content-Type": "text/plain"}}, nil
+		case "/bucket/e":
+			return nil, errDummy()
+		}
+		return &ClientContent{}, nil
+	}
+
+	var paths, algos []string
+	var errs int
+	for content := range statChecksums(context.Background(), in, 3, stat) {
+		paths = append(paths, content.URL.Path)
+		if content.Err != nil {
+			errs++
+			continue
+		}
+		algo, _ := contentChecksum(content)
+		algos = append(algos, algo)
+		if _, ok := content.Metadata["Content-Type"]; ok {
+			t.Errorf("Expected only the checksums to be kept for %s", content.URL.Path)
+		}
+	}
+	if expected := []string{"/bucket/a", "/bucket/b/", "/bucket/c", "/bucket/d", "/bucket/e"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Expected listing order %v, got %v", expected, paths)
+	}
+	if expected := []string{"SHA256", "", "", ""}; !reflect.DeepEqual(algos, expected) {
+		t.Errorf("Expected checksums %v, got %v", expected, algos)
+	}
+	if errs != 1 {
+		t.Errorf("Expected 1 error, got %d", errs)
+	}
+}
+
+func TestContentMessageChecksum(t *testing.T) {
+	msg := contentMessage{Key: "a"}
+	if strings.Contains(msg.JSON(), "checksum") {
+		t.Errorf("Expected no checksum fields without --checksum, got %s", msg.JSON())
+	}
+	algo, value := contentChecksum(&ClientContent{})
+	msg.ChecksumAlgo, msg.ChecksumValue = &algo, &value
+	if !strings.Contains(msg.JSON(), `"checksumAlgo":"","checksumValue":""`) {
+		t.Errorf("Expected empty checksum fields, got %s", msg.JSON())
+	}
+	algo, value = contentChecksum(&ClientContent{Metadata: map[string]string{
+		"X-Amz-Checksum-Crc32":  "crc",
+		"X-Amz-Checksum-Crc32c": "crcc",
+	}})
+	msg.ChecksumAlgo, msg.ChecksumValue = &algo, &value
+	if !strings.Contains(msg.JSON(), `"checksumAlgo":"CRC32C","checksumValue":"crcc"`) {
+		t.Errorf("Expected the CRC32C checksum, got %s", msg.JSON())
+	}
+	if !strings.Contains(msg.String(), "CRC32C:crcc") {
+		t.Errorf("Expected the checksum column, got %s", msg.String())
+	}
+}
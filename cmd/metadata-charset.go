@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	metadataCharsetUTF8   = "utf-8"
+	metadataCharsetLatin1 = "iso-8859-1"
+)
+
+// globalMetadataCharset is the charset of the non ASCII user metadata
+// values sent to and received from S3, set by --metadata-charset.
+var globalMetadataCharset = metadataCharsetUTF8
+
+// parseMetadataCharset validates a --metadata-charset value.
+func parseMetadataCharset(charset string) (string, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "utf8":
+		return metadataCharsetUTF8, nil
+	case "iso-8859-1", "latin-1", "latin1":
+		return metadataCharsetLatin1, nil
+	}
+	return "", fmt.Errorf("unknown metadata charset `%s`, expected utf-8 or iso-8859-1", charset)
+}
+
+// setMetadataCharset sets the metadata charset from --metadata-charset.
+func setMetadataCharset(cliCtx *cli.Context) {
+	charset, e := parseMetadataCharset(cliCtx.String("metadata-charset"))
+	fatalIf(probe.NewError(e).Trace(cliCtx.String("metadata-charset")), "Invalid `--metadata-charset`.")
+	globalMetadataCharset = charset
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeMetadataValue returns a user metadata value as sent in a header,
+// HTTP headers only carry ASCII reliably. Non ASCII values are RFC 2047
+// encoded in UTF-8, the way Amazon S3 returns them, or sent as raw
+// ISO-8859-1 bytes to legacy backends when they fit.
+func encodeMetadataValue(v string) string {
+	if isASCII(v) {
+		return v
+	}
+	if globalMetadataCharset == metadataCharsetLatin1 {
+		if latin1, e := charmap.ISO8859_1.NewEncoder().String(v); e == nil {
+			return latin1
+		}
+	}
+	return mime.BEncoding.Encode("UTF-8", v)
+}
+
+// decodeMetadataValue returns a user metadata value received in a header
+// as UTF-8, decoding RFC 2047 encoded words and, for legacy backends,
+// raw ISO-8859-1 bytes.
+func decodeMetadataValue(v string) string {
+	if globalMetadataCharset == metadataCharsetLatin1 && !utf8.ValidString(v) {
+		if decoded, e := charmap.ISO8859_1.NewDecoder().String(v); e == nil {
+			return decoded
+		}
+	}
+	if strings.Contains(v, "=?") {
+		if decoded, e := new(mime.WordDecoder).DecodeHeader(v); e == nil {
+			return decoded
+		}
+	}
+	return v
+}
+
+// encodeMetadataValues encodes the values of user metadata in place.
+func encodeMetadataValues(metadata map[string]string) {
+	for k, v := range metadata {
+		metadata[k] = encodeMetadataValue(v)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestMetadataValueRoundTrip(t *testing.T) {
+	defer func() { globalMetadataCharset = metadataCharsetUTF8 }()
+
+	for _, charset := range []string{metadataCharsetUTF8, metadataCharsetLatin1} {
+		globalMetadataCharset = charset
+		for _, v := range []string{"plain ascii", "日本語", "Crème brûlée", "Zürich", "=?not encoded", ""} {
+			encoded := encodeMetadataValue(v)
+			if charset == metadataCharsetUTF8 && !isASCII(encoded) {
+				t.Errorf("%s: expected an ASCII header value for %q, got %q", charset, v, encoded)
+			}
+			if decoded := decodeMetadataValue(encoded); decoded != v {
+				t.Errorf("%s: expected %q after a round trip, got %q", charset, v, decoded)
+			}
+		}
+	}
+
+	globalMetadataCharset = metadataCharsetLatin1
+	if encoded := encodeMetadataValue("Zürich"); encoded != "Z\xfcrich" {
+		t.Errorf("expected raw ISO-8859-1 bytes, got %q", encoded)
+	}
+	// Runes outside of ISO-8859-1 fall back to UTF-8.
+	if encoded := encodeMetadataValue("日本語"); encoded != "=?UTF-8?b?5pel5pys6Kqe?=" {
+		t.Errorf("expected an RFC 2047 encoded value, got %q", encoded)
+	}
+}
+
+func TestParseMetadataCharset(t *testing.T) {
+	for in, want := range map[string]string{"": metadataCharsetUTF8, "UTF8": metadataCharsetUTF8, "latin1": metadataCharsetLatin1, "ISO-8859-1": metadataCharsetLatin1} {
+		if got, e := parseMetadataCharset(in); e != nil || got != want {
+			t.Errorf("%q: expected %s, got %s (%v)", in, want, got, e)
+		}
+	}
+	if _, e := parseMetadataCharset("ebcdic"); e == nil {
+		t.Error("expected an unknown charset to be rejected")
+	}
+}
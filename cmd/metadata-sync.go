@@ -0,0 +1,240 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// metadataSyncHeaders are the object headers brought in line with the
+// source by --metadata-sync, along with the user metadata.
+var metadataSyncHeaders = map[string]bool{
+	"Cache-Control":       true,
+	"Content-Disposition": true,
+	"Content-Encoding":    true,
+	"Content-Language":    true,
+	"Content-Type":        true,
+	"Expires":             true,
+}
+
+// metadataSyncAction is what --metadata-sync does with an object.
+type metadataSyncAction int
+
+const (
+	// The content differs or cannot be compared, copy the object.
+	metadataSyncCopy metadataSyncAction = iota
+	// Same content but different metadata, update the target metadata.
+	metadataSyncUpdate
+	// Same content and metadata, nothing to do.
+	metadataSyncNone
+)
+
+// syncedMetadata returns the metadata of an object compared and
+// replaced by --metadata-sync.
+func syncedMetadata(content *ClientContent) map[string]string {
+	metadata := make(map[string]string)
+	for k, v := range content.Metadata {
+		k = http.CanonicalHeaderKey(k)
+		if k == activeActiveSourceModTimeKey || v == "" {
+			continue
+		}
+		if metadataSyncHeaders[k] || strings.HasPrefix(k, "X-Amz-Meta-") {
+			metadata[k] = v
+		}
+	}
+	return metadata
+}
+
+// sameContent reports whether the objects have the same content, with
+// the same size and either the same ETag or the same stored checksum.
+func sameContent(source, target *ClientContent) bool {
+	if source.Size != target.Size {
+		return false
+	}
+	if etag := strings.Trim(source.ETag, `"`); etag != "" && etag == strings.Trim(target.ETag, `"`) {
+		return true
+	}
+	for _, h := range checksumHeaders {
+		if value := source.Metadata[h.header]; value != "" && value == target.Metadata[h.header] {
+			return true
+		}
+	}
+	return false
+}
+
+// compareMetadataSync tells what --metadata-sync does with the objects.
+func compareMetadataSync(source, target *ClientContent) metadataSyncAction {
+	if !sameContent(source, target) {
+		return metadataSyncCopy
+	}
+	sourceMetadata, targetMetadata := syncedMetadata(source), syncedMetadata(target)
+	if len(sourceMetadata) != len(targetMetadata) {
+		return metadataSyncUpdate
+	}
+	for k, v := range sourceMetadata {
+		if targetMetadata[k] != v {
+			return metadataSyncUpdate
+		}
+	}
+	return metadataSyncNone
+}
+
+// checkMetadataSync fetches the source and the target of a copy and
+// compares them. Objects from or to a local filesystem and missing
+// targets are always copied.
+func checkMetadataSync(ctx context.Context, cpURLs URLs, encKeyDB map[string][]prefixSSEPair) (metadataSyncAction, *ClientContent, *ClientContent, *probe.Error) {
+	stat := func(alias string, u ClientURL, versionID string) (*ClientContent, *probe.Error) {
+		clnt, err := newClientFromAlias(alias, u.String())
+		if err != nil {
+			return nil, err.Trace(u.String())
+		}
+		if _, ok := clnt.(*S3Client); !ok {
+			return nil, nil
+		}
+		objectPath := filepath.ToSlash(filepath.Join(alias, u.Path))
+		content, err := clnt.Stat(ctx, StatOptions{sse: getSSE(objectPath, encKeyDB[alias]), versionID: versionID})
+		if err != nil {
+			switch err.ToGoError().(type) {
+			case PathNotFound, ObjectMissing:
+				return nil, nil
+			}
+			return nil, err.Trace(u.String())
+		}
+		return content, nil
+	}
+
+	source, err := stat(cpURLs.SourceAlias, cpURLs.SourceContent.URL, cpURLs.SourceContent.VersionID)
+	if err != nil || source == nil {
+		return metadataSyncCopy, nil, nil, err
+	}
+	target, err := stat(cpURLs.TargetAlias, cpURLs.TargetContent.URL, "")
+	if err != nil || target == nil {
+		return metadataSyncCopy, nil, nil, err
+	}
+	return compareMetadataSync(source, target), source, target, nil
+}
+
+// updateTargetMetadata replaces the metadata of the target with the one
+// of the source, with a server side copy of the target on itself.
+func updateTargetMetadata(ctx context.Context, cpURLs URLs, source, target *ClientContent, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	targetAlias := cpURLs.TargetAlias
+	targetURL := cpURLs.TargetContent.URL
+	clnt, err := newClientFromAlias(targetAlias, targetURL.String())
+	if err != nil {
+		return err.Trace(targetURL.String())
+	}
+	tgtSSE := getSSE(filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path)), encKeyDB[targetAlias])
+	// A copy replacing the metadata resets the storage class unless given.
+	storageClass := target.StorageClass
+	if storageClass == "STANDARD" {
+		storageClass = ""
+	}
+	return clnt.Copy(ctx, targetURL.Path, CopyOptions{
+		size:             target.Size,
+		srcSSE:           tgtSSE,
+		tgtSSE:           tgtSSE,
+		metadata:         syncedMetadata(source),
+		storageClass:     storageClass,
+		disableMultipart: true,
+	}, nil)
+}
+
+// metadataSyncStats counts the objects handled with --metadata-sync.
+type metadataSyncStats struct {
+	updated, copied, unchanged int64
+}
+
+// add records what was done with an object.
+func (s *metadataSyncStats) add(action metadataSyncAction) {
+	switch action {
+	case metadataSyncCopy:
+		atomic.AddInt64(&s.copied, 1)
+	case metadataSyncUpdate:
+		atomic.AddInt64(&s.updated, 1)
+	case metadataSyncNone:
+		atomic.AddInt64(&s.unchanged, 1)
+	}
+}
+
+// summary returns the counts of objects, nil without --metadata-sync.
+func (s *metadataSyncStats) summary() *metadataSyncMessage {
+	if s == nil {
+		return nil
+	}
+	return &metadataSyncMessage{
+		Status:    "success",
+		Updated:   atomic.LoadInt64(&s.updated),
+		Copied:    atomic.LoadInt64(&s.copied),
+		Unchanged: atomic.LoadInt64(&s.unchanged),
+	}
+}
+
+// metadataUpdateMessage container for an object whose metadata only was updated.
+type metadataUpdateMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func newMetadataUpdateMessage(cpURLs URLs) metadataUpdateMessage {
+	return metadataUpdateMessage{
+		Status: "success",
+		Source: filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path)),
+		Target: filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path)),
+	}
+}
+
+func (m metadataUpdateMessage) String() string {
+	return console.Colorize("MetadataUpdate", fmt.Sprintf("`%s` -> `%s` (metadata only)", m.Source, m.Target))
+}
+
+func (m metadataUpdateMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// metadataSyncMessage container for the counts of objects handled with --metadata-sync.
+type metadataSyncMessage struct {
+	Status    string `json:"status,omitempty"`
+	Updated   int64  `json:"metadataUpdated"`
+	Copied    int64  `json:"copied"`
+	Unchanged int64  `json:"unchanged"`
+}
+
+func (m metadataSyncMessage) String() string {
+	return fmt.Sprintf("Metadata sync: %d objects with metadata updated, %d copied, %d unchanged.",
+		m.Updated, m.Copied, m.Unchanged)
+}
+
+func (m metadataSyncMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareMetadataSync(t *testing.T) {
+	object := func(size int64, etag string, metadata map[string]string) *ClientContent {
+		return &ClientContent{Size: size, ETag: etag, Metadata: metadata}
+	}
+	textPlain := map[string]string{"Content-Type": "text/plain", "Last-Modified": "yesterday"}
+	textHTML := map[string]string{"Content-Type": "text/html", "Last-Modified": "today"}
+
+	testCases := []struct {
+		source, target *ClientContent
+		action         metadataSyncAction
+	}{
+		// Only headers which are not synced differ.
+		{object(10, `"abc"`, textPlain), object(10, "abc", textHTML), metadataSyncUpdate},
+		{object(10, "abc", textHTML), object(10, "abc", map[string]string{"Content-Type": "text/html"}), metadataSyncNone},
+		// User metadata added at the source.
+		{
+			object(10, "abc", map[string]string{"Content-Type": "text/html", "X-Amz-Meta-Owner": "ops"}),
+			object(10, "abc", textHTML), metadataSyncUpdate,
+		},
+		// Different content.
+		{object(10, "abc", textPlain), object(11, "abc", textHTML), metadataSyncCopy},
+		{object(10, "abc", textPlain), object(10, "abd", textHTML), metadataSyncCopy},
+		// ETags of multipart uploads differ, the checksum is the same.
+		{
+			object(10, "abc-2", map[string]string{"X-Amz-Checksum-Crc32c": "xyz"}),
+			object(10, "abd", map[string]string{"X-Amz-Checksum-Crc32c": "xyz", "Cache-Control": "no-cache"}),
+			metadataSyncUpdate,
+		},
+	}
+	for i, testCase := range testCases {
+		if action := compareMetadataSync(testCase.source, testCase.target); action != testCase.action {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.action, action)
+		}
+	}
+}
+
+func TestSyncedMetadata(t *testing.T) {
+	content := &ClientContent{Metadata: map[string]string{
+		"content-type":               "text/html",
+		"Cache-Control":              "max-age=60",
+		"X-Amz-Meta-Owner":           "ops",
+		activeActiveSourceModTimeKey: "2023-01-01T00:00:00Z",
+		"Etag":                       "abc",
+		"Content-Language":           "",
+	}}
+	expected := map[string]string{
+		"Content-Type":     "text/html",
+		"Cache-Control":    "max-age=60",
+		"X-Amz-Meta-Owner": "ops",
+	}
+	if got := syncedMetadata(content); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// mirrorDeleteAfterMessage container for the removals skipped with --delete-after.
+type mirrorDeleteAfterMessage struct {
+	Status   string `json:"status"`
+	Failures int64  `json:"failures"`
+	Objects  int    `json:"skippedObjects"`
+	Buckets  int    `json:"skippedBuckets"`
+}
+
+// String colorized skipped removals message.
+func (m mirrorDeleteAfterMessage) String() string {
+	return console.Colorize("MirrorDeleteAfter", fmt.Sprintf("%d copy failure(s), skipped the removal of %d object(s) and %d bucket(s) from the target, use `--force` to remove them anyway.",
+		m.Failures, m.Objects, m.Buckets))
+}
+
+// JSON jsonified skipped removals message.
+func (m mirrorDeleteAfterMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// countCopyFailure counts the failed copies, the removals deferred
+// with --delete-after are skipped if any copy failed.
+func (mj *mirrorJob) countCopyFailure(sURLs URLs) URLs {
+	if sURLs.Error != nil && !isErrIgnored(sURLs.Error) {
+		atomic.AddInt64(&mj.copyFailures, 1)
+	}
+	return sURLs
+}
+
+// removePending removes the extraneous objects and buckets of the
+// target once all copies are done, unless a copy failed and the
+// removals are not forced.
+func (mj *mirrorJob) removePending(ctx context.Context) {
+	if len(mj.pendingRemovals) == 0 && len(mj.pendingBuckets) == 0 {
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if failures := atomic.LoadInt64(&mj.copyFailures); failures > 0 && !mj.opts.forceDeleteAfter {
+		mj.status.PrintMsg(mirrorDeleteAfterMessage{
+			Failures: failures,
+			Objects:  len(mj.pendingRemovals),
+			Buckets:  len(mj.pendingBuckets),
+		})
+		return
+	}
+
+	for _, sURLs := range mj.pendingRemovals {
+		mj.statusCh <- mj.doRemove(ctx, sURLs)
+	}
+	for _, bucket := range mj.pendingBuckets {
+		var err *probe.Error
+		if !mj.opts.isFake {
+			err = deleteBucket(ctx, bucket, false)
+		}
+		mj.statusCh <- URLs{TargetContent: &ClientContent{URL: *newClientURL(bucket)}}.WithError(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestMirrorRemovePending(t *testing.T) {
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	extraneous := filepath.Join(dir, "extraneous")
+	if e := os.WriteFile(extraneous, []byte("data"), 0o644); e != nil {
+		t.Fatal(e)
+	}
+
+	newJob := func(failures int64, force bool) *mirrorJob {
+		mj := &mirrorJob{
+			status:   NewQuietStatus(nil),
+			statusCh: make(chan URLs, 1),
+			opts:     mirrorOptions{isRemove: true, deleteAfter: true, forceDeleteAfter: force},
+		}
+		mj.pendingRemovals = []URLs{{TargetContent: &ClientContent{URL: *newClientURL(extraneous)}}}
+		mj.copyFailures = failures
+		return mj
+	}
+
+	// A failed copy skips the removals.
+	mj := newJob(1, false)
+	mj.removePending(context.Background())
+	if len(mj.statusCh) != 0 {
+		t.Fatalf("expected no removal after a failed copy")
+	}
+	if _, e := os.Stat(extraneous); e != nil {
+		t.Fatalf("expected %s to be kept, %v", extraneous, e)
+	}
+
+	// Canceled mirror skips the removals.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mj = newJob(0, false)
+	mj.removePending(ctx)
+	if len(mj.statusCh) != 0 {
+		t.Fatalf("expected no removal after a cancellation")
+	}
+
+	// --force removes even after a failed copy.
+	mj = newJob(1, true)
+	mj.removePending(context.Background())
+	if sURLs := <-mj.statusCh; sURLs.Error != nil {
+		t.Fatalf("unexpected removal error %v", sURLs.Error)
+	}
+	if _, e := os.Stat(extraneous); !os.IsNotExist(e) {
+		t.Fatalf("expected %s to be removed, %v", extraneous, e)
+	}
+}
+
+func TestMirrorCountCopyFailure(t *testing.T) {
+	mj := &mirrorJob{}
+	mj.countCopyFailure(URLs{})
+	mj.countCopyFailure(URLs{}.WithError(errInvalidArgument()))
+	if mj.copyFailures != 1 {
+		t.Fatalf("expected 1 copy failure, got %d", mj.copyFailures)
+	}
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio-go/v7/pkg/notification"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/pkg/console"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -71,6 +72,23 @@ var (
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
 		},
+		cli.BoolFlag{
+			Name:  "delete-after",
+			Usage: "with --remove, remove extraneous object(s) once all copies succeeded, skipped after a failure unless --force",
+		},
+		cli.DurationFlag{
+			Name:  "retry-budget",
+			Usage: "cap the total time spent retrying failed requests, later failures are not retried, e.g. 10m",
+		},
+		cli.StringFlag{
+			Name:  "metadata-charset",
+			Value: metadataCharsetUTF8,
+			Usage: "charset of the non ASCII metadata values: utf-8, RFC 2047 encoded, or iso-8859-1 for legacy backends",
+		},
+		cli.BoolFlag{
+			Name:  "exclude-bucket-markers",
+			Usage: "skip directory placeholder objects (keys ending with '/') on source and target, as 'cp --recursive' always does",
+		},
 		cli.StringFlag{
 			Name:  "region",
 			Usage: "specify region when creating new bucket(s) on target",
@@ -80,6 +98,18 @@ var (
 			Name:  "preserve, a",
 			Usage: "preserve file(s)/object(s) attributes and bucket(s) policy/locking configuration(s) on target bucket(s)",
 		},
+		cli.BoolFlag{
+			Name:  "preserve-tags",
+			Usage: "copy object tags to the target object(s)",
+		},
+		cli.BoolFlag{
+			Name:  "preserve-retention",
+			Usage: "copy object retention mode and retain until date to the target object(s), requires object lock on the target bucket",
+		},
+		cli.BoolFlag{
+			Name:  "preserve-storage-class",
+			Usage: "copy the storage class of the source object(s), falling back to the default class when the target does not support it",
+		},
 		cli.BoolFlag{
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
@@ -101,6 +131,14 @@ var (
 			Name:  "exclude",
 			Usage: "exclude object(s) that match specified object name pattern",
 		},
+		cli.StringFlag{
+			Name:  "exclude-from",
+			Usage: "exclude object(s) that match any pattern in the specified file",
+		},
+		cli.StringFlag{
+			Name:  "include-from",
+			Usage: "include only object(s) that match any pattern in the specified file",
+		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than value in duration string (e.g. 7d10h31s)",
@@ -109,6 +147,14 @@ var (
 			Name:  "newer-than",
 			Usage: "filter object(s) newer than value in duration string (e.g. 7d10h31s)",
 		},
+		cli.StringFlag{
+			Name:  "min-size",
+			Usage: "filter object(s) of at least this size, e.g. 64KiB",
+		},
+		cli.StringFlag{
+			Name:  "max-size",
+			Usage: "filter object(s) of at most this size, e.g. 1GiB",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "specify storage class for new object(s) on target",
@@ -121,6 +167,10 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for all objects",
 		},
+		cli.BoolFlag{
+			Name:  "metadata-sync",
+			Usage: "only update the metadata of the targets with the same content and different metadata than their source",
+		},
 		cli.StringFlag{
 			Name:  "monitoring-address",
 			Usage: "if specified, a new prometheus endpoint will be created to report mirroring activity. (eg: localhost:8081)",
@@ -135,7 +185,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(mirrorFlags, ioFlags...), listFlags...), tagFilterFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -202,6 +252,38 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Mirror a local folder to MinIO cloud storage, skipping all object name patterns listed in 'excludes.txt'.
+      {{.Prompt}} {{.HelpName}} --exclude-from excludes.txt backup/ play/archive
+
+  18. Mirror a bucket to a bucket with object lock enabled, preserving object tags and retention settings.
+      {{.Prompt}} {{.HelpName}} --preserve-tags --preserve-retention play/records s3/locked-records
+
+  19. Mirror a MinIO bucket to an AWS S3 bucket, ignoring the directory placeholder objects ('foo/') on both sides.
+      {{.Prompt}} {{.HelpName}} --remove --exclude-bucket-markers play/photos s3/photos
+
+  20. Mirror only the objects tagged 'tier=cold' to an archive bucket, fetching tags with 32 workers.
+      {{.Prompt}} {{.HelpName}} --tag-filter "tier=cold" --tag-filter-workers 32 play/photos play/archive
+
+  21. Mirror a bucket, only updating the metadata of the objects with the same content but a different
+      metadata, e.g. a fixed 'Content-Type', without transferring them again.
+      {{.Prompt}} {{.HelpName}} --metadata-sync play/website s3/website
+
+  22. Mirror a bucket and remove the extraneous objects of the target only once all the objects were copied,
+      nothing is removed if a copy fails.
+      {{.Prompt}} {{.HelpName}} --remove --delete-after play/photos s3/backup-photos
+
+  23. Mirror the objects of a bucket up to 1MiB, excluding the temporary files.
+      {{.Prompt}} {{.HelpName}} --max-size 1MiB --exclude "*.tmp" play/photos s3/backup-photos
+
+  24. Mirror a bucket from a nightly job, spending at most 10 minutes retrying failed requests in total.
+      {{.Prompt}} {{.HelpName}} --retry-budget 10m play/photos s3/backup-photos
+
+  25. Migrate a bucket to AWS S3, keeping the storage class of every object.
+      {{.Prompt}} {{.HelpName}} --preserve-storage-class play/archive s3/archive
+
+  26. Mirror a bucket from a legacy backend storing its metadata values in ISO-8859-1.
+      {{.Prompt}} {{.HelpName}} --metadata-charset iso-8859-1 legacy/archive play/archive
 `,
 }
 
@@ -256,6 +338,14 @@ type mirrorJob struct {
 	targetURL string
 
 	opts mirrorOptions
+
+	// Objects handled with --metadata-sync.
+	metadataSync *metadataSyncStats
+
+	// Removals deferred with --delete-after until all copies are done.
+	pendingRemovals []URLs
+	pendingBuckets  []string
+	copyFailures    int64
 }
 
 // mirrorMessage container for file mirror messages
@@ -434,6 +524,29 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 
 	mj.status.SetCaption(sourceURL.String() + ":")
 
+	if sURLs.syncMetadataOnly {
+		action, source, target, err := checkMetadataSync(ctx, sURLs, mj.opts.encKeyDB)
+		if err == nil && action == metadataSyncUpdate {
+			err = updateTargetMetadata(ctx, sURLs, source, target, mj.opts.encKeyDB)
+		}
+		if err != nil {
+			return sURLs.WithError(err)
+		}
+		if action != metadataSyncCopy {
+			if action == metadataSyncUpdate {
+				mj.status.PrintMsg(newMetadataUpdateMessage(sURLs))
+			}
+			mj.metadataSync.add(action)
+			mj.status.Add(length)
+			mj.status.Update()
+			return sURLs.WithError(nil)
+		}
+		// The content differs too, copy the object as any other.
+		if !mj.opts.isOverwrite {
+			return URLs{Error: errOverWriteNotAllowed(targetURL.String()), ErrorCond: differInMetadata}
+		}
+	}
+
 	// Initialize target metadata.
 	sURLs.TargetContent.Metadata = make(map[string]string)
 
@@ -441,6 +554,17 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 		sURLs.TargetContent.StorageClass = mj.opts.storageClass
 	}
 
+	if mj.opts.preserveStorageClass {
+		storageClass, supported := preservedStorageClass(sURLs.SourceContent.StorageClass, mj.opts.targetStorageClasses)
+		if !supported {
+			mj.status.PrintMsg(storageClassFallbackMessage{
+				Source:       filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path)),
+				StorageClass: sURLs.SourceContent.StorageClass,
+			})
+		}
+		sURLs.TargetContent.StorageClass = storageClass
+	}
+
 	if mj.opts.activeActive {
 		srcModTime := getSourceModTimeKey(sURLs.SourceContent.Metadata)
 		// If the source object already has source modtime attribute set, then
@@ -469,6 +593,12 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 
 	now := time.Now()
 	ret := uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.opts.encKeyDB, mj.opts.isMetadata, false)
+	if ret.Error == nil {
+		ret.Error = mj.preserveTagsAndRetention(ctx, sURLs)
+	}
+	if ret.Error == nil && mj.metadataSync != nil {
+		mj.metadataSync.add(metadataSyncCopy)
+	}
 	if ret.Error == nil {
 		durationMs := time.Since(now).Milliseconds()
 		mirrorReplicationDurations.With(prometheus.Labels{"object_size": convertSizeToTag(sURLs.SourceContent.Size)}).Observe(float64(durationMs))
@@ -476,6 +606,58 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 	return ret
 }
 
+// preserveTagsAndRetention copies the tags and the retention settings of the
+// source object to the target object, when requested.
+func (mj *mirrorJob) preserveTagsAndRetention(ctx context.Context, sURLs URLs) *probe.Error {
+	if !mj.opts.preserveTags && !mj.opts.preserveRetention {
+		return nil
+	}
+
+	sourcePath := filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path))
+	targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+	srcClnt, err := newClient(sourcePath)
+	if err != nil {
+		return err.Trace(sourcePath)
+	}
+	tgtClnt, err := newClient(targetPath)
+	if err != nil {
+		return err.Trace(targetPath)
+	}
+
+	versionID := sURLs.SourceContent.VersionID
+	if mj.opts.preserveTags {
+		tagsMap, err := srcClnt.GetTags(ctx, versionID)
+		if err != nil {
+			return err.Trace(sourcePath)
+		}
+		if len(tagsMap) > 0 {
+			t, e := tags.NewTags(tagsMap, true)
+			if e != nil {
+				return probe.NewError(e).Trace(sourcePath)
+			}
+			if err = tgtClnt.SetTags(ctx, "", t.String()); err != nil {
+				return err.Trace(targetPath)
+			}
+		}
+	}
+
+	if mj.opts.preserveRetention {
+		mode, until, err := srcClnt.GetObjectRetention(ctx, versionID)
+		if err != nil {
+			if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchObjectLockConfiguration" {
+				return nil
+			}
+			return err.Trace(sourcePath)
+		}
+		if mode.IsValid() && until.After(time.Now()) {
+			if err = tgtClnt.PutObjectRetention(ctx, "", mode, until, false); err != nil {
+				return err.Trace(targetPath)
+			}
+		}
+	}
+	return nil
+}
+
 // Update progress status
 func (mj *mirrorJob) monitorMirrorStatus(cancel context.CancelFunc) (errDuringMirror bool) {
 	// now we want to start the progress bar
@@ -576,8 +758,12 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 		// build target path, it is the relative of the eventPath with the sourceUrl
 		// joined to the targetURL.
 		sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
-		// Skip the object, if it matches the Exclude options provided
-		if matchExcludeOptions(mj.opts.excludeOptions, sourceSuffix) {
+		// Skip the object, if it matches the Exclude options or does
+		// not match the Include options provided
+		if skipByPatterns(mj.opts.includeOptions, mj.opts.excludeOptions, sourceSuffix) {
+			continue
+		}
+		if mj.opts.excludeBucketMarkers && strings.HasSuffix(sourceSuffix, string(sourceURL.Separator)) {
 			continue
 		}
 
@@ -708,7 +894,7 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 				return
 			}
 			if sURLs.Error != nil {
-				mj.statusCh <- sURLs
+				mj.statusCh <- mj.countCopyFailure(sURLs)
 				continue
 			}
 
@@ -719,6 +905,9 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 				if isNewer(sURLs.SourceContent.Time, mj.opts.newerThan) {
 					continue
 				}
+				if !mj.opts.sizeRange.contains(sURLs.SourceContent.Size) {
+					continue
+				}
 			}
 
 			if sURLs.SourceContent != nil {
@@ -735,8 +924,10 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 
 			if sURLs.SourceContent != nil {
 				mj.parallel.queueTask(func() URLs {
-					return mj.doMirror(ctx, sURLs)
+					return mj.countCopyFailure(mj.doMirror(ctx, sURLs))
 				}, sURLs.SourceContent.Size)
+			} else if sURLs.TargetContent != nil && mj.opts.deleteAfter {
+				mj.pendingRemovals = append(mj.pendingRemovals, sURLs)
 			} else if sURLs.TargetContent != nil && mj.opts.isRemove {
 				mj.parallel.queueTask(func() URLs {
 					return mj.doRemove(ctx, sURLs)
@@ -753,6 +944,9 @@ func (mj *mirrorJob) startMirror(ctx context.Context) {
 // when using a struct for copying, we could save a lot of passing of variables
 func (mj *mirrorJob) mirror(ctx context.Context) bool {
 	var wg sync.WaitGroup
+	// Removals deferred with --delete-after and forced after a failure
+	// are not canceled with the copies.
+	removeCtx := ctx
 	ctx, cancel := context.WithCancel(ctx)
 
 	// Starts watcher loop for watching for new events.
@@ -776,10 +970,20 @@ func (mj *mirrorJob) mirror(ctx context.Context) bool {
 	go func() {
 		wg.Wait()
 		mj.parallel.stopAndWait()
+		if mj.opts.deleteAfter {
+			mj.removePending(removeCtx)
+		}
 		close(mj.statusCh)
 	}()
 
-	return mj.monitorMirrorStatus(cancel)
+	errDuringMirror := mj.monitorMirrorStatus(cancel)
+	if summary := mj.metadataSync.summary(); summary != nil {
+		printMsg(summary)
+	}
+	if globalRetryBudget != nil {
+		printMsg(globalRetryBudget.message())
+	}
+	return errDuringMirror
 }
 
 func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
@@ -795,6 +999,10 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 
 	mj.parallel = newParallelManager(mj.statusCh)
 
+	if opts.metadataSync {
+		mj.metadataSync = &metadataSyncStats{}
+	}
+
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
 	if globalQuiet {
@@ -875,8 +1083,9 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 	dstClt, err := newClient(dstURL)
 	fatalIf(err, "Unable to initialize `"+dstURL+"`.")
 
-	// This is kept for backward compatibility, `--force` means --overwrite.
-	isOverwrite := cli.Bool("force")
+	// This is kept for backward compatibility, `--force` means --overwrite,
+	// unless it forces the removals of --delete-after.
+	isOverwrite := cli.Bool("force") && !cli.Bool("delete-after")
 	if !isOverwrite {
 		isOverwrite = cli.Bool("overwrite")
 	}
@@ -889,22 +1098,39 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 	isOverwrite = isOverwrite || isMetadata
 	isFake := cli.Bool("fake") || cli.Bool("dry-run")
 
+	includeOptions, excludeOptions := mustGetPatternsFromContext(cli)
+
 	mopts := mirrorOptions{
-		isFake:           isFake,
-		isRemove:         isRemove,
-		isOverwrite:      isOverwrite,
-		isWatch:          isWatch,
-		isMetadata:       isMetadata,
-		md5:              cli.Bool("md5"),
-		disableMultipart: cli.Bool("disable-multipart"),
-		excludeOptions:   cli.StringSlice("exclude"),
-		olderThan:        cli.String("older-than"),
-		newerThan:        cli.String("newer-than"),
-		storageClass:     cli.String("storage-class"),
-		userMetadata:     userMetadata,
-		encKeyDB:         encKeyDB,
-		activeActive:     isWatch,
+		isFake:               isFake,
+		isRemove:             isRemove,
+		isOverwrite:          isOverwrite,
+		isWatch:              isWatch,
+		isMetadata:           isMetadata,
+		md5:                  cli.Bool("md5"),
+		disableMultipart:     cli.Bool("disable-multipart"),
+		excludeBucketMarkers: cli.Bool("exclude-bucket-markers"),
+		metadataSync:         cli.Bool("metadata-sync"),
+		tagFilterWorkers:     cli.Int("tag-filter-workers"),
+		preserveTags:         cli.Bool("preserve-tags"),
+		preserveRetention:    cli.Bool("preserve-retention"),
+		excludeOptions:       excludeOptions,
+		includeOptions:       includeOptions,
+		olderThan:            cli.String("older-than"),
+		newerThan:            cli.String("newer-than"),
+		storageClass:         cli.String("storage-class"),
+		preserveStorageClass: cli.Bool("preserve-storage-class"),
+		userMetadata:         userMetadata,
+		encKeyDB:             encKeyDB,
+		activeActive:         isWatch,
+	}
+	if mopts.preserveStorageClass {
+		mopts.targetStorageClasses = targetStorageClasses(dstURL)
 	}
+	mopts.sizeRange, _ = parseSizeRange(cli.String("min-size"), cli.String("max-size"))
+	mopts.deleteAfter = cli.Bool("delete-after")
+	mopts.forceDeleteAfter = mopts.deleteAfter && cli.Bool("force")
+
+	mopts.tagFilters, _ = parseTagFilters(cli.StringSlice("tag-filter"))
 
 	// Create a new mirror job and execute it
 	mj := newMirrorJob(srcURL, dstURL, mopts)
@@ -930,6 +1156,10 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 				diffBucket := strings.TrimPrefix(d.SecondURL, dstClt.GetURL().String())
 				if isRemove {
 					aliasedDstBucket := path.Join(dstURL, diffBucket)
+					if mj.opts.deleteAfter {
+						mj.pendingBuckets = append(mj.pendingBuckets, aliasedDstBucket)
+						continue
+					}
 					err := deleteBucket(ctx, aliasedDstBucket, false)
 					mj.status.fatalIf(err, "Failed to start mirroring.")
 				}
@@ -1011,6 +1241,9 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 func mainMirror(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("MetadataUpdate", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TagFilter", color.New(color.FgYellow))
+	console.SetColor("MirrorDeleteAfter", color.New(color.FgYellow, color.Bold))
 
 	ctx, cancelMirror := context.WithCancel(globalContext)
 	defer cancelMirror()
@@ -1019,6 +1252,14 @@ func mainMirror(cliCtx *cli.Context) error {
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	setMetadataCharset(cliCtx)
+
+	// The retries are accounted by the transport of the S3 clients, which
+	// are cached once created by the syntax checks.
+	if budget := cliCtx.Duration("retry-budget"); budget > 0 {
+		globalRetryBudget = newRetryBudget(budget)
+	}
+
 	// check 'mirror' cli arguments.
 	srcURL, tgtURL := checkMirrorSyntax(ctx, cliCtx, encKeyDB)
 
@@ -18,14 +18,18 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/pkg/wildcard"
 )
 
@@ -45,12 +49,34 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 	srcURL = URLs[0]
 	tgtURL = URLs[1]
 
-	if cliCtx.Bool("force") && cliCtx.Bool("remove") {
+	checkTagFilterSyntax(cliCtx, []string{srcURL})
+
+	if _, err := parseSizeRange(cliCtx.String("min-size"), cliCtx.String("max-size")); err != nil {
+		fatalIf(err.Trace(URLs...), "Invalid `--min-size` or `--max-size`.")
+	}
+
+	if cliCtx.Bool("delete-after") {
+		switch {
+		case !cliCtx.Bool("remove"):
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--delete-after` requires `--remove`.")
+		case cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master"):
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--delete-after` cannot be used with `--watch`, a watch never ends.")
+		}
+	} else if cliCtx.Bool("force") && cliCtx.Bool("remove") {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated, please use `--overwrite` instead with `--remove` for the same functionality.")
 	} else if cliCtx.Bool("force") {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated, please use `--overwrite` instead for the same functionality.")
 	}
 
+	if cliCtx.IsSet("retry-budget") {
+		switch {
+		case cliCtx.Duration("retry-budget") <= 0:
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--retry-budget` must be a positive duration.")
+		case cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master"):
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--retry-budget` cannot be used with `--watch`, a watch never ends.")
+		}
+	}
+
 	_, expandedSourcePath, _ := mustExpandAlias(srcURL)
 	srcClient := newClientURL(expandedSourcePath)
 	_, expandedTargetPath, _ := mustExpandAlias(tgtURL)
@@ -64,6 +90,29 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		}
 	}
 
+	if cliCtx.Bool("preserve-tags") || cliCtx.Bool("preserve-retention") {
+		if srcClient.Type != objectStorage || destClient.Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--preserve-tags` and `--preserve-retention` require object storage source and target.")
+		}
+	}
+
+	if cliCtx.Bool("preserve-storage-class") {
+		switch {
+		case cliCtx.String("storage-class") != "":
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--preserve-storage-class` cannot be used with `--storage-class`.")
+		case srcClient.Type != objectStorage || destClient.Type != objectStorage:
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--preserve-storage-class` requires object storage source and target.")
+		}
+	}
+
+	if cliCtx.Bool("preserve-retention") {
+		withLock, err := isBucketLockEnabled(ctx, tgtURL)
+		fatalIf(err.Trace(tgtURL), "Unable to get object lock configuration of `"+tgtURL+"`.")
+		if !withLock {
+			fatalIf(errInvalidArgument().Trace(tgtURL), "`--preserve-retention` requires object lock to be enabled on the target bucket `"+tgtURL+"`.")
+		}
+	}
+
 	/****** Generic rules *******/
 	if !cliCtx.Bool("watch") && !cliCtx.Bool("active-active") && !cliCtx.Bool("multi-master") {
 		_, srcContent, err := url2Stat(ctx, srcURL, "", false, encKeyDB, time.Time{}, false)
@@ -89,6 +138,15 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 	return
 }
 
+// isDirMarker returns true for directory placeholder objects, i.e. zero
+// sized objects whose key ends with a separator such as 'foo/'.
+func isDirMarker(content *ClientContent) bool {
+	if content == nil || content.URL.Type != objectStorage || !content.Type.IsDir() {
+		return false
+	}
+	return strings.HasSuffix(content.URL.Path, string(content.URL.Separator))
+}
+
 func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	for _, pattern := range excludeOptions {
 		if wildcard.Match(pattern, srcSuffix) {
@@ -98,6 +156,66 @@ func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	return false
 }
 
+// matchIncludeOptions returns true if srcSuffix matches any of the include
+// patterns, an empty list of patterns includes everything.
+func matchIncludeOptions(includeOptions []string, srcSuffix string) bool {
+	if len(includeOptions) == 0 {
+		return true
+	}
+	for _, pattern := range includeOptions {
+		if wildcard.Match(pattern, srcSuffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipByPatterns returns true if srcSuffix must be skipped, excludes always
+// take precedence over includes.
+func skipByPatterns(includeOptions, excludeOptions []string, srcSuffix string) bool {
+	return matchExcludeOptions(excludeOptions, srcSuffix) || !matchIncludeOptions(includeOptions, srcSuffix)
+}
+
+// readPatternsFile reads newline delimited patterns from a file, blank
+// lines and lines starting with '#' are ignored.
+func readPatternsFile(patternsFile string) ([]string, *probe.Error) {
+	if patternsFile == "" {
+		return nil, nil
+	}
+	f, e := os.Open(patternsFile)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(patternsFile)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(patternsFile)
+	}
+	return patterns, nil
+}
+
+// mustGetPatternsFromContext returns the include and exclude patterns passed
+// inline and through --include-from/--exclude-from files.
+func mustGetPatternsFromContext(cliCtx *cli.Context) (includeOptions, excludeOptions []string) {
+	includeFile, err := readPatternsFile(cliCtx.String("include-from"))
+	fatalIf(err, "Unable to read include patterns.")
+	excludeFile, err := readPatternsFile(cliCtx.String("exclude-from"))
+	fatalIf(err, "Unable to read exclude patterns.")
+
+	includeOptions = append(cliCtx.StringSlice("include"), includeFile...)
+	excludeOptions = append(cliCtx.StringSlice("exclude"), excludeFile...)
+	return includeOptions, excludeOptions
+}
+
 func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mirrorOptions, URLsCh chan<- URLs) {
 	// source and targets are always directories
 	sourceSeparator := string(newClientURL(sourceURL).Separator)
@@ -128,7 +246,7 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 	}
 
 	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata) {
+	for diffMsg := range objectDifference(ctx, sourceClnt, targetClnt, opts.isMetadata || opts.metadataSync) {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error, ErrorCond: differInUnknown}
@@ -136,14 +254,22 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 		}
 
 		srcSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-		// Skip the source object if it matches the Exclude options provided
-		if matchExcludeOptions(opts.excludeOptions, srcSuffix) {
+		// Skip the source object if it matches the Exclude options or
+		// does not match the Include options provided
+		if diffMsg.FirstURL != "" && skipByPatterns(opts.includeOptions, opts.excludeOptions, srcSuffix) {
+			continue
+		}
+
+		// Directory placeholders follow backend specific conventions, skip them
+		// on both sides so that they are neither copied nor seen as extraneous.
+		if opts.excludeBucketMarkers && (isDirMarker(diffMsg.firstContent) || isDirMarker(diffMsg.secondContent)) {
 			continue
 		}
 
 		tgtSuffix := strings.TrimPrefix(diffMsg.SecondURL, targetURL)
-		// Skip the target object if it matches the Exclude options provided
-		if matchExcludeOptions(opts.excludeOptions, tgtSuffix) {
+		// Skip the target object if it matches the Exclude options or
+		// does not match the Include options provided
+		if diffMsg.SecondURL != "" && skipByPatterns(opts.includeOptions, opts.excludeOptions, tgtSuffix) {
 			continue
 		}
 
@@ -153,6 +279,19 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 		case differInType:
 			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
 		case differInSize, differInMetadata, differInAASourceMTime:
+			if diffMsg.Diff == differInMetadata && opts.metadataSync {
+				// Checked again before the copy, which only updates the
+				// metadata when the content is the same.
+				sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
+				URLsCh <- URLs{
+					SourceAlias:      sourceAlias,
+					SourceContent:    diffMsg.firstContent,
+					TargetAlias:      targetAlias,
+					TargetContent:    &ClientContent{URL: *newClientURL(urlJoinPath(targetURL, sourceSuffix))},
+					syncMetadataOnly: true,
+				}
+				continue
+			}
 			if !opts.isOverwrite && !opts.isFake && !opts.activeActive {
 				// Size or time or etag differs but --overwrite not set.
 				URLsCh <- URLs{
@@ -205,11 +344,20 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 type mirrorOptions struct {
 	isFake, isOverwrite, activeActive bool
 	isWatch, isRemove, isMetadata     bool
-	excludeOptions                    []string
+	preserveTags, preserveRetention   bool
+	excludeOptions, includeOptions    []string
 	encKeyDB                          map[string][]prefixSSEPair
 	md5, disableMultipart             bool
+	excludeBucketMarkers              bool
+	metadataSync                      bool
+	tagFilters                        []tagFilter
+	tagFilterWorkers                  int
 	olderThan, newerThan              string
+	deleteAfter, forceDeleteAfter     bool
+	sizeRange                         sizeRange
 	storageClass                      string
+	preserveStorageClass              bool
+	targetStorageClasses              set.StringSet
 	userMetadata                      map[string]string
 }
 
@@ -217,5 +365,9 @@ type mirrorOptions struct {
 func prepareMirrorURLs(ctx context.Context, sourceURL string, targetURL string, opts mirrorOptions) <-chan URLs {
 	URLsCh := make(chan URLs)
 	go deltaSourceTarget(ctx, sourceURL, targetURL, opts, URLsCh)
+	if len(opts.tagFilters) > 0 {
+		// Removals have no source and are passed through untouched.
+		return filterURLsByTags(ctx, URLsCh, opts.tagFilters, opts.tagFilterWorkers, getSourceTags)
+	}
 	return URLsCh
 }
@@ -0,0 +1,208 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// movedObject is an object copied to the target whose source is not yet removed.
+type movedObject struct {
+	sourceAlias string
+	sourceURL   ClientURL
+	targetAlias string
+	targetURL   ClientURL
+}
+
+// moveJournal keeps tabs of the objects copied by mv whose source is not yet
+// removed, so that a failed move reports exactly which objects now exist both
+// at the source and the target, or rolls their copies back.
+type moveJournal struct {
+	mutex   sync.Mutex
+	pending map[string]movedObject
+
+	// deferRemoval removes the sources only once all objects
+	// were copied, which allows to roll back every copy.
+	deferRemoval bool
+	// removeErrs counts the sources which failed to be removed.
+	removeErrs int64
+}
+
+func newMoveJournal() *moveJournal {
+	return &moveJournal{pending: make(map[string]movedObject)}
+}
+
+// moveJournalKey identifies an object removed by the given alias.
+func moveJournalKey(alias, urlPath string) string {
+	return alias + "|" + urlPath
+}
+
+// removeResultPath returns the URL path of a removed object.
+func removeResultPath(result RemoveResult) string {
+	if result.BucketName != "" {
+		return "/" + result.BucketName + "/" + result.ObjectName
+	}
+	return result.ObjectName
+}
+
+// copied records an object copied to the target.
+func (j *moveJournal) copied(sourceAlias string, sourceURL ClientURL, targetAlias string, targetURL ClientURL) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.pending[moveJournalKey(sourceAlias, sourceURL.Path)] = movedObject{
+		sourceAlias: sourceAlias,
+		sourceURL:   sourceURL,
+		targetAlias: targetAlias,
+		targetURL:   targetURL,
+	}
+}
+
+// removed records the source of a copied object as removed.
+func (j *moveJournal) removed(sourceAlias string, result RemoveResult) {
+	if result.Err != nil {
+		atomic.AddInt64(&j.removeErrs, 1)
+		return
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	delete(j.pending, moveJournalKey(sourceAlias, removeResultPath(result)))
+}
+
+// failed returns true if any source failed to be removed.
+func (j *moveJournal) failed() bool {
+	return atomic.LoadInt64(&j.removeErrs) > 0
+}
+
+// duplicates returns the objects copied whose source is not removed, sorted by source.
+func (j *moveJournal) duplicates() []movedObject {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	objects := make([]movedObject, 0, len(j.pending))
+	for _, object := range j.pending {
+		objects = append(objects, object)
+	}
+	sort.Slice(objects, func(i, k int) bool {
+		return moveJournalKey(objects[i].sourceAlias, objects[i].sourceURL.Path) <
+			moveJournalKey(objects[k].sourceAlias, objects[k].sourceURL.Path)
+	})
+	return objects
+}
+
+// removeSources removes the sources of all the copied objects.
+func (j *moveJournal) removeSources(ctx context.Context) {
+	for _, object := range j.duplicates() {
+		rmManager.add(ctx, object.sourceAlias, object.sourceURL.String())
+	}
+}
+
+// moveRollbackTimeout bounds the removal of the copies of a failed move.
+const moveRollbackTimeout = 5 * time.Minute
+
+// rollback removes the copies of the objects whose source is not removed,
+// the copies which cannot be removed are kept as duplicates.
+func (j *moveJournal) rollback(ctx context.Context) (rolledBack int) {
+	byAlias := make(map[string][]movedObject)
+	for _, object := range j.duplicates() {
+		byAlias[object.targetAlias] = append(byAlias[object.targetAlias], object)
+	}
+
+	for targetAlias, objects := range byAlias {
+		clnt, err := newClientFromAlias(targetAlias, objects[0].targetURL.String())
+		if err != nil {
+			errorIf(err.Trace(targetAlias), "Unable to roll back the copies in `%s`.", targetAlias)
+			continue
+		}
+
+		contentCh := make(chan *ClientContent)
+		resultCh := clnt.Remove(ctx, false, false, false, false, contentCh)
+		go func(objects []movedObject) {
+			defer close(contentCh)
+			for _, object := range objects {
+				contentCh <- &ClientContent{URL: object.targetURL}
+			}
+		}(objects)
+
+		removed := make(map[string]struct{})
+		for result := range resultCh {
+			if result.Err != nil {
+				errorIf(result.Err.Trace(targetAlias), "Unable to roll back a copy in `%s`.", targetAlias)
+				continue
+			}
+			removed[removeResultPath(result)] = struct{}{}
+		}
+
+		j.mutex.Lock()
+		for _, object := range objects {
+			if _, ok := removed[object.targetURL.Path]; ok {
+				delete(j.pending, moveJournalKey(object.sourceAlias, object.sourceURL.Path))
+				rolledBack++
+			}
+		}
+		j.mutex.Unlock()
+	}
+	return rolledBack
+}
+
+var mvJournal = newMoveJournal()
+
+// moveDuplicateMessage container for an object which was copied but whose source was not removed.
+type moveDuplicateMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+func newMoveDuplicateMessage(object movedObject) moveDuplicateMessage {
+	return moveDuplicateMessage{
+		Status: "error",
+		Source: filepath.ToSlash(filepath.Join(object.sourceAlias, object.sourceURL.Path)),
+		Target: filepath.ToSlash(filepath.Join(object.targetAlias, object.targetURL.Path)),
+	}
+}
+
+func (m moveDuplicateMessage) String() string {
+	return console.Colorize("MoveDuplicate", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
+}
+
+func (m moveDuplicateMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// reportMoveDuplicates reports the objects which exist both at the source and the target.
+func reportMoveDuplicates(duplicates []movedObject) {
+	if len(duplicates) == 0 {
+		return
+	}
+	errorIf(errDummy().Trace(), "%d object(s) were copied but not removed from the source, they exist both at the source and the target.", len(duplicates))
+	for _, object := range duplicates {
+		printMsg(newMoveDuplicateMessage(object))
+	}
+}
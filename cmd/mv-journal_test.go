@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestMoveJournal(t *testing.T) {
+	j := newMoveJournal()
+	for _, name := range []string{"c", "a", "b"} {
+		j.copied("play", *newClientURL("https://play.min.io/src/" + name),
+			"", *newClientURL("/tmp/dst/" + name))
+	}
+	j.copied("", *newClientURL("/tmp/src/d"), "play", *newClientURL("https://play.min.io/dst/d"))
+
+	// Removal results of S3 and local sources.
+	j.removed("play", RemoveResult{BucketName: "src", RemoveObjectResult: minio.RemoveObjectResult{ObjectName: "a"}})
+	j.removed("", RemoveResult{RemoveObjectResult: minio.RemoveObjectResult{ObjectName: "/tmp/src/d"}})
+	// Same object name but another alias.
+	j.removed("s3", RemoveResult{BucketName: "src", RemoveObjectResult: minio.RemoveObjectResult{ObjectName: "b"}})
+
+	if j.failed() {
+		t.Fatal("Expected no failed removal")
+	}
+	j.removed("play", RemoveResult{BucketName: "src", Err: probe.NewError(errors.New("access denied"))})
+	if !j.failed() {
+		t.Fatal("Expected a failed removal")
+	}
+
+	duplicates := j.duplicates()
+	if len(duplicates) != 2 {
+		t.Fatalf("Expected 2 duplicates, got %d", len(duplicates))
+	}
+	for i, expected := range []moveDuplicateMessage{
+		{Status: "error", Source: "play/src/b", Target: "/tmp/dst/b"},
+		{Status: "error", Source: "play/src/c", Target: "/tmp/dst/c"},
+	} {
+		if msg := newMoveDuplicateMessage(duplicates[i]); msg != expected {
+			t.Errorf("Duplicate %d: expected %+v, got %+v", i+1, expected, msg)
+		}
+	}
+}
@@ -68,6 +68,10 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "rollback-on-error",
+			Usage: "remove the sources only once all objects are copied, and remove the copies from the target on error",
+		},
 	}
 )
 
@@ -141,6 +145,10 @@ EXAMPLES:
 
   16. Move a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
+
+  17. Move a folder recursively, keeping the source untouched and removing the copies from the target
+      if any object fails to be moved.
+      {{.Prompt}} {{.HelpName}} --recursive --rollback-on-error play/mybucket/myfolder/ s3/mybucket/
 `,
 }
 
@@ -156,11 +164,12 @@ type removeManager struct {
 	wg             sync.WaitGroup
 }
 
-func (rm *removeManager) readErrors(resultCh <-chan RemoveResult, targetURL string) {
+func (rm *removeManager) readErrors(resultCh <-chan RemoveResult, targetAlias, targetURL string) {
 	rm.wg.Add(1)
 	go func() {
 		defer rm.wg.Done()
 		for result := range resultCh {
+			mvJournal.removed(targetAlias, result)
 			if result.Err != nil {
 				errorIf(result.Err.Trace(targetURL), "Failed to remove in`"+targetURL+"`.")
 			}
@@ -176,13 +185,15 @@ func (rm *removeManager) add(ctx context.Context, targetAlias, targetURL string)
 	if clientInfo == nil {
 		client, pErr := newClientFromAlias(targetAlias, targetURL)
 		if pErr != nil {
+			rm.removeMapMutex.Unlock()
+			mvJournal.removed(targetAlias, RemoveResult{Err: pErr})
 			errorIf(pErr.Trace(targetURL), "Invalid argument `"+targetURL+"`.")
 			return
 		}
 
 		contentCh := make(chan *ClientContent, 10000)
 		resultCh := client.Remove(ctx, false, false, false, false, contentCh)
-		rm.readErrors(resultCh, targetURL)
+		rm.readErrors(resultCh, targetAlias, targetURL)
 
 		clientInfo = &removeClientInfo{
 			client:    client,
@@ -227,7 +238,7 @@ func mainMove(cliCtx *cli.Context) error {
 	}
 
 	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, cliCtx, encKeyDB, true)
+	checkCopySyntax(ctx, cliCtx, cliCtx.Args(), encKeyDB, true)
 
 	if cliCtx.NArg() == 2 {
 		args := cliCtx.Args()
@@ -250,8 +261,14 @@ func mainMove(cliCtx *cli.Context) error {
 		}
 	}
 
+	if cliCtx.Bool("rollback-on-error") && cliCtx.Bool("continue") {
+		fatalIf(errInvalidArgument().Trace(), "`--rollback-on-error` cannot be used with `--continue`.")
+	}
+	mvJournal.deferRemoval = cliCtx.Bool("rollback-on-error")
+
 	// Additional command speific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("MoveDuplicate", color.New(color.FgYellow))
 
 	recursive := cliCtx.Bool("recursive")
 	olderThan := cliCtx.String("older-than")
@@ -303,13 +320,36 @@ func mainMove(cliCtx *cli.Context) error {
 		}
 	}
 
-	e := doCopySession(ctx, cancelMove, cliCtx, session, encKeyDB, true)
+	e := doCopySession(ctx, cancelMove, cliCtx, cliCtx.Args(), session, encKeyDB, true)
 	if session != nil {
 		session.Delete()
 	}
 
+	// Sources are removed only once everything was copied, otherwise
+	// the copies are rolled back so that the sources stay the only ones.
+	if mvJournal.deferRemoval {
+		if e == nil {
+			mvJournal.removeSources(ctx)
+		} else {
+			// The move may have failed because it was interrupted, roll
+			// back with a context of its own, bounded in time.
+			rollbackCtx, cancelRollback := context.WithTimeout(context.Background(), moveRollbackTimeout)
+			if rolledBack := mvJournal.rollback(rollbackCtx); rolledBack > 0 {
+				console.Infof("Removed %d copied object(s) from the target.\n", rolledBack)
+			}
+			cancelRollback()
+		}
+	}
+
 	console.Colorize("Copy", "Waiting for move operations to complete")
 	rmManager.close()
 
+	if e != nil || mvJournal.failed() {
+		reportMoveDuplicates(mvJournal.duplicates())
+		if e == nil {
+			e = exitStatus(globalErrorExitStatus)
+		}
+	}
+
 	return e
 }
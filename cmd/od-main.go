@@ -50,6 +50,7 @@ OPERANDS:
   size=      size of each part. If not specified, will be calculated from the source stream size.
   parts=     number of parts to upload. If not specified, will calculated from the source file size.
   skip=      number of parts to skip.
+  verify=    read the target back after the upload and compare it, part by part, with the checksums taken during the upload (true or false).
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -63,6 +64,9 @@ EXAMPLES:
 
   3. Upload a full file to a bucket in 5 parts.
       {{.HelpName}} if=file.txt of=play/my-bucket/file.txt parts=5
+
+  4. Upload 1GiB of zeroes in 16MiB parts, then read it back to verify that every part is identical.
+      {{.HelpName}} if=/dev/zero of=play/my-bucket/zeroes size=16MiB parts=64 verify=true
 `,
 }
 
@@ -76,6 +80,8 @@ type odMessage struct {
 	Parts     int    `json:"parts"`
 	Skip      int    `json:"skip"`
 	Elapsed   int64  `json:"elapsed"`
+
+	Verify *odVerifyResult `json:"verify,omitempty"`
 }
 
 func (o odMessage) String() string {
@@ -85,7 +91,11 @@ func (o odMessage) String() string {
 	if o.Type == "S3toFS" && o.Parts == 0 {
 		return fmt.Sprintf("Transferred: %s, Full file, Time: %s, Speed: %s/s", cleanSize, elapsed, speed)
 	}
-	return fmt.Sprintf("Transferred: %s, Parts: %d, Time: %s, Speed: %s/s", cleanSize, o.Parts, elapsed, speed)
+	msg := fmt.Sprintf("Transferred: %s, Parts: %d, Time: %s, Speed: %s/s", cleanSize, o.Parts, elapsed, speed)
+	if o.Verify != nil {
+		msg += "\n" + o.Verify.String()
+	}
+	return msg
 }
 
 func (o odMessage) JSON() string {
@@ -143,6 +153,9 @@ func prepareOdUrls(ctx context.Context, sourceURL, sourceVersion string, targetU
 // odCheckType checks if request is a download or upload and calls the appropriate function
 func odCheckType(ctx context.Context, odURLs URLs, args argKVS) (message, error) {
 	if odURLs.SourceAlias != "" && odURLs.TargetAlias == "" {
+		if args.Get("verify") != "" {
+			return nil, fmt.Errorf("verify is only supported for uploads")
+		}
 		return odDownload(ctx, odURLs, args)
 	}
 
@@ -181,5 +194,8 @@ func mainOD(cliCtx *cli.Context) error {
 
 	// Print message.
 	printMsg(message)
+	if msg, ok := message.(odMessage); ok && msg.Verify != nil && len(msg.Verify.Mismatches) > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
 	return nil
 }
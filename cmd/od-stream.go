@@ -116,6 +116,10 @@ func odCopy(ctx context.Context, odURLs URLs, args argKVS, odType string) (odMes
 	if e != nil {
 		return odMessage{}, e
 	}
+	verify, e := odVerifyEnabled(args)
+	if e != nil {
+		return odMessage{}, e
+	}
 
 	sourceAlias := odURLs.SourceAlias
 	sourceURL := odURLs.SourceContent.URL
@@ -139,6 +143,19 @@ func odCopy(ctx context.Context, odURLs URLs, args argKVS, odType string) (odMes
 	fatalIf(err.Trace(sourcePath), "Unable to get source stream")
 	defer reader.Close()
 
+	// Record the sums of the blocks as they are uploaded, the source
+	// may be a stream which cannot be read again.
+	var hasher *odBlockHasher
+	var source io.Reader = reader
+	if verify {
+		blockSize := partSize
+		if blockSize == 0 {
+			blockSize = uint64(combinedSize)
+		}
+		hasher = newOdBlockHasher(blockSize)
+		source = io.TeeReader(reader, hasher)
+	}
+
 	putOpts := PutOptions{
 		storageClass:  odURLs.TargetContent.StorageClass,
 		md5:           odURLs.MD5,
@@ -158,7 +175,7 @@ func odCopy(ctx context.Context, odURLs URLs, args argKVS, odType string) (odMes
 	fatalIf(err.Trace(targetURL.String()), "Unable to initialize target client")
 
 	// Put object.
-	total, err := targetClnt.PutPart(ctx, reader, combinedSize, pg, putOpts)
+	total, err := targetClnt.PutPart(ctx, source, combinedSize, pg, putOpts)
 	fatalIf(err.Trace(targetURL.String()), "Unable to upload")
 
 	// Get upload time.
@@ -176,6 +193,13 @@ func odCopy(ctx context.Context, odURLs URLs, args argKVS, odType string) (odMes
 		Elapsed:   elapsed.Milliseconds(),
 	}
 
+	if verify {
+		message.Verify, e = odVerify(ctx, hasher.Sums(), targetPath, total, hasher.blockSize)
+		if e != nil {
+			return odMessage{}, e
+		}
+	}
+
 	return message, nil
 }
 
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// odVerifyChunkSize is the largest buffer compared at once, large blocks
+// are compared in several chunks.
+const odVerifyChunkSize = 4 << 20
+
+// odVerifyResult holds the result of reading back the written blocks.
+type odVerifyResult struct {
+	Blocks     int     `json:"blocks"`
+	BlockSize  uint64  `json:"blockSize"`
+	Mismatches []int64 `json:"mismatches,omitempty"`
+}
+
+func (v odVerifyResult) String() string {
+	if len(v.Mismatches) == 0 {
+		return fmt.Sprintf("Verified: %d blocks", v.Blocks)
+	}
+	offsets := make([]string, 0, len(v.Mismatches))
+	for _, offset := range v.Mismatches {
+		offsets = append(offsets, strconv.FormatInt(offset, 10))
+	}
+	return fmt.Sprintf("Verify FAILED: %d of %d blocks differ at offsets %s",
+		len(v.Mismatches), v.Blocks, strings.Join(offsets, ", "))
+}
+
+// odVerifyEnabled returns true when the verify operand is set.
+func odVerifyEnabled(args argKVS) (bool, error) {
+	v := args.Get("verify")
+	if v == "" {
+		return false, nil
+	}
+	verify, e := strconv.ParseBool(v)
+	if e != nil {
+		return false, fmt.Errorf("invalid verify=%s, expected true or false", v)
+	}
+	return verify, nil
+}
+
+// odBlockHasher records a SHA-256 sum for every block of the data written
+// to it, so that the uploaded data can be verified without reading the
+// source twice. Streams like /dev/urandom or a pipe cannot be read again.
+type odBlockHasher struct {
+	blockSize uint64
+	written   uint64
+	hash      hash.Hash
+	sums      [][]byte
+}
+
+func newOdBlockHasher(blockSize uint64) *odBlockHasher {
+	return &odBlockHasher{blockSize: blockSize, hash: sha256.New()}
+}
+
+func (h *odBlockHasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		left := h.blockSize - h.written
+		if uint64(len(p)) < left {
+			left = uint64(len(p))
+		}
+		h.hash.Write(p[:left])
+		h.written += left
+		p = p[left:]
+		if h.written == h.blockSize {
+			h.sums = append(h.sums, h.hash.Sum(nil))
+			h.hash.Reset()
+			h.written = 0
+		}
+	}
+	return n, nil
+}
+
+// Sums returns the sums of all the blocks, including the last partial one.
+func (h *odBlockHasher) Sums() [][]byte {
+	if h.written > 0 {
+		h.sums = append(h.sums, h.hash.Sum(nil))
+		h.hash.Reset()
+		h.written = 0
+	}
+	return h.sums
+}
+
+// odVerify reads back size bytes written to the target and compares them,
+// block by block, with the sums recorded while the source was uploaded.
+func odVerify(ctx context.Context, sums [][]byte, targetPath string, size int64, blockSize uint64) (*odVerifyResult, error) {
+	result := &odVerifyResult{BlockSize: blockSize}
+	if size <= 0 {
+		return result, nil
+	}
+
+	// Placeholder encryption key database.
+	var encKeyDB map[string][]prefixSSEPair
+
+	target, err := getSourceStreamFromURL(ctx, targetPath, encKeyDB, getSourceOpts{})
+	if err != nil {
+		return nil, err.Trace(targetPath).ToGoError()
+	}
+	defer target.Close()
+
+	return odCompareBlocks(sums, target, size, blockSize)
+}
+
+// odCompareBlocks compares size bytes of the target in blocks of blockSize
+// with the sums of the source blocks and records the offset of every block
+// which differs. The target must not be longer than size.
+func odCompareBlocks(sums [][]byte, target io.Reader, size int64, blockSize uint64) (*odVerifyResult, error) {
+	result := &odVerifyResult{
+		Blocks:    int((size + int64(blockSize) - 1) / int64(blockSize)),
+		BlockSize: blockSize,
+	}
+	chunk := int64(blockSize)
+	if chunk > odVerifyChunkSize {
+		chunk = odVerifyChunkSize
+	}
+	targetBuf := make([]byte, chunk)
+	targetHash := sha256.New()
+
+	for block, offset := 0, int64(0); offset < size; block, offset = block+1, offset+int64(blockSize) {
+		end := offset + int64(blockSize)
+		if end > size {
+			end = size
+		}
+		targetHash.Reset()
+		for pos := offset; pos < end; pos += chunk {
+			n := chunk
+			if end-pos < n {
+				n = end - pos
+			}
+			if _, e := io.ReadFull(target, targetBuf[:n]); e != nil {
+				if e != io.EOF && e != io.ErrUnexpectedEOF {
+					return nil, fmt.Errorf("unable to read the target back at offset %d: %w", pos, e)
+				}
+				// The target is shorter, this and the remaining blocks are missing.
+				for ; offset < size; offset += int64(blockSize) {
+					result.Mismatches = append(result.Mismatches, offset)
+				}
+				return result, nil
+			}
+			targetHash.Write(targetBuf[:n])
+		}
+		if block >= len(sums) || !bytes.Equal(sums[block], targetHash.Sum(nil)) {
+			result.Mismatches = append(result.Mismatches, offset)
+		}
+	}
+
+	// Anything written after the last block is a mismatch too.
+	if n, _ := io.ReadFull(target, targetBuf[:1]); n > 0 {
+		result.Mismatches = append(result.Mismatches, size)
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestOdCompareBlocks(t *testing.T) {
+	source := bytes.Repeat([]byte("0123456789"), 10)
+	corrupt := func(offsets ...int) []byte {
+		b := append([]byte{}, source...)
+		for _, o := range offsets {
+			b[o] ^= 0xff
+		}
+		return b
+	}
+
+	testCases := []struct {
+		target     []byte
+		blockSize  uint64
+		blocks     int
+		mismatches []int64
+	}{
+		{source, 30, 4, nil},
+		{corrupt(0, 95), 30, 4, []int64{0, 90}},
+		{corrupt(31, 32), 30, 4, []int64{30}},
+		{source[:50], 30, 4, []int64{30, 60, 90}},
+		{append(append([]byte{}, source...), 'x'), 30, 4, []int64{100}},
+		{corrupt(99), 100, 1, []int64{0}},
+	}
+	for i, tc := range testCases {
+		hasher := newOdBlockHasher(tc.blockSize)
+		hasher.Write(source)
+		result, e := odCompareBlocks(hasher.Sums(), bytes.NewReader(tc.target), int64(len(source)), tc.blockSize)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if result.Blocks != tc.blocks {
+			t.Errorf("Test %d: expected %d blocks, got %d", i+1, tc.blocks, result.Blocks)
+		}
+		if !reflect.DeepEqual(result.Mismatches, tc.mismatches) {
+			t.Errorf("Test %d: expected mismatches %v, got %v", i+1, tc.mismatches, result.Mismatches)
+		}
+	}
+}
+
+func TestOdBlockHasher(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 10)
+
+	whole := newOdBlockHasher(30)
+	whole.Write(data)
+
+	// Writes which do not line up with the blocks give the same sums.
+	split := newOdBlockHasher(30)
+	for _, n := range []int{7, 29, 1, 50, 13} {
+		split.Write(data[:n])
+		data = data[n:]
+	}
+
+	if len(whole.Sums()) != 4 {
+		t.Fatalf("expected 4 sums, got %d", len(whole.Sums()))
+	}
+	if !reflect.DeepEqual(whole.Sums(), split.Sums()) {
+		t.Fatal("expected the same sums for split writes")
+	}
+}
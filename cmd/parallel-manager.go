@@ -66,6 +66,9 @@ type ParallelManager struct {
 	// Current threads number
 	workersNum uint32
 
+	// Number of threads to scale down to, 0 when never scaled down
+	targetWorkers uint32
+
 	// Channel to receive tasks to run
 	queueCh chan task
 
@@ -74,6 +77,9 @@ type ParallelManager struct {
 
 	stopMonitorCh chan struct{}
 
+	// Closed once the auto tuner stopped, nil without auto tuning
+	tunerDoneCh chan struct{}
+
 	// The maximum memory to use
 	maxMem uint64
 }
@@ -109,6 +115,56 @@ func (p *ParallelManager) addWorker() {
 			} else {
 				p.barrierSync.RUnlock()
 			}
+
+			if p.retireWorker() {
+				p.wg.Done()
+				return
+			}
+		}
+	}()
+}
+
+// retireWorker returns true when the calling worker has to stop, there
+// are more workers than the number set with setWorkers.
+func (p *ParallelManager) retireWorker() bool {
+	for {
+		target := atomic.LoadUint32(&p.targetWorkers)
+		n := atomic.LoadUint32(&p.workersNum)
+		if target == 0 || n <= target {
+			return false
+		}
+		if atomic.CompareAndSwapUint32(&p.workersNum, n, n-1) {
+			return true
+		}
+	}
+}
+
+// setWorkers scales the number of workers to n, extra workers stop
+// once done with their current task.
+func (p *ParallelManager) setWorkers(n int) {
+	atomic.StoreUint32(&p.targetWorkers, uint32(n))
+	for i := int(atomic.LoadUint32(&p.workersNum)); i < n; i++ {
+		p.addWorker()
+	}
+}
+
+// autoTune adjusts the number of workers to the bytes transferred and
+// the requests throttled during each period.
+func (p *ParallelManager) autoTune(a *autoParallel, pg Progress, stats *requestStats) {
+	p.setWorkers(a.workers)
+	p.tunerDoneCh = make(chan struct{})
+	go func() {
+		defer close(p.tunerDoneCh)
+		ticker := time.NewTicker(autoParallelPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stopMonitorCh:
+				return
+			case <-ticker.C:
+				p.setWorkers(a.adjust(pg.Get(), atomic.LoadInt64(&stats.requests), atomic.LoadInt64(&stats.throttled)))
+			}
 		}
 	}()
 }
@@ -217,9 +273,16 @@ func (p *ParallelManager) doQueueTask(t task) {
 
 // Wait for all workers to finish tasks before shutting down Parallel
 func (p *ParallelManager) stopAndWait() {
+	// No worker is added by the auto tuner once the queue is closed.
+	if p.tunerDoneCh != nil {
+		close(p.stopMonitorCh)
+		<-p.tunerDoneCh
+	}
 	close(p.queueCh)
 	p.wg.Wait()
-	close(p.stopMonitorCh)
+	if p.tunerDoneCh == nil {
+		close(p.stopMonitorCh)
+	}
 }
 
 const cgroupLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
@@ -264,14 +327,7 @@ func availableMemory() (available uint64) {
 
 // newParallelManager starts new workers waiting for executing tasks
 func newParallelManager(resultCh chan URLs) *ParallelManager {
-	p := &ParallelManager{
-		wg:            &sync.WaitGroup{},
-		workersNum:    0,
-		stopMonitorCh: make(chan struct{}),
-		queueCh:       make(chan task),
-		resultCh:      resultCh,
-		maxMem:        availableMemory(),
-	}
+	p := newIdleParallelManager(resultCh)
 
 	// Start with runtime.NumCPU().
 	for i := 0; i < runtime.NumCPU(); i++ {
@@ -283,3 +339,23 @@ func newParallelManager(resultCh chan URLs) *ParallelManager {
 
 	return p
 }
+
+// newAutoParallelManager returns a parallel manager whose number of
+// workers is picked by the given auto tuner.
+func newAutoParallelManager(resultCh chan URLs, a *autoParallel, pg Progress, stats *requestStats) *ParallelManager {
+	p := newIdleParallelManager(resultCh)
+	p.autoTune(a, pg, stats)
+	return p
+}
+
+// newIdleParallelManager returns a parallel manager without workers.
+func newIdleParallelManager(resultCh chan URLs) *ParallelManager {
+	return &ParallelManager{
+		wg:            &sync.WaitGroup{},
+		workersNum:    0,
+		stopMonitorCh: make(chan struct{}),
+		queueCh:       make(chan task),
+		resultCh:      resultCh,
+		maxMem:        availableMemory(),
+	}
+}
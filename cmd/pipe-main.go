@@ -21,6 +21,7 @@ import (
 	"io"
 	"os"
 	"runtime/debug"
+	"strings"
 	"syscall"
 
 	"github.com/dustin/go-humanize"
@@ -61,6 +62,14 @@ var pipeFlags = []cli.Flag{
 		Value: defaultPartSize(),
 		Usage: "customize chunk size for each concurrent upload",
 	},
+	cli.StringFlag{
+		Name:  "checksum",
+		Usage: "verify the checksum recorded by the server against the one of the stream (crc32c)",
+	},
+	cli.BoolFlag{
+		Name:  "md5, content-md5",
+		Usage: "send the Content-MD5 of each part, for the server to verify the uploaded data",
+	},
 }
 
 // Display contents of a file.
@@ -105,6 +114,13 @@ EXAMPLES:
 
   7. Set tags to the uploaded objects
       {{.Prompt}} tar cvf - . | {{.HelpName}} --tags "category=prod&type=backup" play/mybucket/backup.tar
+
+  8. Stream a backup and verify its CRC32C checksum recorded by the server.
+      {{.Prompt}} tar cvf - . | {{.HelpName}} --checksum crc32c play/mybucket/backup.tar
+
+  9. Stream a backup, sending the MD5 of each part for the server to reject corrupted parts. Computing
+     the MD5 costs CPU time, it cannot be combined with --checksum.
+      {{.Prompt}} tar cvf - . | {{.HelpName}} --content-md5 play/mybucket/backup.tar
 `,
 }
 
@@ -143,6 +159,8 @@ func pipe(ctx *cli.Context, targetURL string, encKeyDB map[string][]prefixSSEPai
 		multipartSize:    multipartSize,
 		multipartThreads: uint(multipartThreads),
 		concurrentStream: ctx.IsSet("concurrent"),
+		checksum:         strings.ToLower(ctx.String("checksum")),
+		md5:              ctx.Bool("md5"),
 	}
 
 	pg := newProgressBar(0)
@@ -164,6 +182,17 @@ func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code.
 	}
+	if checksum := ctx.String("checksum"); checksum != "" {
+		if !strings.EqualFold(checksum, "crc32c") {
+			fatalIf(errInvalidArgument().Trace(checksum), "Invalid `--checksum` value, expected 'crc32c'.")
+		}
+		if _, _, hostCfg, _ := expandAlias(ctx.Args().Get(0)); hostCfg == nil {
+			fatalIf(errInvalidArgument().Trace(checksum), "`--checksum` requires an object storage target.")
+		}
+		if ctx.Bool("md5") {
+			fatalIf(errInvalidArgument().Trace(checksum), "`--checksum` cannot be used with `--content-md5`.")
+		}
+	}
 }
 
 // mainPipe is the main entry point for pipe command.
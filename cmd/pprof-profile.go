@@ -0,0 +1,331 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// pprofProfile holds the per function values of one sample type of a
+// pprof profile, which is all needed to compare two profiles.
+type pprofProfile struct {
+	sampleType string
+	unit       string
+	total      int64
+	// flat is the value spent in the function itself and cum
+	// the value spent in the function and its callees.
+	flat map[string]int64
+	cum  map[string]int64
+}
+
+// Field numbers of the pprof protobuf messages, see
+// https://github.com/google/pprof/blob/main/proto/profile.proto
+const (
+	pprofProfileSampleType        protowire.Number = 1
+	pprofProfileSample            protowire.Number = 2
+	pprofProfileLocation          protowire.Number = 4
+	pprofProfileFunction          protowire.Number = 5
+	pprofProfileStringTable       protowire.Number = 6
+	pprofProfileDefaultSampleType protowire.Number = 14
+
+	pprofValueTypeType = 1
+	pprofValueTypeUnit = 2
+
+	pprofSampleLocationID = 1
+	pprofSampleValue      = 2
+
+	pprofLocationID   = 1
+	pprofLocationLine = 4
+
+	pprofLineFunctionID = 1
+
+	pprofFunctionID   = 1
+	pprofFunctionName = 2
+)
+
+// forEachProtoField calls fn for every field of an encoded protobuf message,
+// v is set for varint fields and data for length delimited fields.
+func forEachProtoField(b []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v uint64
+		var data []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			data, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if e := fn(num, typ, v, data); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// appendProtoVarints appends the values of a repeated varint field,
+// which may be either packed or not.
+func appendProtoVarints(dst []uint64, typ protowire.Type, v uint64, data []byte) ([]uint64, error) {
+	if typ == protowire.VarintType {
+		return append(dst, v), nil
+	}
+	for len(data) > 0 {
+		x, n := protowire.ConsumeVarint(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		dst = append(dst, x)
+		data = data[n:]
+	}
+	return dst, nil
+}
+
+type pprofSample struct {
+	locationIDs []uint64
+	values      []uint64
+}
+
+// parsePprofProfile decodes a gzipped or plain pprof profile and computes
+// the flat and cumulative values of its default sample type.
+func parsePprofProfile(data []byte) (*pprofProfile, error) {
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, e := gzip.NewReader(bytes.NewReader(data))
+		if e != nil {
+			return nil, e
+		}
+		if data, e = io.ReadAll(gz); e != nil {
+			return nil, e
+		}
+	}
+
+	var (
+		sampleTypes       [][2]uint64
+		samples           []pprofSample
+		strs              []string
+		defaultSampleType uint64
+		// function ids of every location, the first being the leaf.
+		locations = make(map[uint64][]uint64)
+		// string index of the name of every function.
+		functions = make(map[uint64]uint64)
+	)
+
+	e := forEachProtoField(data, func(num protowire.Number, typ protowire.Type, v uint64, msg []byte) error {
+		switch num {
+		case pprofProfileSampleType:
+			var vt [2]uint64
+			e := forEachProtoField(msg, func(num protowire.Number, typ protowire.Type, v uint64, _ []byte) error {
+				switch num {
+				case pprofValueTypeType:
+					vt[0] = v
+				case pprofValueTypeUnit:
+					vt[1] = v
+				}
+				return nil
+			})
+			sampleTypes = append(sampleTypes, vt)
+			return e
+		case pprofProfileSample:
+			var s pprofSample
+			e := forEachProtoField(msg, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) (e error) {
+				switch num {
+				case pprofSampleLocationID:
+					s.locationIDs, e = appendProtoVarints(s.locationIDs, typ, v, data)
+				case pprofSampleValue:
+					s.values, e = appendProtoVarints(s.values, typ, v, data)
+				}
+				return e
+			})
+			samples = append(samples, s)
+			return e
+		case pprofProfileLocation:
+			var id uint64
+			var funcs []uint64
+			e := forEachProtoField(msg, func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error {
+				switch num {
+				case pprofLocationID:
+					id = v
+				case pprofLocationLine:
+					return forEachProtoField(data, func(num protowire.Number, typ protowire.Type, v uint64, _ []byte) error {
+						if num == pprofLineFunctionID {
+							funcs = append(funcs, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			locations[id] = funcs
+			return e
+		case pprofProfileFunction:
+			var id, name uint64
+			e := forEachProtoField(msg, func(num protowire.Number, typ protowire.Type, v uint64, _ []byte) error {
+				switch num {
+				case pprofFunctionID:
+					id = v
+				case pprofFunctionName:
+					name = v
+				}
+				return nil
+			})
+			functions[id] = name
+			return e
+		case pprofProfileStringTable:
+			strs = append(strs, string(msg))
+		case pprofProfileDefaultSampleType:
+			defaultSampleType = v
+		}
+		return nil
+	})
+	if e != nil {
+		return nil, e
+	}
+	if len(sampleTypes) == 0 {
+		return nil, errors.New("profile has no sample types")
+	}
+
+	str := func(i uint64) string {
+		if i < uint64(len(strs)) {
+			return strs[i]
+		}
+		return ""
+	}
+
+	// Same as pprof, use the default sample type when set and the last one otherwise.
+	idx := len(sampleTypes) - 1
+	if defaultSampleType != 0 {
+		for i, st := range sampleTypes {
+			if st[0] == defaultSampleType {
+				idx = i
+			}
+		}
+	}
+
+	p := &pprofProfile{
+		sampleType: str(sampleTypes[idx][0]),
+		unit:       str(sampleTypes[idx][1]),
+		flat:       make(map[string]int64),
+		cum:        make(map[string]int64),
+	}
+	for _, s := range samples {
+		if idx >= len(s.values) {
+			continue
+		}
+		v := int64(s.values[idx])
+		p.total += v
+		seen := make(map[string]struct{})
+		for i, locID := range s.locationIDs {
+			names := []string{"<unknown>"}
+			if funcs := locations[locID]; len(funcs) > 0 {
+				names = names[:0]
+				for _, fid := range funcs {
+					names = append(names, str(functions[fid]))
+				}
+			}
+			for j, name := range names {
+				if i == 0 && j == 0 {
+					p.flat[name] += v
+				}
+				if _, ok := seen[name]; !ok {
+					seen[name] = struct{}{}
+					p.cum[name] += v
+				}
+			}
+		}
+	}
+	return p, nil
+}
+
+// merge adds the values of another profile of the same sample type.
+func (p *pprofProfile) merge(o *pprofProfile) {
+	p.total += o.total
+	for name, v := range o.flat {
+		p.flat[name] += v
+	}
+	for name, v := range o.cum {
+		p.cum[name] += v
+	}
+}
+
+// pprofFunctionDelta is the change of the values of a function between two profiles.
+type pprofFunctionDelta struct {
+	Function string `json:"function"`
+	Flat     int64  `json:"flat"`
+	Cum      int64  `json:"cum"`
+}
+
+// diffPprofProfiles returns the functions whose values changed from base to
+// cur, largest flat change first, same as 'pprof -top -diff_base'. When top
+// is positive only the first top functions are returned.
+func diffPprofProfiles(base, cur *pprofProfile, top int) []pprofFunctionDelta {
+	names := make(map[string]struct{})
+	for _, p := range []*pprofProfile{base, cur} {
+		for name := range p.cum {
+			names[name] = struct{}{}
+		}
+	}
+
+	var deltas []pprofFunctionDelta
+	for name := range names {
+		d := pprofFunctionDelta{
+			Function: name,
+			Flat:     cur.flat[name] - base.flat[name],
+			Cum:      cur.cum[name] - base.cum[name],
+		}
+		if d.Flat != 0 || d.Cum != 0 {
+			deltas = append(deltas, d)
+		}
+	}
+
+	abs := func(v int64) int64 {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if a, b := abs(deltas[i].Flat), abs(deltas[j].Flat); a != b {
+			return a > b
+		}
+		if a, b := abs(deltas[i].Cum), abs(deltas[j].Cum); a != b {
+			return a > b
+		}
+		return deltas[i].Function < deltas[j].Function
+	})
+	if top > 0 && len(deltas) > top {
+		deltas = deltas[:top]
+	}
+	return deltas
+}
@@ -91,6 +91,39 @@ func newProgressBar(total int64) *progressBar {
 	return &progressBar{ProgressBar: bar}
 }
 
+// Progress styles accepted by --progress.
+const (
+	progressStyleBar   = "bar"
+	progressStylePlain = "plain"
+	progressStyleNone  = "none"
+)
+
+// newProgressReaderWithStyle - instantiate the progress reporting of
+// the requested style, an empty style selects the progress bar when
+// the output is a terminal and plain progress lines otherwise. Output
+// without a terminal turns on globalQuiet, hence quiet tells whether
+// it was explicitly requested.
+func newProgressReaderWithStyle(style string, quiet bool, total int64) ProgressReader {
+	if quiet || globalJSON {
+		return newAccounter(total)
+	}
+	if style == "" {
+		style = progressStyleBar
+		if globalQuiet || !isTerminal() {
+			style = progressStylePlain
+		}
+	}
+	switch style {
+	case progressStylePlain:
+		return newPlainProgress(total)
+	case progressStyleBar:
+		if !globalQuiet {
+			return newProgressBar(total)
+		}
+	}
+	return newAccounter(total)
+}
+
 // Set caption.
 func (p *progressBar) SetCaption(caption string) *progressBar {
 	caption = fixateBarCaption(caption, getFixedWidth(p.ProgressBar.GetWidth(), 18))
@@ -20,6 +20,7 @@ package cmd
 import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
@@ -71,6 +72,10 @@ func mainQuotaInfo(ctx *cli.Context) error {
 	_, targetURL := url2Alias(args[0])
 	qCfg, e := client.GetBucketQuota(globalContext, targetURL)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to get bucket quota")
+	if qCfg.Type == "" && qCfg.Quota > 0 {
+		// Quotas set by older releases have no type, they are hard quotas.
+		qCfg.Type = madmin.HardQuota
+	}
 	printMsg(quotaMessage{
 		op:        ctx.Command.Name,
 		Bucket:    targetURL,
@@ -18,7 +18,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -32,10 +34,19 @@ import (
 var quotaSetFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "size",
-		Usage: "set a hard quota, disallowing writes after quota is reached",
+		Usage: "set the quota size",
+	},
+	cli.StringFlag{
+		Name:  "type",
+		Value: string(madmin.HardQuota),
+		Usage: "'hard' disallows writes after the quota is reached, 'fifo' removes the oldest objects when the quota is exceeded",
 	},
 }
 
+// fifoQuota is the quota type removing the oldest objects of a bucket
+// exceeding its quota, supported by some MinIO server releases only.
+const fifoQuota madmin.QuotaType = "fifo"
+
 var quotaSetCmd = cli.Command{
 	Name:         "set",
 	Usage:        "set bucket quota",
@@ -47,7 +58,7 @@ var quotaSetCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET [--size QUOTA]
+  {{.HelpName}} TARGET --size QUOTA [--type hard|fifo]
 
 QUOTA
   quota accepts human-readable case-insensitive number
@@ -62,6 +73,10 @@ FLAGS:
 EXAMPLES:
   1. Set hard quota of 1gb for a bucket "mybucket" on MinIO.
      {{.Prompt}} {{.HelpName}} myminio/mybucket --size 1GB
+
+  2. Set a FIFO quota of 1TiB for a bucket "logs" on MinIO, removing its oldest objects when it is exceeded.
+     WARNING: objects are deleted automatically, they cannot be recovered.
+     {{.Prompt}} {{.HelpName}} myminio/logs --size 1TiB --type fifo
 `,
 }
 
@@ -78,7 +93,7 @@ func (q quotaMessage) String() string {
 	switch q.op {
 	case "set":
 		return console.Colorize("QuotaMessage",
-			fmt.Sprintf("Successfully set bucket quota of %s on `%s`", humanize.IBytes(q.Quota), q.Bucket))
+			fmt.Sprintf("Successfully set bucket %s quota of %s on `%s`", q.QuotaType, humanize.IBytes(q.Quota), q.Bucket))
 	case "clear":
 		return console.Colorize("QuotaMessage",
 			fmt.Sprintf("Successfully cleared bucket quota configured on `%s`", q.Bucket))
@@ -100,6 +115,22 @@ func checkQuotaSetSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if qType := madmin.QuotaType(ctx.String("type")); qType != madmin.HardQuota && qType != fifoQuota {
+		fatalIf(errInvalidArgument().Trace(ctx.String("type")), "Invalid quota type, expected 'hard' or 'fifo'.")
+	}
+}
+
+// parseQuotaSize parses a human readable quota size, a quota must not
+// be empty, clear it with 'mc quota clear' instead.
+func parseQuotaSize(s string) (uint64, *probe.Error) {
+	quota, e := humanize.ParseBytes(s)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(s)
+	}
+	if quota == 0 {
+		return 0, probe.NewError(errors.New("quota must be larger than zero, use 'mc quota clear' to remove it")).Trace(s)
+	}
+	return quota, nil
 }
 
 // mainQuotaSet is the handler for "mc quota set" command.
@@ -122,15 +153,23 @@ func mainQuotaSet(ctx *cli.Context) error {
 		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
 			"--size flag needs to be set.")
 	}
-	qType := madmin.HardQuota
-	quotaStr := ctx.String("size")
-	quota, e := humanize.ParseBytes(quotaStr)
-	fatalIf(probe.NewError(e).Trace(quotaStr), "Unable to parse quota")
+	qType := madmin.QuotaType(ctx.String("type"))
+	quota, err := parseQuotaSize(ctx.String("size"))
+	fatalIf(err, "Unable to parse quota")
+
+	if qType == fifoQuota && !globalJSON {
+		fmt.Fprintln(os.Stderr, warnText(fmt.Sprintf("WARNING: the oldest objects of `%s` will be DELETED automatically once it exceeds %s.",
+			targetURL, humanize.IBytes(quota))))
+	}
 
-	fatalIf(probe.NewError(client.SetBucketQuota(globalContext, targetURL, &madmin.BucketQuota{
+	e := client.SetBucketQuota(globalContext, targetURL, &madmin.BucketQuota{
 		Quota: quota,
 		Type:  qType,
-	})).Trace(args...), "Unable to set bucket quota")
+	})
+	if e != nil && qType == fifoQuota {
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to set bucket quota, the server may not support FIFO quotas")
+	}
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to set bucket quota")
 
 	printMsg(quotaMessage{
 		op:        ctx.Command.Name,
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestParseQuotaSize(t *testing.T) {
+	testCases := []struct {
+		size    string
+		quota   uint64
+		success bool
+	}{
+		{"1TiB", 1 << 40, true},
+		{"1gb", 1000 * 1000 * 1000, true},
+		{"512", 512, true},
+		{"0", 0, false},
+		{"1 XB", 0, false},
+		{"", 0, false},
+	}
+	for i, tc := range testCases {
+		quota, err := parseQuotaSize(tc.size)
+		if tc.success != (err == nil) {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+			continue
+		}
+		if quota != tc.quota {
+			t.Errorf("Test %d: expected %d, got %d", i+1, tc.quota, quota)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestCheckReplicationPriority(t *testing.T) {
+	rcfg := replication.Config{Rules: []replication.Rule{
+		{ID: "rule1", Priority: 1},
+		{ID: "rule2", Priority: 2},
+	}}
+	testCases := []struct {
+		id       string
+		priority int
+		success  bool
+	}{
+		{"", 3, true},
+		{"rule3", 3, true},
+		{"", 1, false},
+		{"rule3", 2, false},
+		// A rule keeps its own priority.
+		{"rule2", 2, true},
+		{"rule2", 1, false},
+	}
+	for i, testCase := range testCases {
+		e := checkReplicationPriority(rcfg, testCase.id, testCase.priority)
+		if (e == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, e)
+		}
+	}
+}
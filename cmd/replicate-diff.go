@@ -22,11 +22,13 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -40,13 +42,22 @@ import (
 
 var replicateDiffFlags = []cli.Flag{
 	cli.StringFlag{
-		Name:  "arn",
+		Name:  "arn, target",
 		Usage: "unique role ARN",
 	},
 	cli.BoolFlag{
 		Name:  "verbose,v",
 		Usage: "include replicated versions",
 	},
+	cli.IntFlag{
+		Name:  "limit",
+		Usage: "stop after listing this many object versions",
+	},
+	cli.IntFlag{
+		Name:  "concurrency",
+		Usage: "number of concurrent requests looking up the size of the object versions",
+		Value: defaultReplicateDiffConcurrency,
+	},
 }
 
 var replicateDiffCmd = cli.Command{
@@ -71,6 +82,9 @@ EXAMPLES:
 
   2. Show unreplicated objects on "myminio" alias for objects in prefix "path/to/prefix" of "mybucket" for all targets.
      {{.Prompt}} {{.HelpName}} myminio/mybucket/path/to/prefix
+
+  3. Show the first 1000 object versions of "mybucket" pending or failed replication to a remote target, in JSON.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --target <remote-arn> --limit 1000 --json
 `,
 }
 
@@ -79,11 +93,24 @@ func checkReplicateDiffSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.IsSet("limit") && ctx.Int("limit") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "`--limit` must be a positive number.")
+	}
+	if ctx.Int("concurrency") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "`--concurrency` must be a positive number.")
+	}
 }
 
+// defaultReplicateDiffConcurrency is the default number of concurrent
+// requests looking up the size of the unreplicated object versions.
+const defaultReplicateDiffConcurrency = 8
+
 type replicateDiffMessage struct {
 	Op string `json:"op"`
 	madmin.DiffInfo
+	// Size of the object version, not set for delete markers or when
+	// the object version cannot be found anymore.
+	Size     *int64 `json:"size,omitempty"`
 	OpStatus string `json:"opStatus"`
 	arn      string `json:"-"`
 	verbose  bool   `json:"-"`
@@ -117,8 +144,12 @@ func (r replicateDiffMessage) toRow() (row table.Row) {
 	case op == "DEL":
 		replTimeStamp = ""
 	}
+	size := ""
+	if r.Size != nil {
+		size = humanize.IBytes(uint64(*r.Size))
+	}
 	return table.Row{
-		replTimeStamp, r.LastModified.Format(printDate), st, r.VersionID, op, r.Object,
+		replTimeStamp, r.LastModified.Format(printDate), st, r.VersionID, op, size, r.Object,
 	}
 }
 
@@ -148,11 +179,71 @@ func (r *replicateDiffMessage) replStatus() string {
 	return st
 }
 
+// replicateDiffSizes looks up the size of the object versions received on
+// diffCh with concurrency requests in flight, the messages are not sent in
+// the order of diffCh. Once limit versions are received, when limit is
+// positive, the listing is cancelled with cancelList. A listing error is
+// sent as the last message.
+func replicateDiffSizes(ctx context.Context, cancelList context.CancelFunc, diffCh <-chan madmin.DiffInfo, arn string, verbose bool, concurrency, limit int,
+	statSize func(ctx context.Context, di madmin.DiffInfo) (int64, error),
+) <-chan replicateDiffMessage {
+	jobs := make(chan madmin.DiffInfo)
+	go func() {
+		defer close(jobs)
+		var n int
+		for di := range diffCh {
+			if di.Object == "" && di.Err == nil {
+				continue
+			}
+			select {
+			case jobs <- di:
+			case <-ctx.Done():
+				return
+			}
+			if di.Err != nil {
+				return
+			}
+			if n++; limit > 0 && n >= limit {
+				cancelList()
+				return
+			}
+		}
+	}()
+
+	msgCh := make(chan replicateDiffMessage)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for di := range jobs {
+				msg := replicateDiffMessage{
+					Op:       "diff",
+					DiffInfo: di,
+					arn:      arn,
+					verbose:  verbose,
+				}
+				if di.Err == nil && !di.IsDeleteMarker {
+					if size, e := statSize(ctx, di); e == nil {
+						msg.Size = &size
+					}
+				}
+				msgCh <- msg
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(msgCh)
+	}()
+	return msgCh
+}
+
 const rowLimit = 10000
 
 type replicateDiffUI struct {
 	spinner  spinner.Model
-	sub      <-chan madmin.DiffInfo
+	sub      <-chan replicateDiffMessage
 	arn      string
 	quitting bool
 	table    table.Model
@@ -160,6 +251,7 @@ type replicateDiffUI struct {
 	help     help.Model
 	keymap   keyMap
 	count    int
+	err      error
 }
 type keyMap struct {
 	quit  key.Binding
@@ -189,7 +281,7 @@ func newKeyMap() keyMap {
 	}
 }
 
-func initReplicateDiffUI(arn string, diffCh <-chan madmin.DiffInfo) *replicateDiffUI {
+func initReplicateDiffUI(arn string, diffCh <-chan replicateDiffMessage) *replicateDiffUI {
 	s := spinner.New()
 	s.Spinner = spinner.Points
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -222,7 +314,7 @@ func (m *replicateDiffUI) Init() tea.Cmd {
 }
 
 // A command that waits for the activity on a channel.
-func waitForActivity(sub <-chan madmin.DiffInfo) tea.Cmd {
+func waitForActivity(sub <-chan replicateDiffMessage) tea.Cmd {
 	return func() tea.Msg {
 		msg := <-sub
 		return msg
@@ -236,6 +328,7 @@ func getDiffHeader() []table.Column {
 		{Title: "Status", Width: 9},
 		{Title: "VersionID", Width: 36},
 		{Title: "Op", Width: 3},
+		{Title: "Size", Width: 10},
 		{Title: "Object", Width: 60},
 	}
 }
@@ -281,15 +374,16 @@ func (m *replicateDiffUI) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.table.SetStyles(ts)
 		default:
 		}
-	case madmin.DiffInfo:
+	case replicateDiffMessage:
+		if msg.Err != nil {
+			m.err = msg.Err
+			m.quitting = true
+			return m, tea.Quit
+		}
 		if msg.Object != "" {
 			m.count++
 			if m.count <= rowLimit { // don't buffer more than 10k entries
-				rdif := replicateDiffMessage{
-					DiffInfo: msg,
-					arn:      m.arn,
-				}
-				m.rows = append(m.rows, rdif.toRow())
+				m.rows = append(m.rows, msg.toRow())
 			}
 			return m, waitForActivity(m.sub)
 		}
@@ -395,28 +489,44 @@ func mainReplicateDiff(cliCtx *cli.Context) error {
 	fatalIf(cerr, "Unable to initialize admin connection.")
 	verbose := cliCtx.Bool("verbose")
 	arn := cliCtx.String("arn")
-	diffCh := client.BucketReplicationDiff(ctx, bucket, madmin.ReplDiffOpts{
+	// The listing is cancelled alone once --limit versions are listed,
+	// the size of the listed versions is still looked up.
+	listCtx, cancelList := context.WithCancel(ctx)
+	defer cancelList()
+	diffCh := client.BucketReplicationDiff(listCtx, bucket, madmin.ReplDiffOpts{
 		Verbose: verbose,
 		ARN:     arn,
 		Prefix:  prefix,
 	})
+
+	alias, _ := url2Alias(aliasedURL)
+	statSize := func(ctx context.Context, di madmin.DiffInfo) (int64, error) {
+		clnt, err := newClient(alias + "/" + bucket + "/" + di.Object)
+		if err != nil {
+			return 0, err.ToGoError()
+		}
+		content, err := clnt.Stat(ctx, StatOptions{versionID: di.VersionID})
+		if err != nil {
+			return 0, err.ToGoError()
+		}
+		return content.Size, nil
+	}
+	msgCh := replicateDiffSizes(ctx, cancelList, diffCh, arn, verbose, cliCtx.Int("concurrency"), cliCtx.Int("limit"), statSize)
 	if globalJSON {
-		for di := range diffCh {
-			console.Println(replicateDiffMessage{
-				Op:       "diff",
-				DiffInfo: di,
-				arn:      arn,
-				verbose:  verbose,
-			}.JSON())
+		for msg := range msgCh {
+			fatalIf(probe.NewError(msg.Err).Trace(aliasedURL), "Unable to fetch replication diff")
+			console.Println(msg.JSON())
 		}
 		return nil
 	}
 
-	ui := tea.NewProgram(initReplicateDiffUI(arn, diffCh))
+	diffUI := initReplicateDiffUI(arn, msgCh)
+	ui := tea.NewProgram(diffUI)
 
 	if e := ui.Start(); e != nil {
 		cancel()
 		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to fetch replication diff")
 	}
+	fatalIf(probe.NewError(diffUI.err).Trace(aliasedURL), "Unable to fetch replication diff")
 	return nil
 }
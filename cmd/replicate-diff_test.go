@@ -0,0 +1,109 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestReplicateDiffSizes(t *testing.T) {
+	diffs := []madmin.DiffInfo{
+		{Object: "a", VersionID: "1"},
+		{Object: "b", VersionID: "2", IsDeleteMarker: true},
+		{Object: "c", VersionID: "3"},
+		{Object: "d", VersionID: "4"},
+		{Object: "e", VersionID: "5"},
+	}
+	statSize := func(ctx context.Context, di madmin.DiffInfo) (int64, error) {
+		if di.Object == "d" {
+			return 0, errors.New("not found")
+		}
+		return int64(len(di.Object) + len(di.VersionID)), nil
+	}
+	testCases := []struct {
+		limit     int
+		objects   []string
+		cancelled bool
+	}{
+		{0, []string{"a", "b", "c", "d", "e"}, false},
+		{3, []string{"a", "b", "c"}, true},
+		{5, []string{"a", "b", "c", "d", "e"}, true},
+	}
+	for i, testCase := range testCases {
+		diffCh := make(chan madmin.DiffInfo)
+		listCtx, cancelList := context.WithCancel(context.Background())
+		go func() {
+			defer close(diffCh)
+			for _, di := range diffs {
+				select {
+				case diffCh <- di:
+				case <-listCtx.Done():
+					return
+				}
+			}
+		}()
+		var objects []string
+		for msg := range replicateDiffSizes(context.Background(), cancelList, diffCh, "arn", false, 2, testCase.limit, statSize) {
+			objects = append(objects, msg.Object)
+			switch msg.Object {
+			case "b", "d":
+				if msg.Size != nil {
+					t.Errorf("Test %d: expected no size for %s, got %d", i+1, msg.Object, *msg.Size)
+				}
+			default:
+				if msg.Size == nil || *msg.Size != 2 {
+					t.Errorf("Test %d: expected size 2 for %s, got %v", i+1, msg.Object, msg.Size)
+				}
+			}
+		}
+		sort.Strings(objects)
+		if len(objects) != len(testCase.objects) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.objects, objects)
+		}
+		for j := range objects {
+			if objects[j] != testCase.objects[j] {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.objects, objects)
+			}
+		}
+		if cancelled := listCtx.Err() != nil; cancelled != testCase.cancelled {
+			t.Errorf("Test %d: expected the listing cancelled %v, got %v", i+1, testCase.cancelled, cancelled)
+		}
+		cancelList()
+	}
+}
+
+func TestReplicateDiffSizesError(t *testing.T) {
+	diffCh := make(chan madmin.DiffInfo, 3)
+	diffCh <- madmin.DiffInfo{Object: "a"}
+	diffCh <- madmin.DiffInfo{Err: errors.New("access denied")}
+	close(diffCh)
+
+	statSize := func(ctx context.Context, di madmin.DiffInfo) (int64, error) { return 1, nil }
+	var msgs []replicateDiffMessage
+	for msg := range replicateDiffSizes(context.Background(), func() {}, diffCh, "", false, 1, 0, statSize) {
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) != 2 || msgs[1].Err == nil || msgs[1].Size != nil {
+		t.Fatalf("expected the listing error as the last message, got %+v", msgs)
+	}
+}
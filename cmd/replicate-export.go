@@ -23,23 +23,31 @@ import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/console"
 )
 
+var replicateExportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "remote-targets",
+		Usage: "include the remote targets of the rules, with their credentials, for 'mc replicate import' to recreate them",
+	},
+}
+
 var replicateExportCmd = cli.Command{
 	Name:         "export",
 	Usage:        "export server side replication configuration",
 	Action:       mainReplicateExport,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(globalFlags, replicateExportFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -50,6 +58,10 @@ EXAMPLES:
 
   2. Export replication configuration on bucket "mybucket" for alias "myminio" to '/data/replicate/config'.
      {{.Prompt}} {{.HelpName}} myminio/mybucket > /data/replicate/config
+
+  3. Export replication configuration on bucket "mybucket" along with its remote targets, to import it on another cluster.
+     The file holds the secret keys of the remote targets, keep it safe.
+     {{.Prompt}} {{.HelpName}} --remote-targets myminio/mybucket > /data/replicate/config
 `,
 }
 
@@ -61,10 +73,11 @@ func checkReplicateExportSyntax(ctx *cli.Context) {
 }
 
 type replicateExportMessage struct {
-	Op                string             `json:"op"`
-	Status            string             `json:"status"`
-	URL               string             `json:"url"`
-	ReplicationConfig replication.Config `json:"config"`
+	Op                string                `json:"op"`
+	Status            string                `json:"status"`
+	URL               string                `json:"url"`
+	ReplicationConfig replication.Config    `json:"config"`
+	RemoteTargets     []madmin.BucketTarget `json:"targets,omitempty"`
 }
 
 func (r replicateExportMessage) JSON() string {
@@ -78,11 +91,39 @@ func (r replicateExportMessage) String() string {
 	if r.ReplicationConfig.Empty() {
 		return console.Colorize("ReplicateNMessage", "No replication configuration found for "+r.URL+".")
 	}
-	msgBytes, e := json.MarshalIndent(r.ReplicationConfig, "", " ")
+	var v interface{} = r.ReplicationConfig
+	if len(r.RemoteTargets) > 0 {
+		v = replicateManifest{
+			Config:  &r.ReplicationConfig,
+			Targets: r.RemoteTargets,
+		}
+	}
+	msgBytes, e := json.MarshalIndent(v, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal replication configuration")
 	return string(msgBytes)
 }
 
+// replicateManifest is the replication configuration of a bucket along
+// with the remote targets its rules replicate to.
+type replicateManifest struct {
+	Config  *replication.Config   `json:"config"`
+	Targets []madmin.BucketTarget `json:"targets"`
+}
+
+// replicationARNs returns the remote target ARNs used by the rules.
+func replicationARNs(cfg replication.Config) map[string]bool {
+	arns := make(map[string]bool)
+	if cfg.Role != "" {
+		arns[cfg.Role] = true
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Destination.Bucket != "" {
+			arns[rule.Destination.Bucket] = true
+		}
+	}
+	return arns
+}
+
 func mainReplicateExport(cliCtx *cli.Context) error {
 	ctx, cancelReplicateExport := context.WithCancel(globalContext)
 	defer cancelReplicateExport()
@@ -100,11 +141,30 @@ func mainReplicateExport(cliCtx *cli.Context) error {
 	fatalIf(err, "Unable to initialize connection.")
 	rCfg, err := client.GetReplication(ctx)
 	fatalIf(err.Trace(args...), "Unable to get replication configuration")
+
+	var targets []madmin.BucketTarget
+	if cliCtx.Bool("remote-targets") && !rCfg.Empty() {
+		admClient, err := newAdminClient(aliasedURL)
+		fatalIf(err, "Unable to initialize admin connection.")
+
+		_, sourceBucket := url2Alias(aliasedURL)
+		remoteTargets, e := admClient.ListRemoteTargets(ctx, sourceBucket, string(madmin.ReplicationService))
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to list remote targets.")
+
+		arns := replicationARNs(rCfg)
+		for _, t := range remoteTargets {
+			if arns[t.Arn] {
+				targets = append(targets, t)
+			}
+		}
+	}
+
 	printMsg(replicateExportMessage{
 		Op:                cliCtx.Command.Name,
 		Status:            "success",
 		URL:               aliasedURL,
 		ReplicationConfig: rCfg,
+		RemoteTargets:     targets,
 	})
 	return nil
 }
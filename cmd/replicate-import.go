@@ -19,11 +19,15 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/console"
@@ -51,6 +55,10 @@ EXAMPLES:
 
   2. Import replication configuration for bucket "mybucket" on alias "myminio" from STDIN.
      {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  3. Import replication configuration exported with '--remote-targets' on bucket "newbucket" of another cluster.
+     The remote targets are created on "newbucket" and the rules are updated to use their ARNs.
+     {{.Prompt}} {{.HelpName}} otherminio/newbucket < '/data/replication/config'
 `,
 }
 
@@ -79,18 +87,125 @@ func (r replicateImportMessage) String() string {
 	return console.Colorize("replicateImportMessage", "Replication configuration successfully set on `"+r.URL+"`.")
 }
 
-// readReplicationConfig read from stdin, returns XML.
-func readReplicationConfig() (*replication.Config, *probe.Error) {
+// replicateImportTargetMessage reports the ARN of a remote target on the
+// import bucket, replacing the ARN it had on the exported bucket.
+type replicateImportTargetMessage struct {
+	Status   string `json:"status"`
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	OldARN   string `json:"oldARN"`
+	NewARN   string `json:"newARN"`
+	Existing bool   `json:"existing"`
+}
+
+func (r replicateImportTargetMessage) JSON() string {
+	r.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func (r replicateImportTargetMessage) String() string {
+	action := "created"
+	if r.Existing {
+		action = "existing"
+	}
+	return console.Colorize("replicateImportMessage", fmt.Sprintf("Remote target `%s/%s` (%s): %s -> %s",
+		r.Endpoint, r.Bucket, action, r.OldARN, r.NewARN))
+}
+
+// readReplicationConfig read from stdin, returns XML. The input is either
+// a replication configuration or a manifest exported with --remote-targets.
+func readReplicationConfig() (*replication.Config, []madmin.BucketTarget, *probe.Error) {
 	// User is expected to enter the replication configuration in JSON format
+	data, e := io.ReadAll(os.Stdin)
+	if e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+
+	var manifest replicateManifest
+	if e = json.Unmarshal(data, &manifest); e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+	if manifest.Config != nil {
+		return manifest.Config, manifest.Targets, nil
+	}
+
 	cfg := replication.Config{}
+	if e = json.Unmarshal(data, &cfg); e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+	return &cfg, nil, nil
+}
 
-	// Consume json from STDIN
-	dec := json.NewDecoder(os.Stdin)
-	if e := dec.Decode(&cfg); e != nil {
-		return &cfg, probe.NewError(e)
+// findRemoteTarget returns the ARN of the remote target replicating to
+// the same remote bucket as t, if any.
+func findRemoteTarget(targets []madmin.BucketTarget, t madmin.BucketTarget) string {
+	for _, existing := range targets {
+		if existing.Endpoint == t.Endpoint && existing.TargetBucket == t.TargetBucket && existing.Path == t.Path {
+			return existing.Arn
+		}
 	}
+	return ""
+}
 
-	return &cfg, nil
+// remapReplicationARNs rewrites the remote target ARNs of the rules.
+func remapReplicationARNs(cfg *replication.Config, arns map[string]string) {
+	if arn, ok := arns[cfg.Role]; ok {
+		cfg.Role = arn
+	}
+	for i := range cfg.Rules {
+		if arn, ok := arns[cfg.Rules[i].Destination.Bucket]; ok {
+			cfg.Rules[i].Destination.Bucket = arn
+		}
+	}
+}
+
+// importRemoteTargets creates the remote targets used by the rules on
+// bucket, reusing the ones already replicating to the same remote bucket,
+// and returns the new ARN of each exported ARN.
+func importRemoteTargets(ctx context.Context, aliasedURL string, cfg *replication.Config, targets []madmin.BucketTarget) (map[string]string, *probe.Error) {
+	admClient, err := newAdminClient(aliasedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bucket := url2Alias(aliasedURL)
+	existing, e := admClient.ListRemoteTargets(ctx, bucket, string(madmin.ReplicationService))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	used := replicationARNs(*cfg)
+	arns := make(map[string]string)
+	for _, t := range targets {
+		if !used[t.Arn] {
+			continue
+		}
+		msg := replicateImportTargetMessage{
+			Endpoint: t.Endpoint,
+			Bucket:   t.TargetBucket,
+			OldARN:   t.Arn,
+		}
+		if arn := findRemoteTarget(existing, t); arn != "" {
+			msg.NewARN, msg.Existing = arn, true
+		} else {
+			if t.Credentials == nil || t.Credentials.SecretKey == "" {
+				return nil, probe.NewError(fmt.Errorf("remote target %s has no secret key", t.Arn))
+			}
+			t.SourceBucket = bucket
+			t.Arn = ""
+			t.ResetID = ""
+			t.ResetBeforeDate = time.Time{}
+			msg.NewARN, e = admClient.SetRemoteTarget(ctx, bucket, &t)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(msg.OldARN)
+			}
+		}
+		arns[msg.OldARN] = msg.NewARN
+		printMsg(msg)
+	}
+	return arns, nil
 }
 
 func mainReplicateImport(cliCtx *cli.Context) error {
@@ -106,9 +221,15 @@ func mainReplicateImport(cliCtx *cli.Context) error {
 	// Create a new Client
 	client, err := newClient(aliasedURL)
 	fatalIf(err, "Unable to initialize connection.")
-	rCfg, err := readReplicationConfig()
+	rCfg, targets, err := readReplicationConfig()
 	fatalIf(err.Trace(args...), "Unable to read replication configuration")
 
+	if len(targets) > 0 {
+		arns, err := importRemoteTargets(ctx, aliasedURL, rCfg, targets)
+		fatalIf(err.Trace(aliasedURL), "Unable to create the remote targets")
+		remapReplicationARNs(rCfg, arns)
+	}
+
 	fatalIf(client.SetReplication(ctx, rCfg, replication.Options{Op: replication.ImportOption}).Trace(aliasedURL), "Unable to set replication configuration")
 	printMsg(replicateImportMessage{
 		Op:     cliCtx.Command.Name,
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestRemapReplicationARNs(t *testing.T) {
+	oldARN := "arn:minio:replication::old:remote"
+	cfg := replication.Config{
+		Rules: []replication.Rule{
+			{ID: "a", Destination: replication.Destination{Bucket: oldARN}},
+			{ID: "b", Destination: replication.Destination{Bucket: "arn:minio:replication::other:remote"}},
+		},
+	}
+	if arns := replicationARNs(cfg); len(arns) != 2 || !arns[oldARN] {
+		t.Fatalf("unexpected ARNs %v", arns)
+	}
+
+	remapReplicationARNs(&cfg, map[string]string{oldARN: "arn:minio:replication::new:remote"})
+	if got := cfg.Rules[0].Destination.Bucket; got != "arn:minio:replication::new:remote" {
+		t.Errorf("expected the ARN of rule a to be remapped, got %s", got)
+	}
+	if got := cfg.Rules[1].Destination.Bucket; got != "arn:minio:replication::other:remote" {
+		t.Errorf("expected the ARN of rule b to be kept, got %s", got)
+	}
+}
+
+func TestFindRemoteTarget(t *testing.T) {
+	existing := []madmin.BucketTarget{
+		{Endpoint: "site2:9000", TargetBucket: "photos", Arn: "arn:1"},
+		{Endpoint: "site3:9000", TargetBucket: "photos", Arn: "arn:2"},
+	}
+	if arn := findRemoteTarget(existing, madmin.BucketTarget{Endpoint: "site3:9000", TargetBucket: "photos"}); arn != "arn:2" {
+		t.Errorf("expected arn:2, got %q", arn)
+	}
+	if arn := findRemoteTarget(existing, madmin.BucketTarget{Endpoint: "site3:9000", TargetBucket: "videos"}); arn != "" {
+		t.Errorf("expected no remote target, got %q", arn)
+	}
+}
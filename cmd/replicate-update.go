@@ -332,7 +332,7 @@ func mainReplicateUpdate(cliCtx *cli.Context) error {
 				dmReplicate = enableStatus
 			case "delete":
 				vDeleteReplicate = enableStatus
-			case "metadata-sync", "replica-metadata-sync":
+			case "metadata", "metadata-sync", "replica-metadata-sync":
 				replicasync = enableStatus
 			case "existing-objects":
 				existingReplState = enableStatus
@@ -358,6 +358,8 @@ func mainReplicateUpdate(cliCtx *cli.Context) error {
 	}
 
 	if cliCtx.IsSet("priority") {
+		e = checkReplicationPriority(rcfg, opts.ID, cliCtx.Int("priority"))
+		fatalIf(probe.NewError(e).Trace(args...), "invalid --priority value, use a unique priority for each rule")
 		opts.Priority = strconv.Itoa(cliCtx.Int("priority"))
 	}
 	if cliCtx.IsSet("replicate") {
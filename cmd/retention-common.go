@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	json "github.com/minio/colorjson"
@@ -170,6 +171,23 @@ func parseRetentionValidity(validityStr string) (uint64, minio.ValidityUnit, *pr
 	return validity, unit, nil
 }
 
+// parseRetentionModeDuration parses a retention in the MODE:DURATION
+// form, e.g. 'governance:30d' or 'COMPLIANCE:1y'.
+func parseRetentionModeDuration(retention string) (mode minio.RetentionMode, duration string, err *probe.Error) {
+	modeStr, duration, ok := strings.Cut(retention, ":")
+	if !ok || duration == "" {
+		return "", "", probe.NewError(fmt.Errorf("retention '%s' must be of the form MODE:DURATION, e.g. governance:30d", retention))
+	}
+	mode = minio.RetentionMode(strings.ToUpper(modeStr))
+	if !mode.IsValid() {
+		return "", "", probe.NewError(fmt.Errorf("invalid retention mode '%s', expected governance or compliance", modeStr))
+	}
+	if _, _, err = parseRetentionValidity(duration); err != nil {
+		return "", "", err.Trace(retention)
+	}
+	return mode, duration, nil
+}
+
 func fatalIfBucketLockNotEnabled(ctx context.Context, aliasedURL string) {
 	enabled, err := getBucketLockStatus(ctx, aliasedURL)
 	fatalIf(err.Trace(), "Unable to get bucket lock configuration from `%s`", aliasedURL)
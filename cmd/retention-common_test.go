@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestParseRetentionModeDuration(t *testing.T) {
+	testCases := []struct {
+		retention string
+		mode      minio.RetentionMode
+		duration  string
+		success   bool
+	}{
+		{"governance:30d", minio.Governance, "30d", true},
+		{"COMPLIANCE:1y", minio.Compliance, "1y", true},
+		{"governance", "", "", false},
+		{"governance:", "", "", false},
+		{"locked:30d", "", "", false},
+		{"governance:30x", "", "", false},
+	}
+
+	for i, testCase := range testCases {
+		mode, duration, err := parseRetentionModeDuration(testCase.retention)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if mode != testCase.mode || duration != testCase.duration {
+			t.Fatalf("Test %d: expected %s:%s, got %s:%s", i+1, testCase.mode, testCase.duration, mode, duration)
+		}
+	}
+}
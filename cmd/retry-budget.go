@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// globalRetryBudget caps the time spent retrying failed requests
+// during a cp or mirror run, nil without --retry-budget.
+var globalRetryBudget *retryBudget
+
+// errRetryBudgetExhausted is returned instead of sending a retry once the
+// budget is spent, minio-go does not retry an expired deadline.
+var errRetryBudgetExhausted = fmt.Errorf("retry budget exhausted: %w", context.DeadlineExceeded)
+
+// retryableHTTPStatus are the HTTP status codes retried by minio-go.
+var retryableHTTPStatus = map[int]struct{}{
+	http.StatusRequestTimeout:      {},
+	http.StatusTooManyRequests:     {},
+	499:                            {}, // client closed request, nginx
+	http.StatusInternalServerError: {},
+	http.StatusBadGateway:          {},
+	http.StatusServiceUnavailable:  {},
+	http.StatusGatewayTimeout:      {},
+	520:                            {}, // unknown error, Cloudflare
+}
+
+// retryBudget accounts the time spent on retries by all the requests of a
+// job: from the failure of a request until its retry completes, backoff
+// included. The retries themselves are done by minio-go for each failed
+// request, this only refuses them once the budget is spent.
+type retryBudget struct {
+	budget time.Duration
+
+	used    int64 // nanoseconds
+	refused int64
+
+	mutex sync.Mutex
+	// last failure time of the requests which may be retried
+	failedAt map[string]time.Time
+}
+
+func newRetryBudget(budget time.Duration) *retryBudget {
+	return &retryBudget{
+		budget:   budget,
+		failedAt: make(map[string]time.Time),
+	}
+}
+
+func (b *retryBudget) exhausted() bool {
+	return time.Duration(atomic.LoadInt64(&b.used)) >= b.budget
+}
+
+// retryBudgetTransport accounts the retries sent through a transport.
+type retryBudgetTransport struct {
+	transport http.RoundTripper
+	budget    *retryBudget
+}
+
+func (t retryBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := t.budget
+	// A retry is the same request sent again after a failure.
+	key := req.Method + " " + req.URL.String()
+	b.mutex.Lock()
+	failedAt, retry := b.failedAt[key]
+	if retry && b.exhausted() {
+		delete(b.failedAt, key)
+		b.mutex.Unlock()
+		atomic.AddInt64(&b.refused, 1)
+		return nil, errRetryBudgetExhausted
+	}
+	b.mutex.Unlock()
+
+	resp, e := t.transport.RoundTrip(req)
+	now := time.Now()
+	if retry {
+		atomic.AddInt64(&b.used, int64(now.Sub(failedAt)))
+	}
+
+	failed := e != nil
+	if resp != nil {
+		_, failed = retryableHTTPStatus[resp.StatusCode]
+	}
+	b.mutex.Lock()
+	if failed {
+		b.failedAt[key] = now
+	} else {
+		delete(b.failedAt, key)
+	}
+	b.mutex.Unlock()
+	return resp, e
+}
+
+// message returns the summary of the budget consumption.
+func (b *retryBudget) message() retryBudgetMessage {
+	used := time.Duration(atomic.LoadInt64(&b.used))
+	refused := atomic.LoadInt64(&b.refused)
+	return retryBudgetMessage{
+		Status:  "success",
+		Budget:  b.budget,
+		Used:    used,
+		Refused: refused,
+		Limited: refused > 0,
+	}
+}
+
+// retryBudgetMessage container for the consumption of --retry-budget.
+type retryBudgetMessage struct {
+	Status  string        `json:"status,omitempty"`
+	Budget  time.Duration `json:"budget"`
+	Used    time.Duration `json:"used"`
+	Refused int64         `json:"refusedRetries"`
+	Limited bool          `json:"limited"`
+}
+
+func (r retryBudgetMessage) String() string {
+	msg := fmt.Sprintf("Spent %s of the %s retry budget.", r.Used.Round(time.Millisecond), r.Budget)
+	if r.Limited {
+		msg += fmt.Sprintf(" The budget was exhausted, %d retries were not attempted.", r.Refused)
+	}
+	return msg
+}
+
+func (r retryBudgetMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryBudgetTransport(t *testing.T) {
+	// Objects starting with 'fail' fail on their first attempt.
+	attempts := make(map[string]int)
+	budget := newRetryBudget(20 * time.Millisecond)
+	transport := retryBudgetTransport{
+		budget: budget,
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			attempts[req.URL.Path]++
+			if strings.HasPrefix(req.URL.Path, "/bucket/fail") && attempts[req.URL.Path] == 1 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+	}
+	send := func(object string) error {
+		req, e := http.NewRequest(http.MethodPut, "https://play.min.io/bucket/"+object, nil)
+		if e != nil {
+			t.Fatal(e)
+		}
+		_, e = transport.RoundTrip(req)
+		return e
+	}
+
+	// Sending the same request again after a success is not a retry.
+	for i := 0; i < 2; i++ {
+		if e := send("ok"); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if msg := budget.message(); msg.Used != 0 || msg.Limited {
+		t.Fatalf("Expected no retry, got %+v", msg)
+	}
+
+	// The backoff before a retry is part of its time.
+	if e := send("fail1"); e != nil {
+		t.Fatal(e)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if e := send("fail1"); e != nil {
+		t.Fatal(e)
+	}
+	if msg := budget.message(); msg.Used < 30*time.Millisecond || msg.Limited {
+		t.Fatalf("Expected at least 30ms used without limit, got %+v", msg)
+	}
+
+	// The budget is spent, the next retry is refused and not retried by minio-go.
+	if e := send("fail2"); e != nil {
+		t.Fatal(e)
+	}
+	e := send("fail2")
+	if !errors.Is(e, errRetryBudgetExhausted) || !errors.Is(e, context.DeadlineExceeded) {
+		t.Fatalf("Expected the retry to be refused, got %v", e)
+	}
+	if attempts["/bucket/fail2"] != 1 {
+		t.Errorf("Expected 1 attempt of the refused retry, got %d", attempts["/bucket/fail2"])
+	}
+	if msg := budget.message(); !msg.Limited || msg.Refused != 1 {
+		t.Errorf("Expected 1 refused retry, got %+v", msg)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -78,7 +80,7 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "stdin",
-			Usage: "read object names from STDIN",
+			Usage: "read object names from STDIN, use '-' as TARGET to do the same",
 		},
 		cli.StringFlag{
 			Name:  "older-than",
@@ -96,6 +98,10 @@ var (
 			Name:  "non-current",
 			Usage: "remove object(s) versions that are non-current",
 		},
+		cli.BoolFlag{
+			Name:  "include-delete-markers",
+			Usage: "remove delete markers too with --non-current",
+		},
 		cli.BoolFlag{
 			Name:   "purge",
 			Usage:  "attempt a prefix purge, requires confirmation please use with caution - only works with '--force'",
@@ -111,7 +117,7 @@ var rmCmd = cli.Command{
 	Action:       mainRm,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(rmFlags, ioFlags...), listFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -143,30 +149,37 @@ EXAMPLES:
   06. Remove all objects read from STDIN.
       {{.Prompt}} {{.HelpName}} --force --stdin
 
-  07. Remove all objects recursively from Amazon S3 cloud storage.
+  07. Remove all objects listed in a file, one object per line.
+      {{.Prompt}} {{.HelpName}} --force - < objects-to-remove.txt
+
+  08. Remove all objects recursively from Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} --recursive --force --dangerous s3
 
-  08. Remove all objects older than '90' days recursively under all buckets.
+  09. Remove all objects older than '90' days recursively under all buckets.
       {{.Prompt}} {{.HelpName}} --recursive --dangerous --force --older-than 90d s3
 
-  09. Drop all incomplete uploads on the bucket 'jazz-songs'.
+  10. Drop all incomplete uploads on the bucket 'jazz-songs'.
       {{.Prompt}} {{.HelpName}} --incomplete --recursive --force s3/jazz-songs/
 
-  10. Remove an encrypted object from Amazon S3 cloud storage.
+  11. Remove an encrypted object from Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} --encrypt-key "s3/sql-backups/=32byteslongsecretkeymustbegiven1" s3/sql-backups/1999/old-backup.tgz
 
-  11. Bypass object retention in governance mode and delete the object.
+  12. Bypass object retention in governance mode and delete the object.
       {{.Prompt}} {{.HelpName}} --bypass s3/pop-songs/
 
-  12. Remove a particular version ID.
+  13. Remove a particular version ID.
       {{.Prompt}} {{.HelpName}} s3/docs/money.xls --version-id "f20f3792-4bd4-4288-8d3c-b9d05b3b62f6"
 
-  13. Remove all object versions older than one year.
+  14. Remove all object versions older than one year.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --versions --rewind 365d
 
-  14. Perform a fake removal of object(s) versions that are non-current and older than 10 days. If top-level version is a delete 
-  marker, this will also be deleted when --non-current flag is specified.
+  15. Perform a fake removal of object(s) versions that are non-current and older than 10 days. The current version of an
+      object is never removed.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --older-than 10d --dry-run
+
+  16. Remove the non-current versions older than 30 days along with the delete markers. If the top-level version is a
+      delete marker, it is removed too and the latest remaining version becomes current.
+      {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --force --versions --non-current --include-delete-markers --older-than 30d
 `,
 }
 
@@ -206,10 +219,11 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	// Set command flags from context.
 	isForce := cliCtx.Bool("force")
 	isRecursive := cliCtx.Bool("recursive")
-	isStdin := cliCtx.Bool("stdin")
+	isStdin := cliCtx.Bool("stdin") || isRmStdinArg(cliCtx.Args())
 	isDangerous := cliCtx.Bool("dangerous")
 	isVersions := cliCtx.Bool("versions")
 	isNoncurrentVersion := cliCtx.Bool("non-current")
+	isWithDeleteMarkers := cliCtx.Bool("include-delete-markers")
 	isForceDel := cliCtx.Bool("purge")
 	versionID := cliCtx.String("version-id")
 	rewind := cliCtx.String("rewind")
@@ -225,6 +239,11 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 			"You cannot specify --non-current without --versions --recursive, please use --non-current --versions --recursive.")
 	}
 
+	if isWithDeleteMarkers && !isNoncurrentVersion {
+		fatalIf(errDummy().Trace(),
+			"You cannot specify --include-delete-markers without --non-current.")
+	}
+
 	if isForceDel && !isForce {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --purge without --force.")
@@ -236,6 +255,9 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	}
 
 	for _, url := range cliCtx.Args() {
+		if url == "-" {
+			continue
+		}
 		// clean path for aliases like s3/.
 		// Note: UNC path using / works properly in go 1.9.2 even though it breaks the UNC specification.
 		url = filepath.ToSlash(filepath.Clean(url))
@@ -272,6 +294,128 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 	}
 }
 
+// isRmStdinArg returns true if '-' is passed as a target, which
+// is a shorthand for --stdin.
+func isRmStdinArg(args cli.Args) bool {
+	for _, arg := range args {
+		if arg == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFromReader removes the objects named on each line of r. Consecutive
+// objects on the same alias are sent to a single remove call, so S3 backends
+// delete them in batches with multi-object delete. Failures are reported per
+// object and do not stop the removal of the remaining objects.
+func removeFromReader(ctx context.Context, r io.Reader, opts removeOpts) error {
+	var (
+		failed    bool
+		alias     string
+		contentCh chan *ClientContent
+		// reports whether the removal of a batch failed
+		doneCh chan bool
+	)
+
+	flush := func() {
+		if contentCh == nil {
+			return
+		}
+		close(contentCh)
+		if <-doneCh {
+			failed = true
+		}
+		contentCh = nil
+	}
+
+	start := func(targetAlias string) *probe.Error {
+		_, aliasURL, _ := mustExpandAlias(targetAlias)
+		clnt, err := newClientFromAlias(targetAlias, aliasURL)
+		if err != nil {
+			return err
+		}
+		alias = targetAlias
+		contentCh = make(chan *ClientContent)
+		doneCh = make(chan bool, 1)
+		resultCh := clnt.Remove(ctx, opts.isIncomplete, false, opts.isBypass, false, contentCh)
+		go func(doneCh chan<- bool) {
+			var batchFailed bool
+			defer func() { doneCh <- batchFailed }()
+			for result := range resultCh {
+				path := path.Join(targetAlias, result.BucketName, result.ObjectName)
+				if result.Err != nil {
+					errorIf(result.Err.Trace(path), "Failed to remove `"+path+"`.")
+					batchFailed = true
+					continue
+				}
+				msg := rmMessage{
+					Key:       path,
+					VersionID: result.ObjectVersionID,
+				}
+				if result.DeleteMarker {
+					msg.DeleteMarker = true
+					msg.VersionID = result.DeleteMarkerVersionID
+				}
+				printMsg(msg)
+			}
+		}(doneCh)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		url := strings.TrimSpace(scanner.Text())
+		if url == "" {
+			continue
+		}
+
+		targetAlias, targetURL, aliasCfg := mustExpandAlias(url)
+		if aliasCfg == nil {
+			// Local files are not batched.
+			flush()
+			if e := removeSingle(url, "", opts); e != nil {
+				failed = true
+			}
+			continue
+		}
+
+		// Only objects are removed, like removeSingle does without --recursive.
+		if _, object := url2BucketAndObject(newClientURL(targetURL)); object == "" {
+			errorIf(errInvalidArgument().Trace(url), "Removal of `"+url+"` requires a path to an object.")
+			failed = true
+			continue
+		}
+
+		if contentCh == nil || targetAlias != alias {
+			flush()
+			if err := start(targetAlias); err != nil {
+				errorIf(err.Trace(url), "Invalid argument `"+url+"`.")
+				failed = true
+				continue
+			}
+		}
+		select {
+		case contentCh <- &ClientContent{URL: *newClientURL(targetURL)}:
+		case <-ctx.Done():
+			// Remove stops reading once canceled.
+			flush()
+			errorIf(probe.NewError(ctx.Err()), "Unable to remove the remaining objects.")
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
+	flush()
+
+	if e := scanner.Err(); e != nil {
+		errorIf(probe.NewError(e), "Unable to read object names.")
+		failed = true
+	}
+	if failed {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
 // Remove a single object or a single version in a versioned bucket
 func removeSingle(url, versionID string, opts removeOpts) error {
 	ctx, cancel := context.WithCancel(globalContext)
@@ -367,6 +511,7 @@ type removeOpts struct {
 	timeRef           time.Time
 	withVersions      bool
 	nonCurrentVersion bool
+	withDeleteMarkers bool
 	isForce           bool
 	isRecursive       bool
 	isIncomplete      bool
@@ -389,6 +534,64 @@ func printDryRunMsg(content *ClientContent) {
 	fmt.Println("DRYRUN: Removing ", content.URL.Path)
 }
 
+// isRemovableNoncurrentVersion returns true if a version listed with
+// --non-current is to be removed. The current version of an object is
+// never removed, delete markers are only removed with --include-delete-markers.
+func isRemovableNoncurrentVersion(content *ClientContent, opts removeOpts) bool {
+	if content.Time.IsZero() {
+		// Skip prefix levels.
+		return false
+	}
+	if content.IsDeleteMarker {
+		if !opts.withDeleteMarkers {
+			return false
+		}
+	} else if content.IsLatest {
+		return false
+	}
+	// Skip objects older than --older-than parameter, if specified
+	if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
+		return false
+	}
+	// Skip objects newer than --newer-than parameter if specified
+	if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
+		return false
+	}
+	return true
+}
+
+// rmNoncurrentMessage container for the number of noncurrent versions removed of one object.
+type rmNoncurrentMessage struct {
+	Status   string `json:"status"`
+	Key      string `json:"key"`
+	Versions int    `json:"noncurrentVersionsRemoved"`
+}
+
+// Colorized message for console printing.
+func (r rmNoncurrentMessage) String() string {
+	return fmt.Sprintf("Removed %d noncurrent version(s) of %s.", r.Versions, console.Colorize("Removed", fmt.Sprintf("`%s`", r.Key)))
+}
+
+// JSON'ified message for scripting.
+func (r rmNoncurrentMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// printNoncurrentRemoved reports the number of noncurrent versions removed per key.
+func printNoncurrentRemoved(removed map[string]int) {
+	keys := make([]string, 0, len(removed))
+	for key := range removed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		printMsg(rmNoncurrentMessage{Key: key, Versions: removed[key]})
+	}
+}
+
 // listAndRemove uses listing before removal, it can list recursively or not, with versions or not.
 //
 //	Use cases:
@@ -419,6 +622,15 @@ func listAndRemove(url string, opts removeOpts) error {
 
 	var lastPath string
 	var perObjectVersions []*ClientContent
+
+	// Noncurrent versions removed by key, reported once the removal is done.
+	noncurrentRemoved := make(map[string]int)
+	printRemoved := func(msg rmMessage) {
+		if opts.nonCurrentVersion {
+			noncurrentRemoved[msg.Key]++
+		}
+		printMsg(msg)
+	}
 	for content := range clnt.List(ctx, listOpts) {
 		if content.Err != nil {
 			errorIf(content.Err.Trace(url), "Failed to remove `"+url+"` recursively.")
@@ -450,26 +662,13 @@ func listAndRemove(url string, opts removeOpts) error {
 			if lastPath != content.URL.Path {
 				lastPath = content.URL.Path
 				for _, content := range perObjectVersions {
-					if content.IsLatest && !content.IsDeleteMarker {
-						continue
-					}
-					if !content.Time.IsZero() {
-						// Skip objects older than --older-than parameter, if specified
-						if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
-							continue
-						}
-
-						// Skip objects newer than --newer-than parameter if specified
-						if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
-							continue
-						}
-					} else {
-						// Skip prefix levels.
+					if !isRemovableNoncurrentVersion(content, opts) {
 						continue
 					}
 
 					if opts.isFake {
 						printDryRunMsg(content)
+						noncurrentRemoved[path.Join(targetAlias, content.URL.Path)]++
 						continue
 					}
 
@@ -499,7 +698,7 @@ func listAndRemove(url string, opts removeOpts) error {
 								msg.DeleteMarker = true
 								msg.VersionID = result.DeleteMarkerVersionID
 							}
-							printMsg(msg)
+							printRemoved(msg)
 						}
 					}
 				}
@@ -561,7 +760,7 @@ func listAndRemove(url string, opts removeOpts) error {
 						msg.DeleteMarker = true
 						msg.VersionID = result.DeleteMarkerVersionID
 					}
-					printMsg(msg)
+					printRemoved(msg)
 				}
 			}
 		} else {
@@ -571,26 +770,13 @@ func listAndRemove(url string, opts removeOpts) error {
 
 	if opts.nonCurrentVersion && opts.isRecursive && opts.withVersions {
 		for _, content := range perObjectVersions {
-			if content.IsLatest && !content.IsDeleteMarker {
-				continue
-			}
-			if !content.Time.IsZero() {
-				// Skip objects older than --older-than parameter, if specified
-				if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
-					continue
-				}
-
-				// Skip objects newer than --newer-than parameter if specified
-				if opts.newerThan != "" && isNewer(content.Time, opts.newerThan) {
-					continue
-				}
-			} else {
-				// Skip prefix levels.
+			if !isRemovableNoncurrentVersion(content, opts) {
 				continue
 			}
 
 			if opts.isFake {
 				printDryRunMsg(content)
+				noncurrentRemoved[path.Join(targetAlias, content.URL.Path)]++
 				continue
 			}
 
@@ -620,7 +806,7 @@ func listAndRemove(url string, opts removeOpts) error {
 						msg.DeleteMarker = true
 						msg.VersionID = result.DeleteMarkerVersionID
 					}
-					printMsg(msg)
+					printRemoved(msg)
 				}
 			}
 		}
@@ -628,6 +814,7 @@ func listAndRemove(url string, opts removeOpts) error {
 
 	close(contentCh)
 	if opts.isFake {
+		printNoncurrentRemoved(noncurrentRemoved)
 		return nil
 	}
 	for result := range resultCh {
@@ -649,8 +836,9 @@ func listAndRemove(url string, opts removeOpts) error {
 			msg.DeleteMarker = true
 			msg.VersionID = result.DeleteMarkerVersionID
 		}
-		printMsg(msg)
+		printRemoved(msg)
 	}
+	printNoncurrentRemoved(noncurrentRemoved)
 
 	if !atLeastOneObjectFound {
 		if opts.isForce {
@@ -681,13 +869,14 @@ func mainRm(cliCtx *cli.Context) error {
 	isIncomplete := cliCtx.Bool("incomplete")
 	isRecursive := cliCtx.Bool("recursive")
 	isFake := cliCtx.Bool("dry-run") || cliCtx.Bool("fake")
-	isStdin := cliCtx.Bool("stdin")
+	isStdin := cliCtx.Bool("stdin") || isRmStdinArg(cliCtx.Args())
 	isBypass := cliCtx.Bool("bypass")
 	olderThan := cliCtx.String("older-than")
 	newerThan := cliCtx.String("newer-than")
 	isForce := cliCtx.Bool("force")
 	isForceDel := cliCtx.Bool("purge")
 	withNoncurrentVersion := cliCtx.Bool("non-current")
+	withDeleteMarkers := cliCtx.Bool("include-delete-markers")
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
@@ -703,11 +892,15 @@ func mainRm(cliCtx *cli.Context) error {
 	var e error
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
+		if url == "-" {
+			continue
+		}
 		if isRecursive || withVersions {
 			e = listAndRemove(url, removeOpts{
 				timeRef:           rewind,
 				withVersions:      withVersions,
 				nonCurrentVersion: withNoncurrentVersion,
+				withDeleteMarkers: withDeleteMarkers,
 				isForce:           isForce,
 				isRecursive:       isRecursive,
 				isIncomplete:      isIncomplete,
@@ -738,6 +931,20 @@ func mainRm(cliCtx *cli.Context) error {
 		return rerr
 	}
 
+	// Plain object removals are batched per alias.
+	if !isRecursive && !withVersions && !isFake && !isForceDel && versionID == "" && olderThan == "" && newerThan == "" {
+		e = removeFromReader(ctx, os.Stdin, removeOpts{
+			isIncomplete: isIncomplete,
+			isForce:      isForce,
+			isBypass:     isBypass,
+			encKeyDB:     encKeyDB,
+		})
+		if rerr == nil {
+			rerr = e
+		}
+		return rerr
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		url := scanner.Text()
@@ -746,6 +953,7 @@ func mainRm(cliCtx *cli.Context) error {
 				timeRef:           rewind,
 				withVersions:      withVersions,
 				nonCurrentVersion: withNoncurrentVersion,
+				withDeleteMarkers: withDeleteMarkers,
 				isForce:           isForce,
 				isRecursive:       isRecursive,
 				isIncomplete:      isIncomplete,
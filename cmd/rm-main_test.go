@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestIsRemovableNoncurrentVersion(t *testing.T) {
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	testCases := []struct {
+		content           ClientContent
+		withDeleteMarkers bool
+		removable         bool
+	}{
+		// The current version is never removed.
+		{ClientContent{Time: old, IsLatest: true}, false, false},
+		{ClientContent{Time: old, IsLatest: true}, true, false},
+		// Noncurrent versions older than the threshold.
+		{ClientContent{Time: old}, false, true},
+		{ClientContent{Time: recent}, false, false},
+		// Delete markers only with --include-delete-markers.
+		{ClientContent{Time: old, IsDeleteMarker: true}, false, false},
+		{ClientContent{Time: old, IsDeleteMarker: true}, true, true},
+		{ClientContent{Time: old, IsDeleteMarker: true, IsLatest: true}, false, false},
+		{ClientContent{Time: old, IsDeleteMarker: true, IsLatest: true}, true, true},
+		{ClientContent{Time: recent, IsDeleteMarker: true}, true, false},
+		// Prefix levels.
+		{ClientContent{}, true, false},
+	}
+	for i, tc := range testCases {
+		opts := removeOpts{olderThan: "30d", withDeleteMarkers: tc.withDeleteMarkers}
+		if removable := isRemovableNoncurrentVersion(&tc.content, opts); removable != tc.removable {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.removable, removable)
+		}
+	}
+}
+
+func TestRemoveFromReaderCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<DeleteResult></DeleteResult>"))
+	}))
+	defer srv.Close()
+
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		cfg := newMcConfig()
+		cfg.Aliases["rmtest"] = aliasConfigV10{URL: srv.URL, AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"}
+		return cfg, nil
+	}
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	var lines strings.Builder
+	lines.WriteString("rmtest/bucket\n")
+	for i := 0; i < 1000; i++ {
+		lines.WriteString("rmtest/bucket/object\n")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() { done <- removeFromReader(ctx, strings.NewReader(lines.String()), removeOpts{isForce: true}) }()
+	select {
+	case e := <-done:
+		if e == nil {
+			t.Fatal("expected an error once canceled")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("the removal did not stop once canceled")
+	}
+}
+
+func TestRemoveFromReaderFailures(t *testing.T) {
+	// Every object of a multi-object delete fails.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Objects []struct {
+				Key string
+			} `xml:"Object"`
+		}
+		if e := xml.NewDecoder(r.Body).Decode(&req); e != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var result strings.Builder
+		result.WriteString("<DeleteResult>")
+		for _, object := range req.Objects {
+			fmt.Fprintf(&result, "<Error><Key>%s</Key><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>", object.Key)
+		}
+		result.WriteString("</DeleteResult>")
+		w.Write([]byte(result.String()))
+	}))
+	defer srv.Close()
+
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		cfg := newMcConfig()
+		cfg.Aliases["rmtest"] = aliasConfigV10{URL: srv.URL, AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"}
+		return cfg, nil
+	}
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	// More objects than a single multi-object delete, so the first batch
+	// fails while the following lines are still read.
+	var lines strings.Builder
+	for i := 0; i < 1500; i++ {
+		fmt.Fprintf(&lines, "rmtest/bucket/object%d\n", i)
+		if i%100 == 0 {
+			lines.WriteString("rmtest/bucket\n")
+		}
+	}
+	if e := removeFromReader(context.Background(), strings.NewReader(lines.String()), removeOpts{isForce: true}); e == nil {
+		t.Fatal("expected an error for the failed removals")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// sizeRange is the range of sizes of the objects copied with
+// --min-size and --max-size, both inclusive.
+type sizeRange struct {
+	min, max int64
+	bounded  bool
+}
+
+// parseSizeRange parses the human readable sizes of --min-size and
+// --max-size, an empty value is not a limit.
+func parseSizeRange(minSize, maxSize string) (r sizeRange, err *probe.Error) {
+	r.max = math.MaxInt64
+	r.bounded = minSize != "" || maxSize != ""
+	if minSize != "" {
+		size, e := humanize.ParseBytes(minSize)
+		if e != nil {
+			return r, probe.NewError(e).Trace(minSize)
+		}
+		r.min = int64(size)
+	}
+	if maxSize != "" {
+		size, e := humanize.ParseBytes(maxSize)
+		if e != nil {
+			return r, probe.NewError(e).Trace(maxSize)
+		}
+		r.max = int64(size)
+	}
+	if r.min > r.max {
+		return r, probe.NewError(fmt.Errorf("--min-size %s is larger than --max-size %s", minSize, maxSize))
+	}
+	return r, nil
+}
+
+// contains returns whether an object of this size is copied, the zero
+// value contains all sizes.
+func (r sizeRange) contains(size int64) bool {
+	return !r.bounded || (size >= r.min && size <= r.max)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParseSizeRange(t *testing.T) {
+	testCases := []struct {
+		minSize, maxSize string
+		size             int64
+		contains         bool
+		shouldFail       bool
+	}{
+		{"", "", 1 << 40, true, false},
+		{"1KiB", "", 1023, false, false},
+		{"1KiB", "", 1024, true, false},
+		{"", "1MB", 1000000, true, false},
+		{"", "1MB", 1000001, false, false},
+		{"", "0", 0, true, false},
+		{"", "0", 1, false, false},
+		{"2MiB", "1MiB", 0, false, true},
+		{"1XB", "", 0, false, true},
+	}
+	for i, testCase := range testCases {
+		r, err := parseSizeRange(testCase.minSize, testCase.maxSize)
+		if testCase.shouldFail {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if got := r.contains(testCase.size); got != testCase.contains {
+			t.Errorf("Test %d: expected %v for %d, got %v", i+1, testCase.contains, testCase.size, got)
+		}
+	}
+	if !(sizeRange{}).contains(42) {
+		t.Errorf("expected the zero range to contain all sizes")
+	}
+}
+
+func TestCopySizeRange(t *testing.T) {
+	savedLoadMcConfig := loadMcConfig
+	loadMcConfig = func() (*configV10, *probe.Error) { return newMcConfig(), nil }
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	if e := os.Mkdir(src, 0o755); e != nil {
+		t.Fatal(e)
+	}
+	for name, size := range map[string]int{"tiny": 10, "medium": 2000, "huge": 5000} {
+		if e := os.WriteFile(filepath.Join(src, name), []byte(strings.Repeat("x", size)), 0o644); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	r, err := parseSizeRange("1KB", "4KB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var copied []string
+	for cpURLs := range prepareCopyURLs(context.Background(), prepareCopyURLsOpts{
+		sourceURLs:  []string{src + string(filepath.Separator)},
+		targetURL:   filepath.Join(dir, "dst") + string(filepath.Separator),
+		isRecursive: true,
+		sizeRange:   r,
+	}) {
+		if cpURLs.Error != nil {
+			t.Fatal(cpURLs.Error)
+		}
+		copied = append(copied, filepath.Base(cpURLs.SourceContent.URL.Path))
+	}
+	sort.Strings(copied)
+	if len(copied) != 1 || copied[0] != "medium" {
+		t.Fatalf("expected only `medium` to be copied, got %v", copied)
+	}
+}
@@ -29,11 +29,14 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
 	"github.com/minio/pkg/mimedb"
 )
 
@@ -71,6 +74,14 @@ var sqlFlags = []cli.Flag{
 		Name:  "json-output",
 		Usage: "json output serialization option",
 	},
+	cli.BoolFlag{
+		Name:  "progress",
+		Usage: "show the bytes scanned and processed by the server during the query, and its statistics",
+	},
+	cli.BoolFlag{
+		Name:  "stats",
+		Usage: "show the bytes scanned, processed and returned by the query once done",
+	},
 }
 
 // Display contents of a file.
@@ -122,6 +133,10 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
          --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
          --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Run a query on a large object, showing its progress and how many of the scanned bytes it returned,
+     on stderr.
+     {{.Prompt}} {{.HelpName}} --progress --query "select * from S3Object s where s.power > 100" myminio/iot-devices/data.csv
 `,
 }
 
@@ -368,6 +383,7 @@ func getSQLOpts(ctx *cli.Context, csvHdrs []string) (s SelectObjectOpts) {
 		InputSerOpts:    is,
 		OutputSerOpts:   os,
 		CompressionType: minio.SelectCompressionType(ctx.String("compression")),
+		RequestProgress: ctx.Bool("progress"),
 	}
 }
 
@@ -381,7 +397,7 @@ func isCSVOrJSON(inOpts map[string]map[string]string) bool {
 	return false
 }
 
-func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr bool) *probe.Error {
+func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr, showStats bool) *probe.Error {
 	ctx, cancelSelect := context.WithCancel(globalContext)
 	defer cancelSelect()
 
@@ -395,6 +411,11 @@ func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair
 		return err.Trace(targetURL)
 	}
 
+	var progress *sqlProgress
+	if selOpts.RequestProgress {
+		ctx, progress = withSQLProgress(ctx)
+	}
+
 	sseKey := getSSE(targetURL, encKeyDB[alias])
 	outputer, err := targetClnt.Select(ctx, expression, sseKey, selOpts)
 	if err != nil {
@@ -406,8 +427,36 @@ func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair
 	if len(csvHdrs) > 0 && writeHdr {
 		fmt.Println(strings.Join(csvHdrs, ","))
 	}
+
+	// Progress and stats are only reported on object storage.
+	results, _ := outputer.(*minio.SelectResults)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	if results != nil && selOpts.RequestProgress {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			showSQLProgress(targetURL, progress, done)
+		}()
+	}
 	_, e := io.Copy(os.Stdout, outputer)
-	return probe.NewError(e)
+	close(done)
+	wg.Wait()
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	if results != nil && showStats {
+		stats := results.Stats()
+		printSQLStats(sqlStatsMessage{
+			Target:         targetURL,
+			BytesScanned:   stats.BytesScanned,
+			BytesProcessed: stats.BytesProcessed,
+			BytesReturned:  stats.BytesReturned,
+			Final:          true,
+		})
+	}
+	return nil
 }
 
 func validateOpts(selOpts SelectObjectOpts, url string) {
@@ -449,6 +498,8 @@ func mainSQL(cliCtx *cli.Context) error {
 
 	// validate sql input arguments.
 	checkSQLSyntax(cliCtx)
+	console.SetColor("SQLStats", color.New(color.FgCyan))
+	showStats := cliCtx.Bool("stats") || cliCtx.Bool("progress")
 	// extract URLs.
 	URLs := cliCtx.Args()
 	writeHdr := true
@@ -460,7 +511,7 @@ func mainSQL(cliCtx *cli.Context) error {
 			if writeHdr {
 				query, csvHdrs, selOpts = getAndValidateArgs(cliCtx, encKeyDB, url)
 			}
-			errorIf(sqlSelect(url, query, encKeyDB, selOpts, csvHdrs, writeHdr).Trace(url), "Unable to run sql")
+			errorIf(sqlSelect(url, query, encKeyDB, selOpts, csvHdrs, writeHdr, showStats).Trace(url), "Unable to run sql")
 			writeHdr = false
 			continue
 		}
@@ -483,7 +534,7 @@ func mainSQL(cliCtx *cli.Context) error {
 			for _, cTypeSuffix := range supportedContentTypes {
 				if strings.Contains(contentType, cTypeSuffix) {
 					errorIf(sqlSelect(targetAlias+content.URL.Path, query,
-						encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
+						encKeyDB, selOpts, csvHdrs, writeHdr, showStats).Trace(content.URL.String()), "Unable to run sql")
 				}
 				writeHdr = false
 			}
@@ -0,0 +1,263 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mattn/go-isatty"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
+)
+
+// Interval to show the progress of a query with --progress.
+const sqlProgressInterval = time.Second
+
+// sqlStatsMessage container for the progress and the statistics of a
+// query, as reported by the progress and stats events of the server.
+type sqlStatsMessage struct {
+	Status         string `json:"status"`
+	Target         string `json:"target"`
+	BytesScanned   int64  `json:"bytesScanned"`
+	BytesProcessed int64  `json:"bytesProcessed"`
+	BytesReturned  int64  `json:"bytesReturned"`
+	Final          bool   `json:"final"`
+}
+
+// String colorized query statistics, along with the share of the
+// processed bytes returned by the query.
+func (s sqlStatsMessage) String() string {
+	msg := fmt.Sprintf("`%s`: scanned %s, processed %s, returned %s", s.Target,
+		humanize.IBytes(uint64(s.BytesScanned)), humanize.IBytes(uint64(s.BytesProcessed)), humanize.IBytes(uint64(s.BytesReturned)))
+	if s.Final && s.BytesProcessed > 0 {
+		msg += fmt.Sprintf(" (%.2f%% of the processed bytes)", float64(s.BytesReturned)*100/float64(s.BytesProcessed))
+	}
+	return console.Colorize("SQLStats", msg)
+}
+
+// JSON jsonified query statistics.
+func (s sqlStatsMessage) JSON() string {
+	s.Status = "success"
+	statsJSON, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statsJSON)
+}
+
+// printSQLStats prints the statistics of a query on stderr, since the
+// query results are written on stdout. The progress of a query is
+// refreshed in place on a terminal.
+func printSQLStats(s sqlStatsMessage) {
+	switch {
+	case globalJSON:
+		fmt.Fprintln(os.Stderr, s.JSON())
+	case !isatty.IsTerminal(os.Stderr.Fd()):
+		fmt.Fprintln(os.Stderr, s.String())
+	case s.Final:
+		fmt.Fprintf(os.Stderr, "\r\033[2K%s\n", s)
+	default:
+		fmt.Fprintf(os.Stderr, "\r\033[2K%s", s)
+	}
+}
+
+// sqlProgress holds the latest progress event of a query. minio-go decodes
+// the events in its own goroutine without a lock, so the progress is parsed
+// again here from the response stream, see sqlProgressTransport.
+type sqlProgress struct {
+	mutex    sync.Mutex
+	progress minio.StatsMessage
+}
+
+type sqlProgressKey struct{}
+
+// withSQLProgress returns a context recording the progress events of the
+// query run with it.
+func withSQLProgress(ctx context.Context) (context.Context, *sqlProgress) {
+	p := &sqlProgress{}
+	return context.WithValue(ctx, sqlProgressKey{}, p), p
+}
+
+func (p *sqlProgress) set(progress minio.StatsMessage) {
+	p.mutex.Lock()
+	p.progress = progress
+	p.mutex.Unlock()
+}
+
+func (p *sqlProgress) get() minio.StatsMessage {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.progress
+}
+
+// sqlProgressTransport hands the progress events of select responses to
+// the recorder of the request context, if any.
+type sqlProgressTransport struct {
+	transport http.RoundTripper
+}
+
+func (t sqlProgressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.transport.RoundTrip(req)
+	if resp != nil && resp.StatusCode == http.StatusOK && req.Method == http.MethodPost && req.URL.Query().Has("select") {
+		if p, ok := req.Context().Value(sqlProgressKey{}).(*sqlProgress); ok {
+			resp.Body = sqlProgressReader{ReadCloser: resp.Body, events: &sqlEventParser{progress: p}}
+		}
+	}
+	return resp, e
+}
+
+// sqlProgressReader passes the bytes read from a select response to the
+// event parser.
+type sqlProgressReader struct {
+	io.ReadCloser
+	events *sqlEventParser
+}
+
+func (r sqlProgressReader) Read(b []byte) (int, error) {
+	n, e := r.ReadCloser.Read(b)
+	r.events.write(b[:n])
+	return n, e
+}
+
+// sqlEventParser parses the messages of an event stream as they are read,
+// the payload of the progress events only is kept and decoded.
+type sqlEventParser struct {
+	progress *sqlProgress
+	buf      []byte
+	// bytes of the current message left to skip
+	skip uint32
+	// stop parsing a stream which cannot be parsed
+	failed bool
+}
+
+// Length of the prelude of a message, the total and headers lengths
+// followed by a checksum, and of the checksum ending a message.
+const (
+	sqlEventPreludeLen = 12
+	sqlEventCRCLen     = 4
+)
+
+func (p *sqlEventParser) write(b []byte) {
+	for len(b) > 0 && !p.failed {
+		if p.skip > 0 {
+			n := p.skip
+			if uint32(len(b)) < n {
+				n = uint32(len(b))
+			}
+			p.skip -= n
+			b = b[n:]
+			continue
+		}
+		p.buf = append(p.buf, b...)
+		b = nil
+		p.parse()
+	}
+}
+
+// parse consumes the complete messages of the buffer, or starts skipping
+// a message which is not a progress event once its headers are read.
+func (p *sqlEventParser) parse() {
+	for len(p.buf) >= sqlEventPreludeLen {
+		totalLen := binary.BigEndian.Uint32(p.buf[0:4])
+		headersLen := binary.BigEndian.Uint32(p.buf[4:8])
+		if totalLen < sqlEventPreludeLen+headersLen+sqlEventCRCLen {
+			p.failed = true
+			p.buf = nil
+			return
+		}
+		if uint32(len(p.buf)) < sqlEventPreludeLen+headersLen {
+			return
+		}
+		if sqlEventType(p.buf[sqlEventPreludeLen:sqlEventPreludeLen+headersLen]) != "Progress" {
+			if uint32(len(p.buf)) < totalLen {
+				p.skip = totalLen - uint32(len(p.buf))
+				p.buf = p.buf[:0]
+				return
+			}
+			p.buf = p.buf[totalLen:]
+			continue
+		}
+		if uint32(len(p.buf)) < totalLen {
+			return
+		}
+		var progress minio.ProgressMessage
+		if xml.Unmarshal(p.buf[sqlEventPreludeLen+headersLen:totalLen-sqlEventCRCLen], &progress) == nil {
+			p.progress.set(progress.StatsMessage)
+		}
+		p.buf = p.buf[totalLen:]
+	}
+}
+
+// sqlEventType returns the value of the ':event-type' header of a message.
+func sqlEventType(headers []byte) string {
+	const stringType = 7
+	for len(headers) > 0 {
+		nameLen := int(headers[0])
+		if len(headers) < 1+nameLen+3 || headers[1+nameLen] != stringType {
+			return ""
+		}
+		name := string(headers[1 : 1+nameLen])
+		valueLen := int(binary.BigEndian.Uint16(headers[2+nameLen : 4+nameLen]))
+		headers = headers[4+nameLen:]
+		if len(headers) < valueLen {
+			return ""
+		}
+		if name == ":event-type" {
+			return string(headers[:valueLen])
+		}
+		headers = headers[valueLen:]
+	}
+	return ""
+}
+
+// showSQLProgress shows the progress of a query reported by the server
+// until done is closed.
+func showSQLProgress(target string, p *sqlProgress, done <-chan struct{}) {
+	ticker := time.NewTicker(sqlProgressInterval)
+	defer ticker.Stop()
+
+	var last minio.StatsMessage
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			progress := p.get()
+			if progress == last {
+				continue
+			}
+			last = progress
+			printSQLStats(sqlStatsMessage{
+				Target:         target,
+				BytesScanned:   progress.BytesScanned,
+				BytesProcessed: progress.BytesProcessed,
+				BytesReturned:  progress.BytesReturned,
+			})
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	gojson "encoding/json"
+)
+
+func TestSQLStatsMessage(t *testing.T) {
+	msg := sqlStatsMessage{
+		Target:         "myminio/iot-devices/data.csv",
+		BytesScanned:   4 << 20,
+		BytesProcessed: 4 << 20,
+		BytesReturned:  1 << 20,
+	}
+	if s := msg.String(); strings.Contains(s, "%") {
+		t.Fatalf("expected no selectivity during the query, got %q", s)
+	}
+	msg.Final = true
+	s := msg.String()
+	for _, expected := range []string{"scanned 4.0 MiB", "processed 4.0 MiB", "returned 1.0 MiB", "25.00% of the processed bytes"} {
+		if !strings.Contains(s, expected) {
+			t.Errorf("expected %q in %q", expected, s)
+		}
+	}
+	var decoded sqlStatsMessage
+	if e := gojson.Unmarshal([]byte(msg.JSON()), &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if decoded.BytesReturned != msg.BytesReturned || !decoded.Final {
+		t.Errorf("unexpected JSON %+v", decoded)
+	}
+}
+
+// sqlEventMessage encodes an event stream message, the checksums are not
+// verified by the parser and left empty.
+func sqlEventMessage(eventType, payload string) []byte {
+	var headers bytes.Buffer
+	for _, kv := range [][2]string{{":message-type", "event"}, {":event-type", eventType}} {
+		headers.WriteByte(byte(len(kv[0])))
+		headers.WriteString(kv[0])
+		headers.WriteByte(7)
+		binary.Write(&headers, binary.BigEndian, uint16(len(kv[1])))
+		headers.WriteString(kv[1])
+	}
+	var msg bytes.Buffer
+	binary.Write(&msg, binary.BigEndian, uint32(sqlEventPreludeLen+headers.Len()+len(payload)+sqlEventCRCLen))
+	binary.Write(&msg, binary.BigEndian, uint32(headers.Len()))
+	msg.Write(make([]byte, 4))
+	msg.Write(headers.Bytes())
+	msg.WriteString(payload)
+	msg.Write(make([]byte, sqlEventCRCLen))
+	return msg.Bytes()
+}
+
+func TestSQLEventParser(t *testing.T) {
+	var stream []byte
+	stream = append(stream, sqlEventMessage("Records", strings.Repeat("a,b,c\n", 100))...)
+	stream = append(stream, sqlEventMessage("Progress", "<Progress><BytesScanned>10</BytesScanned><BytesProcessed>10</BytesProcessed><BytesReturned>5</BytesReturned></Progress>")...)
+	stream = append(stream, sqlEventMessage("Records", "d,e,f\n")...)
+	stream = append(stream, sqlEventMessage("Progress", "<Progress><BytesScanned>20</BytesScanned><BytesProcessed>18</BytesProcessed><BytesReturned>7</BytesReturned></Progress>")...)
+	stream = append(stream, sqlEventMessage("End", "")...)
+
+	// The stream is parsed whatever the size of the reads.
+	for _, chunk := range []int{1, 7, 64, len(stream)} {
+		p := &sqlEventParser{progress: &sqlProgress{}}
+		for b := stream; len(b) > 0; {
+			n := chunk
+			if n > len(b) {
+				n = len(b)
+			}
+			p.write(b[:n])
+			b = b[n:]
+		}
+		progress := p.progress.get()
+		if progress.BytesScanned != 20 || progress.BytesProcessed != 18 || progress.BytesReturned != 7 {
+			t.Errorf("chunk %d: unexpected progress %+v", chunk, progress)
+		}
+	}
+}
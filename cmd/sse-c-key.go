@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// sseCKeyFlags pass the SSE-C key of the objects read by `cat` and `head`,
+// without the prefix of --encrypt-key.
+var sseCKeyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "sse-c-key",
+		Usage: "SSE-C key of the objects, 32 bytes or base64 encoded (prefer --sse-c-key-file)",
+	},
+	cli.StringFlag{
+		Name:  "sse-c-key-file",
+		Usage: "file with the SSE-C key of the objects, must not be accessible by group or others",
+	},
+}
+
+// errSSECKeyMismatch is returned when the server refuses an SSE-C key.
+var errSSECKeyMismatch = errors.New("decryption failed, the SSE-C key does not match the key of the object or access is denied")
+
+// decodeSSECKey returns a 32 bytes SSE-C key, passed as is or base64 encoded.
+func decodeSSECKey(key string) ([]byte, *probe.Error) {
+	if len(key) == 32 {
+		return []byte(key), nil
+	}
+	decoded, e := base64.StdEncoding.DecodeString(key)
+	if e != nil || len(decoded) != 32 {
+		return nil, probe.NewError(errors.New("SSE-C key should be 32 bytes plain text key or 44 bytes base64 encoded key"))
+	}
+	return decoded, nil
+}
+
+// readSSECKeyFile reads an SSE-C key from a file only its owner can access.
+func readSSECKeyFile(path string) ([]byte, *probe.Error) {
+	st, e := os.Stat(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	// Permissions are not enforced on Windows.
+	if runtime.GOOS != "windows" && st.Mode().Perm()&0o077 != 0 {
+		return nil, probe.NewError(fmt.Errorf("`%s` is accessible by group or others, restrict it with 'chmod 600 %s'", path, path))
+	}
+	data, e := os.ReadFile(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	key := string(data)
+	if len(key) != 32 {
+		key = strings.TrimRight(key, "\r\n")
+	}
+	return decodeSSECKey(key)
+}
+
+// getSSECKey returns the SSE-C key passed with --sse-c-key or
+// --sse-c-key-file, nil without any.
+func getSSECKey(ctx *cli.Context) ([]byte, *probe.Error) {
+	key, keyFile := ctx.String("sse-c-key"), ctx.String("sse-c-key-file")
+	switch {
+	case key != "" && keyFile != "":
+		return nil, errInvalidArgument().Trace(keyFile)
+	case keyFile != "":
+		return readSSECKeyFile(keyFile)
+	case key != "":
+		return decodeSSECKey(key)
+	}
+	return nil, nil
+}
+
+// addSSECKey sets the SSE-C key of the given remote URLs in encKeyDB, local
+// files are not encrypted.
+func addSSECKey(encKeyDB map[string][]prefixSSEPair, urls []string, key []byte) *probe.Error {
+	for _, url := range urls {
+		alias, _ := url2Alias(url)
+		if alias == "" || mustGetHostConfig(alias) == nil {
+			continue
+		}
+		sse, e := encrypt.NewSSEC(key)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		encKeyDB[alias] = append(encKeyDB[alias], prefixSSEPair{Prefix: url, SSE: sse})
+		sort.Sort(byPrefixLength(encKeyDB[alias]))
+	}
+	return nil
+}
+
+// sseCKeyError replaces the error of an object read with an SSE-C key when
+// the server refused the key, instead of a generic access denied error.
+func sseCKeyError(err *probe.Error) *probe.Error {
+	if err == nil {
+		return nil
+	}
+	var permErr PathInsufficientPermission
+	e := err.ToGoError()
+	if errors.As(e, &permErr) {
+		return probe.NewError(errSSECKeyMismatch)
+	}
+	if errResponse := minio.ToErrorResponse(e); errResponse.Code == "AccessDenied" || errResponse.StatusCode == http.StatusForbidden {
+		return probe.NewError(errSSECKeyMismatch)
+	}
+	return err
+}
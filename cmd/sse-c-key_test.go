@@ -0,0 +1,78 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestReadSSECKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		content string
+		perm    os.FileMode
+		success bool
+	}{
+		{"32byteslongsecretkeymustbegiven1", 0o600, true},
+		{"32byteslongsecretkeymustbegiven1\n", 0o400, true},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZQltdXN0YmVnaXZlbjE=\n", 0o600, true},
+		{"tooshort", 0o600, false},
+		{"32byteslongsecretkeymustbegiven1", 0o644, runtime.GOOS == "windows"},
+	}
+	for i, testCase := range testCases {
+		path := filepath.Join(dir, "key")
+		os.Remove(path)
+		if e := os.WriteFile(path, []byte(testCase.content), testCase.perm); e != nil {
+			t.Fatal(e)
+		}
+		key, err := readSSECKeyFile(path)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && len(key) != 32 {
+			t.Fatalf("Test %d: expected a 32 bytes key, got %d bytes", i+1, len(key))
+		}
+	}
+}
+
+func TestSSECKeyError(t *testing.T) {
+	otherErr := probe.NewError(errors.New("network error"))
+	testCases := []struct {
+		err      *probe.Error
+		mismatch bool
+	}{
+		{nil, false},
+		{otherErr, false},
+		{probe.NewError(PathInsufficientPermission{Path: "play/bucket/object"}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}), false},
+	}
+	for i, testCase := range testCases {
+		err := sseCKeyError(testCase.err)
+		if mismatch := err != nil && err.ToGoError() == errSSECKeyMismatch; mismatch != testCase.mismatch {
+			t.Fatalf("Test %d: expected mismatch %v, got %v", i+1, testCase.mismatch, err)
+		}
+	}
+}
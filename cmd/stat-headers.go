@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// sensitiveResponseHeaders are never shown by `stat --raw-headers`.
+var sensitiveResponseHeaders = set.CreateStringSet(
+	"Authorization",
+	"Proxy-Authorization",
+	"Proxy-Authenticate",
+	"Set-Cookie",
+	"X-Amz-Security-Token",
+	"X-Amz-Server-Side-Encryption-Customer-Key",
+)
+
+type responseHeadersKey struct{}
+
+// responseHeaders records the headers of the last HEAD response
+// received for the requests of a context.
+type responseHeaders struct {
+	mutex  sync.Mutex
+	header http.Header
+}
+
+// withResponseHeaders returns a context recording the headers of the
+// HEAD responses of its requests.
+func withResponseHeaders(ctx context.Context) (context.Context, *responseHeaders) {
+	r := &responseHeaders{}
+	return context.WithValue(ctx, responseHeadersKey{}, r), r
+}
+
+// raw returns the recorded headers, without the sensitive ones and with
+// multiple values joined by a comma.
+func (r *responseHeaders) raw() map[string]string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.header == nil {
+		return nil
+	}
+	headers := make(map[string]string, len(r.header))
+	for k, v := range r.header {
+		if sensitiveResponseHeaders.Contains(http.CanonicalHeaderKey(k)) {
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
+
+// responseHeadersTransport hands the headers of HEAD responses to the
+// recorder of the request context, if any.
+type responseHeadersTransport struct {
+	transport http.RoundTripper
+}
+
+func (t responseHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.transport.RoundTrip(req)
+	if resp != nil && req.Method == http.MethodHead {
+		if r, ok := req.Context().Value(responseHeadersKey{}).(*responseHeaders); ok {
+			r.mutex.Lock()
+			r.header = resp.Header.Clone()
+			r.mutex.Unlock()
+		}
+	}
+	return resp, e
+}
+
+// sortedHeaderNames returns the names of the headers sorted.
+func sortedHeaderNames(headers map[string]string) []string {
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
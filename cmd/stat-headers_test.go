@@ -0,0 +1,77 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestResponseHeadersTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amz-Meta-Owner", "alice")
+		w.Header().Set("X-Cache", "MISS")
+		w.Header().Add("Vary", "Origin")
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.Header().Set("Set-Cookie", "session=secret")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: responseHeadersTransport{transport: http.DefaultTransport}}
+	do := func(ctx context.Context, method string) {
+		req, e := http.NewRequestWithContext(ctx, method, server.URL, nil)
+		if e != nil {
+			t.Fatal(e)
+		}
+		resp, e := client.Do(req)
+		if e != nil {
+			t.Fatal(e)
+		}
+		resp.Body.Close()
+	}
+
+	// Requests without a recorder are left alone.
+	do(context.Background(), http.MethodHead)
+
+	ctx, headers := withResponseHeaders(context.Background())
+	do(ctx, http.MethodGet)
+	if raw := headers.raw(); raw != nil {
+		t.Fatalf("expected no headers recorded for a GET, got %v", raw)
+	}
+
+	do(ctx, http.MethodHead)
+	raw := headers.raw()
+	for k, v := range map[string]string{
+		"X-Amz-Meta-Owner": "alice",
+		"X-Cache":          "MISS",
+		"Vary":             "Origin, Accept-Encoding",
+	} {
+		if raw[k] != v {
+			t.Errorf("expected %s: %s, got %q", k, v, raw[k])
+		}
+	}
+	if _, ok := raw["Set-Cookie"]; ok {
+		t.Error("expected Set-Cookie to be omitted")
+	}
+	if names := sortedHeaderNames(map[string]string{"X-Cache": "", "Vary": "", "Etag": ""}); !reflect.DeepEqual(names, []string{"Etag", "Vary", "X-Cache"}) {
+		t.Errorf("unexpected header names order %v", names)
+	}
+}
@@ -46,6 +46,10 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.BoolFlag{
+			Name:  "raw-headers",
+			Usage: "show all the HTTP response headers of the object(s), except the sensitive ones",
+		},
 	}
 )
 
@@ -91,6 +95,15 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Stat a bucket as JSON, including its versioning, object lock, replication, encryption, tagging and quota.
+     {{.Prompt}} {{.HelpName}} --json myminio/mybucket
+
+  9. Inspect a previous version as JSON, including its checksums, tags, retention and whether it is the latest.
+     {{.Prompt}} {{.HelpName}} --json --version-id "CL3sWgdSN2pNntSf6UnZAuh2kcu8E8si" s3/personal-docs/2018-account_report.docx
+
+  10. Show every HTTP response header of an object, to find out why a CDN or a browser does not cache it.
+      {{.Prompt}} {{.HelpName}} --raw-headers play/website/index.html
 `,
 }
 
@@ -124,6 +137,10 @@ func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 	}
 
 	for _, url := range URLs {
+		// A specific version, delete markers included, is looked up by statURL.
+		if versionID != "" {
+			break
+		}
 		_, _, err := url2Stat(ctx, url, versionID, false, encKeyDB, rewind, false)
 		if err != nil {
 			fatalIf(err.Trace(url), "Unable to stat `"+url+"`.")
@@ -165,7 +182,7 @@ func mainStat(cliCtx *cli.Context) error {
 	}
 
 	for _, targetURL := range args {
-		fatalIf(statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, encKeyDB), "Unable to stat `"+targetURL+"`.")
+		fatalIf(statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, cliCtx.Bool("raw-headers"), encKeyDB), "Unable to stat `"+targetURL+"`.")
 	}
 
 	return nil
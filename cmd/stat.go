@@ -53,6 +53,15 @@ type statMessage struct {
 	Metadata          map[string]string `json:"metadata,omitempty"`
 	VersionID         string            `json:"versionID,omitempty"`
 	DeleteMarker      bool              `json:"deleteMarker,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	Version           *statVersion      `json:"version,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+}
+
+// statVersion describes the version requested with --version-id.
+type statVersion struct {
+	IsLatest     bool `json:"isLatest"`
+	DeleteMarker bool `json:"deleteMarker"`
 }
 
 func (stat statMessage) String() (msg string) {
@@ -75,6 +84,9 @@ func (stat statMessage) String() (msg string) {
 		if stat.DeleteMarker {
 			versionIDField += " (delete-marker)"
 		}
+		if stat.Version != nil && stat.Version.IsLatest {
+			versionIDField += " (latest)"
+		}
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "VersionID", versionIDField) + "\n")
 	}
 	msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Type", stat.Type) + "\n")
@@ -117,9 +129,29 @@ func (stat statMessage) String() (msg string) {
 			}
 		}
 	}
+	if len(stat.Tags) > 0 {
+		keys := make([]string, 0, len(stat.Tags))
+		for k := range stat.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		msgBuilder.WriteString(fmt.Sprintf("%-10s:", "Tags") + "\n")
+		for _, k := range keys {
+			msgBuilder.WriteString(fmt.Sprintf("  %s: %s ", k, stat.Tags[k]) + "\n")
+		}
+	}
 	if stat.ReplicationStatus != "" {
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Replication Status", stat.ReplicationStatus))
 	}
+	if len(stat.Headers) > 0 {
+		if stat.ReplicationStatus != "" {
+			msgBuilder.WriteString("\n")
+		}
+		msgBuilder.WriteString(fmt.Sprintf("%-10s:", "Headers") + "\n")
+		for _, k := range sortedHeaderNames(stat.Headers) {
+			msgBuilder.WriteString(fmt.Sprintf("  %s: %s", k, stat.Headers[k]) + "\n")
+		}
+	}
 
 	msgBuilder.WriteString("\n")
 
@@ -148,6 +180,7 @@ func parseStat(c *ClientContent) statMessage {
 	}()
 	content.Size = c.Size
 	content.VersionID = c.VersionID
+	content.DeleteMarker = c.IsDeleteMarker
 	content.Key = getKey(c)
 	content.Metadata = c.Metadata
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
@@ -171,7 +204,7 @@ func getStandardizedURL(targetURL string) string {
 // statURL - uses combination of GET listing and HEAD to fetch information of one or more objects
 // HEAD can fail with 400 with an SSE-C encrypted object but we still return information gathered
 // from GET listing.
-func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive, rawHeaders bool, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	clnt, err := newClient(targetURL)
 	if err != nil {
 		return err
@@ -196,6 +229,7 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 	adminClient, _ := newAdminClient(targetURL)
 
 	var e error
+	var foundVersion bool
 	for content := range clnt.List(ctx, lstOptions) {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
@@ -233,8 +267,26 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 			if versionID != content.VersionID {
 				continue
 			}
+			foundVersion = true
+		}
+		// A HEAD on a delete marker fails, what the listing returned is all there is.
+		if content.IsDeleteMarker {
+			contentURL := filepath.ToSlash(content.URL.Path)
+			content.URL.Path = strings.TrimPrefix(contentURL, filepath.ToSlash(prefixPath))
+			msg := parseStat(content)
+			if versionID != "" {
+				msg.Version = &statVersion{IsLatest: content.IsLatest, DeleteMarker: true}
+			}
+			printMsg(msg)
+			continue
 		}
-		clnt, stat, err := url2Stat(ctx, url, content.VersionID, true, encKeyDB, timeRef, false)
+
+		statCtx := ctx
+		var headers *responseHeaders
+		if rawHeaders {
+			statCtx, headers = withResponseHeaders(ctx)
+		}
+		clnt, stat, err := url2Stat(statCtx, url, content.VersionID, true, encKeyDB, timeRef, false)
 		if err != nil {
 			continue
 		}
@@ -260,6 +312,10 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 					if e == nil {
 						bu = duinfo.BucketsUsage[stat.BucketName]
 					}
+					// Quota is not supported by every server, ignore errors.
+					if quota, e := adminClient.GetBucketQuota(globalContext, stat.BucketName); e == nil && quota.Quota > 0 {
+						bstat.Quota = &quota
+					}
 				}
 
 				if prefixPath != "/" {
@@ -282,9 +338,25 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 		contentURL = strings.TrimPrefix(contentURL, prefixPath)
 		stat.URL.Path = contentURL
 
-		printMsg(parseStat(stat))
+		msg := parseStat(stat)
+		if headers != nil && !stat.Type.IsDir() {
+			msg.Headers = headers.raw()
+		}
+		if versionID != "" {
+			// HEAD does not tell whether a version is the latest, the listing does.
+			msg.Version = &statVersion{IsLatest: content.IsLatest}
+			if stat.Metadata["X-Amz-Tagging-Count"] != "" {
+				tags, err := clnt.GetTags(ctx, versionID)
+				errorIf(err.Trace(url), "Unable to fetch tags.")
+				msg.Tags = tags
+			}
+		}
+		printMsg(msg)
 	}
 
+	if versionID != "" && !foundVersion && e == nil {
+		return probe.NewError(ObjectMissing{}).Trace(targetURL, versionID)
+	}
 	return probe.NewError(e)
 }
 
@@ -311,6 +383,7 @@ type BucketInfo struct {
 	} `json:"ObjectLock,omitempty"`
 	Replication struct {
 		Enabled bool               `json:"enabled"`
+		Targets int                `json:"targets"`
 		Config  replication.Config `json:"config,omitempty"`
 	} `json:"Replication"`
 	Policy struct {
@@ -325,6 +398,7 @@ type BucketInfo struct {
 	Notification struct {
 		Config notification.Configuration `json:"config,omitempty"`
 	} `json:"notification,omitempty"`
+	Quota *madmin.BucketQuota `json:"quota,omitempty"`
 }
 
 // Tags returns stringified tag list.
@@ -501,6 +575,17 @@ func prettyPrintBucketMetadata(info BucketInfo) string {
 	if info.Replication.Enabled {
 		fmt.Fprintf(&b, "%2s%s", placeHolder, "Replication: ")
 		fmt.Fprint(&b, console.Colorize("Set", "Enabled"))
+		if info.Replication.Targets > 0 {
+			fmt.Fprintf(&b, " (%d target(s))", info.Replication.Targets)
+		}
+		fmt.Fprintln(&b)
+	}
+	if info.Quota != nil {
+		fmt.Fprintf(&b, "%2s%s", placeHolder, "Quota: ")
+		fmt.Fprint(&b, console.Colorize("Value", humanize.IBytes(info.Quota.Quota)))
+		if info.Quota.Type != "" {
+			fmt.Fprintf(&b, " (%s)", info.Quota.Type)
+		}
 		fmt.Fprintln(&b)
 	}
 	fmt.Fprintf(&b, "%2s%s", placeHolder, "Location: ")
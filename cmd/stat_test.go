@@ -68,3 +68,27 @@ func TestParseStat(t *testing.T) {
 		})
 	}
 }
+
+func TestStatVersionMessage(t *testing.T) {
+	content := ClientContent{URL: *newClientURL("https://play.min.io/bucket/object"), Type: 0o644, VersionID: "v1", IsDeleteMarker: true, Metadata: map[string]string{}}
+	statMsg := parseStat(&content)
+	if !statMsg.DeleteMarker {
+		t.Fatal("Expecting the delete marker to be reported")
+	}
+
+	statMsg.DeleteMarker = false
+	statMsg.Version = &statVersion{IsLatest: true}
+	statMsg.Tags = map[string]string{"team": "storage"}
+	msg := statMsg.String()
+	for _, want := range []string{"v1 (latest)", "Tags", "team: storage"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expecting %q in %q", want, msg)
+		}
+	}
+	js := statMsg.JSON()
+	for _, want := range []string{`"isLatest":true`, `"deleteMarker":false`, `"team":"storage"`} {
+		if !strings.Contains(js, want) {
+			t.Errorf("Expecting %q in %s", want, js)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"context"
+	gojson "encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// maximum number of log lines collected per bundle
+	diagLocalMaxLogLines = 1000
+	// stop waiting for more log lines after the server is idle for this long
+	diagLocalLogIdleTimeout = 2 * time.Second
+
+	diagLocalMaskedValue = "*redacted*"
+)
+
+// diagManifestEntry describes an item collected into, or skipped from, a local bundle.
+type diagManifestEntry struct {
+	Name   string `json:"name"`
+	File   string `json:"file,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// diagManifest is stored as manifest.json inside a local diagnostics bundle.
+type diagManifest struct {
+	Version    string              `json:"version"`
+	Alias      string              `json:"alias"`
+	Created    time.Time           `json:"created"`
+	Anonymized bool                `json:"anonymized"`
+	Collected  []diagManifestEntry `json:"collected"`
+	Skipped    []diagManifestEntry `json:"skipped,omitempty"`
+}
+
+func (m *diagManifest) collected(name, file string) {
+	m.Collected = append(m.Collected, diagManifestEntry{Name: name, File: file})
+}
+
+func (m *diagManifest) skipped(name, reason string) {
+	m.Skipped = append(m.Skipped, diagManifestEntry{Name: name, Reason: reason})
+}
+
+// supportDiagLocalMessage is printed once a local bundle is written.
+type supportDiagLocalMessage struct {
+	Status   string       `json:"status"`
+	Bundle   string       `json:"bundle"`
+	Manifest diagManifest `json:"manifest"`
+}
+
+func (s supportDiagLocalMessage) String() string {
+	console.SetColor("DiagSkipped", color.New(color.FgYellow))
+	var b strings.Builder
+	for _, c := range s.Manifest.Collected {
+		fmt.Fprintf(&b, "Collected %s (%s)\n", c.Name, c.File)
+	}
+	for _, sk := range s.Manifest.Skipped {
+		b.WriteString(console.Colorize("DiagSkipped", fmt.Sprintf("Skipped %s: %s", sk.Name, sk.Reason)) + "\n")
+	}
+	fmt.Fprintf(&b, "MinIO diagnostics bundle saved at %s", s.Bundle)
+	return b.String()
+}
+
+func (s supportDiagLocalMessage) JSON() string {
+	s.Status = "success"
+	jsonBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonBytes)
+}
+
+// diagSecretKeyRegex matches config keys whose values must never leave the cluster.
+var diagSecretKeyRegex = regexp.MustCompile(`(?i)(secret|password|token|private|credential|auth_key|client_key)`)
+
+// maskDiagSecrets replaces the values of secret looking keys, walking
+// both JSON objects and the "key=value" lines of the server config.
+func maskDiagSecrets(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, sub := range val {
+			if s, ok := sub.(string); ok && s != "" && diagSecretKeyRegex.MatchString(k) {
+				val[k] = diagLocalMaskedValue
+				continue
+			}
+			val[k] = maskDiagSecrets(sub)
+		}
+		return val
+	case []interface{}:
+		for i := range val {
+			val[i] = maskDiagSecrets(val[i])
+		}
+		return val
+	case string:
+		return maskDiagConfigLine(val)
+	}
+	return v
+}
+
+var diagConfigKVRegex = regexp.MustCompile(`([A-Za-z0-9_]+)=("[^"]*"|[^\s]*)`)
+
+func maskDiagConfigLine(s string) string {
+	if !strings.Contains(s, "=") {
+		return s
+	}
+	return diagConfigKVRegex.ReplaceAllStringFunc(s, func(kv string) string {
+		parts := strings.SplitN(kv, "=", 2)
+		if !diagSecretKeyRegex.MatchString(parts[0]) || parts[1] == "" || parts[1] == `""` {
+			return kv
+		}
+		return parts[0] + "=" + diagLocalMaskedValue
+	})
+}
+
+var (
+	diagIPv4Regex = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	diagIPv6Regex = regexp.MustCompile(`\b(?:[0-9a-fA-F]{1,4}:){3,7}[0-9a-fA-F]{1,4}\b`)
+)
+
+// diagAnonymizer replaces hostnames and IP addresses with stable
+// placeholders so that the same host maps to the same name everywhere
+// in a bundle.
+type diagAnonymizer struct {
+	hosts   []string
+	mapping map[string]string
+	ips     int
+}
+
+func newDiagAnonymizer(hosts []string) *diagAnonymizer {
+	a := &diagAnonymizer{mapping: map[string]string{}}
+	for _, h := range hosts {
+		if h == "" || net.ParseIP(h) != nil {
+			continue
+		}
+		if _, ok := a.mapping[h]; !ok {
+			a.mapping[h] = fmt.Sprintf("host-%d", len(a.hosts)+1)
+			a.hosts = append(a.hosts, h)
+		}
+	}
+	// Replace longer names first so that a hostname which is a prefix
+	// of another one does not leave partial leftovers.
+	sort.SliceStable(a.hosts, func(i, j int) bool { return len(a.hosts[i]) > len(a.hosts[j]) })
+	return a
+}
+
+func (a *diagAnonymizer) ip(s string) string {
+	if net.ParseIP(s) == nil {
+		return s
+	}
+	if r, ok := a.mapping[s]; ok {
+		return r
+	}
+	a.ips++
+	r := fmt.Sprintf("ip-%d", a.ips)
+	a.mapping[s] = r
+	return r
+}
+
+func (a *diagAnonymizer) scrub(data []byte) []byte {
+	s := string(data)
+	for _, h := range a.hosts {
+		s = strings.ReplaceAll(s, h, a.mapping[h])
+	}
+	s = diagIPv6Regex.ReplaceAllStringFunc(s, a.ip)
+	s = diagIPv4Regex.ReplaceAllStringFunc(s, a.ip)
+	return []byte(s)
+}
+
+// diagHostnames returns the hostnames of the alias and of every server
+// endpoint found in the health information.
+func diagHostnames(aliasURL string, health []byte) []string {
+	var hosts []string
+	addHost := func(endpoint string) {
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "http://" + endpoint
+		}
+		if u, e := url.Parse(endpoint); e == nil && u.Hostname() != "" {
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	addHost(aliasURL)
+	gjson.GetBytes(health, "minio.info.servers.#.endpoint").ForEach(func(_, v gjson.Result) bool {
+		addHost(v.String())
+		return true
+	})
+	return hosts
+}
+
+// offlineDiagServers returns the endpoints reported as not online.
+func offlineDiagServers(health []byte) []string {
+	var offline []string
+	gjson.GetBytes(health, "minio.info.servers").ForEach(func(_, v gjson.Result) bool {
+		if state := v.Get("state").String(); state != "" && state != string(madmin.ItemOnline) {
+			offline = append(offline, v.Get("endpoint").String())
+		}
+		return true
+	})
+	return offline
+}
+
+// fetchDiagLogs collects the most recent server log lines, returning as
+// soon as the server stops sending buffered entries.
+func fetchDiagLogs(client *madmin.AdminClient) ([]byte, int, error) {
+	ctx, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	var buf []byte
+	lines := 0
+	logCh := client.GetLogs(ctx, "", diagLocalMaxLogLines, "all")
+	idle := time.NewTimer(diagLocalLogIdleTimeout)
+	defer idle.Stop()
+	for lines < diagLocalMaxLogLines {
+		select {
+		case l, ok := <-logCh:
+			if !ok {
+				return buf, lines, nil
+			}
+			if l.Err != nil {
+				return buf, lines, l.Err
+			}
+			b, e := gojson.Marshal(l)
+			if e != nil {
+				return buf, lines, e
+			}
+			buf = append(append(buf, b...), '\n')
+			lines++
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(diagLocalLogIdleTimeout)
+		case <-idle.C:
+			return buf, lines, nil
+		}
+	}
+	return buf, lines, nil
+}
+
+// writeDiagZip writes the given files, plus the manifest, into a new zip archive.
+func writeDiagZip(filename string, names []string, files map[string][]byte) error {
+	f, e := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if e != nil {
+		return e
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range names {
+		w, e := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: UTCNow()})
+		if e != nil {
+			f.Close()
+			return e
+		}
+		if _, e = w.Write(files[name]); e != nil {
+			f.Close()
+			return e
+		}
+	}
+	if e = zw.Close(); e != nil {
+		f.Close()
+		return e
+	}
+	return f.Close()
+}
+
+// validateSupportDiagLocalFlags rejects flags that only make sense when talking to SUBNET.
+func validateSupportDiagLocalFlags(ctx *cli.Context) error {
+	if ctx.String("api-key") != "" {
+		return fmt.Errorf("--api-key is not applicable with --local-only")
+	}
+	return nil
+}
+
+// execSupportDiagLocal gathers diagnostics into a local zip bundle,
+// without contacting SUBNET at any point.
+func execSupportDiagLocal(ctx *cli.Context, client *madmin.AdminClient, alias, aliasURL string) {
+	anonymize := ctx.Bool("anonymize")
+	filename := fmt.Sprintf("%s-diag_%s.zip", filepath.Clean(alias), UTCNow().Format("20060102150405"))
+
+	healthInfo, version, e := fetchServerDiagInfo(ctx, client)
+	fatalIf(probe.NewError(e), "Unable to fetch health information.")
+
+	raw, e := gojson.Marshal(healthInfo)
+	fatalIf(probe.NewError(e), "Unable to marshal health information.")
+	var generic interface{}
+	e = gojson.Unmarshal(raw, &generic)
+	fatalIf(probe.NewError(e), "Unable to parse health information.")
+	health, e := gojson.MarshalIndent(maskDiagSecrets(generic), "", "  ")
+	fatalIf(probe.NewError(e), "Unable to marshal health information.")
+
+	manifest := diagManifest{
+		Version:    version,
+		Alias:      alias,
+		Created:    UTCNow(),
+		Anonymized: anonymize,
+	}
+	files := map[string][]byte{"health.json": health}
+	names := []string{"health.json"}
+
+	manifest.collected("health", "health.json")
+	if gjson.GetBytes(health, "minio.config.config").Exists() {
+		manifest.collected("config (secrets masked)", "health.json")
+	} else {
+		manifest.skipped("config", "server did not return its configuration")
+	}
+	if gjson.GetBytes(health, "sys.cpus").Exists() || gjson.GetBytes(health, "sys.meminfo").Exists() {
+		manifest.collected("perf (cpu, memory, process load)", "health.json")
+	} else {
+		manifest.skipped("perf", "server did not return system metrics")
+	}
+	for _, endpoint := range offlineDiagServers(health) {
+		manifest.skipped("server "+endpoint, "server is offline")
+	}
+
+	logs, lines, e := fetchDiagLogs(client)
+	switch {
+	case e != nil:
+		manifest.skipped("logs", e.Error())
+	case lines == 0:
+		manifest.skipped("logs", "no log entries returned by the servers")
+	default:
+		files["logs.json"] = logs
+		names = append(names, "logs.json")
+		manifest.collected(fmt.Sprintf("logs (%d lines)", lines), "logs.json")
+	}
+
+	var anonymizer *diagAnonymizer
+	if anonymize {
+		anonymizer = newDiagAnonymizer(diagHostnames(aliasURL, health))
+		manifest.Alias = "cluster"
+		for _, name := range names {
+			files[name] = anonymizer.scrub(files[name])
+		}
+	}
+
+	manifestBytes, e := gojson.MarshalIndent(manifest, "", "  ")
+	fatalIf(probe.NewError(e), "Unable to marshal bundle manifest.")
+	if anonymizer != nil {
+		manifestBytes = anonymizer.scrub(manifestBytes)
+		e = gojson.Unmarshal(manifestBytes, &manifest)
+		fatalIf(probe.NewError(e), "Unable to parse bundle manifest.")
+	}
+	files["manifest.json"] = manifestBytes
+	names = append(names, "manifest.json")
+
+	e = writeDiagZip(filename, names, files)
+	fatalIf(probe.NewError(e), "Unable to save MinIO diagnostics bundle")
+
+	printMsg(supportDiagLocalMessage{Bundle: filename, Manifest: manifest})
+}
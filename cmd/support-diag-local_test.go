@@ -0,0 +1,67 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	gojson "encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaskDiagSecrets(t *testing.T) {
+	var v interface{}
+	in := `{"config":{"identity_openid":"client_id=abc client_secret=shh enable=on"},"nested":[{"password":"pw","user":"bob"}]}`
+	if e := gojson.Unmarshal([]byte(in), &v); e != nil {
+		t.Fatal(e)
+	}
+	out, e := gojson.Marshal(maskDiagSecrets(v))
+	if e != nil {
+		t.Fatal(e)
+	}
+	got := string(out)
+	for _, secret := range []string{"shh", `"pw"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("secret %s was not masked: %s", secret, got)
+		}
+	}
+	for _, kept := range []string{"client_id=abc", "enable=on", `"bob"`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("expected %s to be kept: %s", kept, got)
+		}
+	}
+}
+
+func TestDiagAnonymizer(t *testing.T) {
+	health := []byte(`{"minio":{"info":{"servers":[{"endpoint":"node1.example.com:9000","state":"online"},{"endpoint":"node10.example.com:9000","state":"offline"}]}}}`)
+	hosts := diagHostnames("https://10.0.0.5:9000", health)
+	if !reflect.DeepEqual(hosts, []string{"10.0.0.5", "node1.example.com", "node10.example.com"}) {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+
+	a := newDiagAnonymizer(hosts)
+	got := string(a.scrub([]byte("node10.example.com node1.example.com 10.0.0.5 10.0.0.5 192.168.1.1 fe80:0:0:0:1:2:3:4 12:30:45")))
+	want := "host-2 host-1 ip-2 ip-2 ip-3 ip-1 12:30:45"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if offline := offlineDiagServers(health); !reflect.DeepEqual(offline, []string{"node10.example.com:9000"}) {
+		t.Errorf("unexpected offline servers %v", offline)
+	}
+}
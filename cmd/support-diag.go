@@ -64,6 +64,14 @@ var supportDiagFlags = append([]cli.Flag{
 		Usage:  "Specify the name to associate to this MinIO cluster in SUBNET",
 		Hidden: true, // deprecated may 2022
 	},
+	cli.BoolFlag{
+		Name:  "local-only",
+		Usage: "save a local zip bundle of health, config, logs and perf info without contacting SUBNET",
+	},
+	cli.BoolFlag{
+		Name:  "anonymize",
+		Usage: "scrub hostnames and IP addresses from the bundle, requires --local-only",
+	},
 }, subnetCommonFlags...)
 
 var supportDiagCmd = cli.Command{
@@ -89,6 +97,12 @@ EXAMPLES:
 
   2. Generate MinIO diagnostics report for cluster with alias 'myminio', save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} myminio --airgap
+
+  3. Save a diagnostics bundle for cluster with alias 'myminio' locally, without contacting SUBNET
+     {{.Prompt}} {{.HelpName}} myminio --local-only
+
+  4. Save a local diagnostics bundle with hostnames and IP addresses scrubbed, to share by email
+     {{.Prompt}} {{.HelpName}} myminio --local-only --anonymize
 `,
 }
 
@@ -97,6 +111,9 @@ func checkSupportDiagSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.Bool("anonymize") && !ctx.Bool("local-only") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--anonymize requires --local-only")
+	}
 }
 
 // compress and tar MinIO diagnostics output
@@ -159,6 +176,18 @@ func mainSupportDiag(ctx *cli.Context) error {
 
 	// Get the alias parameter from cli
 	aliasedURL := ctx.Args().Get(0)
+
+	// `--local-only` never talks to SUBNET, hence neither
+	// connectivity nor cluster registration is checked.
+	if ctx.Bool("local-only") {
+		fatalIf(probe.NewError(validateSupportDiagLocalFlags(ctx)), "Invalid flags:")
+		globalAirgapped = true
+		alias, _ := url2Alias(aliasedURL)
+		client := getClient(aliasedURL)
+		execSupportDiagLocal(ctx, client, alias, mustGetHostConfig(alias).URL)
+		return nil
+	}
+
 	alias, apiKey := initSubnetConnectivity(ctx, aliasedURL, true)
 	if len(apiKey) == 0 {
 		// api key not passed as flag. Check that the cluster is registered.
@@ -0,0 +1,458 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go/v2"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var supportHealthCheckFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "config",
+		Usage: "JSON file with the checks to skip and the thresholds of the checks",
+	},
+	cli.StringSliceFlag{
+		Name:  "skip",
+		Usage: "skip a check, may be repeated",
+	},
+}
+
+var supportHealthCheckCmd = cli.Command{
+	Name:            "health-check",
+	Usage:           "check the health of a cluster against best practice thresholds",
+	Action:          mainSupportHealthCheck,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(supportHealthCheckFlags, supportGlobalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  The checks only use the server, heal and data usage information of the cluster, nothing is
+  uploaded to SUBNET. Each check passes, warns or fails, the command exits with an error when
+  a check fails.
+
+CHECKS:
+  offline-nodes     servers which are not online
+  offline-drives    drives which are not ok, fails when an erasure set has more offline drives than parity
+  drive-usage       drives near full
+  pool-balance      pools with an unbalanced usage
+  heal-backlog      drives being healed and objects waiting to be healed
+  server-versions   servers running different MinIO versions
+  object-versions   buckets with many versions per object
+
+CONFIG:
+  The JSON config file skips checks and changes the thresholds, which default to:
+  {
+    "skip": [],
+    "driveUsageWarn": 80,
+    "driveUsageFail": 95,
+    "poolBalanceWarn": 20,
+    "healBacklogWarn": 1000,
+    "versionsPerObjectWarn": 10
+  }
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Check the health of the cluster with alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Check the health of the cluster from a CI job, without the version checks, failing the job when a check fails.
+     {{.Prompt}} {{.HelpName}} --json --skip server-versions --skip object-versions myminio
+
+  3. Check the health of the cluster with the thresholds of 'health.json'.
+     {{.Prompt}} {{.HelpName}} --config health.json myminio
+`,
+}
+
+// Results of a health check.
+const (
+	healthCheckPass = "PASS"
+	healthCheckWarn = "WARN"
+	healthCheckFail = "FAIL"
+)
+
+// healthCheckConfig holds the checks to skip and the thresholds, usage
+// thresholds are percentages.
+type healthCheckConfig struct {
+	Skip                  []string `json:"skip"`
+	DriveUsageWarn        float64  `json:"driveUsageWarn"`
+	DriveUsageFail        float64  `json:"driveUsageFail"`
+	PoolBalanceWarn       float64  `json:"poolBalanceWarn"`
+	HealBacklogWarn       uint64   `json:"healBacklogWarn"`
+	VersionsPerObjectWarn float64  `json:"versionsPerObjectWarn"`
+}
+
+func defaultHealthCheckConfig() healthCheckConfig {
+	return healthCheckConfig{
+		DriveUsageWarn:        80,
+		DriveUsageFail:        95,
+		PoolBalanceWarn:       20,
+		HealBacklogWarn:       1000,
+		VersionsPerObjectWarn: 10,
+	}
+}
+
+// healthCheckInput is the cluster information evaluated by the checks,
+// the heal status and the data usage are nil when they are unavailable.
+type healthCheckInput struct {
+	info     madmin.InfoMessage
+	heal     *madmin.BgHealState
+	healErr  error
+	usage    *madmin.DataUsageInfo
+	usageErr error
+}
+
+// healthCheckMessage is the result of a check.
+type healthCheckMessage struct {
+	Status  string `json:"status"`
+	Check   string `json:"check"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+func (h healthCheckMessage) String() string {
+	msg := fmt.Sprintf("%s  %-16s %s", console.Colorize("HealthCheck"+h.Result, h.Result), h.Check, h.Message)
+	if h.Hint != "" {
+		msg += "\n      " + console.Colorize("HealthCheckHint", "hint: "+h.Hint)
+	}
+	return msg
+}
+
+func (h healthCheckMessage) JSON() string {
+	h.Status = "success"
+	return toJSON(h)
+}
+
+// healthChecks are all the checks, in the order they are run.
+var healthChecks = []struct {
+	name string
+	run  func(in healthCheckInput, cfg healthCheckConfig) healthCheckMessage
+}{
+	{"offline-nodes", checkOfflineNodes},
+	{"offline-drives", checkOfflineDrives},
+	{"drive-usage", checkDriveUsage},
+	{"pool-balance", checkPoolBalance},
+	{"heal-backlog", checkHealBacklog},
+	{"server-versions", checkServerVersions},
+	{"object-versions", checkObjectVersions},
+}
+
+func checkOfflineNodes(in healthCheckInput, _ healthCheckConfig) healthCheckMessage {
+	var offline []string
+	for _, server := range in.info.Servers {
+		if server.State != string(madmin.ItemOnline) {
+			offline = append(offline, server.Endpoint)
+		}
+	}
+	if len(offline) > 0 {
+		return healthCheckMessage{
+			Result:  healthCheckFail,
+			Message: fmt.Sprintf("%d of %d servers are offline: %s", len(offline), len(in.info.Servers), strings.Join(offline, ", ")),
+			Hint:    "check the network connectivity and the MinIO process of the offline servers",
+		}
+	}
+	return healthCheckMessage{Result: healthCheckPass, Message: fmt.Sprintf("all %d servers are online", len(in.info.Servers))}
+}
+
+func checkOfflineDrives(in healthCheckInput, _ healthCheckConfig) healthCheckMessage {
+	parity := 0
+	if in.heal != nil {
+		parity = in.heal.SCParity["STANDARD"]
+	}
+	var total, offline int
+	offlineBySet := make(map[string]int)
+	for _, server := range in.info.Servers {
+		for _, drive := range server.Disks {
+			total++
+			if drive.State != madmin.DriveStateOk {
+				offline++
+				offlineBySet[fmt.Sprintf("pool %d set %d", drive.PoolIndex+1, drive.SetIndex+1)]++
+			}
+		}
+	}
+	if offline == 0 {
+		return healthCheckMessage{Result: healthCheckPass, Message: fmt.Sprintf("all %d drives are ok", total)}
+	}
+	var lost []string
+	for set, count := range offlineBySet {
+		if parity > 0 && count > parity {
+			lost = append(lost, set)
+		}
+	}
+	if len(lost) > 0 {
+		sort.Strings(lost)
+		return healthCheckMessage{
+			Result:  healthCheckFail,
+			Message: fmt.Sprintf("%d of %d drives are offline, more than the parity %d in %s", offline, total, parity, strings.Join(lost, ", ")),
+			Hint:    "bring the offline drives back online, the objects of these erasure sets are not available",
+		}
+	}
+	return healthCheckMessage{
+		Result:  healthCheckWarn,
+		Message: fmt.Sprintf("%d of %d drives are offline", offline, total),
+		Hint:    "replace the failed drives, they are healed automatically once replaced",
+	}
+}
+
+func checkDriveUsage(in healthCheckInput, cfg healthCheckConfig) healthCheckMessage {
+	var highest float64
+	var endpoint string
+	for _, server := range in.info.Servers {
+		for _, drive := range server.Disks {
+			if drive.TotalSpace == 0 {
+				continue
+			}
+			if usage := float64(drive.UsedSpace) * 100 / float64(drive.TotalSpace); usage > highest {
+				highest, endpoint = usage, drive.Endpoint
+			}
+		}
+	}
+	msg := healthCheckMessage{Result: healthCheckPass, Message: fmt.Sprintf("the fullest drive is %.1f%% used", highest)}
+	if endpoint != "" {
+		msg.Message += " (" + endpoint + ")"
+	}
+	switch {
+	case highest >= cfg.DriveUsageFail:
+		msg.Result = healthCheckFail
+	case highest >= cfg.DriveUsageWarn:
+		msg.Result = healthCheckWarn
+	}
+	if msg.Result != healthCheckPass {
+		msg.Hint = "expand the cluster with a new pool or remove data, writes fail once drives are full"
+	}
+	return msg
+}
+
+func checkPoolBalance(in healthCheckInput, cfg healthCheckConfig) healthCheckMessage {
+	type poolUsage struct{ used, total uint64 }
+	pools := make(map[int]*poolUsage)
+	for _, server := range in.info.Servers {
+		for _, drive := range server.Disks {
+			if drive.PoolIndex < 0 || drive.TotalSpace == 0 {
+				continue
+			}
+			if pools[drive.PoolIndex] == nil {
+				pools[drive.PoolIndex] = &poolUsage{}
+			}
+			pools[drive.PoolIndex].used += drive.UsedSpace
+			pools[drive.PoolIndex].total += drive.TotalSpace
+		}
+	}
+	if len(pools) < 2 {
+		return healthCheckMessage{Result: healthCheckPass, Message: fmt.Sprintf("%d pool, nothing to balance", len(pools))}
+	}
+	lowest, highest := 100.0, 0.0
+	for _, pool := range pools {
+		usage := float64(pool.used) * 100 / float64(pool.total)
+		if usage < lowest {
+			lowest = usage
+		}
+		if usage > highest {
+			highest = usage
+		}
+	}
+	msg := healthCheckMessage{
+		Result:  healthCheckPass,
+		Message: fmt.Sprintf("pool usage ranges from %.1f%% to %.1f%% across %d pools", lowest, highest, len(pools)),
+	}
+	if highest-lowest > cfg.PoolBalanceWarn {
+		msg.Result = healthCheckWarn
+		msg.Hint = "rebalance the pools with 'mc admin rebalance start'"
+	}
+	return msg
+}
+
+func checkHealBacklog(in healthCheckInput, cfg healthCheckConfig) healthCheckMessage {
+	if in.heal == nil {
+		return healthCheckMessage{Result: healthCheckWarn, Message: fmt.Sprintf("unable to get the heal status: %v", in.healErr)}
+	}
+	var pending uint64
+	for _, mrf := range in.heal.MRF {
+		if mrf.TotalItems > mrf.ItemsHealed {
+			pending += mrf.TotalItems - mrf.ItemsHealed
+		}
+	}
+	msg := healthCheckMessage{
+		Result:  healthCheckPass,
+		Message: fmt.Sprintf("%d drives healing, %d objects waiting to be healed", len(in.heal.HealDisks), pending),
+	}
+	if len(in.heal.HealDisks) > 0 || pending > cfg.HealBacklogWarn {
+		msg.Result = healthCheckWarn
+		msg.Hint = "follow the healing with 'mc admin heal', avoid replacing more drives until it completes"
+	}
+	return msg
+}
+
+func checkServerVersions(in healthCheckInput, _ healthCheckConfig) healthCheckMessage {
+	versions := make(map[string]int)
+	for _, server := range in.info.Servers {
+		if server.Version != "" {
+			versions[server.Version]++
+		}
+	}
+	if len(versions) > 1 {
+		var list []string
+		for version, count := range versions {
+			list = append(list, fmt.Sprintf("%s (%d)", version, count))
+		}
+		sort.Strings(list)
+		return healthCheckMessage{
+			Result:  healthCheckWarn,
+			Message: fmt.Sprintf("servers run %d different versions: %s", len(versions), strings.Join(list, ", ")),
+			Hint:    "update all the servers to the same version with 'mc admin update'",
+		}
+	}
+	return healthCheckMessage{Result: healthCheckPass, Message: "all servers run the same version"}
+}
+
+func checkObjectVersions(in healthCheckInput, cfg healthCheckConfig) healthCheckMessage {
+	if in.usage == nil {
+		return healthCheckMessage{Result: healthCheckWarn, Message: fmt.Sprintf("unable to get the data usage: %v", in.usageErr)}
+	}
+	var skewed []string
+	for bucket, usage := range in.usage.BucketsUsage {
+		if usage.ObjectsCount > 0 && float64(usage.VersionsCount)/float64(usage.ObjectsCount) > cfg.VersionsPerObjectWarn {
+			skewed = append(skewed, fmt.Sprintf("%s (%.1f)", bucket, float64(usage.VersionsCount)/float64(usage.ObjectsCount)))
+		}
+	}
+	if len(skewed) > 0 {
+		sort.Strings(skewed)
+		return healthCheckMessage{
+			Result:  healthCheckWarn,
+			Message: fmt.Sprintf("%d buckets have more than %g versions per object: %s", len(skewed), cfg.VersionsPerObjectWarn, strings.Join(skewed, ", ")),
+			Hint:    "expire the noncurrent versions with 'mc ilm rule add --noncurrent-expire-days'",
+		}
+	}
+	return healthCheckMessage{Result: healthCheckPass, Message: fmt.Sprintf("no bucket has more than %g versions per object", cfg.VersionsPerObjectWarn)}
+}
+
+// runHealthChecks runs all the checks which are not skipped.
+func runHealthChecks(in healthCheckInput, cfg healthCheckConfig) []healthCheckMessage {
+	skip := make(map[string]bool)
+	for _, name := range cfg.Skip {
+		skip[name] = true
+	}
+	var results []healthCheckMessage
+	for _, check := range healthChecks {
+		if skip[check.name] {
+			continue
+		}
+		result := check.run(in, cfg)
+		result.Check = check.name
+		results = append(results, result)
+	}
+	return results
+}
+
+// loadHealthCheckConfig returns the config of the checks, with the
+// defaults overridden by the config file and the checks skipped by flags.
+func loadHealthCheckConfig(ctx *cli.Context) (healthCheckConfig, *probe.Error) {
+	cfg := defaultHealthCheckConfig()
+	if file := ctx.String("config"); file != "" {
+		data, e := os.ReadFile(file)
+		if e != nil {
+			return cfg, probe.NewError(e)
+		}
+		if e = gojson.Unmarshal(data, &cfg); e != nil {
+			return cfg, probe.NewError(e)
+		}
+	}
+	cfg.Skip = append(cfg.Skip, ctx.StringSlice("skip")...)
+	for _, name := range cfg.Skip {
+		known := false
+		for _, check := range healthChecks {
+			known = known || check.name == name
+		}
+		if !known {
+			return cfg, probe.NewError(fmt.Errorf("unknown check `%s`", name))
+		}
+	}
+	if cfg.DriveUsageWarn > cfg.DriveUsageFail {
+		return cfg, probe.NewError(errors.New("driveUsageWarn cannot be greater than driveUsageFail"))
+	}
+	return cfg, nil
+}
+
+func checkSupportHealthCheckSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		showCommandHelpAndExit(ctx, 1) // last argument is exit code
+	}
+}
+
+// mainSupportHealthCheck is the handler for "mc support health-check" command.
+func mainSupportHealthCheck(ctx *cli.Context) error {
+	checkSupportHealthCheckSyntax(ctx)
+
+	console.SetColor("HealthCheck"+healthCheckPass, color.New(color.FgGreen, color.Bold))
+	console.SetColor("HealthCheck"+healthCheckWarn, color.New(color.FgYellow, color.Bold))
+	console.SetColor("HealthCheck"+healthCheckFail, color.New(color.FgRed, color.Bold))
+	console.SetColor("HealthCheckHint", color.New(color.Italic))
+
+	cfg, err := loadHealthCheckConfig(ctx)
+	fatalIf(err, "Unable to load the health check config.")
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var in healthCheckInput
+	var e error
+	in.info, e = client.ServerInfo(globalContext)
+	fatalIf(probe.NewError(e), "Unable to get the server information of `"+aliasedURL+"`.")
+
+	// The checks using the heal status or the data usage warn when they
+	// are unavailable instead of failing the whole command.
+	if heal, e := client.BackgroundHealStatus(globalContext); e == nil {
+		in.heal = &heal
+	} else {
+		in.healErr = e
+	}
+	if usage, e := client.DataUsageInfo(globalContext); e == nil {
+		in.usage = &usage
+	} else {
+		in.usageErr = e
+	}
+
+	failed := false
+	for _, result := range runHealthChecks(in, cfg) {
+		printMsg(result)
+		failed = failed || result.Result == healthCheckFail
+	}
+	if failed {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
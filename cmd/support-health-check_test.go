@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go/v2"
+)
+
+func TestRunHealthChecks(t *testing.T) {
+	drive := func(pool, set int, state string, used, total uint64) madmin.Disk {
+		return madmin.Disk{PoolIndex: pool, SetIndex: set, State: state, UsedSpace: used, TotalSpace: total}
+	}
+	in := healthCheckInput{
+		info: madmin.InfoMessage{Servers: []madmin.ServerProperties{
+			{
+				State: "online", Endpoint: "node1:9000", Version: "2023-01-01",
+				Disks: []madmin.Disk{drive(0, 0, "ok", 50, 100), drive(0, 0, "offline", 0, 0)},
+			},
+			{
+				State: "online", Endpoint: "node2:9000", Version: "2023-01-01",
+				Disks: []madmin.Disk{drive(1, 0, "ok", 85, 100), drive(1, 0, "ok", 85, 100)},
+			},
+		}},
+		heal: &madmin.BgHealState{SCParity: map[string]int{"STANDARD": 1}},
+		usage: &madmin.DataUsageInfo{BucketsUsage: map[string]madmin.BucketUsageInfo{
+			"logs":   {ObjectsCount: 10, VersionsCount: 200},
+			"photos": {ObjectsCount: 10, VersionsCount: 10},
+		}},
+	}
+
+	expected := map[string]string{
+		"offline-nodes":   healthCheckPass,
+		"offline-drives":  healthCheckWarn,
+		"drive-usage":     healthCheckWarn,
+		"pool-balance":    healthCheckWarn,
+		"heal-backlog":    healthCheckPass,
+		"server-versions": healthCheckPass,
+		"object-versions": healthCheckWarn,
+	}
+	results := runHealthChecks(in, defaultHealthCheckConfig())
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for _, result := range results {
+		if result.Result != expected[result.Check] {
+			t.Errorf("%s: expected %s, got %s: %s", result.Check, expected[result.Check], result.Result, result.Message)
+		}
+	}
+
+	// A second offline drive in the set is more than the parity.
+	in.info.Servers[0].Disks[0].State = "offline"
+	in.info.Servers[1].State = "offline"
+	in.heal = &madmin.BgHealState{SCParity: map[string]int{"STANDARD": 1}, HealDisks: []string{"node1:9000/d1"}}
+	cfg := defaultHealthCheckConfig()
+	cfg.Skip = []string{"object-versions"}
+	cfg.DriveUsageFail = 85
+	expected = map[string]string{
+		"offline-nodes":   healthCheckFail,
+		"offline-drives":  healthCheckFail,
+		"drive-usage":     healthCheckFail,
+		"pool-balance":    healthCheckWarn,
+		"heal-backlog":    healthCheckWarn,
+		"server-versions": healthCheckPass,
+	}
+	results = runHealthChecks(in, cfg)
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+	}
+	for _, result := range results {
+		if result.Result != expected[result.Check] {
+			t.Errorf("%s: expected %s, got %s: %s", result.Check, expected[result.Check], result.Result, result.Message)
+		}
+	}
+}
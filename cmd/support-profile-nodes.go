@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/wildcard"
+
+	gojson "encoding/json"
+)
+
+// Manifest of a profile bundle restricted to some nodes with --nodes.
+const profileManifestFile = "profile-manifest.json"
+
+type profileManifest struct {
+	Profilers string   `json:"profilers"`
+	Duration  int      `json:"duration"`
+	Patterns  []string `json:"patterns"`
+	Nodes     []string `json:"nodes"`
+}
+
+// parseProfileNodes parses the comma separated node patterns of --nodes.
+func parseProfileNodes(nodes string) (patterns []string) {
+	for _, pattern := range strings.Split(nodes, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// matchProfileNode returns whether a node, 'host:port', matches one
+// of the patterns, with or without its port.
+func matchProfileNode(node string, patterns []string) bool {
+	host, _, e := net.SplitHostPort(node)
+	if e != nil {
+		host = node
+	}
+	for _, pattern := range patterns {
+		if wildcard.Match(pattern, node) || wildcard.Match(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterProfileBundle keeps the profiles of the nodes matching the
+// patterns in a profile bundle, along with a manifest listing them.
+func filterProfileBundle(bundle string, manifest profileManifest) ([]string, *probe.Error) {
+	zr, e := zip.OpenReader(bundle)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(bundle)
+	}
+	defer zr.Close()
+
+	tmpFile, e := os.CreateTemp(filepath.Dir(bundle), "mc-profile-nodes-")
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	profiled := make(map[string]bool)
+	zw := zip.NewWriter(tmpFile)
+	for _, f := range zr.File {
+		if node, _, ok := parseProfileEntryName(f.Name); ok {
+			if _, seen := profiled[node]; !seen {
+				profiled[node] = matchProfileNode(node, manifest.Patterns)
+			}
+			if !profiled[node] {
+				continue
+			}
+		}
+		if e = zw.Copy(f); e != nil {
+			return nil, probe.NewError(e).Trace(bundle, f.Name)
+		}
+	}
+
+	var available []string
+	for node, matched := range profiled {
+		available = append(available, node)
+		if matched {
+			manifest.Nodes = append(manifest.Nodes, node)
+		}
+	}
+	sort.Strings(available)
+	sort.Strings(manifest.Nodes)
+	if len(manifest.Nodes) == 0 {
+		return nil, probe.NewError(fmt.Errorf("no node matches `%s`, the profiled nodes are: %s",
+			strings.Join(manifest.Patterns, ","), strings.Join(available, ", ")))
+	}
+
+	w, e := zw.Create(profileManifestFile)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = gojson.NewEncoder(w).Encode(manifest); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = zw.Close(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = tmpFile.Close(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = os.Rename(tmpFile.Name(), bundle); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return manifest.Nodes, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	gojson "encoding/json"
+)
+
+func TestMatchProfileNode(t *testing.T) {
+	testCases := []struct {
+		node     string
+		patterns []string
+		expected bool
+	}{
+		{"node1:9000", []string{"node1"}, true},
+		{"node1:9000", []string{"node1:9000"}, true},
+		{"node1:9000", []string{"node1:9001"}, false},
+		{"node12:9000", []string{"node1"}, false},
+		{"node12:9000", []string{"node1*"}, true},
+		{"node3:9000", []string{"node1", "node3"}, true},
+		{"10.0.0.4:9000", []string{"10.0.0.*"}, true},
+	}
+	for i, testCase := range testCases {
+		if got := matchProfileNode(testCase.node, testCase.patterns); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestFilterProfileBundle(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "profile.zip")
+	f, e := os.Create(bundle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{
+		"profile-node1:9000-cpu.pprof",
+		"profile-node1:9000-mem.pprof",
+		"profile-node2:9000-cpu.pprof",
+		"profile-node3:9000-cpu.pprof",
+		"README.txt",
+	} {
+		if _, e = zw.Create(name); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if e = zw.Close(); e != nil {
+		t.Fatal(e)
+	}
+	f.Close()
+
+	if _, err := filterProfileBundle(bundle, profileManifest{Patterns: []string{"node9"}}); err == nil {
+		t.Fatalf("expected an error when no node matches")
+	}
+
+	nodes, err := filterProfileBundle(bundle, profileManifest{Profilers: "cpu", Patterns: []string{"node1", "node3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"node1:9000", "node3:9000"}; !reflect.DeepEqual(nodes, expected) {
+		t.Fatalf("expected %v, got %v", expected, nodes)
+	}
+
+	zr, e := zip.OpenReader(bundle)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer zr.Close()
+	var names []string
+	var manifest profileManifest
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name != profileManifestFile {
+			continue
+		}
+		rc, e := f.Open()
+		if e != nil {
+			t.Fatal(e)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if e = gojson.Unmarshal(data, &manifest); e != nil {
+			t.Fatal(e)
+		}
+	}
+	sort.Strings(names)
+	expected := []string{"README.txt", "profile-manifest.json", "profile-node1:9000-cpu.pprof", "profile-node1:9000-mem.pprof", "profile-node3:9000-cpu.pprof"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	if !reflect.DeepEqual(manifest.Nodes, nodes) || manifest.Profilers != "cpu" {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+}
@@ -44,6 +44,10 @@ var (
 			Usage: "profiler type, possible values are 'cpu', 'cpuio', 'mem', 'block', 'mutex', 'trace', 'threads' and 'goroutines'",
 			Value: "cpu,mem,block,mutex,goroutines",
 		},
+		cli.StringFlag{
+			Name:  "nodes",
+			Usage: "only keep the profiles of the nodes matching these comma separated patterns, e.g. 'node1:9000,node2*'",
+		},
 	}, subnetCommonFlags...)
 )
 
@@ -78,6 +82,9 @@ EXAMPLES:
 
   4. Profile CPU for 10 seconds on cluster with alias 'myminio', save and upload to SUBNET manually
      {{.Prompt}} {{.HelpName}} --type cpu --airgap myminio
+
+  5. Profile CPU for 10 seconds on cluster with alias 'myminio', keeping only the nodes 'node3' and 'node7'
+     {{.Prompt}} {{.HelpName}} --type cpu --nodes node3,node7 myminio
 `,
 }
 
@@ -107,6 +114,10 @@ func checkAdminProfileSyntax(ctx *cli.Context) {
 	if ctx.Int("duration") < 10 {
 		fatal(errDummy().Trace(), "profiling must be run for atleast 10 seconds")
 	}
+
+	if ctx.IsSet("nodes") && len(parseProfileNodes(ctx.String("nodes"))) == 0 {
+		fatal(errDummy().Trace(ctx.String("nodes")), "please provide the nodes to profile, for example '--nodes node1:9000,node2*'")
+	}
 }
 
 // moveFile - os.Rename cannot handle cross device renames, in our situation
@@ -136,7 +147,7 @@ func moveFile(sourcePath, destPath string) error {
 	return os.Remove(sourcePath)
 }
 
-func saveProfileFile(data io.ReadCloser) {
+func saveProfileFile(data io.ReadCloser, manifest profileManifest) {
 	// Create profile zip file
 	tmpFile, e := os.CreateTemp("", "mc-profile-")
 	fatalIf(probe.NewError(e), "Unable to download profile data.")
@@ -149,6 +160,15 @@ func saveProfileFile(data io.ReadCloser) {
 	data.Close()
 	tmpFile.Close()
 
+	if len(manifest.Patterns) > 0 {
+		nodes, err := filterProfileBundle(tmpFile.Name(), manifest)
+		if err != nil {
+			os.Remove(tmpFile.Name())
+			fatalIf(err, "Unable to select the profiled nodes")
+		}
+		console.Infof("Kept the profiles of %s... ", strings.Join(nodes, ", "))
+	}
+
 	downloadedFile := profileFile + "." + time.Now().Format(dateTimeFormatFilename)
 
 	fi, e := os.Stat(profileFile)
@@ -201,7 +221,11 @@ func execSupportProfile(ctx *cli.Context, client *madmin.AdminClient, alias stri
 	data, e := client.Profile(globalContext, madmin.ProfilerType(profilers), time.Second*time.Duration(duration))
 	fatalIf(probe.NewError(e), "Unable to save profile data")
 
-	saveProfileFile(data)
+	saveProfileFile(data, profileManifest{
+		Profilers: profilers,
+		Duration:  duration,
+		Patterns:  parseProfileNodes(ctx.String("nodes")),
+	})
 
 	successClr := color.New(color.FgGreen, color.Bold)
 	failureClr := color.New(color.FgRed, color.Bold)
@@ -46,6 +46,7 @@ var supportSubcommands = []cli.Command{
 	supportProfileCmd,
 	supportTopCmd,
 	supportProxyCmd,
+	supportHealthCheckCmd,
 }
 
 var supportCmd = cli.Command{
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+const defaultTagFilterWorkers = 16
+
+var tagFilterFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "tag-filter",
+		Usage: "only transfer objects whose tags match 'key=value', 'key!=value' or 'key', join terms with '&', repeat to match any",
+	},
+	cli.IntFlag{
+		Name:  "tag-filter-workers",
+		Value: defaultTagFilterWorkers,
+		Usage: "number of concurrent tag fetches used by --tag-filter",
+	},
+}
+
+// tagFilterTerm is a single condition on an object tag.
+type tagFilterTerm struct {
+	key, value string
+	negate     bool
+	anyValue   bool
+}
+
+// tagFilter matches objects whose tags satisfy all of its terms.
+type tagFilter []tagFilterTerm
+
+// parseTagFilters parses --tag-filter expressions such as
+// 'class=archive&owner!=ops'. Every term must hold for an expression to
+// match, and an object is selected when any expression matches.
+func parseTagFilters(exprs []string) ([]tagFilter, *probe.Error) {
+	filters := make([]tagFilter, 0, len(exprs))
+	for _, expr := range exprs {
+		var filter tagFilter
+		for _, t := range strings.Split(expr, "&") {
+			t = strings.TrimSpace(t)
+			var term tagFilterTerm
+			switch {
+			case strings.Contains(t, "!="):
+				kv := strings.SplitN(t, "!=", 2)
+				term = tagFilterTerm{key: kv[0], value: kv[1], negate: true}
+			case strings.Contains(t, "="):
+				kv := strings.SplitN(t, "=", 2)
+				term = tagFilterTerm{key: kv[0], value: kv[1]}
+			default:
+				term = tagFilterTerm{key: t, anyValue: true}
+			}
+			if term.key == "" {
+				return nil, errInvalidArgument().Trace(expr)
+			}
+			filter = append(filter, term)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func (f tagFilter) match(tags map[string]string) bool {
+	for _, term := range f {
+		v, ok := tags[term.key]
+		switch {
+		case term.anyValue:
+			if !ok {
+				return false
+			}
+		case term.negate:
+			if ok && v == term.value {
+				return false
+			}
+		default:
+			if !ok || v != term.value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchTagFilters returns true if the tags match any of the filters.
+func matchTagFilters(filters []tagFilter, tags map[string]string) bool {
+	for _, f := range filters {
+		if f.match(tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSourceTags fetches the tags of the source object of a copy.
+func getSourceTags(ctx context.Context, u URLs) (map[string]string, *probe.Error) {
+	clnt, err := newClientFromAlias(u.SourceAlias, u.SourceContent.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	return clnt.GetTags(ctx, u.SourceContent.VersionID)
+}
+
+// filterURLsByTags only lets through the copies whose source object tags
+// match the filters, fetching tags with the given number of workers. The
+// order of the URLs is not kept. Errors and removals are passed as is.
+func filterURLsByTags(ctx context.Context, in <-chan URLs, filters []tagFilter, workers int,
+	getTags func(context.Context, URLs) (map[string]string, *probe.Error),
+) <-chan URLs {
+	if workers <= 0 {
+		workers = defaultTagFilterWorkers
+	}
+	out := make(chan URLs)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Stop when the copy is canceled, nothing reads the output anymore.
+			send := func(u URLs) bool {
+				select {
+				case out <- u:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			for u := range in {
+				if u.Error != nil || u.SourceContent == nil {
+					if !send(u) {
+						return
+					}
+					continue
+				}
+				tags, err := getTags(ctx, u)
+				if err != nil {
+					if !send(URLs{Error: err.Trace(u.SourceContent.URL.String())}) {
+						return
+					}
+					continue
+				}
+				if matchTagFilters(filters, tags) && !send(u) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// checkTagFilterSyntax validates the tag filter flags, tags only exist on object storage.
+func checkTagFilterSyntax(cliCtx *cli.Context, sourceURLs []string) {
+	if len(cliCtx.StringSlice("tag-filter")) == 0 {
+		return
+	}
+	_, err := parseTagFilters(cliCtx.StringSlice("tag-filter"))
+	fatalIf(err, "Unable to parse --tag-filter.")
+	if cliCtx.Int("tag-filter-workers") <= 0 {
+		fatalIf(errInvalidArgument().Trace(), "--tag-filter-workers must be a positive number.")
+	}
+	for _, u := range sourceURLs {
+		if _, expanded, _ := mustExpandAlias(u); newClientURL(expanded).Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(u), "--tag-filter requires object storage sources, `"+u+"` has no tags.")
+		}
+	}
+	if !globalQuiet {
+		printMsg(tagFilterMessage{Status: "warning", Workers: cliCtx.Int("tag-filter-workers")})
+	}
+}
+
+// tagFilterMessage warns about the cost of fetching tags.
+type tagFilterMessage struct {
+	Status  string `json:"status"`
+	Workers int    `json:"workers"`
+}
+
+func (t tagFilterMessage) String() string {
+	return console.Colorize("TagFilter", "--tag-filter fetches the tags of every candidate object, one extra request per object, "+
+		"consider narrowing the copy with --include, --exclude, --older-than or --newer-than first.")
+}
+
+func (t tagFilterMessage) JSON() string {
+	jsonBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonBytes)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestTagFilters(t *testing.T) {
+	filters, err := parseTagFilters([]string{"class=archive&owner!=ops", "legal-hold"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		tags map[string]string
+		want bool
+	}{
+		{map[string]string{"class": "archive"}, true},
+		{map[string]string{"class": "archive", "owner": "dev"}, true},
+		{map[string]string{"class": "archive", "owner": "ops"}, false},
+		{map[string]string{"class": "hot"}, false},
+		{map[string]string{"legal-hold": ""}, true},
+		{nil, false},
+	}
+	for i, testCase := range testCases {
+		if got := matchTagFilters(filters, testCase.tags); got != testCase.want {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.want, got)
+		}
+	}
+
+	for _, expr := range []string{"=archive", "class=archive&"} {
+		if _, err := parseTagFilters([]string{expr}); err == nil {
+			t.Errorf("Expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestFilterURLsByTags(t *testing.T) {
+	tags := map[string]map[string]string{
+		"/bucket/a": {"tier": "cold"},
+		"/bucket/b": {"tier": "hot"},
+		"/bucket/c": {"tier": "cold"},
+	}
+	in := make(chan URLs)
+	go func() {
+		defer close(in)
+		for _, name := range []string{"/bucket/a", "/bucket/b", "/bucket/c"} {
+			in <- URLs{SourceContent: &ClientContent{URL: *newClientURL(name)}}
+		}
+		in <- URLs{TargetContent: &ClientContent{URL: *newClientURL("/bucket/removed")}}
+	}()
+
+	filters, _ := parseTagFilters([]string{"tier=cold"})
+	getTags := func(_ context.Context, u URLs) (map[string]string, *probe.Error) {
+		return tags[u.SourceContent.URL.Path], nil
+	}
+	var got []string
+	for u := range filterURLsByTags(context.Background(), in, filters, 4, getTags) {
+		if u.SourceContent == nil {
+			got = append(got, u.TargetContent.URL.Path)
+			continue
+		}
+		got = append(got, u.SourceContent.URL.Path)
+	}
+	sort.Strings(got)
+	want := []string{"/bucket/a", "/bucket/c", "/bucket/removed"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestFilterURLsByTagsCanceled(t *testing.T) {
+	const total = 8
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan URLs, total)
+	for i := 0; i < total; i++ {
+		in <- URLs{SourceContent: &ClientContent{URL: *newClientURL("/bucket/a")}}
+	}
+	close(in)
+
+	filters, _ := parseTagFilters([]string{"tier=cold"})
+	getTags := func(_ context.Context, _ URLs) (map[string]string, *probe.Error) {
+		return map[string]string{"tier": "cold"}, nil
+	}
+	out := filterURLsByTags(ctx, in, filters, 2, getTags)
+	<-out
+	cancel()
+	// Give the workers blocked on sending time to notice the cancel.
+	time.Sleep(100 * time.Millisecond)
+
+	var got int
+	for range out {
+		got++
+	}
+	if got == total-1 {
+		t.Fatalf("Expected workers to stop sending after cancel, got all %d entries", got)
+	}
+}
@@ -31,9 +31,26 @@ type URLs struct {
 	TotalSize        int64
 	MD5              bool
 	DisableMultipart bool
+	Compress         bool
+	Decompress       bool
+	// ManifestKey identifies the copy in the state of a manifest or list cache.
+	ManifestKey string `json:"-"`
+	// verifySourceETag is set for copies replayed from a list cache,
+	// their source must not have changed since it was listed.
+	verifySourceETag bool
+	// syncMetadataOnly is set by mirror --metadata-sync for objects whose
+	// metadata differs, only their metadata is updated when possible.
+	syncMetadataOnly bool
+	timeouts         copyTimeouts
 	encKeyDB         map[string][]prefixSSEPair
 	Error            *probe.Error `json:"-"`
 	ErrorCond        differType   `json:"-"`
+	// resumeDownload is set by cp to resume the interrupted downloads
+	// to the local filesystem.
+	resumeDownload bool
+	// symlinkRoot is the target folder of a recursive download, the
+	// symlinks recreated with --preserve must point within it.
+	symlinkRoot string
 }
 
 // WithError sets the error and returns object
@@ -450,15 +450,22 @@ func httpClient(timeout time.Duration) *http.Client {
 }
 
 func getPrometheusToken(hostConfig *aliasConfigV10) (string, error) {
+	token, _, e := newPrometheusToken(hostConfig)
+	return token, e
+}
+
+// newPrometheusToken returns a bearer token for Prometheus along with its expiry.
+func newPrometheusToken(hostConfig *aliasConfigV10) (string, time.Time, error) {
+	expiry := UTCNow().Add(defaultPrometheusJWTExpiry).Truncate(time.Second)
 	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.RegisteredClaims{
-		ExpiresAt: jwtgo.NewNumericDate(UTCNow().Add(defaultPrometheusJWTExpiry)),
+		ExpiresAt: jwtgo.NewNumericDate(expiry),
 		Subject:   hostConfig.AccessKey,
 		Issuer:    "prometheus",
 	})
 
 	token, e := jwt.SignedString([]byte(hostConfig.SecretKey))
 	if e != nil {
-		return "", e
+		return "", time.Time{}, e
 	}
-	return token, nil
+	return token, expiry, nil
 }
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -26,13 +27,18 @@ import (
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/console"
 )
 
 var versionEnableFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "excluded-prefixes",
-		Usage: "exclude versioning on these prefix patterns",
+		Usage: "exclude versioning on these comma separated prefix patterns",
+	},
+	cli.StringSliceFlag{
+		Name:  "exclude-prefix",
+		Usage: "exclude versioning on this prefix pattern, may be repeated",
 	},
 	cli.BoolFlag{
 		Name:  "exclude-folders",
@@ -66,6 +72,9 @@ EXAMPLES:
   3. Enable versioning on bucket "mybucket" while excluding versioning on a few select prefixes and all folders.
      Note: this is useful on buckets used with Spark/Hadoop workloads.
      {{.Prompt}} {{.HelpName}} myminio/mybucket --excluded-prefixes "app1/*/_temporary/,app2/*/_staging/" --exclude-folders
+
+  4. Enable versioning on bucket "mybucket" while excluding versioning on the prefixes "tmp/" and "cache/".
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --exclude-prefix "tmp/" --exclude-prefix "cache/"
 `,
 }
 
@@ -76,6 +85,60 @@ func checkVersionEnableSyntax(ctx *cli.Context) {
 	}
 }
 
+// maxVersioningExcludedPrefixes is the number of excluded prefixes accepted by the server.
+const maxVersioningExcludedPrefixes = 10
+
+// parseVersioningExcludedPrefixes merges the prefixes of --exclude-prefix
+// and the comma separated ones of --excluded-prefixes, and validates them.
+func parseVersioningExcludedPrefixes(prefixes []string, prefixesStr string) ([]string, error) {
+	if prefixesStr != "" {
+		prefixes = append(prefixes, strings.Split(prefixesStr, ",")...)
+	}
+	var excludedPrefixes []string
+	seen := make(map[string]struct{}, len(prefixes))
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			return nil, errors.New("excluded prefixes cannot be empty")
+		}
+		if strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("excluded prefix `%s` cannot start with '/'", prefix)
+		}
+		if _, ok := seen[prefix]; ok {
+			continue
+		}
+		seen[prefix] = struct{}{}
+		excludedPrefixes = append(excludedPrefixes, prefix)
+	}
+	if len(excludedPrefixes) > maxVersioningExcludedPrefixes {
+		return nil, fmt.Errorf("at most %d excluded prefixes are supported, got %d", maxVersioningExcludedPrefixes, len(excludedPrefixes))
+	}
+	return excludedPrefixes, nil
+}
+
+// excludedPrefixReplicationOverlaps returns a warning for each excluded prefix
+// which overlaps the prefix of an enabled replication rule, the objects under
+// such prefix are not versioned and hence their deletes are not replicated.
+func excludedPrefixReplicationOverlaps(excludedPrefixes []string, rcfg replication.Config) (warnings []string) {
+	for _, excluded := range excludedPrefixes {
+		// Only the part of the pattern before any wildcard is a literal prefix.
+		base := excluded
+		if i := strings.IndexByte(base, '*'); i >= 0 {
+			base = base[:i]
+		}
+		for _, rule := range rcfg.Rules {
+			if rule.Status != replication.Enabled {
+				continue
+			}
+			rulePrefix := rule.Prefix()
+			if strings.HasPrefix(base, rulePrefix) || strings.HasPrefix(rulePrefix, base) {
+				warnings = append(warnings, fmt.Sprintf("excluded prefix `%s` overlaps replication rule `%s` with prefix `%s`", excluded, rule.ID, rulePrefix))
+			}
+		}
+	}
+	return warnings
+}
+
 type versionEnableMessage struct {
 	Op         string
 	Status     string `json:"status"`
@@ -86,6 +149,7 @@ type versionEnableMessage struct {
 		ExcludedPrefixes []string `json:"ExcludedPrefixes,omitempty"`
 		ExcludeFolders   bool     `json:"ExcludeFolders,,omitempty"`
 	} `json:"versioning"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 func (v versionEnableMessage) JSON() string {
@@ -96,7 +160,17 @@ func (v versionEnableMessage) JSON() string {
 }
 
 func (v versionEnableMessage) String() string {
-	return console.Colorize("versionEnableMessage", fmt.Sprintf("%s versioning is enabled", v.URL))
+	msg := console.Colorize("versionEnableMessage", fmt.Sprintf("%s versioning is enabled", v.URL))
+	if len(v.Versioning.ExcludedPrefixes) > 0 {
+		msg += "\n" + console.Colorize("versionEnableMessage", "Excluded prefixes: "+strings.Join(v.Versioning.ExcludedPrefixes, ", "))
+	}
+	if v.Versioning.ExcludeFolders {
+		msg += "\n" + console.Colorize("versionEnableMessage", "Folders are excluded")
+	}
+	for _, warning := range v.Warnings {
+		msg += "\n" + console.Colorize("versionEnableWarning", "Warning: "+warning)
+	}
+	return msg
 }
 
 func mainVersionEnable(cliCtx *cli.Context) error {
@@ -104,6 +178,7 @@ func mainVersionEnable(cliCtx *cli.Context) error {
 	defer cancelVersionEnable()
 
 	console.SetColor("versionEnableMessage", color.New(color.FgGreen))
+	console.SetColor("versionEnableWarning", color.New(color.FgYellow))
 
 	checkVersionEnableSyntax(cliCtx)
 
@@ -111,21 +186,28 @@ func mainVersionEnable(cliCtx *cli.Context) error {
 	args := cliCtx.Args()
 	aliasedURL := args.Get(0)
 
-	var excludedPrefixes []string
-	prefixesStr := cliCtx.String("excluded-prefixes")
-	if prefixesStr != "" {
-		excludedPrefixes = strings.Split(prefixesStr, ",")
-	}
+	excludedPrefixes, e := parseVersioningExcludedPrefixes(cliCtx.StringSlice("exclude-prefix"), cliCtx.String("excluded-prefixes"))
+	fatalIf(probe.NewError(e), "Invalid excluded prefixes.")
 	excludeFolders := cliCtx.Bool("exclude-folders")
 
 	// Create a new Client
 	client, err := newClient(aliasedURL)
 	fatalIf(err, "Unable to initialize connection.")
 	fatalIf(client.SetVersion(ctx, "enable", excludedPrefixes, excludeFolders), "Unable to enable versioning")
-	printMsg(versionEnableMessage{
+	vMsg := versionEnableMessage{
 		Op:     cliCtx.Command.Name,
 		Status: "success",
 		URL:    aliasedURL,
-	})
+	}
+	vMsg.Versioning.Status = "Enabled"
+	vMsg.Versioning.ExcludedPrefixes = excludedPrefixes
+	vMsg.Versioning.ExcludeFolders = excludeFolders
+	if len(excludedPrefixes) > 0 {
+		// A bucket without replication configured is not an error here.
+		if rcfg, err := client.GetReplication(ctx); err == nil {
+			vMsg.Warnings = excludedPrefixReplicationOverlaps(excludedPrefixes, rcfg)
+		}
+	}
+	printMsg(vMsg)
 	return nil
 }
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/replication"
+)
+
+func TestParseVersioningExcludedPrefixes(t *testing.T) {
+	testCases := []struct {
+		prefixes    []string
+		prefixesStr string
+		expected    []string
+		expectErr   bool
+	}{
+		{nil, "", nil, false},
+		{[]string{"tmp/", " cache/ "}, "", []string{"tmp/", "cache/"}, false},
+		{[]string{"tmp/"}, "app1/*/_temporary/,tmp/", []string{"tmp/", "app1/*/_temporary/"}, false},
+		{[]string{""}, "", nil, true},
+		{nil, "tmp/,", nil, true},
+		{[]string{"/tmp/"}, "", nil, true},
+		{nil, strings.Repeat("a,b,c,d,", 3), nil, true},
+	}
+	for i, tc := range testCases {
+		prefixes, e := parseVersioningExcludedPrefixes(tc.prefixes, tc.prefixesStr)
+		if tc.expectErr != (e != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.expectErr, e)
+		}
+		if !reflect.DeepEqual(prefixes, tc.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.expected, prefixes)
+		}
+	}
+}
+
+func TestExcludedPrefixReplicationOverlaps(t *testing.T) {
+	rcfg := replication.Config{Rules: []replication.Rule{
+		{ID: "logs", Status: replication.Enabled, Filter: replication.Filter{Prefix: "logs/"}},
+		{ID: "data", Status: replication.Enabled, Filter: replication.Filter{And: replication.And{Prefix: "data/app1/"}}},
+		{ID: "off", Status: replication.Disabled, Filter: replication.Filter{Prefix: "tmp/"}},
+	}}
+	testCases := []struct {
+		prefix   string
+		overlaps int
+	}{
+		{"tmp/", 0},
+		{"logs/old/", 1},
+		{"data/", 1},
+		{"data/*/_temporary/", 1},
+		{"*/_staging/", 2},
+		{"cache/", 0},
+	}
+	for i, tc := range testCases {
+		if warnings := excludedPrefixReplicationOverlaps([]string{tc.prefix}, rcfg); len(warnings) != tc.overlaps {
+			t.Errorf("Test %d: expected %d overlaps, got %v", i+1, tc.overlaps, warnings)
+		}
+	}
+	rcfg.Rules = append(rcfg.Rules, replication.Rule{ID: "all", Status: replication.Enabled})
+	if warnings := excludedPrefixReplicationOverlaps([]string{"cache/"}, rcfg); len(warnings) != 1 {
+		t.Errorf("Expected the rule without prefix to overlap, got %v", warnings)
+	}
+}
+
+func TestVersioningInfoMessage(t *testing.T) {
+	var msg versioningInfoMessage
+	msg.URL = "myminio/mybucket"
+	msg.Versioning.Status = "Enabled"
+	msg.Versioning.ExcludedPrefixes = []string{"tmp/"}
+	if strings.Contains(msg.JSON(), "MFADelete") {
+		t.Errorf("Expected MFA delete to be omitted when not reported, got %s", msg.JSON())
+	}
+	if strings.Contains(msg.String(), "MFA delete") {
+		t.Errorf("Expected MFA delete to be omitted when not reported, got %s", msg.String())
+	}
+	msg.Versioning.MFADelete = "Disabled"
+	if !strings.Contains(msg.JSON(), `"MFADelete":"Disabled"`) {
+		t.Errorf("Expected MFA delete in JSON output, got %s", msg.JSON())
+	}
+	if !strings.Contains(msg.String(), "MFA delete is disabled") {
+		t.Errorf("Expected MFA delete in output, got %s", msg.String())
+	}
+}
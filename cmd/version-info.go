@@ -48,6 +48,10 @@ FLAGS:
 EXAMPLES:
    1. Display bucket versioning status for bucket "mybucket".
       {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+   2. Display the full versioning configuration of bucket "mybucket" as JSON, including
+      MFA delete when the backend supports it and the prefixes excluded from versioning.
+      {{.Prompt}} {{.HelpName}} --json myminio/mybucket
 `,
 }
 
@@ -63,8 +67,9 @@ type versioningInfoMessage struct {
 	Status     string `json:"status"`
 	URL        string `json:"url"`
 	Versioning struct {
-		Status           string   `json:"status"`
-		MFADelete        string   `json:"MFADelete"`
+		Status string `json:"status"`
+		// Only reported by backends supporting MFA delete.
+		MFADelete        string   `json:"MFADelete,omitempty"`
 		ExcludedPrefixes []string `json:"ExcludedPrefixes,omitempty"`
 		ExcludeFolders   bool     `json:"ExcludeFolders,omitempty"`
 	} `json:"versioning"`
@@ -85,6 +90,15 @@ func (v versioningInfoMessage) String() string {
 	default:
 		msg = fmt.Sprintf("%s versioning is %s", v.URL, strings.ToLower(v.Versioning.Status))
 	}
+	if v.Versioning.MFADelete != "" {
+		msg += fmt.Sprintf("\nMFA delete is %s", strings.ToLower(v.Versioning.MFADelete))
+	}
+	if len(v.Versioning.ExcludedPrefixes) > 0 {
+		msg += "\nExcluded prefixes: " + strings.Join(v.Versioning.ExcludedPrefixes, ", ")
+	}
+	if v.Versioning.ExcludeFolders {
+		msg += "\nFolders are excluded"
+	}
 	return console.Colorize("versioningInfoMessage", msg)
 }
 
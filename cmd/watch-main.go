@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -50,6 +51,14 @@ var watchFlags = []cli.Flag{
 		Name:  "recursive",
 		Usage: "recursively watch for events",
 	},
+	cli.BoolFlag{
+		Name:  "at-least-once",
+		Usage: "persist the time of the last event and replay the object creations missed while not watching on restart",
+	},
+	cli.StringFlag{
+		Name:  "state",
+		Usage: "file persisting the watch position of --at-least-once, defaults to a file in the mc config folder",
+	},
 }
 
 var watchCmd = cli.Command{
@@ -86,6 +95,12 @@ EXAMPLES:
 
   6. Watch for events on local directory.
      {{.Prompt}} {{.HelpName}} /usr/share
+
+  7. Watch new and removed objects of a bucket, replaying the new objects missed while mc was not running when
+     it is started again. They are found by listing the objects modified since the last event printed, and may
+     be printed more than once, missed removals are not replayed. For guaranteed delivery to a pipeline, add a
+     bucket notification target on the server with 'mc event add'.
+     {{.Prompt}} {{.HelpName}} --at-least-once --events put,delete play/testbucket
 `,
 }
 
@@ -94,6 +109,9 @@ func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		showCommandHelpAndExit(ctx, 1) // last argument is exit code
 	}
+	if ctx.String("state") != "" && !ctx.Bool("at-least-once") {
+		fatalIf(errInvalidArgument().Trace(), "`--state` requires `--at-least-once`.")
+	}
 }
 
 // watchMessage container to hold one event notification
@@ -110,6 +128,7 @@ type watchMessage struct {
 		Port      string `json:"port,omitempty"`
 		UserAgent string `json:"userAgent,omitempty"`
 	} `json:"source,omitempty"`
+	Replay bool `json:"replayed,omitempty"`
 }
 
 func (u watchMessage) JSON() string {
@@ -128,6 +147,22 @@ func (u watchMessage) String() string {
 	}
 	msg += console.Colorize("EventType", fmt.Sprintf("%s ", u.Event.Type))
 	msg += console.Colorize("ObjectName", u.Event.Path)
+	if u.Replay {
+		msg += " (replayed)"
+	}
+	return msg
+}
+
+// newWatchMessage returns the message printed for an event.
+func newWatchMessage(event EventInfo) watchMessage {
+	msg := watchMessage{}
+	msg.Event.Path = event.Path
+	msg.Event.Size = event.Size
+	msg.Event.Time = event.Time
+	msg.Event.Type = event.Type
+	msg.Source.Host = event.Host
+	msg.Source.Port = event.Port
+	msg.Source.UserAgent = event.UserAgent
 	return msg
 }
 
@@ -162,16 +197,68 @@ func mainWatch(cliCtx *cli.Context) error {
 	ctx, cancelWatch := context.WithCancel(globalContext)
 	defer cancelWatch()
 
+	var state *watchState
+	var found bool
+	if cliCtx.Bool("at-least-once") {
+		if s3Client.GetURL().Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(path), "`--at-least-once` is only supported for object storage targets.")
+		}
+		targetURL := s3Client.GetURL()
+		if bucket, _ := url2BucketAndObject(&targetURL); bucket == "" {
+			fatalIf(errInvalidArgument().Trace(path), "`--at-least-once` requires a bucket to watch.")
+		}
+		statePath := cliCtx.String("state")
+		if statePath == "" {
+			statePath = watchStatePath(path, options)
+		}
+		state, found, pErr = loadWatchState(statePath, path)
+		fatalIf(pErr, "Unable to load the watch state.")
+	}
+
+	// Without a state, the watch starts now.
+	if state != nil && !found {
+		state.advance(UTCNow())
+	}
+
 	// Start watching on events
 	wo, err := s3Client.Watch(ctx, options)
 	fatalIf(err, "Unable to watch on the specified bucket.")
 
+	// The listing follows the start of the watch so that no event is
+	// missed in between, such events may be printed twice.
+	if state != nil && found {
+		listURL := path
+		if prefix != "" {
+			listURL = strings.TrimSuffix(path, "/") + "/" + prefix
+		}
+		listClient, pErr := newClient(listURL)
+		fatalIf(pErr.Trace(listURL), "Unable to parse the provided url.")
+		contents, pErr := listWatchedObjects(ctx, listClient, suffix, state.Checkpoint)
+		fatalIf(pErr, "Unable to list the watched objects.")
+		for _, event := range state.reconcile(contents, events) {
+			msg := newWatchMessage(event)
+			msg.Replay = true
+			printMsg(msg)
+		}
+	}
+	if state != nil {
+		fatalIf(state.save(), "Unable to save the watch state.")
+	}
+
 	// Initialize.. waitgroup to track the go-routine.
 	var wg sync.WaitGroup
 
 	// Increment wait group to wait subsequent routine.
 	wg.Add(1)
 
+	// Persist the events printed with --at-least-once.
+	var saveCh <-chan time.Time
+	if state != nil {
+		saveTicker := time.NewTicker(watchStateInterval)
+		defer saveTicker.Stop()
+		saveCh = saveTicker.C
+	}
+
 	// Start routine to watching on events.
 	go func() {
 		defer wg.Done()
@@ -188,15 +275,14 @@ func mainWatch(cliCtx *cli.Context) error {
 					return
 				}
 				for _, event := range events {
-					msg := watchMessage{}
-					msg.Event.Path = event.Path
-					msg.Event.Size = event.Size
-					msg.Event.Time = event.Time
-					msg.Event.Type = event.Type
-					msg.Source.Host = event.Host
-					msg.Source.Port = event.Port
-					msg.Source.UserAgent = event.UserAgent
-					printMsg(msg)
+					printMsg(newWatchMessage(event))
+					if state != nil {
+						state.apply(event)
+					}
+				}
+			case <-saveCh:
+				if state.dirty {
+					errorIf(state.save(), "Unable to save the watch state.")
 				}
 			case err, ok := <-wo.Errors():
 				if !ok {
@@ -213,5 +299,9 @@ func mainWatch(cliCtx *cli.Context) error {
 	// Wait on the routine to be finished or exit.
 	wg.Wait()
 
+	if state != nil && state.dirty {
+		fatalIf(state.save(), "Unable to save the watch state.")
+	}
+
 	return nil
 }
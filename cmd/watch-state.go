@@ -0,0 +1,182 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/notification"
+
+	gojson "encoding/json"
+)
+
+// watchStateVersion is the version of the watch state format.
+const watchStateVersion = 2
+
+// watchStateInterval is how often the state of `watch --at-least-once`
+// is persisted, events printed since the last save are replayed after
+// a restart.
+const watchStateInterval = time.Second
+
+// watchState is the position of `watch --at-least-once`, the time of the
+// latest event printed. Only this checkpoint is persisted, so the state
+// stays small whatever the number of watched objects.
+type watchState struct {
+	Version    int       `json:"version"`
+	Target     string    `json:"target"`
+	Checkpoint time.Time `json:"checkpoint"`
+
+	path  string
+	dirty bool
+}
+
+// watchStatePath returns the default state file of a watch in the mc
+// config folder, one per target and filters.
+func watchStatePath(target string, options WatchOptions) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{target, options.Prefix, options.Suffix}, "\x00")))
+	return filepath.Join(mustGetMcConfigDir(), "watch", hex.EncodeToString(sum[:])+".json")
+}
+
+// loadWatchState reads the state of a watch, returns false if there is
+// no state yet.
+func loadWatchState(path, target string) (*watchState, bool, *probe.Error) {
+	state := &watchState{
+		Version: watchStateVersion,
+		Target:  target,
+		path:    path,
+	}
+	data, e := os.ReadFile(path)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return state, false, nil
+		}
+		return nil, false, probe.NewError(e).Trace(path)
+	}
+	if e = gojson.Unmarshal(data, state); e != nil {
+		return nil, false, probe.NewError(e).Trace(path)
+	}
+	if state.Version != watchStateVersion {
+		return nil, false, probe.NewError(fmt.Errorf("unsupported watch state version %d", state.Version)).Trace(path)
+	}
+	if state.Target != target {
+		return nil, false, probe.NewError(fmt.Errorf("watch state was recorded for `%s`", state.Target)).Trace(path)
+	}
+	return state, true, nil
+}
+
+// save persists the state, the previous state is kept if saving fails.
+func (s *watchState) save() *probe.Error {
+	if e := os.MkdirAll(filepath.Dir(s.path), 0o700); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	data, e := gojson.Marshal(s)
+	if e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	if e = os.WriteFile(s.path+".tmp", data, 0o600); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	if e = os.Rename(s.path+".tmp", s.path); e != nil {
+		return probe.NewError(e).Trace(s.path)
+	}
+	s.dirty = false
+	return nil
+}
+
+// advance moves the checkpoint forward to t.
+func (s *watchState) advance(t time.Time) {
+	if t.After(s.Checkpoint) {
+		s.Checkpoint = t
+		s.dirty = true
+	}
+}
+
+// apply records a printed event, the checkpoint moves to its time.
+func (s *watchState) apply(event EventInfo) {
+	if t, e := time.Parse(time.RFC3339Nano, event.Time); e == nil {
+		s.advance(t)
+	}
+}
+
+// reconcile compares the checkpoint with the current objects and returns
+// the object creations missed since the state was saved, when they are
+// watched. Objects modified at or after the checkpoint are replayed, which
+// may print some events twice. Removals cannot be found without keeping
+// every object in the state and are not replayed.
+func (s *watchState) reconcile(contents []*ClientContent, events []string) []EventInfo {
+	watchPut := false
+	for _, event := range events {
+		if event == "put" {
+			watchPut = true
+		}
+	}
+	if !watchPut {
+		return nil
+	}
+
+	var changed []*ClientContent
+	for _, content := range contents {
+		if !content.Time.Before(s.Checkpoint) {
+			changed = append(changed, content)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		if !changed[i].Time.Equal(changed[j].Time) {
+			return changed[i].Time.Before(changed[j].Time)
+		}
+		return changed[i].URL.String() < changed[j].URL.String()
+	})
+
+	missed := make([]EventInfo, 0, len(changed))
+	for _, content := range changed {
+		missed = append(missed, EventInfo{
+			Time: content.Time.UTC().Format(time.RFC3339Nano),
+			Size: content.Size,
+			Path: content.URL.String(),
+			ETag: content.ETag,
+			Type: notification.ObjectCreatedPut,
+		})
+		s.advance(content.Time)
+	}
+	return missed
+}
+
+// listWatchedObjects lists the objects a watch receives events for, which
+// were modified at or after since.
+func listWatchedObjects(ctx context.Context, clnt Client, suffix string, since time.Time) ([]*ClientContent, *probe.Error) {
+	var contents []*ClientContent
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(clnt.GetURL().String())
+		}
+		if content.Type.IsDir() || !strings.HasSuffix(content.URL.Path, suffix) || content.Time.Before(since) {
+			continue
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
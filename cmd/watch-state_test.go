@@ -0,0 +1,94 @@
+// Copyright (c) 2015-2022 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+)
+
+func TestWatchStateReconcile(t *testing.T) {
+	checkpoint := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	content := func(name string, modTime time.Time) *ClientContent {
+		return &ClientContent{URL: *newClientURL("https://s3.example.com/bucket/" + name), ETag: name, Time: modTime}
+	}
+	objectPath := func(name string) string {
+		return "https://s3.example.com/bucket/" + name
+	}
+
+	state := &watchState{Checkpoint: checkpoint}
+	contents := []*ClientContent{
+		content("old", checkpoint.Add(-time.Hour)),
+		content("later", checkpoint.Add(2*time.Hour)),
+		content("at-checkpoint", checkpoint),
+		content("new", checkpoint.Add(time.Hour)),
+	}
+
+	missed := state.reconcile(contents, []string{"put", "delete"})
+	var got []string
+	for _, event := range missed {
+		got = append(got, string(event.Type)+" "+event.Path)
+	}
+	expected := []string{
+		string(notification.ObjectCreatedPut) + " " + objectPath("at-checkpoint"),
+		string(notification.ObjectCreatedPut) + " " + objectPath("new"),
+		string(notification.ObjectCreatedPut) + " " + objectPath("later"),
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected missed events %v, got %v", expected, got)
+	}
+	if !state.Checkpoint.Equal(checkpoint.Add(2 * time.Hour)) {
+		t.Fatalf("expected the checkpoint to move to the latest replayed object, got %v", state.Checkpoint)
+	}
+
+	// Only the watched event types are replayed.
+	state.Checkpoint = checkpoint
+	if missed = state.reconcile(contents, []string{"delete"}); len(missed) != 0 {
+		t.Fatalf("expected no missed event, got %v", missed)
+	}
+}
+
+func TestWatchStateSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watch", "state.json")
+	target := "play/bucket"
+
+	state, found, err := loadWatchState(path, target)
+	if err != nil || found {
+		t.Fatalf("expected no state, got found=%v err=%v", found, err)
+	}
+	state.apply(EventInfo{Time: "2022-01-01T00:00:02.000Z", Path: "https://s3.example.com/bucket/a", Type: notification.ObjectCreatedPut})
+	state.apply(EventInfo{Time: "2022-01-01T00:00:01.000Z", Path: "https://s3.example.com/bucket/b", Type: notification.ObjectRemovedDelete})
+	if err = state.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, found, err := loadWatchState(path, target)
+	if err != nil || !found {
+		t.Fatalf("expected a state, got found=%v err=%v", found, err)
+	}
+	if expected := time.Date(2022, 1, 1, 0, 0, 2, 0, time.UTC); !loaded.Checkpoint.Equal(expected) {
+		t.Fatalf("expected checkpoint %v, got %v", expected, loaded.Checkpoint)
+	}
+	if _, _, err = loadWatchState(path, "play/other"); err == nil {
+		t.Fatal("expected an error for a state recorded for another target")
+	}
+}
@@ -33,6 +33,7 @@ type EventInfo struct {
 	Size         int64
 	UserMetadata map[string]string
 	Path         string
+	ETag         string
 	Host         string
 	Port         string
 	UserAgent    string